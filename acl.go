@@ -0,0 +1,329 @@
+package ovpm
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/jinzhu/gorm"
+	"github.com/sirupsen/logrus"
+)
+
+// ACL rule actions.
+const (
+	ACLAllow = "allow"
+	ACLDeny  = "deny"
+)
+
+// ACL rule protocols.
+const (
+	ACLAnyProto = "any"
+	ACLTCP      = "tcp"
+	ACLUDP      = "udp"
+	ACLICMP     = "icmp"
+)
+
+// dbACLRuleModel is database model for the access control rules of the vpn
+// users. A rule either belongs to a user or to a pool.
+type dbACLRuleModel struct {
+	gorm.Model
+	UserID      uint   // Zero if the rule is for a pool.
+	PoolID      uint   // Zero if the rule is for a user.
+	Action      string // ACLAllow or ACLDeny.
+	Destination string // Destination network in the CIDR form.
+	Proto       string // ACLAnyProto, ACLTCP, ACLUDP or ACLICMP.
+	Port        string // Destination port or range (e.g. 8000:8080), only for tcp and udp.
+}
+
+// ACLRule allows or denies the traffic of a user, or of all of the users of a
+// pool, towards a destination network, optionally by protocol and port.
+//
+// Rules of a user are matched before the rules of its pool, both in the order
+// they are created. Traffic that doesn't match any rule is let through.
+type ACLRule struct {
+	dbACLRuleModel
+}
+
+// CreateNewACLRule adds an access control rule for either the user or the
+// pool with the given name.
+func CreateNewACLRule(username, pool, action, destination, proto, port string) (*ACLRule, error) {
+	if govalidator.IsNull(username) == govalidator.IsNull(pool) {
+		return nil, fmt.Errorf("validation error: either a user or a pool should be given")
+	}
+	if !govalidator.IsNull(pool) {
+		p, err := GetPool(pool)
+		if err != nil {
+			return nil, err
+		}
+		return p.AddACLRule(action, destination, proto, port)
+	}
+	u, err := GetUser(username)
+	if err != nil {
+		return nil, err
+	}
+	return u.AddACLRule(action, destination, proto, port)
+}
+
+// AddACLRule adds an access control rule for the user.
+func (u *User) AddACLRule(action, destination, proto, port string) (*ACLRule, error) {
+	return createACLRule(dbACLRuleModel{UserID: u.ID}, u.GetServer(), action, destination, proto, port)
+}
+
+// AddACLRule adds an access control rule for all of the users of the pool.
+func (p *Pool) AddACLRule(action, destination, proto, port string) (*ACLRule, error) {
+	if strings.Contains(destination, ":") {
+		return nil, fmt.Errorf("validation error: pools only have IPv4 addresses, `%s` can't be used as a destination", destination)
+	}
+	return createACLRule(dbACLRuleModel{PoolID: p.ID}, p.GetServer(), action, destination, proto, port)
+}
+
+func createACLRule(rule dbACLRuleModel, svr *Server, action, destination, proto, port string) (*ACLRule, error) {
+	if action != ACLAllow && action != ACLDeny {
+		return nil, fmt.Errorf("validation error: action `%s` should be either %s or %s", action, ACLAllow, ACLDeny)
+	}
+	_, ipnet, err := net.ParseCIDR(destination)
+	if err != nil {
+		return nil, fmt.Errorf("validation error: `%s` must be a network in the CIDR form", destination)
+	}
+	if proto == "" {
+		proto = ACLAnyProto
+	}
+	if !isOneOf(proto, []string{ACLAnyProto, ACLTCP, ACLUDP, ACLICMP}) {
+		return nil, fmt.Errorf("validation error: protocol `%s` should be one of %s, %s, %s or %s", proto, ACLAnyProto, ACLTCP, ACLUDP, ACLICMP)
+	}
+	if port != "" {
+		if proto != ACLTCP && proto != ACLUDP {
+			return nil, fmt.Errorf("validation error: port can only be set for %s and %s", ACLTCP, ACLUDP)
+		}
+		if !isPortRange(port) {
+			return nil, fmt.Errorf("validation error: `%s` should be a port or a range of ports like 8000:8080", port)
+		}
+	}
+
+	rule.Action = action
+	rule.Destination = ipnet.String()
+	rule.Proto = proto
+	rule.Port = port
+	if err := db.Create(&rule).Error; err != nil {
+		return nil, fmt.Errorf("can not create acl rule in the db: %v", err)
+	}
+	r := &ACLRule{dbACLRuleModel: rule}
+	logrus.Infof("acl rule added: %s", r)
+	if svr.IsInitialized() {
+		if err := svr.Emit(); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// isPortRange tells whether s is a port or an ascending range of ports.
+func isPortRange(s string) bool {
+	ports := strings.SplitN(s, ":", 2)
+	var last int
+	for _, p := range ports {
+		port, err := strconv.Atoi(p)
+		if err != nil || port < 1 || port > 65535 || port < last {
+			return false
+		}
+		last = port
+	}
+	return true
+}
+
+// GetACLRule returns the acl rule with the given id.
+func GetACLRule(id uint) (*ACLRule, error) {
+	var rule dbACLRuleModel
+	q := db.First(&rule, id)
+	if q.RecordNotFound() {
+		return nil, fmt.Errorf("acl rule not found: %d", id)
+	}
+	if q.Error != nil {
+		return nil, q.Error
+	}
+	return &ACLRule{dbACLRuleModel: rule}, nil
+}
+
+// GetAllACLRules returns the acl rules in the order they are matched.
+func GetAllACLRules() ([]*ACLRule, error) {
+	var dbRules []*dbACLRuleModel
+	if err := db.Order("pool_id, id").Find(&dbRules).Error; err != nil {
+		return nil, err
+	}
+	var rules []*ACLRule
+	for _, r := range dbRules {
+		rules = append(rules, &ACLRule{dbACLRuleModel: *r})
+	}
+	return rules, nil
+}
+
+// Delete deletes the acl rule.
+func (r *ACLRule) Delete() error {
+	if err := db.Unscoped().Delete(&r.dbACLRuleModel).Error; err != nil {
+		return err
+	}
+	logrus.Infof("acl rule deleted: %s", r)
+	if svr := r.getServer(); svr.IsInitialized() {
+		return svr.Emit()
+	}
+	return nil
+}
+
+// GetID returns the id of the rule.
+func (r *ACLRule) GetID() uint {
+	return r.ID
+}
+
+// GetUsername returns the name of the user that the rule belongs to, or an
+// empty string if it's a rule of a pool.
+func (r *ACLRule) GetUsername() string {
+	if u := r.getUser(); u != nil {
+		return u.Username
+	}
+	return ""
+}
+
+// GetPoolName returns the name of the pool that the rule belongs to, or an
+// empty string if it's a rule of a user.
+func (r *ACLRule) GetPoolName() string {
+	if p := r.getPool(); p != nil {
+		return p.Name
+	}
+	return ""
+}
+
+// GetAction returns either ACLAllow or ACLDeny.
+func (r *ACLRule) GetAction() string {
+	return r.Action
+}
+
+// GetDestination returns the destination network of the rule in the CIDR form.
+func (r *ACLRule) GetDestination() string {
+	return r.Destination
+}
+
+// GetProto returns the protocol of the rule.
+func (r *ACLRule) GetProto() string {
+	return r.Proto
+}
+
+// GetPort returns the destination port or range of ports of the rule, if any.
+func (r *ACLRule) GetPort() string {
+	return r.Port
+}
+
+// GetCreatedAt returns the creation time of the rule.
+func (r *ACLRule) GetCreatedAt() string {
+	return r.CreatedAt.Format(time.UnixDate)
+}
+
+func (r *ACLRule) String() string {
+	subject := "user:" + r.GetUsername()
+	if r.PoolID != 0 {
+		subject = "pool:" + r.GetPoolName()
+	}
+	dst := r.Destination
+	if r.Port != "" {
+		dst += " port " + r.Port
+	}
+	return fmt.Sprintf("#%d %s %s -> %s (%s)", r.ID, r.Action, subject, dst, r.Proto)
+}
+
+func (r *ACLRule) getUser() *User {
+	if r.UserID == 0 {
+		return nil
+	}
+	var user dbUserModel
+	if err := db.First(&user, r.UserID).Error; err != nil {
+		return nil
+	}
+	return &User{dbUserModel: user}
+}
+
+func (r *ACLRule) getPool() *Pool {
+	if r.PoolID == 0 {
+		return nil
+	}
+	var pool dbPoolModel
+	if err := db.First(&pool, r.PoolID).Error; err != nil {
+		return nil
+	}
+	return &Pool{dbPoolModel: pool}
+}
+
+// getServer returns the server of the user or the pool that the rule belongs to.
+func (r *ACLRule) getServer() *Server {
+	if u := r.getUser(); u != nil {
+		return u.GetServer()
+	}
+	if p := r.getPool(); p != nil {
+		return p.GetServer()
+	}
+	return TheServer()
+}
+
+// source returns the source address of the rule's traffic in the given
+// address family, or an empty string if it has none.
+func (r *ACLRule) source(ipv6 bool) string {
+	if u := r.getUser(); u != nil {
+		ip := u.getIP()
+		if ipv6 {
+			ip = u.getIPv6()
+		}
+		if ip == nil {
+			return ""
+		}
+		return ip.String()
+	}
+	if p := r.getPool(); p != nil && !ipv6 {
+		return p.CIDR
+	}
+	return ""
+}
+
+// firewallRule returns the firewall rule of the acl rule, or nil if it
+// doesn't apply to the given address family.
+func (r *ACLRule) firewallRule(ipv6 bool) *firewallRule {
+	_, dst, err := net.ParseCIDR(r.Destination)
+	if err != nil || (dst.IP.To4() == nil) != ipv6 {
+		return nil
+	}
+	src := r.source(ipv6)
+	if src == "" {
+		return nil
+	}
+	rule := &firewallRule{Source: src, Destination: dst.String(), Port: r.Port, Accept: r.Action == ACLAllow}
+	if r.Proto != ACLAnyProto {
+		rule.Proto = r.Proto
+	}
+	return rule
+}
+
+// aclFirewallRules returns the firewall rules of the acl rules for the given
+// address family, in the order they are matched.
+func aclFirewallRules(ipv6 bool) ([]firewallRule, error) {
+	rules, err := GetAllACLRules()
+	if err != nil {
+		return nil, err
+	}
+	var fwRules []firewallRule
+	for _, r := range rules {
+		if rule := r.firewallRule(ipv6); rule != nil {
+			fwRules = append(fwRules, *rule)
+		}
+	}
+	return fwRules, nil
+}
+
+// deleteACLRules deletes the rules of the user.
+func (u *User) deleteACLRules() {
+	db.Unscoped().Where("user_id = ?", u.ID).Delete(&dbACLRuleModel{})
+}
+
+// deleteACLRules deletes the rules of the pool.
+func (p *Pool) deleteACLRules() error {
+	return db.Unscoped().Where("pool_id = ?", p.ID).Delete(&dbACLRuleModel{}).Error
+}
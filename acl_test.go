@@ -0,0 +1,96 @@
+package ovpm
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestACLRules(t *testing.T) {
+	// Init:
+	setupTestCase()
+	createTestDB()
+	defer db.Cease()
+	svr := TheServer()
+	if err := svr.Init("localhost", "", UDPProto, "10.9.0.0/16", "", "", "", false); err != nil {
+		t.Fatalf("server can not be initialized: %v", err)
+	}
+	if _, err := svr.CreateNewPool("contractors", "10.9.1.0/24"); err != nil {
+		t.Fatalf("pool can not be created: %v", err)
+	}
+	usr1, err := CreateNewUser("usr1", "1234", false, 0, true, "description", WithPool("contractors"))
+	if err != nil {
+		t.Fatalf("user can not be created: %v", err)
+	}
+	if _, err := CreateNewUser("usr2", "1234", false, 0, true, "description"); err != nil {
+		t.Fatalf("user can not be created: %v", err)
+	}
+
+	// Test:
+	var acltests = []struct {
+		username    string
+		pool        string
+		action      string
+		destination string
+		proto       string
+		port        string
+		ok          bool
+	}{
+		{"", "contractors", ACLDeny, "192.168.1.0/24", "", "", true},
+		{"usr1", "", ACLAllow, "192.168.1.10/32", ACLTCP, "443", true},
+		{"usr2", "", ACLDeny, "0.0.0.0/0", ACLUDP, "53:54", true},
+		{"usr1", "contractors", ACLAllow, "192.168.1.0/24", "", "", false}, // Both user and pool.
+		{"", "", ACLAllow, "192.168.1.0/24", "", "", false},                // Neither user nor pool.
+		{"usr1", "", "reject", "192.168.1.0/24", "", "", false},            // Invalid action.
+		{"usr1", "", ACLAllow, "192.168.1.0", "", "", false},               // Not a CIDR.
+		{"usr1", "", ACLAllow, "192.168.1.0/24", "sctp", "", false},        // Invalid protocol.
+		{"usr1", "", ACLAllow, "192.168.1.0/24", ACLICMP, "80", false},     // Port without tcp or udp.
+		{"usr1", "", ACLAllow, "192.168.1.0/24", ACLTCP, "90:80", false},   // Descending range.
+		{"", "contractors", ACLAllow, "fd00::/64", "", "", false},          // IPv6 destination for a pool.
+		{"missing", "", ACLAllow, "192.168.1.0/24", "", "", false},
+	}
+	for _, tt := range acltests {
+		if _, err := CreateNewACLRule(tt.username, tt.pool, tt.action, tt.destination, tt.proto, tt.port); (err == nil) != tt.ok {
+			t.Errorf("rule %+v is expected to be created: %t, got %v", tt, tt.ok, err)
+		}
+	}
+
+	// Rules of the users come before the rules of the pools.
+	rules, err := aclFirewallRules(false)
+	if err != nil {
+		t.Fatalf("rules can not be rendered: %v", err)
+	}
+	expected := []firewallRule{
+		{Source: usr1.getIP().String(), Destination: "192.168.1.10/32", Proto: ACLTCP, Port: "443", Accept: true},
+		{Source: "10.9.0.2", Destination: "0.0.0.0/0", Proto: ACLUDP, Port: "53:54"},
+		{Source: "10.9.1.0/24", Destination: "192.168.1.0/24"},
+	}
+	if !reflect.DeepEqual(rules, expected) {
+		t.Errorf("unexpected rules:\n%v\nexpected:\n%v", rules, expected)
+	}
+	if rules, _ := aclFirewallRules(true); len(rules) != 0 {
+		t.Errorf("IPv4 rules are not expected to be rendered for IPv6: %v", rules)
+	}
+	if got := testFirewall.plans[false].ACL; !reflect.DeepEqual(got, expected) {
+		t.Errorf("rules are expected to be installed as they change: %v", got)
+	}
+
+	// Rules are deleted along with their users and pools.
+	usr1.Delete()
+	contractors, _ := GetPool("contractors")
+	if err := contractors.Delete(); err != nil {
+		t.Fatalf("pool can not be deleted: %v", err)
+	}
+	left, _ := GetAllACLRules()
+	if len(left) != 1 || left[0].GetUsername() != "usr2" {
+		t.Errorf("only the rule of usr2 is expected to be left: %v", left)
+	}
+	if err := left[0].Delete(); err != nil {
+		t.Errorf("rule can not be deleted: %v", err)
+	}
+	if len(testFirewall.plans[false].ACL) != 0 {
+		t.Errorf("deleted rules are expected to be uninstalled: %v", testFirewall.plans[false].ACL)
+	}
+	if _, err := GetACLRule(left[0].GetID()); err == nil {
+		t.Error("deleted rule is not expected to be found")
+	}
+}
@@ -14,11 +14,20 @@ import (
 )
 
 func authRequired(ctx gcontext.Context, req interface{}, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	newCtx, err := authorize(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return handler(newCtx, req)
+}
+
+// authorize returns the context of the user whose token is in the context.
+func authorize(ctx gcontext.Context) (gcontext.Context, error) {
 	logrus.Debugln("rpc: auth applied")
 	token, err := authzTokenFromContext(ctx)
 	if err != nil {
 		logrus.Debugln("rpc: auth denied because token can not be gathered from header contest")
-		return nil, grpc.Errorf(codes.Unauthenticated, err.Error())
+		return nil, grpc.Errorf(codes.Unauthenticated, "%v", err)
 	}
 	user, err := ovpm.GetUserByToken(token)
 	if err != nil {
@@ -35,8 +44,7 @@ func authRequired(ctx gcontext.Context, req interface{}, handler grpc.UnaryHandl
 	}
 
 	newCtx := NewUsernameContext(ctx, user.GetUsername())
-	newCtx = permset.NewContext(newCtx, permissions)
-	return handler(newCtx, req)
+	return permset.NewContext(newCtx, permissions), nil
 }
 
 func authzTokenFromContext(ctx gcontext.Context) (string, error) {
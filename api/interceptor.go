@@ -17,43 +17,15 @@ import (
 //
 // See https://godoc.org/google.golang.org/grpc#UnaryServerInterceptor.
 func AuthUnaryInterceptor(ctx gcontext.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
-	var enableAuthCheck bool
-
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
 		return nil, fmt.Errorf("Expected 2 metadata items in context; got %v", md)
 	}
 
-	// We enable auth check if we find a non-loopback
-	// or invalid IP in the headers coming from the grpc-gateway.
-	for _, userAgentIP := range md["x-forwarded-for"] {
-		// Check if the remote user IP addr is a proper IP addr.
-		if !govalidator.IsIP(userAgentIP) {
-			enableAuthCheck = true
-			logrus.Debugf("grpc request user agent ip can not be fetched from x-forwarded-for metadata, enabling auth check module '%s'", userAgentIP)
-			break
-		}
-
-		// Check if the remote user IP addr is a loopback IP addr.
-		if ip := net.ParseIP(userAgentIP); !ip.IsLoopback() {
-			enableAuthCheck = true
-			logrus.Debugf("grpc request user agent ips include non-loopback ip, enabling auth check module '%s'", userAgentIP)
-			break
-		}
-
-		// TODO(cad): We assume gRPC endpoints are for cli only therefore
-		//            we are listening only on looback IP.
-		//
-		// But if we decide use gRPC endpoints publicly, we need to add
-		// extra checks against gRPC remote peer IP to test if the request
-		// is coming from a remote peer IP or also from a loopback ip.
-	}
-
+	enableAuthCheck := authCheckEnabled(md)
 	if !enableAuthCheck {
 		logrus.Debugf("rpc: auth-check not enabled: %s", md["x-forwarded-for"])
-		ctx = NewUsernameContext(ctx, "root")
-		permissions := permset.New(ovpm.AdminPerms()...)
-		ctx = permset.NewContext(ctx, permissions)
+		ctx = rootContext(ctx)
 	}
 
 	if enableAuthCheck {
@@ -75,6 +47,12 @@ func AuthUnaryInterceptor(ctx gcontext.Context, req interface{}, info *grpc.Unar
 			return authRequired(ctx, req, handler)
 		case "/pb.UserService/GenConfig":
 			return authRequired(ctx, req, handler)
+		case "/pb.UserService/Disconnect":
+			return authRequired(ctx, req, handler)
+		case "/pb.UserService/GetDirectives":
+			return authRequired(ctx, req, handler)
+		case "/pb.UserService/SetDirectives":
+			return authRequired(ctx, req, handler)
 
 		// VPNService methods
 		case "/pb.VPNService/Status":
@@ -85,6 +63,42 @@ func AuthUnaryInterceptor(ctx gcontext.Context, req interface{}, info *grpc.Unar
 			return authRequired(ctx, req, handler)
 		case "/pb.VPNService/Restart":
 			return authRequired(ctx, req, handler)
+		case "/pb.VPNService/List":
+			return authRequired(ctx, req, handler)
+		case "/pb.VPNService/Expiring":
+			return authRequired(ctx, req, handler)
+		case "/pb.VPNService/RotateTLSKey":
+			return authRequired(ctx, req, handler)
+		case "/pb.VPNService/StartCARotation":
+			return authRequired(ctx, req, handler)
+		case "/pb.VPNService/FinishCARotation":
+			return authRequired(ctx, req, handler)
+		case "/pb.VPNService/CARotationStatus":
+			return authRequired(ctx, req, handler)
+		case "/pb.VPNService/Backup":
+			return authRequired(ctx, req, handler)
+		case "/pb.VPNService/Restore":
+			return authRequired(ctx, req, handler)
+		case "/pb.VPNService/GetDirectives":
+			return authRequired(ctx, req, handler)
+		case "/pb.VPNService/SetDirectives":
+			return authRequired(ctx, req, handler)
+		case "/pb.VPNService/RepackLeases":
+			return authRequired(ctx, req, handler)
+		case "/pb.VPNService/CreatePool":
+			return authRequired(ctx, req, handler)
+		case "/pb.VPNService/ListPools":
+			return authRequired(ctx, req, handler)
+		case "/pb.VPNService/DeletePool":
+			return authRequired(ctx, req, handler)
+		case "/pb.VPNService/AddACLRule":
+			return authRequired(ctx, req, handler)
+		case "/pb.VPNService/ListACLRules":
+			return authRequired(ctx, req, handler)
+		case "/pb.VPNService/DeleteACLRule":
+			return authRequired(ctx, req, handler)
+		case "/pb.VPNService/ShowFirewall":
+			return authRequired(ctx, req, handler)
 
 		// NetworkService methods
 		case "/pb.NetworkService/Create":
@@ -118,3 +132,77 @@ func AuthUnaryInterceptor(ctx gcontext.Context, req interface{}, info *grpc.Unar
 	}
 	return handler(ctx, req)
 }
+
+// AuthStreamInterceptor is the AuthUnaryInterceptor of the streaming rpcs.
+//
+// See https://godoc.org/google.golang.org/grpc#StreamServerInterceptor.
+func AuthStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx := ss.Context()
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return fmt.Errorf("Expected 2 metadata items in context; got %v", md)
+	}
+
+	if !authCheckEnabled(md) {
+		logrus.Debugf("rpc: auth-check not enabled: %s", md["x-forwarded-for"])
+		ctx = rootContext(ctx)
+	} else {
+		switch info.FullMethod {
+		// UserService methods
+		case "/pb.UserService/Import":
+			var err error
+			if ctx, err = authorize(ctx); err != nil {
+				return err
+			}
+
+		default:
+			logrus.Debugf("rpc: auth not required for endpoint: '%s'", info.FullMethod)
+		}
+	}
+	return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+}
+
+// contextStream is a server stream with a replaced context.
+type contextStream struct {
+	grpc.ServerStream
+	ctx gcontext.Context
+}
+
+func (s *contextStream) Context() gcontext.Context {
+	return s.ctx
+}
+
+// authCheckEnabled tells whether the request should be authenticated.
+//
+// We enable auth check if we find a non-loopback or invalid IP in the
+// headers coming from the grpc-gateway.
+func authCheckEnabled(md metadata.MD) bool {
+	for _, userAgentIP := range md["x-forwarded-for"] {
+		// Check if the remote user IP addr is a proper IP addr.
+		if !govalidator.IsIP(userAgentIP) {
+			logrus.Debugf("grpc request user agent ip can not be fetched from x-forwarded-for metadata, enabling auth check module '%s'", userAgentIP)
+			return true
+		}
+
+		// Check if the remote user IP addr is a loopback IP addr.
+		if ip := net.ParseIP(userAgentIP); !ip.IsLoopback() {
+			logrus.Debugf("grpc request user agent ips include non-loopback ip, enabling auth check module '%s'", userAgentIP)
+			return true
+		}
+
+		// TODO(cad): We assume gRPC endpoints are for cli only therefore
+		//            we are listening only on looback IP.
+		//
+		// But if we decide use gRPC endpoints publicly, we need to add
+		// extra checks against gRPC remote peer IP to test if the request
+		// is coming from a remote peer IP or also from a loopback ip.
+	}
+	return false
+}
+
+// rootContext returns the context of the requests that aren't authenticated,
+// which have all of the permissions.
+func rootContext(ctx gcontext.Context) gcontext.Context {
+	ctx = NewUsernameContext(ctx, "root")
+	return permset.NewContext(ctx, permset.New(ovpm.AdminPerms()...))
+}
@@ -28,6 +28,10 @@ type UserServiceClient interface {
 	Delete(ctx context.Context, in *UserDeleteRequest, opts ...grpc.CallOption) (*UserResponse, error)
 	Renew(ctx context.Context, in *UserRenewRequest, opts ...grpc.CallOption) (*UserResponse, error)
 	GenConfig(ctx context.Context, in *UserGenConfigRequest, opts ...grpc.CallOption) (*UserGenConfigResponse, error)
+	Disconnect(ctx context.Context, in *UserDisconnectRequest, opts ...grpc.CallOption) (*UserResponse, error)
+	GetDirectives(ctx context.Context, in *UserDirectivesRequest, opts ...grpc.CallOption) (*UserDirectivesResponse, error)
+	SetDirectives(ctx context.Context, in *UserSetDirectivesRequest, opts ...grpc.CallOption) (*UserDirectivesResponse, error)
+	Import(ctx context.Context, opts ...grpc.CallOption) (UserService_ImportClient, error)
 }
 
 type userServiceClient struct {
@@ -92,6 +96,67 @@ func (c *userServiceClient) GenConfig(ctx context.Context, in *UserGenConfigRequ
 	return out, nil
 }
 
+func (c *userServiceClient) Disconnect(ctx context.Context, in *UserDisconnectRequest, opts ...grpc.CallOption) (*UserResponse, error) {
+	out := new(UserResponse)
+	err := c.cc.Invoke(ctx, "/pb.UserService/Disconnect", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetDirectives(ctx context.Context, in *UserDirectivesRequest, opts ...grpc.CallOption) (*UserDirectivesResponse, error) {
+	out := new(UserDirectivesResponse)
+	err := c.cc.Invoke(ctx, "/pb.UserService/GetDirectives", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) SetDirectives(ctx context.Context, in *UserSetDirectivesRequest, opts ...grpc.CallOption) (*UserDirectivesResponse, error) {
+	out := new(UserDirectivesResponse)
+	err := c.cc.Invoke(ctx, "/pb.UserService/SetDirectives", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) Import(ctx context.Context, opts ...grpc.CallOption) (UserService_ImportClient, error) {
+	stream, err := c.cc.NewStream(ctx, &UserService_ServiceDesc.Streams[0], "/pb.UserService/Import", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &userServiceImportClient{stream}
+	return x, nil
+}
+
+type UserService_ImportClient interface {
+	Send(*UserImportRequest) error
+	CloseAndRecv() (*UserImportResponse, error)
+	grpc.ClientStream
+}
+
+type userServiceImportClient struct {
+	grpc.ClientStream
+}
+
+func (x *userServiceImportClient) Send(m *UserImportRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *userServiceImportClient) CloseAndRecv() (*UserImportResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(UserImportResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // UserServiceServer is the server API for UserService service.
 // All implementations must embed UnimplementedUserServiceServer
 // for forward compatibility
@@ -102,6 +167,10 @@ type UserServiceServer interface {
 	Delete(context.Context, *UserDeleteRequest) (*UserResponse, error)
 	Renew(context.Context, *UserRenewRequest) (*UserResponse, error)
 	GenConfig(context.Context, *UserGenConfigRequest) (*UserGenConfigResponse, error)
+	Disconnect(context.Context, *UserDisconnectRequest) (*UserResponse, error)
+	GetDirectives(context.Context, *UserDirectivesRequest) (*UserDirectivesResponse, error)
+	SetDirectives(context.Context, *UserSetDirectivesRequest) (*UserDirectivesResponse, error)
+	Import(UserService_ImportServer) error
 	mustEmbedUnimplementedUserServiceServer()
 }
 
@@ -127,6 +196,18 @@ func (UnimplementedUserServiceServer) Renew(context.Context, *UserRenewRequest)
 func (UnimplementedUserServiceServer) GenConfig(context.Context, *UserGenConfigRequest) (*UserGenConfigResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GenConfig not implemented")
 }
+func (UnimplementedUserServiceServer) Disconnect(context.Context, *UserDisconnectRequest) (*UserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Disconnect not implemented")
+}
+func (UnimplementedUserServiceServer) GetDirectives(context.Context, *UserDirectivesRequest) (*UserDirectivesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDirectives not implemented")
+}
+func (UnimplementedUserServiceServer) SetDirectives(context.Context, *UserSetDirectivesRequest) (*UserDirectivesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetDirectives not implemented")
+}
+func (UnimplementedUserServiceServer) Import(UserService_ImportServer) error {
+	return status.Errorf(codes.Unimplemented, "method Import not implemented")
+}
 func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
 
 // UnsafeUserServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -248,6 +329,86 @@ func _UserService_GenConfig_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_Disconnect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserDisconnectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).Disconnect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.UserService/Disconnect",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).Disconnect(ctx, req.(*UserDisconnectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetDirectives_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserDirectivesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetDirectives(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.UserService/GetDirectives",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetDirectives(ctx, req.(*UserDirectivesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_SetDirectives_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserSetDirectivesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).SetDirectives(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.UserService/SetDirectives",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).SetDirectives(ctx, req.(*UserSetDirectivesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_Import_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(UserServiceServer).Import(&userServiceImportServer{stream})
+}
+
+type UserService_ImportServer interface {
+	SendAndClose(*UserImportResponse) error
+	Recv() (*UserImportRequest, error)
+	grpc.ServerStream
+}
+
+type userServiceImportServer struct {
+	grpc.ServerStream
+}
+
+func (x *userServiceImportServer) SendAndClose(m *UserImportResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *userServiceImportServer) Recv() (*UserImportRequest, error) {
+	m := new(UserImportRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -279,7 +440,25 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GenConfig",
 			Handler:    _UserService_GenConfig_Handler,
 		},
+		{
+			MethodName: "Disconnect",
+			Handler:    _UserService_Disconnect_Handler,
+		},
+		{
+			MethodName: "GetDirectives",
+			Handler:    _UserService_GetDirectives_Handler,
+		},
+		{
+			MethodName: "SetDirectives",
+			Handler:    _UserService_SetDirectives_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Import",
+			Handler:       _UserService_Import_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "user.proto",
 }
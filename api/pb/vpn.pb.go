@@ -119,10 +119,156 @@ func (VPNLZOPref) EnumDescriptor() ([]byte, []int) {
 	return file_vpn_proto_rawDescGZIP(), []int{1}
 }
 
+type VPNResolverPref int32
+
+const (
+	VPNResolverPref_RESOLVER_NOPREF  VPNResolverPref = 0
+	VPNResolverPref_RESOLVER_ENABLE  VPNResolverPref = 1
+	VPNResolverPref_RESOLVER_DISABLE VPNResolverPref = 2
+)
+
+// Enum value maps for VPNResolverPref.
+var (
+	VPNResolverPref_name = map[int32]string{
+		0: "RESOLVER_NOPREF",
+		1: "RESOLVER_ENABLE",
+		2: "RESOLVER_DISABLE",
+	}
+	VPNResolverPref_value = map[string]int32{
+		"RESOLVER_NOPREF":  0,
+		"RESOLVER_ENABLE":  1,
+		"RESOLVER_DISABLE": 2,
+	}
+)
+
+func (x VPNResolverPref) Enum() *VPNResolverPref {
+	p := new(VPNResolverPref)
+	*p = x
+	return p
+}
+
+func (x VPNResolverPref) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (VPNResolverPref) Descriptor() protoreflect.EnumDescriptor {
+	return file_vpn_proto_enumTypes[2].Descriptor()
+}
+
+func (VPNResolverPref) Type() protoreflect.EnumType {
+	return &file_vpn_proto_enumTypes[2]
+}
+
+func (x VPNResolverPref) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use VPNResolverPref.Descriptor instead.
+func (VPNResolverPref) EnumDescriptor() ([]byte, []int) {
+	return file_vpn_proto_rawDescGZIP(), []int{2}
+}
+
+type VPNDHPref int32
+
+const (
+	VPNDHPref_DH_NOPREF   VPNDHPref = 0
+	VPNDHPref_DH_GENERATE VPNDHPref = 1
+	VPNDHPref_DH_NONE     VPNDHPref = 2
+)
+
+// Enum value maps for VPNDHPref.
+var (
+	VPNDHPref_name = map[int32]string{
+		0: "DH_NOPREF",
+		1: "DH_GENERATE",
+		2: "DH_NONE",
+	}
+	VPNDHPref_value = map[string]int32{
+		"DH_NOPREF":   0,
+		"DH_GENERATE": 1,
+		"DH_NONE":     2,
+	}
+)
+
+func (x VPNDHPref) Enum() *VPNDHPref {
+	p := new(VPNDHPref)
+	*p = x
+	return p
+}
+
+func (x VPNDHPref) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (VPNDHPref) Descriptor() protoreflect.EnumDescriptor {
+	return file_vpn_proto_enumTypes[3].Descriptor()
+}
+
+func (VPNDHPref) Type() protoreflect.EnumType {
+	return &file_vpn_proto_enumTypes[3]
+}
+
+func (x VPNDHPref) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use VPNDHPref.Descriptor instead.
+func (VPNDHPref) EnumDescriptor() ([]byte, []int) {
+	return file_vpn_proto_rawDescGZIP(), []int{3}
+}
+
+type VPNDirectiveScope int32
+
+const (
+	VPNDirectiveScope_SERVER_CONF    VPNDirectiveScope = 0
+	VPNDirectiveScope_CLIENT_PROFILE VPNDirectiveScope = 1
+)
+
+// Enum value maps for VPNDirectiveScope.
+var (
+	VPNDirectiveScope_name = map[int32]string{
+		0: "SERVER_CONF",
+		1: "CLIENT_PROFILE",
+	}
+	VPNDirectiveScope_value = map[string]int32{
+		"SERVER_CONF":    0,
+		"CLIENT_PROFILE": 1,
+	}
+)
+
+func (x VPNDirectiveScope) Enum() *VPNDirectiveScope {
+	p := new(VPNDirectiveScope)
+	*p = x
+	return p
+}
+
+func (x VPNDirectiveScope) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (VPNDirectiveScope) Descriptor() protoreflect.EnumDescriptor {
+	return file_vpn_proto_enumTypes[4].Descriptor()
+}
+
+func (VPNDirectiveScope) Type() protoreflect.EnumType {
+	return &file_vpn_proto_enumTypes[4]
+}
+
+func (x VPNDirectiveScope) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use VPNDirectiveScope.Descriptor instead.
+func (VPNDirectiveScope) EnumDescriptor() ([]byte, []int) {
+	return file_vpn_proto_rawDescGZIP(), []int{4}
+}
+
 type VPNStatusRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Server string `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
 }
 
 func (x *VPNStatusRequest) Reset() {
@@ -157,19 +303,40 @@ func (*VPNStatusRequest) Descriptor() ([]byte, []int) {
 	return file_vpn_proto_rawDescGZIP(), []int{0}
 }
 
+func (x *VPNStatusRequest) GetServer() string {
+	if x != nil {
+		return x.Server
+	}
+	return ""
+}
+
 type VPNInitRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Hostname         string   `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
-	Port             string   `protobuf:"bytes,2,opt,name=port,proto3" json:"port,omitempty"`
-	ProtoPref        VPNProto `protobuf:"varint,3,opt,name=proto_pref,json=protoPref,proto3,enum=pb.VPNProto" json:"proto_pref,omitempty"`
-	IpBlock          string   `protobuf:"bytes,4,opt,name=ip_block,json=ipBlock,proto3" json:"ip_block,omitempty"`
-	Dns              string   `protobuf:"bytes,5,opt,name=dns,proto3" json:"dns,omitempty"`
-	KeepalivePeriod  string   `protobuf:"bytes,6,opt,name=keepalive_period,json=keepalivePeriod,proto3" json:"keepalive_period,omitempty"`
-	KeepaliveTimeout string   `protobuf:"bytes,7,opt,name=keepalive_timeout,json=keepaliveTimeout,proto3" json:"keepalive_timeout,omitempty"`
-	UseLzo           bool     `protobuf:"varint,8,opt,name=use_lzo,json=useLzo,proto3" json:"use_lzo,omitempty"`
+	Hostname         string    `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Port             string    `protobuf:"bytes,2,opt,name=port,proto3" json:"port,omitempty"`
+	ProtoPref        VPNProto  `protobuf:"varint,3,opt,name=proto_pref,json=protoPref,proto3,enum=pb.VPNProto" json:"proto_pref,omitempty"`
+	IpBlock          string    `protobuf:"bytes,4,opt,name=ip_block,json=ipBlock,proto3" json:"ip_block,omitempty"`
+	Dns              string    `protobuf:"bytes,5,opt,name=dns,proto3" json:"dns,omitempty"`
+	KeepalivePeriod  string    `protobuf:"bytes,6,opt,name=keepalive_period,json=keepalivePeriod,proto3" json:"keepalive_period,omitempty"`
+	KeepaliveTimeout string    `protobuf:"bytes,7,opt,name=keepalive_timeout,json=keepaliveTimeout,proto3" json:"keepalive_timeout,omitempty"`
+	UseLzo           bool      `protobuf:"varint,8,opt,name=use_lzo,json=useLzo,proto3" json:"use_lzo,omitempty"`
+	Server           string    `protobuf:"bytes,9,opt,name=server,proto3" json:"server,omitempty"`
+	CaFrom           string    `protobuf:"bytes,10,opt,name=ca_from,json=caFrom,proto3" json:"ca_from,omitempty"`
+	KeyAlgorithm     string    `protobuf:"bytes,11,opt,name=key_algorithm,json=keyAlgorithm,proto3" json:"key_algorithm,omitempty"`
+	RsaKeySize       int32     `protobuf:"varint,12,opt,name=rsa_key_size,json=rsaKeySize,proto3" json:"rsa_key_size,omitempty"`
+	CertValidityDays int32     `protobuf:"varint,13,opt,name=cert_validity_days,json=certValidityDays,proto3" json:"cert_validity_days,omitempty"`
+	DhPref           VPNDHPref `protobuf:"varint,14,opt,name=dh_pref,json=dhPref,proto3,enum=pb.VPNDHPref" json:"dh_pref,omitempty"`
+	TlsKeyMode       string    `protobuf:"bytes,15,opt,name=tls_key_mode,json=tlsKeyMode,proto3" json:"tls_key_mode,omitempty"`
+	DataCiphers      string    `protobuf:"bytes,16,opt,name=data_ciphers,json=dataCiphers,proto3" json:"data_ciphers,omitempty"`
+	AuthDigest       string    `protobuf:"bytes,17,opt,name=auth_digest,json=authDigest,proto3" json:"auth_digest,omitempty"`
+	TlsVersionMin    string    `protobuf:"bytes,18,opt,name=tls_version_min,json=tlsVersionMin,proto3" json:"tls_version_min,omitempty"`
+	Ipv6Block        string    `protobuf:"bytes,19,opt,name=ipv6_block,json=ipv6Block,proto3" json:"ipv6_block,omitempty"`
+	DnsDomain        string    `protobuf:"bytes,20,opt,name=dns_domain,json=dnsDomain,proto3" json:"dns_domain,omitempty"`
+	DnsSearch        string    `protobuf:"bytes,21,opt,name=dns_search,json=dnsSearch,proto3" json:"dns_search,omitempty"`
+	Resolver         bool      `protobuf:"varint,22,opt,name=resolver,proto3" json:"resolver,omitempty"`
 }
 
 func (x *VPNInitRequest) Reset() {
@@ -260,145 +427,141 @@ func (x *VPNInitRequest) GetUseLzo() bool {
 	return false
 }
 
-type VPNUpdateRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	IpBlock string     `protobuf:"bytes,1,opt,name=ip_block,json=ipBlock,proto3" json:"ip_block,omitempty"`
-	Dns     string     `protobuf:"bytes,2,opt,name=dns,proto3" json:"dns,omitempty"`
-	LzoPref VPNLZOPref `protobuf:"varint,3,opt,name=lzo_pref,json=lzoPref,proto3,enum=pb.VPNLZOPref" json:"lzo_pref,omitempty"`
+func (x *VPNInitRequest) GetServer() string {
+	if x != nil {
+		return x.Server
+	}
+	return ""
 }
 
-func (x *VPNUpdateRequest) Reset() {
-	*x = VPNUpdateRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_vpn_proto_msgTypes[2]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *VPNInitRequest) GetCaFrom() string {
+	if x != nil {
+		return x.CaFrom
 	}
+	return ""
 }
 
-func (x *VPNUpdateRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *VPNInitRequest) GetKeyAlgorithm() string {
+	if x != nil {
+		return x.KeyAlgorithm
+	}
+	return ""
 }
 
-func (*VPNUpdateRequest) ProtoMessage() {}
+func (x *VPNInitRequest) GetRsaKeySize() int32 {
+	if x != nil {
+		return x.RsaKeySize
+	}
+	return 0
+}
 
-func (x *VPNUpdateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_vpn_proto_msgTypes[2]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *VPNInitRequest) GetCertValidityDays() int32 {
+	if x != nil {
+		return x.CertValidityDays
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use VPNUpdateRequest.ProtoReflect.Descriptor instead.
-func (*VPNUpdateRequest) Descriptor() ([]byte, []int) {
-	return file_vpn_proto_rawDescGZIP(), []int{2}
+func (x *VPNInitRequest) GetDhPref() VPNDHPref {
+	if x != nil {
+		return x.DhPref
+	}
+	return VPNDHPref_DH_NOPREF
 }
 
-func (x *VPNUpdateRequest) GetIpBlock() string {
+func (x *VPNInitRequest) GetTlsKeyMode() string {
 	if x != nil {
-		return x.IpBlock
+		return x.TlsKeyMode
 	}
 	return ""
 }
 
-func (x *VPNUpdateRequest) GetDns() string {
+func (x *VPNInitRequest) GetDataCiphers() string {
 	if x != nil {
-		return x.Dns
+		return x.DataCiphers
 	}
 	return ""
 }
 
-func (x *VPNUpdateRequest) GetLzoPref() VPNLZOPref {
+func (x *VPNInitRequest) GetAuthDigest() string {
 	if x != nil {
-		return x.LzoPref
+		return x.AuthDigest
 	}
-	return VPNLZOPref_USE_LZO_NOPREF
+	return ""
 }
 
-type VPNRestartRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *VPNInitRequest) GetTlsVersionMin() string {
+	if x != nil {
+		return x.TlsVersionMin
+	}
+	return ""
 }
 
-func (x *VPNRestartRequest) Reset() {
-	*x = VPNRestartRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_vpn_proto_msgTypes[3]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *VPNInitRequest) GetIpv6Block() string {
+	if x != nil {
+		return x.Ipv6Block
 	}
+	return ""
 }
 
-func (x *VPNRestartRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *VPNInitRequest) GetDnsDomain() string {
+	if x != nil {
+		return x.DnsDomain
+	}
+	return ""
 }
 
-func (*VPNRestartRequest) ProtoMessage() {}
-
-func (x *VPNRestartRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_vpn_proto_msgTypes[3]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *VPNInitRequest) GetDnsSearch() string {
+	if x != nil {
+		return x.DnsSearch
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use VPNRestartRequest.ProtoReflect.Descriptor instead.
-func (*VPNRestartRequest) Descriptor() ([]byte, []int) {
-	return file_vpn_proto_rawDescGZIP(), []int{3}
+func (x *VPNInitRequest) GetResolver() bool {
+	if x != nil {
+		return x.Resolver
+	}
+	return false
 }
 
-type VPNStatusResponse struct {
+type VPNUpdateRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name         string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	SerialNumber string `protobuf:"bytes,2,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
-	Hostname     string `protobuf:"bytes,3,opt,name=hostname,proto3" json:"hostname,omitempty"`
-	Port         string `protobuf:"bytes,4,opt,name=port,proto3" json:"port,omitempty"`
-	Cert         string `protobuf:"bytes,5,opt,name=cert,proto3" json:"cert,omitempty"`
-	CaCert       string `protobuf:"bytes,6,opt,name=ca_cert,json=caCert,proto3" json:"ca_cert,omitempty"`
-	Net          string `protobuf:"bytes,7,opt,name=net,proto3" json:"net,omitempty"`
-	Mask         string `protobuf:"bytes,8,opt,name=mask,proto3" json:"mask,omitempty"`
-	CreatedAt    string `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	Proto        string `protobuf:"bytes,10,opt,name=proto,proto3" json:"proto,omitempty"`
-	Dns          string `protobuf:"bytes,11,opt,name=dns,proto3" json:"dns,omitempty"`
-	ExpiresAt    string `protobuf:"bytes,12,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
-	CaExpiresAt  string `protobuf:"bytes,13,opt,name=ca_expires_at,json=caExpiresAt,proto3" json:"ca_expires_at,omitempty"`
-	UseLzo       bool   `protobuf:"varint,14,opt,name=use_lzo,json=useLzo,proto3" json:"use_lzo,omitempty"`
+	IpBlock       string          `protobuf:"bytes,1,opt,name=ip_block,json=ipBlock,proto3" json:"ip_block,omitempty"`
+	Dns           string          `protobuf:"bytes,2,opt,name=dns,proto3" json:"dns,omitempty"`
+	LzoPref       VPNLZOPref      `protobuf:"varint,3,opt,name=lzo_pref,json=lzoPref,proto3,enum=pb.VPNLZOPref" json:"lzo_pref,omitempty"`
+	Server        string          `protobuf:"bytes,4,opt,name=server,proto3" json:"server,omitempty"`
+	DhPref        VPNDHPref       `protobuf:"varint,5,opt,name=dh_pref,json=dhPref,proto3,enum=pb.VPNDHPref" json:"dh_pref,omitempty"`
+	TlsKeyMode    string          `protobuf:"bytes,6,opt,name=tls_key_mode,json=tlsKeyMode,proto3" json:"tls_key_mode,omitempty"`
+	DataCiphers   string          `protobuf:"bytes,7,opt,name=data_ciphers,json=dataCiphers,proto3" json:"data_ciphers,omitempty"`
+	AuthDigest    string          `protobuf:"bytes,8,opt,name=auth_digest,json=authDigest,proto3" json:"auth_digest,omitempty"`
+	TlsVersionMin string          `protobuf:"bytes,9,opt,name=tls_version_min,json=tlsVersionMin,proto3" json:"tls_version_min,omitempty"`
+	Ipv6Block     string          `protobuf:"bytes,10,opt,name=ipv6_block,json=ipv6Block,proto3" json:"ipv6_block,omitempty"`
+	DnsDomain     string          `protobuf:"bytes,11,opt,name=dns_domain,json=dnsDomain,proto3" json:"dns_domain,omitempty"`
+	DnsSearch     string          `protobuf:"bytes,12,opt,name=dns_search,json=dnsSearch,proto3" json:"dns_search,omitempty"`
+	ResolverPref  VPNResolverPref `protobuf:"varint,13,opt,name=resolver_pref,json=resolverPref,proto3,enum=pb.VPNResolverPref" json:"resolver_pref,omitempty"`
 }
 
-func (x *VPNStatusResponse) Reset() {
-	*x = VPNStatusResponse{}
+func (x *VPNUpdateRequest) Reset() {
+	*x = VPNUpdateRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_vpn_proto_msgTypes[4]
+		mi := &file_vpn_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *VPNStatusResponse) String() string {
+func (x *VPNUpdateRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VPNStatusResponse) ProtoMessage() {}
+func (*VPNUpdateRequest) ProtoMessage() {}
 
-func (x *VPNStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_vpn_proto_msgTypes[4]
+func (x *VPNUpdateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vpn_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -409,132 +572,127 @@ func (x *VPNStatusResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VPNStatusResponse.ProtoReflect.Descriptor instead.
-func (*VPNStatusResponse) Descriptor() ([]byte, []int) {
-	return file_vpn_proto_rawDescGZIP(), []int{4}
-}
-
-func (x *VPNStatusResponse) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
+// Deprecated: Use VPNUpdateRequest.ProtoReflect.Descriptor instead.
+func (*VPNUpdateRequest) Descriptor() ([]byte, []int) {
+	return file_vpn_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *VPNStatusResponse) GetSerialNumber() string {
+func (x *VPNUpdateRequest) GetIpBlock() string {
 	if x != nil {
-		return x.SerialNumber
+		return x.IpBlock
 	}
 	return ""
 }
 
-func (x *VPNStatusResponse) GetHostname() string {
+func (x *VPNUpdateRequest) GetDns() string {
 	if x != nil {
-		return x.Hostname
+		return x.Dns
 	}
 	return ""
 }
 
-func (x *VPNStatusResponse) GetPort() string {
+func (x *VPNUpdateRequest) GetLzoPref() VPNLZOPref {
 	if x != nil {
-		return x.Port
+		return x.LzoPref
 	}
-	return ""
+	return VPNLZOPref_USE_LZO_NOPREF
 }
 
-func (x *VPNStatusResponse) GetCert() string {
+func (x *VPNUpdateRequest) GetServer() string {
 	if x != nil {
-		return x.Cert
+		return x.Server
 	}
 	return ""
 }
 
-func (x *VPNStatusResponse) GetCaCert() string {
+func (x *VPNUpdateRequest) GetDhPref() VPNDHPref {
 	if x != nil {
-		return x.CaCert
+		return x.DhPref
 	}
-	return ""
+	return VPNDHPref_DH_NOPREF
 }
 
-func (x *VPNStatusResponse) GetNet() string {
+func (x *VPNUpdateRequest) GetTlsKeyMode() string {
 	if x != nil {
-		return x.Net
+		return x.TlsKeyMode
 	}
 	return ""
 }
 
-func (x *VPNStatusResponse) GetMask() string {
+func (x *VPNUpdateRequest) GetDataCiphers() string {
 	if x != nil {
-		return x.Mask
+		return x.DataCiphers
 	}
 	return ""
 }
 
-func (x *VPNStatusResponse) GetCreatedAt() string {
+func (x *VPNUpdateRequest) GetAuthDigest() string {
 	if x != nil {
-		return x.CreatedAt
+		return x.AuthDigest
 	}
 	return ""
 }
 
-func (x *VPNStatusResponse) GetProto() string {
+func (x *VPNUpdateRequest) GetTlsVersionMin() string {
 	if x != nil {
-		return x.Proto
+		return x.TlsVersionMin
 	}
 	return ""
 }
 
-func (x *VPNStatusResponse) GetDns() string {
+func (x *VPNUpdateRequest) GetIpv6Block() string {
 	if x != nil {
-		return x.Dns
+		return x.Ipv6Block
 	}
 	return ""
 }
 
-func (x *VPNStatusResponse) GetExpiresAt() string {
+func (x *VPNUpdateRequest) GetDnsDomain() string {
 	if x != nil {
-		return x.ExpiresAt
+		return x.DnsDomain
 	}
 	return ""
 }
 
-func (x *VPNStatusResponse) GetCaExpiresAt() string {
+func (x *VPNUpdateRequest) GetDnsSearch() string {
 	if x != nil {
-		return x.CaExpiresAt
+		return x.DnsSearch
 	}
 	return ""
 }
 
-func (x *VPNStatusResponse) GetUseLzo() bool {
+func (x *VPNUpdateRequest) GetResolverPref() VPNResolverPref {
 	if x != nil {
-		return x.UseLzo
+		return x.ResolverPref
 	}
-	return false
+	return VPNResolverPref_RESOLVER_NOPREF
 }
 
-type VPNInitResponse struct {
+type VPNRestartRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Server string `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
 }
 
-func (x *VPNInitResponse) Reset() {
-	*x = VPNInitResponse{}
+func (x *VPNRestartRequest) Reset() {
+	*x = VPNRestartRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_vpn_proto_msgTypes[5]
+		mi := &file_vpn_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *VPNInitResponse) String() string {
+func (x *VPNRestartRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VPNInitResponse) ProtoMessage() {}
+func (*VPNRestartRequest) ProtoMessage() {}
 
-func (x *VPNInitResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_vpn_proto_msgTypes[5]
+func (x *VPNRestartRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vpn_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -545,19 +703,113 @@ func (x *VPNInitResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VPNInitResponse.ProtoReflect.Descriptor instead.
-func (*VPNInitResponse) Descriptor() ([]byte, []int) {
-	return file_vpn_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use VPNRestartRequest.ProtoReflect.Descriptor instead.
+func (*VPNRestartRequest) Descriptor() ([]byte, []int) {
+	return file_vpn_proto_rawDescGZIP(), []int{3}
 }
 
-type VPNUpdateResponse struct {
+func (x *VPNRestartRequest) GetServer() string {
+	if x != nil {
+		return x.Server
+	}
+	return ""
+}
+
+type VPNListRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 }
 
-func (x *VPNUpdateResponse) Reset() {
-	*x = VPNUpdateResponse{}
+func (x *VPNListRequest) Reset() {
+	*x = VPNListRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vpn_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VPNListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VPNListRequest) ProtoMessage() {}
+
+func (x *VPNListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vpn_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VPNListRequest.ProtoReflect.Descriptor instead.
+func (*VPNListRequest) Descriptor() ([]byte, []int) {
+	return file_vpn_proto_rawDescGZIP(), []int{4}
+}
+
+type VPNExpiringRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Days int32 `protobuf:"varint,1,opt,name=days,proto3" json:"days,omitempty"`
+}
+
+func (x *VPNExpiringRequest) Reset() {
+	*x = VPNExpiringRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vpn_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VPNExpiringRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VPNExpiringRequest) ProtoMessage() {}
+
+func (x *VPNExpiringRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vpn_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VPNExpiringRequest.ProtoReflect.Descriptor instead.
+func (*VPNExpiringRequest) Descriptor() ([]byte, []int) {
+	return file_vpn_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *VPNExpiringRequest) GetDays() int32 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+type VPNRotateTLSKeyRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Server string `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
+}
+
+func (x *VPNRotateTLSKeyRequest) Reset() {
+	*x = VPNRotateTLSKeyRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_vpn_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -565,13 +817,13 @@ func (x *VPNUpdateResponse) Reset() {
 	}
 }
 
-func (x *VPNUpdateResponse) String() string {
+func (x *VPNRotateTLSKeyRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VPNUpdateResponse) ProtoMessage() {}
+func (*VPNRotateTLSKeyRequest) ProtoMessage() {}
 
-func (x *VPNUpdateResponse) ProtoReflect() protoreflect.Message {
+func (x *VPNRotateTLSKeyRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_vpn_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -583,19 +835,28 @@ func (x *VPNUpdateResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VPNUpdateResponse.ProtoReflect.Descriptor instead.
-func (*VPNUpdateResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use VPNRotateTLSKeyRequest.ProtoReflect.Descriptor instead.
+func (*VPNRotateTLSKeyRequest) Descriptor() ([]byte, []int) {
 	return file_vpn_proto_rawDescGZIP(), []int{6}
 }
 
-type VPNRestartResponse struct {
+func (x *VPNRotateTLSKeyRequest) GetServer() string {
+	if x != nil {
+		return x.Server
+	}
+	return ""
+}
+
+type VPNCARotationRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Server string `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
 }
 
-func (x *VPNRestartResponse) Reset() {
-	*x = VPNRestartResponse{}
+func (x *VPNCARotationRequest) Reset() {
+	*x = VPNCARotationRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_vpn_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -603,175 +864,2812 @@ func (x *VPNRestartResponse) Reset() {
 	}
 }
 
-func (x *VPNRestartResponse) String() string {
+func (x *VPNCARotationRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VPNRestartResponse) ProtoMessage() {}
+func (*VPNCARotationRequest) ProtoMessage() {}
 
-func (x *VPNRestartResponse) ProtoReflect() protoreflect.Message {
+func (x *VPNCARotationRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_vpn_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
 		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use VPNRestartResponse.ProtoReflect.Descriptor instead.
-func (*VPNRestartResponse) Descriptor() ([]byte, []int) {
-	return file_vpn_proto_rawDescGZIP(), []int{7}
-}
-
-var File_vpn_proto protoreflect.FileDescriptor
-
-var file_vpn_proto_rawDesc = []byte{
-	0x0a, 0x09, 0x76, 0x70, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x70, 0x62, 0x1a,
-	0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f,
-	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x12, 0x0a,
-	0x10, 0x56, 0x50, 0x4e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x22, 0x8b, 0x02, 0x0a, 0x0e, 0x56, 0x50, 0x4e, 0x49, 0x6e, 0x69, 0x74, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65,
-	0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x70, 0x6f, 0x72, 0x74, 0x12, 0x2b, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x5f, 0x70, 0x72,
-	0x65, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50,
-	0x4e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x72, 0x65,
-	0x66, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x70, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x07, 0x69, 0x70, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x10, 0x0a, 0x03,
-	0x64, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x64, 0x6e, 0x73, 0x12, 0x29,
-	0x0a, 0x10, 0x6b, 0x65, 0x65, 0x70, 0x61, 0x6c, 0x69, 0x76, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x69,
-	0x6f, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x6b, 0x65, 0x65, 0x70, 0x61, 0x6c,
-	0x69, 0x76, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x12, 0x2b, 0x0a, 0x11, 0x6b, 0x65, 0x65,
-	0x70, 0x61, 0x6c, 0x69, 0x76, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x07,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x6b, 0x65, 0x65, 0x70, 0x61, 0x6c, 0x69, 0x76, 0x65, 0x54,
-	0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x5f, 0x6c, 0x7a,
-	0x6f, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x75, 0x73, 0x65, 0x4c, 0x7a, 0x6f, 0x22,
-	0x6a, 0x0a, 0x10, 0x56, 0x50, 0x4e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x70, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x69, 0x70, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x10,
-	0x0a, 0x03, 0x64, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x64, 0x6e, 0x73,
-	0x12, 0x29, 0x0a, 0x08, 0x6c, 0x7a, 0x6f, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x4c, 0x5a, 0x4f, 0x50, 0x72,
-	0x65, 0x66, 0x52, 0x07, 0x6c, 0x7a, 0x6f, 0x50, 0x72, 0x65, 0x66, 0x22, 0x13, 0x0a, 0x11, 0x56,
-	0x50, 0x4e, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x22, 0xf2, 0x02, 0x0a, 0x11, 0x56, 0x50, 0x4e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65,
-	0x72, 0x69, 0x61, 0x6c, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0c, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12,
-	0x1a, 0x0a, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70,
-	0x6f, 0x72, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x12,
-	0x12, 0x0a, 0x04, 0x63, 0x65, 0x72, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63,
-	0x65, 0x72, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x63, 0x61, 0x5f, 0x63, 0x65, 0x72, 0x74, 0x18, 0x06,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x61, 0x43, 0x65, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03,
-	0x6e, 0x65, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6e, 0x65, 0x74, 0x12, 0x12,
-	0x0a, 0x04, 0x6d, 0x61, 0x73, 0x6b, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6d, 0x61,
-	0x73, 0x6b, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74,
-	0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41,
-	0x74, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x10, 0x0a, 0x03, 0x64, 0x6e, 0x73, 0x18, 0x0b,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x64, 0x6e, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x78, 0x70,
-	0x69, 0x72, 0x65, 0x73, 0x5f, 0x61, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x65,
-	0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x63, 0x61, 0x5f, 0x65,
-	0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x61, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0b, 0x63, 0x61, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x12, 0x17, 0x0a, 0x07,
-	0x75, 0x73, 0x65, 0x5f, 0x6c, 0x7a, 0x6f, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x75,
-	0x73, 0x65, 0x4c, 0x7a, 0x6f, 0x22, 0x11, 0x0a, 0x0f, 0x56, 0x50, 0x4e, 0x49, 0x6e, 0x69, 0x74,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x13, 0x0a, 0x11, 0x56, 0x50, 0x4e, 0x55,
-	0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x14, 0x0a,
-	0x12, 0x56, 0x50, 0x4e, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x2a, 0x28, 0x0a, 0x08, 0x56, 0x50, 0x4e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12,
-	0x0a, 0x0a, 0x06, 0x4e, 0x4f, 0x50, 0x52, 0x45, 0x46, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x55,
-	0x44, 0x50, 0x10, 0x01, 0x12, 0x07, 0x0a, 0x03, 0x54, 0x43, 0x50, 0x10, 0x02, 0x2a, 0x49, 0x0a,
-	0x0a, 0x56, 0x50, 0x4e, 0x4c, 0x5a, 0x4f, 0x50, 0x72, 0x65, 0x66, 0x12, 0x12, 0x0a, 0x0e, 0x55,
-	0x53, 0x45, 0x5f, 0x4c, 0x5a, 0x4f, 0x5f, 0x4e, 0x4f, 0x50, 0x52, 0x45, 0x46, 0x10, 0x00, 0x12,
-	0x12, 0x0a, 0x0e, 0x55, 0x53, 0x45, 0x5f, 0x4c, 0x5a, 0x4f, 0x5f, 0x45, 0x4e, 0x41, 0x42, 0x4c,
-	0x45, 0x10, 0x01, 0x12, 0x13, 0x0a, 0x0f, 0x55, 0x53, 0x45, 0x5f, 0x4c, 0x5a, 0x4f, 0x5f, 0x44,
-	0x49, 0x53, 0x41, 0x42, 0x4c, 0x45, 0x10, 0x03, 0x32, 0xda, 0x02, 0x0a, 0x0a, 0x56, 0x50, 0x4e,
-	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x51, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x12, 0x14, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e,
-	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1a,
-	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x14, 0x12, 0x12, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f,
-	0x76, 0x70, 0x6e, 0x2f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x4c, 0x0a, 0x04, 0x49, 0x6e,
-	0x69, 0x74, 0x12, 0x12, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x49, 0x6e, 0x69, 0x74, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x49,
-	0x6e, 0x69, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1b, 0x82, 0xd3, 0xe4,
-	0x93, 0x02, 0x15, 0x22, 0x10, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x76, 0x70, 0x6e,
-	0x2f, 0x69, 0x6e, 0x69, 0x74, 0x3a, 0x01, 0x2a, 0x12, 0x54, 0x0a, 0x06, 0x55, 0x70, 0x64, 0x61,
-	0x74, 0x65, 0x12, 0x14, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x55, 0x70, 0x64, 0x61, 0x74,
-	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50,
-	0x4e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x1d, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x17, 0x22, 0x12, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31,
-	0x2f, 0x76, 0x70, 0x6e, 0x2f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x3a, 0x01, 0x2a, 0x12, 0x55,
-	0x0a, 0x07, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x15, 0x2e, 0x70, 0x62, 0x2e, 0x56,
-	0x50, 0x4e, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x16, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1b, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x15,
-	0x22, 0x13, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x76, 0x70, 0x6e, 0x2f, 0x72, 0x65,
-	0x73, 0x74, 0x61, 0x72, 0x74, 0x42, 0x22, 0x5a, 0x20, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
-	0x63, 0x6f, 0x6d, 0x2f, 0x47, 0x6f, 0x6c, 0x64, 0x65, 0x6e, 0x52, 0x55, 0x53, 0x2f, 0x6f, 0x76,
-	0x70, 0x6d, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
-}
-
-var (
-	file_vpn_proto_rawDescOnce sync.Once
-	file_vpn_proto_rawDescData = file_vpn_proto_rawDesc
-)
-
-func file_vpn_proto_rawDescGZIP() []byte {
-	file_vpn_proto_rawDescOnce.Do(func() {
-		file_vpn_proto_rawDescData = protoimpl.X.CompressGZIP(file_vpn_proto_rawDescData)
-	})
-	return file_vpn_proto_rawDescData
-}
-
-var file_vpn_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_vpn_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
-var file_vpn_proto_goTypes = []interface{}{
-	(VPNProto)(0),              // 0: pb.VPNProto
-	(VPNLZOPref)(0),            // 1: pb.VPNLZOPref
-	(*VPNStatusRequest)(nil),   // 2: pb.VPNStatusRequest
-	(*VPNInitRequest)(nil),     // 3: pb.VPNInitRequest
-	(*VPNUpdateRequest)(nil),   // 4: pb.VPNUpdateRequest
-	(*VPNRestartRequest)(nil),  // 5: pb.VPNRestartRequest
-	(*VPNStatusResponse)(nil),  // 6: pb.VPNStatusResponse
-	(*VPNInitResponse)(nil),    // 7: pb.VPNInitResponse
-	(*VPNUpdateResponse)(nil),  // 8: pb.VPNUpdateResponse
-	(*VPNRestartResponse)(nil), // 9: pb.VPNRestartResponse
-}
-var file_vpn_proto_depIdxs = []int32{
-	0, // 0: pb.VPNInitRequest.proto_pref:type_name -> pb.VPNProto
-	1, // 1: pb.VPNUpdateRequest.lzo_pref:type_name -> pb.VPNLZOPref
-	2, // 2: pb.VPNService.Status:input_type -> pb.VPNStatusRequest
-	3, // 3: pb.VPNService.Init:input_type -> pb.VPNInitRequest
-	4, // 4: pb.VPNService.Update:input_type -> pb.VPNUpdateRequest
-	5, // 5: pb.VPNService.Restart:input_type -> pb.VPNRestartRequest
-	6, // 6: pb.VPNService.Status:output_type -> pb.VPNStatusResponse
-	7, // 7: pb.VPNService.Init:output_type -> pb.VPNInitResponse
-	8, // 8: pb.VPNService.Update:output_type -> pb.VPNUpdateResponse
-	9, // 9: pb.VPNService.Restart:output_type -> pb.VPNRestartResponse
-	6, // [6:10] is the sub-list for method output_type
-	2, // [2:6] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
-}
-
-func init() { file_vpn_proto_init() }
-func file_vpn_proto_init() {
-	if File_vpn_proto != nil {
-		return
-	}
-	if !protoimpl.UnsafeEnabled {
-		file_vpn_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VPNStatusRequest); i {
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VPNCARotationRequest.ProtoReflect.Descriptor instead.
+func (*VPNCARotationRequest) Descriptor() ([]byte, []int) {
+	return file_vpn_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *VPNCARotationRequest) GetServer() string {
+	if x != nil {
+		return x.Server
+	}
+	return ""
+}
+
+type VPNFinishCARotationRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Server string `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
+	Force  bool   `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+}
+
+func (x *VPNFinishCARotationRequest) Reset() {
+	*x = VPNFinishCARotationRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vpn_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VPNFinishCARotationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VPNFinishCARotationRequest) ProtoMessage() {}
+
+func (x *VPNFinishCARotationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vpn_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VPNFinishCARotationRequest.ProtoReflect.Descriptor instead.
+func (*VPNFinishCARotationRequest) Descriptor() ([]byte, []int) {
+	return file_vpn_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *VPNFinishCARotationRequest) GetServer() string {
+	if x != nil {
+		return x.Server
+	}
+	return ""
+}
+
+func (x *VPNFinishCARotationRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+type VPNBackupRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Passphrase string `protobuf:"bytes,1,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
+}
+
+func (x *VPNBackupRequest) Reset() {
+	*x = VPNBackupRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vpn_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VPNBackupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VPNBackupRequest) ProtoMessage() {}
+
+func (x *VPNBackupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vpn_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VPNBackupRequest.ProtoReflect.Descriptor instead.
+func (*VPNBackupRequest) Descriptor() ([]byte, []int) {
+	return file_vpn_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *VPNBackupRequest) GetPassphrase() string {
+	if x != nil {
+		return x.Passphrase
+	}
+	return ""
+}
+
+type VPNRestoreRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Archive    []byte `protobuf:"bytes,1,opt,name=archive,proto3" json:"archive,omitempty"`
+	Passphrase string `protobuf:"bytes,2,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
+	Force      bool   `protobuf:"varint,3,opt,name=force,proto3" json:"force,omitempty"`
+}
+
+func (x *VPNRestoreRequest) Reset() {
+	*x = VPNRestoreRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vpn_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VPNRestoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VPNRestoreRequest) ProtoMessage() {}
+
+func (x *VPNRestoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vpn_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VPNRestoreRequest.ProtoReflect.Descriptor instead.
+func (*VPNRestoreRequest) Descriptor() ([]byte, []int) {
+	return file_vpn_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *VPNRestoreRequest) GetArchive() []byte {
+	if x != nil {
+		return x.Archive
+	}
+	return nil
+}
+
+func (x *VPNRestoreRequest) GetPassphrase() string {
+	if x != nil {
+		return x.Passphrase
+	}
+	return ""
+}
+
+func (x *VPNRestoreRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+type VPNDirectivesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Server string `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
+}
+
+func (x *VPNDirectivesRequest) Reset() {
+	*x = VPNDirectivesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vpn_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VPNDirectivesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VPNDirectivesRequest) ProtoMessage() {}
+
+func (x *VPNDirectivesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vpn_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VPNDirectivesRequest.ProtoReflect.Descriptor instead.
+func (*VPNDirectivesRequest) Descriptor() ([]byte, []int) {
+	return file_vpn_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *VPNDirectivesRequest) GetServer() string {
+	if x != nil {
+		return x.Server
+	}
+	return ""
+}
+
+type VPNSetDirectivesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Server     string            `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
+	Scope      VPNDirectiveScope `protobuf:"varint,2,opt,name=scope,proto3,enum=pb.VPNDirectiveScope" json:"scope,omitempty"`
+	Directives string            `protobuf:"bytes,3,opt,name=directives,proto3" json:"directives,omitempty"`
+}
+
+func (x *VPNSetDirectivesRequest) Reset() {
+	*x = VPNSetDirectivesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vpn_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VPNSetDirectivesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VPNSetDirectivesRequest) ProtoMessage() {}
+
+func (x *VPNSetDirectivesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vpn_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VPNSetDirectivesRequest.ProtoReflect.Descriptor instead.
+func (*VPNSetDirectivesRequest) Descriptor() ([]byte, []int) {
+	return file_vpn_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *VPNSetDirectivesRequest) GetServer() string {
+	if x != nil {
+		return x.Server
+	}
+	return ""
+}
+
+func (x *VPNSetDirectivesRequest) GetScope() VPNDirectiveScope {
+	if x != nil {
+		return x.Scope
+	}
+	return VPNDirectiveScope_SERVER_CONF
+}
+
+func (x *VPNSetDirectivesRequest) GetDirectives() string {
+	if x != nil {
+		return x.Directives
+	}
+	return ""
+}
+
+type VPNRepackLeasesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Server string `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
+}
+
+func (x *VPNRepackLeasesRequest) Reset() {
+	*x = VPNRepackLeasesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vpn_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VPNRepackLeasesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VPNRepackLeasesRequest) ProtoMessage() {}
+
+func (x *VPNRepackLeasesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vpn_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VPNRepackLeasesRequest.ProtoReflect.Descriptor instead.
+func (*VPNRepackLeasesRequest) Descriptor() ([]byte, []int) {
+	return file_vpn_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *VPNRepackLeasesRequest) GetServer() string {
+	if x != nil {
+		return x.Server
+	}
+	return ""
+}
+
+type VPNCreatePoolRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Server string `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
+	Name   string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Cidr   string `protobuf:"bytes,3,opt,name=cidr,proto3" json:"cidr,omitempty"`
+}
+
+func (x *VPNCreatePoolRequest) Reset() {
+	*x = VPNCreatePoolRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vpn_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VPNCreatePoolRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VPNCreatePoolRequest) ProtoMessage() {}
+
+func (x *VPNCreatePoolRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vpn_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VPNCreatePoolRequest.ProtoReflect.Descriptor instead.
+func (*VPNCreatePoolRequest) Descriptor() ([]byte, []int) {
+	return file_vpn_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *VPNCreatePoolRequest) GetServer() string {
+	if x != nil {
+		return x.Server
+	}
+	return ""
+}
+
+func (x *VPNCreatePoolRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *VPNCreatePoolRequest) GetCidr() string {
+	if x != nil {
+		return x.Cidr
+	}
+	return ""
+}
+
+type VPNListPoolsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Server string `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
+}
+
+func (x *VPNListPoolsRequest) Reset() {
+	*x = VPNListPoolsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vpn_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VPNListPoolsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VPNListPoolsRequest) ProtoMessage() {}
+
+func (x *VPNListPoolsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vpn_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VPNListPoolsRequest.ProtoReflect.Descriptor instead.
+func (*VPNListPoolsRequest) Descriptor() ([]byte, []int) {
+	return file_vpn_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *VPNListPoolsRequest) GetServer() string {
+	if x != nil {
+		return x.Server
+	}
+	return ""
+}
+
+type VPNDeletePoolRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *VPNDeletePoolRequest) Reset() {
+	*x = VPNDeletePoolRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vpn_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VPNDeletePoolRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VPNDeletePoolRequest) ProtoMessage() {}
+
+func (x *VPNDeletePoolRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vpn_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VPNDeletePoolRequest.ProtoReflect.Descriptor instead.
+func (*VPNDeletePoolRequest) Descriptor() ([]byte, []int) {
+	return file_vpn_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *VPNDeletePoolRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type VPNAddACLRuleRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Username    string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Pool        string `protobuf:"bytes,2,opt,name=pool,proto3" json:"pool,omitempty"`
+	Action      string `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	Destination string `protobuf:"bytes,4,opt,name=destination,proto3" json:"destination,omitempty"`
+	Proto       string `protobuf:"bytes,5,opt,name=proto,proto3" json:"proto,omitempty"`
+	Port        string `protobuf:"bytes,6,opt,name=port,proto3" json:"port,omitempty"`
+}
+
+func (x *VPNAddACLRuleRequest) Reset() {
+	*x = VPNAddACLRuleRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vpn_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VPNAddACLRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VPNAddACLRuleRequest) ProtoMessage() {}
+
+func (x *VPNAddACLRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vpn_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VPNAddACLRuleRequest.ProtoReflect.Descriptor instead.
+func (*VPNAddACLRuleRequest) Descriptor() ([]byte, []int) {
+	return file_vpn_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *VPNAddACLRuleRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *VPNAddACLRuleRequest) GetPool() string {
+	if x != nil {
+		return x.Pool
+	}
+	return ""
+}
+
+func (x *VPNAddACLRuleRequest) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *VPNAddACLRuleRequest) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+func (x *VPNAddACLRuleRequest) GetProto() string {
+	if x != nil {
+		return x.Proto
+	}
+	return ""
+}
+
+func (x *VPNAddACLRuleRequest) GetPort() string {
+	if x != nil {
+		return x.Port
+	}
+	return ""
+}
+
+type VPNListACLRulesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *VPNListACLRulesRequest) Reset() {
+	*x = VPNListACLRulesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vpn_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VPNListACLRulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VPNListACLRulesRequest) ProtoMessage() {}
+
+func (x *VPNListACLRulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vpn_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VPNListACLRulesRequest.ProtoReflect.Descriptor instead.
+func (*VPNListACLRulesRequest) Descriptor() ([]byte, []int) {
+	return file_vpn_proto_rawDescGZIP(), []int{18}
+}
+
+type VPNDeleteACLRuleRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id uint32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *VPNDeleteACLRuleRequest) Reset() {
+	*x = VPNDeleteACLRuleRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vpn_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VPNDeleteACLRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VPNDeleteACLRuleRequest) ProtoMessage() {}
+
+func (x *VPNDeleteACLRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vpn_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VPNDeleteACLRuleRequest.ProtoReflect.Descriptor instead.
+func (*VPNDeleteACLRuleRequest) Descriptor() ([]byte, []int) {
+	return file_vpn_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *VPNDeleteACLRuleRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type VPNShowFirewallRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *VPNShowFirewallRequest) Reset() {
+	*x = VPNShowFirewallRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vpn_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VPNShowFirewallRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VPNShowFirewallRequest) ProtoMessage() {}
+
+func (x *VPNShowFirewallRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vpn_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VPNShowFirewallRequest.ProtoReflect.Descriptor instead.
+func (*VPNShowFirewallRequest) Descriptor() ([]byte, []int) {
+	return file_vpn_proto_rawDescGZIP(), []int{20}
+}
+
+type VPNStatusResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name              string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	SerialNumber      string `protobuf:"bytes,2,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	Hostname          string `protobuf:"bytes,3,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Port              string `protobuf:"bytes,4,opt,name=port,proto3" json:"port,omitempty"`
+	Cert              string `protobuf:"bytes,5,opt,name=cert,proto3" json:"cert,omitempty"`
+	CaCert            string `protobuf:"bytes,6,opt,name=ca_cert,json=caCert,proto3" json:"ca_cert,omitempty"`
+	Net               string `protobuf:"bytes,7,opt,name=net,proto3" json:"net,omitempty"`
+	Mask              string `protobuf:"bytes,8,opt,name=mask,proto3" json:"mask,omitempty"`
+	CreatedAt         string `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Proto             string `protobuf:"bytes,10,opt,name=proto,proto3" json:"proto,omitempty"`
+	Dns               string `protobuf:"bytes,11,opt,name=dns,proto3" json:"dns,omitempty"`
+	ExpiresAt         string `protobuf:"bytes,12,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	CaExpiresAt       string `protobuf:"bytes,13,opt,name=ca_expires_at,json=caExpiresAt,proto3" json:"ca_expires_at,omitempty"`
+	UseLzo            bool   `protobuf:"varint,14,opt,name=use_lzo,json=useLzo,proto3" json:"use_lzo,omitempty"`
+	KeyAlgorithm      string `protobuf:"bytes,15,opt,name=key_algorithm,json=keyAlgorithm,proto3" json:"key_algorithm,omitempty"`
+	CertValidityDays  int32  `protobuf:"varint,16,opt,name=cert_validity_days,json=certValidityDays,proto3" json:"cert_validity_days,omitempty"`
+	DhParams          string `protobuf:"bytes,17,opt,name=dh_params,json=dhParams,proto3" json:"dh_params,omitempty"`
+	DhParamsStartedAt string `protobuf:"bytes,18,opt,name=dh_params_started_at,json=dhParamsStartedAt,proto3" json:"dh_params_started_at,omitempty"`
+	DhParamsError     string `protobuf:"bytes,19,opt,name=dh_params_error,json=dhParamsError,proto3" json:"dh_params_error,omitempty"`
+	TlsKeyMode        string `protobuf:"bytes,20,opt,name=tls_key_mode,json=tlsKeyMode,proto3" json:"tls_key_mode,omitempty"`
+	DataCiphers       string `protobuf:"bytes,21,opt,name=data_ciphers,json=dataCiphers,proto3" json:"data_ciphers,omitempty"`
+	AuthDigest        string `protobuf:"bytes,22,opt,name=auth_digest,json=authDigest,proto3" json:"auth_digest,omitempty"`
+	TlsVersionMin     string `protobuf:"bytes,23,opt,name=tls_version_min,json=tlsVersionMin,proto3" json:"tls_version_min,omitempty"`
+	Ipv6Net           string `protobuf:"bytes,24,opt,name=ipv6_net,json=ipv6Net,proto3" json:"ipv6_net,omitempty"`
+	DnsDomain         string `protobuf:"bytes,25,opt,name=dns_domain,json=dnsDomain,proto3" json:"dns_domain,omitempty"`
+	DnsSearch         string `protobuf:"bytes,26,opt,name=dns_search,json=dnsSearch,proto3" json:"dns_search,omitempty"`
+	Resolver          bool   `protobuf:"varint,27,opt,name=resolver,proto3" json:"resolver,omitempty"`
+}
+
+func (x *VPNStatusResponse) Reset() {
+	*x = VPNStatusResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vpn_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VPNStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VPNStatusResponse) ProtoMessage() {}
+
+func (x *VPNStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vpn_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VPNStatusResponse.ProtoReflect.Descriptor instead.
+func (*VPNStatusResponse) Descriptor() ([]byte, []int) {
+	return file_vpn_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *VPNStatusResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *VPNStatusResponse) GetSerialNumber() string {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return ""
+}
+
+func (x *VPNStatusResponse) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *VPNStatusResponse) GetPort() string {
+	if x != nil {
+		return x.Port
+	}
+	return ""
+}
+
+func (x *VPNStatusResponse) GetCert() string {
+	if x != nil {
+		return x.Cert
+	}
+	return ""
+}
+
+func (x *VPNStatusResponse) GetCaCert() string {
+	if x != nil {
+		return x.CaCert
+	}
+	return ""
+}
+
+func (x *VPNStatusResponse) GetNet() string {
+	if x != nil {
+		return x.Net
+	}
+	return ""
+}
+
+func (x *VPNStatusResponse) GetMask() string {
+	if x != nil {
+		return x.Mask
+	}
+	return ""
+}
+
+func (x *VPNStatusResponse) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *VPNStatusResponse) GetProto() string {
+	if x != nil {
+		return x.Proto
+	}
+	return ""
+}
+
+func (x *VPNStatusResponse) GetDns() string {
+	if x != nil {
+		return x.Dns
+	}
+	return ""
+}
+
+func (x *VPNStatusResponse) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *VPNStatusResponse) GetCaExpiresAt() string {
+	if x != nil {
+		return x.CaExpiresAt
+	}
+	return ""
+}
+
+func (x *VPNStatusResponse) GetUseLzo() bool {
+	if x != nil {
+		return x.UseLzo
+	}
+	return false
+}
+
+func (x *VPNStatusResponse) GetKeyAlgorithm() string {
+	if x != nil {
+		return x.KeyAlgorithm
+	}
+	return ""
+}
+
+func (x *VPNStatusResponse) GetCertValidityDays() int32 {
+	if x != nil {
+		return x.CertValidityDays
+	}
+	return 0
+}
+
+func (x *VPNStatusResponse) GetDhParams() string {
+	if x != nil {
+		return x.DhParams
+	}
+	return ""
+}
+
+func (x *VPNStatusResponse) GetDhParamsStartedAt() string {
+	if x != nil {
+		return x.DhParamsStartedAt
+	}
+	return ""
+}
+
+func (x *VPNStatusResponse) GetDhParamsError() string {
+	if x != nil {
+		return x.DhParamsError
+	}
+	return ""
+}
+
+func (x *VPNStatusResponse) GetTlsKeyMode() string {
+	if x != nil {
+		return x.TlsKeyMode
+	}
+	return ""
+}
+
+func (x *VPNStatusResponse) GetDataCiphers() string {
+	if x != nil {
+		return x.DataCiphers
+	}
+	return ""
+}
+
+func (x *VPNStatusResponse) GetAuthDigest() string {
+	if x != nil {
+		return x.AuthDigest
+	}
+	return ""
+}
+
+func (x *VPNStatusResponse) GetTlsVersionMin() string {
+	if x != nil {
+		return x.TlsVersionMin
+	}
+	return ""
+}
+
+func (x *VPNStatusResponse) GetIpv6Net() string {
+	if x != nil {
+		return x.Ipv6Net
+	}
+	return ""
+}
+
+func (x *VPNStatusResponse) GetDnsDomain() string {
+	if x != nil {
+		return x.DnsDomain
+	}
+	return ""
+}
+
+func (x *VPNStatusResponse) GetDnsSearch() string {
+	if x != nil {
+		return x.DnsSearch
+	}
+	return ""
+}
+
+func (x *VPNStatusResponse) GetResolver() bool {
+	if x != nil {
+		return x.Resolver
+	}
+	return false
+}
+
+type VPNInitResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *VPNInitResponse) Reset() {
+	*x = VPNInitResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vpn_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VPNInitResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VPNInitResponse) ProtoMessage() {}
+
+func (x *VPNInitResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vpn_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VPNInitResponse.ProtoReflect.Descriptor instead.
+func (*VPNInitResponse) Descriptor() ([]byte, []int) {
+	return file_vpn_proto_rawDescGZIP(), []int{22}
+}
+
+type VPNUpdateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *VPNUpdateResponse) Reset() {
+	*x = VPNUpdateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vpn_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VPNUpdateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VPNUpdateResponse) ProtoMessage() {}
+
+func (x *VPNUpdateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vpn_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VPNUpdateResponse.ProtoReflect.Descriptor instead.
+func (*VPNUpdateResponse) Descriptor() ([]byte, []int) {
+	return file_vpn_proto_rawDescGZIP(), []int{23}
+}
+
+type VPNRestartResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *VPNRestartResponse) Reset() {
+	*x = VPNRestartResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vpn_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VPNRestartResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VPNRestartResponse) ProtoMessage() {}
+
+func (x *VPNRestartResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vpn_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VPNRestartResponse.ProtoReflect.Descriptor instead.
+func (*VPNRestartResponse) Descriptor() ([]byte, []int) {
+	return file_vpn_proto_rawDescGZIP(), []int{24}
+}
+
+type VPNListResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Servers []*VPNStatusResponse `protobuf:"bytes,1,rep,name=servers,proto3" json:"servers,omitempty"`
+}
+
+func (x *VPNListResponse) Reset() {
+	*x = VPNListResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vpn_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VPNListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VPNListResponse) ProtoMessage() {}
+
+func (x *VPNListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vpn_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VPNListResponse.ProtoReflect.Descriptor instead.
+func (*VPNListResponse) Descriptor() ([]byte, []int) {
+	return file_vpn_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *VPNListResponse) GetServers() []*VPNStatusResponse {
+	if x != nil {
+		return x.Servers
+	}
+	return nil
+}
+
+type VPNExpiringResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Certs []*VPNExpiringResponse_Cert `protobuf:"bytes,1,rep,name=certs,proto3" json:"certs,omitempty"`
+}
+
+func (x *VPNExpiringResponse) Reset() {
+	*x = VPNExpiringResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vpn_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VPNExpiringResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VPNExpiringResponse) ProtoMessage() {}
+
+func (x *VPNExpiringResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vpn_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VPNExpiringResponse.ProtoReflect.Descriptor instead.
+func (*VPNExpiringResponse) Descriptor() ([]byte, []int) {
+	return file_vpn_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *VPNExpiringResponse) GetCerts() []*VPNExpiringResponse_Cert {
+	if x != nil {
+		return x.Certs
+	}
+	return nil
+}
+
+type VPNRotateTLSKeyResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *VPNRotateTLSKeyResponse) Reset() {
+	*x = VPNRotateTLSKeyResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vpn_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VPNRotateTLSKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VPNRotateTLSKeyResponse) ProtoMessage() {}
+
+func (x *VPNRotateTLSKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vpn_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VPNRotateTLSKeyResponse.ProtoReflect.Descriptor instead.
+func (*VPNRotateTLSKeyResponse) Descriptor() ([]byte, []int) {
+	return file_vpn_proto_rawDescGZIP(), []int{27}
+}
+
+type VPNCARotationStatusResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rotating        bool     `protobuf:"varint,1,opt,name=rotating,proto3" json:"rotating,omitempty"`
+	StartedAt       string   `protobuf:"bytes,2,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	Users           int32    `protobuf:"varint,3,opt,name=users,proto3" json:"users,omitempty"`
+	Fetched         int32    `protobuf:"varint,4,opt,name=fetched,proto3" json:"fetched,omitempty"`
+	Pending         []string `protobuf:"bytes,5,rep,name=pending,proto3" json:"pending,omitempty"`
+	NextCaExpiresAt string   `protobuf:"bytes,6,opt,name=next_ca_expires_at,json=nextCaExpiresAt,proto3" json:"next_ca_expires_at,omitempty"`
+}
+
+func (x *VPNCARotationStatusResponse) Reset() {
+	*x = VPNCARotationStatusResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vpn_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VPNCARotationStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VPNCARotationStatusResponse) ProtoMessage() {}
+
+func (x *VPNCARotationStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vpn_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VPNCARotationStatusResponse.ProtoReflect.Descriptor instead.
+func (*VPNCARotationStatusResponse) Descriptor() ([]byte, []int) {
+	return file_vpn_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *VPNCARotationStatusResponse) GetRotating() bool {
+	if x != nil {
+		return x.Rotating
+	}
+	return false
+}
+
+func (x *VPNCARotationStatusResponse) GetStartedAt() string {
+	if x != nil {
+		return x.StartedAt
+	}
+	return ""
+}
+
+func (x *VPNCARotationStatusResponse) GetUsers() int32 {
+	if x != nil {
+		return x.Users
+	}
+	return 0
+}
+
+func (x *VPNCARotationStatusResponse) GetFetched() int32 {
+	if x != nil {
+		return x.Fetched
+	}
+	return 0
+}
+
+func (x *VPNCARotationStatusResponse) GetPending() []string {
+	if x != nil {
+		return x.Pending
+	}
+	return nil
+}
+
+func (x *VPNCARotationStatusResponse) GetNextCaExpiresAt() string {
+	if x != nil {
+		return x.NextCaExpiresAt
+	}
+	return ""
+}
+
+type VPNBackupResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Archive []byte `protobuf:"bytes,1,opt,name=archive,proto3" json:"archive,omitempty"`
+}
+
+func (x *VPNBackupResponse) Reset() {
+	*x = VPNBackupResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vpn_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VPNBackupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VPNBackupResponse) ProtoMessage() {}
+
+func (x *VPNBackupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vpn_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VPNBackupResponse.ProtoReflect.Descriptor instead.
+func (*VPNBackupResponse) Descriptor() ([]byte, []int) {
+	return file_vpn_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *VPNBackupResponse) GetArchive() []byte {
+	if x != nil {
+		return x.Archive
+	}
+	return nil
+}
+
+type VPNRestoreResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *VPNRestoreResponse) Reset() {
+	*x = VPNRestoreResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vpn_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VPNRestoreResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VPNRestoreResponse) ProtoMessage() {}
+
+func (x *VPNRestoreResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vpn_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VPNRestoreResponse.ProtoReflect.Descriptor instead.
+func (*VPNRestoreResponse) Descriptor() ([]byte, []int) {
+	return file_vpn_proto_rawDescGZIP(), []int{30}
+}
+
+type VPNDirectivesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ServerDirectives string `protobuf:"bytes,1,opt,name=server_directives,json=serverDirectives,proto3" json:"server_directives,omitempty"`
+	ClientDirectives string `protobuf:"bytes,2,opt,name=client_directives,json=clientDirectives,proto3" json:"client_directives,omitempty"`
+}
+
+func (x *VPNDirectivesResponse) Reset() {
+	*x = VPNDirectivesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vpn_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VPNDirectivesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VPNDirectivesResponse) ProtoMessage() {}
+
+func (x *VPNDirectivesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vpn_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VPNDirectivesResponse.ProtoReflect.Descriptor instead.
+func (*VPNDirectivesResponse) Descriptor() ([]byte, []int) {
+	return file_vpn_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *VPNDirectivesResponse) GetServerDirectives() string {
+	if x != nil {
+		return x.ServerDirectives
+	}
+	return ""
+}
+
+func (x *VPNDirectivesResponse) GetClientDirectives() string {
+	if x != nil {
+		return x.ClientDirectives
+	}
+	return ""
+}
+
+type VPNRepackLeasesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Changes []*VPNRepackLeasesResponse_Change `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+}
+
+func (x *VPNRepackLeasesResponse) Reset() {
+	*x = VPNRepackLeasesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vpn_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VPNRepackLeasesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VPNRepackLeasesResponse) ProtoMessage() {}
+
+func (x *VPNRepackLeasesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vpn_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VPNRepackLeasesResponse.ProtoReflect.Descriptor instead.
+func (*VPNRepackLeasesResponse) Descriptor() ([]byte, []int) {
+	return file_vpn_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *VPNRepackLeasesResponse) GetChanges() []*VPNRepackLeasesResponse_Change {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+type VPNPool struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name      string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Cidr      string   `protobuf:"bytes,2,opt,name=cidr,proto3" json:"cidr,omitempty"`
+	Server    string   `protobuf:"bytes,3,opt,name=server,proto3" json:"server,omitempty"`
+	Usernames []string `protobuf:"bytes,4,rep,name=usernames,proto3" json:"usernames,omitempty"`
+	CreatedAt string   `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+}
+
+func (x *VPNPool) Reset() {
+	*x = VPNPool{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vpn_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VPNPool) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VPNPool) ProtoMessage() {}
+
+func (x *VPNPool) ProtoReflect() protoreflect.Message {
+	mi := &file_vpn_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VPNPool.ProtoReflect.Descriptor instead.
+func (*VPNPool) Descriptor() ([]byte, []int) {
+	return file_vpn_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *VPNPool) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *VPNPool) GetCidr() string {
+	if x != nil {
+		return x.Cidr
+	}
+	return ""
+}
+
+func (x *VPNPool) GetServer() string {
+	if x != nil {
+		return x.Server
+	}
+	return ""
+}
+
+func (x *VPNPool) GetUsernames() []string {
+	if x != nil {
+		return x.Usernames
+	}
+	return nil
+}
+
+func (x *VPNPool) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type VPNListPoolsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pools []*VPNPool `protobuf:"bytes,1,rep,name=pools,proto3" json:"pools,omitempty"`
+}
+
+func (x *VPNListPoolsResponse) Reset() {
+	*x = VPNListPoolsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vpn_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VPNListPoolsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VPNListPoolsResponse) ProtoMessage() {}
+
+func (x *VPNListPoolsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vpn_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VPNListPoolsResponse.ProtoReflect.Descriptor instead.
+func (*VPNListPoolsResponse) Descriptor() ([]byte, []int) {
+	return file_vpn_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *VPNListPoolsResponse) GetPools() []*VPNPool {
+	if x != nil {
+		return x.Pools
+	}
+	return nil
+}
+
+type VPNDeletePoolResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *VPNDeletePoolResponse) Reset() {
+	*x = VPNDeletePoolResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vpn_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VPNDeletePoolResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VPNDeletePoolResponse) ProtoMessage() {}
+
+func (x *VPNDeletePoolResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vpn_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VPNDeletePoolResponse.ProtoReflect.Descriptor instead.
+func (*VPNDeletePoolResponse) Descriptor() ([]byte, []int) {
+	return file_vpn_proto_rawDescGZIP(), []int{35}
+}
+
+type VPNACLRule struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id          uint32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Username    string `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Pool        string `protobuf:"bytes,3,opt,name=pool,proto3" json:"pool,omitempty"`
+	Action      string `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`
+	Destination string `protobuf:"bytes,5,opt,name=destination,proto3" json:"destination,omitempty"`
+	Proto       string `protobuf:"bytes,6,opt,name=proto,proto3" json:"proto,omitempty"`
+	Port        string `protobuf:"bytes,7,opt,name=port,proto3" json:"port,omitempty"`
+	CreatedAt   string `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+}
+
+func (x *VPNACLRule) Reset() {
+	*x = VPNACLRule{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vpn_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VPNACLRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VPNACLRule) ProtoMessage() {}
+
+func (x *VPNACLRule) ProtoReflect() protoreflect.Message {
+	mi := &file_vpn_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VPNACLRule.ProtoReflect.Descriptor instead.
+func (*VPNACLRule) Descriptor() ([]byte, []int) {
+	return file_vpn_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *VPNACLRule) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *VPNACLRule) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *VPNACLRule) GetPool() string {
+	if x != nil {
+		return x.Pool
+	}
+	return ""
+}
+
+func (x *VPNACLRule) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *VPNACLRule) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+func (x *VPNACLRule) GetProto() string {
+	if x != nil {
+		return x.Proto
+	}
+	return ""
+}
+
+func (x *VPNACLRule) GetPort() string {
+	if x != nil {
+		return x.Port
+	}
+	return ""
+}
+
+func (x *VPNACLRule) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type VPNListACLRulesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rules []*VPNACLRule `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+}
+
+func (x *VPNListACLRulesResponse) Reset() {
+	*x = VPNListACLRulesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vpn_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VPNListACLRulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VPNListACLRulesResponse) ProtoMessage() {}
+
+func (x *VPNListACLRulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vpn_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VPNListACLRulesResponse.ProtoReflect.Descriptor instead.
+func (*VPNListACLRulesResponse) Descriptor() ([]byte, []int) {
+	return file_vpn_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *VPNListACLRulesResponse) GetRules() []*VPNACLRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+type VPNDeleteACLRuleResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *VPNDeleteACLRuleResponse) Reset() {
+	*x = VPNDeleteACLRuleResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vpn_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VPNDeleteACLRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VPNDeleteACLRuleResponse) ProtoMessage() {}
+
+func (x *VPNDeleteACLRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vpn_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VPNDeleteACLRuleResponse.ProtoReflect.Descriptor instead.
+func (*VPNDeleteACLRuleResponse) Descriptor() ([]byte, []int) {
+	return file_vpn_proto_rawDescGZIP(), []int{38}
+}
+
+type VPNShowFirewallResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Backend   string   `protobuf:"bytes,1,opt,name=backend,proto3" json:"backend,omitempty"`
+	Ipv4Rules []string `protobuf:"bytes,2,rep,name=ipv4_rules,json=ipv4Rules,proto3" json:"ipv4_rules,omitempty"`
+	Ipv6Rules []string `protobuf:"bytes,3,rep,name=ipv6_rules,json=ipv6Rules,proto3" json:"ipv6_rules,omitempty"`
+}
+
+func (x *VPNShowFirewallResponse) Reset() {
+	*x = VPNShowFirewallResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vpn_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VPNShowFirewallResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VPNShowFirewallResponse) ProtoMessage() {}
+
+func (x *VPNShowFirewallResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vpn_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VPNShowFirewallResponse.ProtoReflect.Descriptor instead.
+func (*VPNShowFirewallResponse) Descriptor() ([]byte, []int) {
+	return file_vpn_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *VPNShowFirewallResponse) GetBackend() string {
+	if x != nil {
+		return x.Backend
+	}
+	return ""
+}
+
+func (x *VPNShowFirewallResponse) GetIpv4Rules() []string {
+	if x != nil {
+		return x.Ipv4Rules
+	}
+	return nil
+}
+
+func (x *VPNShowFirewallResponse) GetIpv6Rules() []string {
+	if x != nil {
+		return x.Ipv6Rules
+	}
+	return nil
+}
+
+type VPNExpiringResponse_Cert struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Server    string `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
+	Kind      string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Username  string `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	ExpiresAt string `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+}
+
+func (x *VPNExpiringResponse_Cert) Reset() {
+	*x = VPNExpiringResponse_Cert{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vpn_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VPNExpiringResponse_Cert) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VPNExpiringResponse_Cert) ProtoMessage() {}
+
+func (x *VPNExpiringResponse_Cert) ProtoReflect() protoreflect.Message {
+	mi := &file_vpn_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VPNExpiringResponse_Cert.ProtoReflect.Descriptor instead.
+func (*VPNExpiringResponse_Cert) Descriptor() ([]byte, []int) {
+	return file_vpn_proto_rawDescGZIP(), []int{26, 0}
+}
+
+func (x *VPNExpiringResponse_Cert) GetServer() string {
+	if x != nil {
+		return x.Server
+	}
+	return ""
+}
+
+func (x *VPNExpiringResponse_Cert) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *VPNExpiringResponse_Cert) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *VPNExpiringResponse_Cert) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+type VPNRepackLeasesResponse_Change struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	OldIp    string `protobuf:"bytes,2,opt,name=old_ip,json=oldIp,proto3" json:"old_ip,omitempty"`
+	NewIp    string `protobuf:"bytes,3,opt,name=new_ip,json=newIp,proto3" json:"new_ip,omitempty"`
+}
+
+func (x *VPNRepackLeasesResponse_Change) Reset() {
+	*x = VPNRepackLeasesResponse_Change{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vpn_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VPNRepackLeasesResponse_Change) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VPNRepackLeasesResponse_Change) ProtoMessage() {}
+
+func (x *VPNRepackLeasesResponse_Change) ProtoReflect() protoreflect.Message {
+	mi := &file_vpn_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VPNRepackLeasesResponse_Change.ProtoReflect.Descriptor instead.
+func (*VPNRepackLeasesResponse_Change) Descriptor() ([]byte, []int) {
+	return file_vpn_proto_rawDescGZIP(), []int{32, 0}
+}
+
+func (x *VPNRepackLeasesResponse_Change) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *VPNRepackLeasesResponse_Change) GetOldIp() string {
+	if x != nil {
+		return x.OldIp
+	}
+	return ""
+}
+
+func (x *VPNRepackLeasesResponse_Change) GetNewIp() string {
+	if x != nil {
+		return x.NewIp
+	}
+	return ""
+}
+
+var File_vpn_proto protoreflect.FileDescriptor
+
+var file_vpn_proto_rawDesc = []byte{
+	0x0a, 0x09, 0x76, 0x70, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x70, 0x62, 0x1a,
+	0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f,
+	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x2a, 0x0a,
+	0x10, 0x56, 0x50, 0x4e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x22, 0xe0, 0x05, 0x0a, 0x0e, 0x56, 0x50,
+	0x4e, 0x49, 0x6e, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08,
+	0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x2b, 0x0a, 0x0a,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x0c, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x52, 0x09,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x72, 0x65, 0x66, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x70, 0x5f,
+	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x69, 0x70, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x10, 0x0a, 0x03, 0x64, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x64, 0x6e, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x6b, 0x65, 0x65, 0x70, 0x61, 0x6c,
+	0x69, 0x76, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0f, 0x6b, 0x65, 0x65, 0x70, 0x61, 0x6c, 0x69, 0x76, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f,
+	0x64, 0x12, 0x2b, 0x0a, 0x11, 0x6b, 0x65, 0x65, 0x70, 0x61, 0x6c, 0x69, 0x76, 0x65, 0x5f, 0x74,
+	0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x6b, 0x65,
+	0x65, 0x70, 0x61, 0x6c, 0x69, 0x76, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x17,
+	0x0a, 0x07, 0x75, 0x73, 0x65, 0x5f, 0x6c, 0x7a, 0x6f, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x06, 0x75, 0x73, 0x65, 0x4c, 0x7a, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12,
+	0x17, 0x0a, 0x07, 0x63, 0x61, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x63, 0x61, 0x46, 0x72, 0x6f, 0x6d, 0x12, 0x23, 0x0a, 0x0d, 0x6b, 0x65, 0x79, 0x5f,
+	0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x6b, 0x65, 0x79, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x12, 0x20, 0x0a,
+	0x0c, 0x72, 0x73, 0x61, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x0c, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0a, 0x72, 0x73, 0x61, 0x4b, 0x65, 0x79, 0x53, 0x69, 0x7a, 0x65, 0x12,
+	0x2c, 0x0a, 0x12, 0x63, 0x65, 0x72, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x69, 0x74, 0x79,
+	0x5f, 0x64, 0x61, 0x79, 0x73, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10, 0x63, 0x65, 0x72,
+	0x74, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x69, 0x74, 0x79, 0x44, 0x61, 0x79, 0x73, 0x12, 0x26, 0x0a,
+	0x07, 0x64, 0x68, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0d,
+	0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x44, 0x48, 0x50, 0x72, 0x65, 0x66, 0x52, 0x06, 0x64,
+	0x68, 0x50, 0x72, 0x65, 0x66, 0x12, 0x20, 0x0a, 0x0c, 0x74, 0x6c, 0x73, 0x5f, 0x6b, 0x65, 0x79,
+	0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x6c, 0x73,
+	0x4b, 0x65, 0x79, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x5f,
+	0x63, 0x69, 0x70, 0x68, 0x65, 0x72, 0x73, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64,
+	0x61, 0x74, 0x61, 0x43, 0x69, 0x70, 0x68, 0x65, 0x72, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x75,
+	0x74, 0x68, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x61, 0x75, 0x74, 0x68, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x0f, 0x74,
+	0x6c, 0x73, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x69, 0x6e, 0x18, 0x12,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x74, 0x6c, 0x73, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x4d, 0x69, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x70, 0x76, 0x36, 0x5f, 0x62, 0x6c, 0x6f, 0x63,
+	0x6b, 0x18, 0x13, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x69, 0x70, 0x76, 0x36, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x6e, 0x73, 0x5f, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e,
+	0x18, 0x14, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x6e, 0x73, 0x44, 0x6f, 0x6d, 0x61, 0x69,
+	0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x6e, 0x73, 0x5f, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x18,
+	0x15, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x6e, 0x73, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x72, 0x18, 0x16, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x72, 0x22, 0xcf, 0x03, 0x0a,
+	0x10, 0x56, 0x50, 0x4e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x70, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x69, 0x70, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x10, 0x0a, 0x03,
+	0x64, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x64, 0x6e, 0x73, 0x12, 0x29,
+	0x0a, 0x08, 0x6c, 0x7a, 0x6f, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x0e, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x4c, 0x5a, 0x4f, 0x50, 0x72, 0x65, 0x66,
+	0x52, 0x07, 0x6c, 0x7a, 0x6f, 0x50, 0x72, 0x65, 0x66, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x12, 0x26, 0x0a, 0x07, 0x64, 0x68, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x0d, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x44, 0x48, 0x50, 0x72, 0x65,
+	0x66, 0x52, 0x06, 0x64, 0x68, 0x50, 0x72, 0x65, 0x66, 0x12, 0x20, 0x0a, 0x0c, 0x74, 0x6c, 0x73,
+	0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x74, 0x6c, 0x73, 0x4b, 0x65, 0x79, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x64,
+	0x61, 0x74, 0x61, 0x5f, 0x63, 0x69, 0x70, 0x68, 0x65, 0x72, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x64, 0x61, 0x74, 0x61, 0x43, 0x69, 0x70, 0x68, 0x65, 0x72, 0x73, 0x12, 0x1f,
+	0x0a, 0x0b, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x75, 0x74, 0x68, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12,
+	0x26, 0x0a, 0x0f, 0x74, 0x6c, 0x73, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x6d,
+	0x69, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x74, 0x6c, 0x73, 0x56, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x4d, 0x69, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x70, 0x76, 0x36, 0x5f,
+	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x69, 0x70, 0x76,
+	0x36, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x6e, 0x73, 0x5f, 0x64, 0x6f,
+	0x6d, 0x61, 0x69, 0x6e, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x6e, 0x73, 0x44,
+	0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x6e, 0x73, 0x5f, 0x73, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x6e, 0x73, 0x53, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x12, 0x38, 0x0a, 0x0d, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x72,
+	0x5f, 0x70, 0x72, 0x65, 0x66, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x70, 0x62,
+	0x2e, 0x56, 0x50, 0x4e, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x72, 0x50, 0x72, 0x65, 0x66,
+	0x52, 0x0c, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x72, 0x50, 0x72, 0x65, 0x66, 0x22, 0x2b,
+	0x0a, 0x11, 0x56, 0x50, 0x4e, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x22, 0x10, 0x0a, 0x0e, 0x56,
+	0x50, 0x4e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x28, 0x0a,
+	0x12, 0x56, 0x50, 0x4e, 0x45, 0x78, 0x70, 0x69, 0x72, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x79, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x04, 0x64, 0x61, 0x79, 0x73, 0x22, 0x30, 0x0a, 0x16, 0x56, 0x50, 0x4e, 0x52, 0x6f,
+	0x74, 0x61, 0x74, 0x65, 0x54, 0x4c, 0x53, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x22, 0x2e, 0x0a, 0x14, 0x56, 0x50, 0x4e,
+	0x43, 0x41, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x22, 0x4a, 0x0a, 0x1a, 0x56, 0x50, 0x4e,
+	0x46, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x43, 0x41, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12,
+	0x14, 0x0a, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05,
+	0x66, 0x6f, 0x72, 0x63, 0x65, 0x22, 0x32, 0x0a, 0x10, 0x56, 0x50, 0x4e, 0x42, 0x61, 0x63, 0x6b,
+	0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x61, 0x73,
+	0x73, 0x70, 0x68, 0x72, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70,
+	0x61, 0x73, 0x73, 0x70, 0x68, 0x72, 0x61, 0x73, 0x65, 0x22, 0x63, 0x0a, 0x11, 0x56, 0x50, 0x4e,
+	0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18,
+	0x0a, 0x07, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x07, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x61, 0x73, 0x73,
+	0x70, 0x68, 0x72, 0x61, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x61,
+	0x73, 0x73, 0x70, 0x68, 0x72, 0x61, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6f, 0x72, 0x63,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x22, 0x2e,
+	0x0a, 0x14, 0x56, 0x50, 0x4e, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x22, 0x7e,
+	0x0a, 0x17, 0x56, 0x50, 0x4e, 0x53, 0x65, 0x74, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x76,
+	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x12, 0x2b, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x15, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69,
+	0x76, 0x65, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x12, 0x1e,
+	0x0a, 0x0a, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x73, 0x22, 0x30,
+	0x0a, 0x16, 0x56, 0x50, 0x4e, 0x52, 0x65, 0x70, 0x61, 0x63, 0x6b, 0x4c, 0x65, 0x61, 0x73, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x22, 0x56, 0x0a, 0x14, 0x56, 0x50, 0x4e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x6f,
+	0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x69, 0x64, 0x72, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x63, 0x69, 0x64, 0x72, 0x22, 0x2d, 0x0a, 0x13, 0x56, 0x50, 0x4e, 0x4c,
+	0x69, 0x73, 0x74, 0x50, 0x6f, 0x6f, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x22, 0x2a, 0x0a, 0x14, 0x56, 0x50, 0x4e, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x22, 0xaa, 0x01, 0x0a, 0x14, 0x56, 0x50, 0x4e, 0x41, 0x64, 0x64, 0x41, 0x43,
+	0x4c, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08,
+	0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x6f, 0x6c,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x6f, 0x6f, 0x6c, 0x12, 0x16, 0x0a, 0x06,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69,
+	0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x12, 0x0a, 0x04,
+	0x70, 0x6f, 0x72, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74,
+	0x22, 0x18, 0x0a, 0x16, 0x56, 0x50, 0x4e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x43, 0x4c, 0x52, 0x75,
+	0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x29, 0x0a, 0x17, 0x56, 0x50,
+	0x4e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x43, 0x4c, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x02, 0x69, 0x64, 0x22, 0x18, 0x0a, 0x16, 0x56, 0x50, 0x4e, 0x53, 0x68, 0x6f, 0x77,
+	0x46, 0x69, 0x72, 0x65, 0x77, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22,
+	0xbe, 0x06, 0x0a, 0x11, 0x56, 0x50, 0x4e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65, 0x72,
+	0x69, 0x61, 0x6c, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0c, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1a,
+	0x0a, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f,
+	0x72, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x63, 0x65, 0x72, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x65,
+	0x72, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x63, 0x61, 0x5f, 0x63, 0x65, 0x72, 0x74, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x61, 0x43, 0x65, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6e,
+	0x65, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6e, 0x65, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x6d, 0x61, 0x73, 0x6b, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6d, 0x61, 0x73,
+	0x6b, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x10, 0x0a, 0x03, 0x64, 0x6e, 0x73, 0x18, 0x0b, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x64, 0x6e, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69,
+	0x72, 0x65, 0x73, 0x5f, 0x61, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x65, 0x78,
+	0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x63, 0x61, 0x5f, 0x65, 0x78,
+	0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x61, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x63, 0x61, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75,
+	0x73, 0x65, 0x5f, 0x6c, 0x7a, 0x6f, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x75, 0x73,
+	0x65, 0x4c, 0x7a, 0x6f, 0x12, 0x23, 0x0a, 0x0d, 0x6b, 0x65, 0x79, 0x5f, 0x61, 0x6c, 0x67, 0x6f,
+	0x72, 0x69, 0x74, 0x68, 0x6d, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6b, 0x65, 0x79,
+	0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x12, 0x2c, 0x0a, 0x12, 0x63, 0x65, 0x72,
+	0x74, 0x5f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x69, 0x74, 0x79, 0x5f, 0x64, 0x61, 0x79, 0x73, 0x18,
+	0x10, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10, 0x63, 0x65, 0x72, 0x74, 0x56, 0x61, 0x6c, 0x69, 0x64,
+	0x69, 0x74, 0x79, 0x44, 0x61, 0x79, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x68, 0x5f, 0x70, 0x61,
+	0x72, 0x61, 0x6d, 0x73, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x68, 0x50, 0x61,
+	0x72, 0x61, 0x6d, 0x73, 0x12, 0x2f, 0x0a, 0x14, 0x64, 0x68, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d,
+	0x73, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x12, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x11, 0x64, 0x68, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x53, 0x74, 0x61, 0x72,
+	0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x26, 0x0a, 0x0f, 0x64, 0x68, 0x5f, 0x70, 0x61, 0x72, 0x61,
+	0x6d, 0x73, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x13, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
+	0x64, 0x68, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x20, 0x0a,
+	0x0c, 0x74, 0x6c, 0x73, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x14, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x6c, 0x73, 0x4b, 0x65, 0x79, 0x4d, 0x6f, 0x64, 0x65, 0x12,
+	0x21, 0x0a, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x63, 0x69, 0x70, 0x68, 0x65, 0x72, 0x73, 0x18,
+	0x15, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x61, 0x74, 0x61, 0x43, 0x69, 0x70, 0x68, 0x65,
+	0x72, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73,
+	0x74, 0x18, 0x16, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x75, 0x74, 0x68, 0x44, 0x69, 0x67,
+	0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x0f, 0x74, 0x6c, 0x73, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x5f, 0x6d, 0x69, 0x6e, 0x18, 0x17, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x74, 0x6c,
+	0x73, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x4d, 0x69, 0x6e, 0x12, 0x19, 0x0a, 0x08, 0x69,
+	0x70, 0x76, 0x36, 0x5f, 0x6e, 0x65, 0x74, 0x18, 0x18, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x69,
+	0x70, 0x76, 0x36, 0x4e, 0x65, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x6e, 0x73, 0x5f, 0x64, 0x6f,
+	0x6d, 0x61, 0x69, 0x6e, 0x18, 0x19, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x6e, 0x73, 0x44,
+	0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x6e, 0x73, 0x5f, 0x73, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x6e, 0x73, 0x53, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x72,
+	0x18, 0x1b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x72,
+	0x22, 0x11, 0x0a, 0x0f, 0x56, 0x50, 0x4e, 0x49, 0x6e, 0x69, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x13, 0x0a, 0x11, 0x56, 0x50, 0x4e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x14, 0x0a, 0x12, 0x56, 0x50, 0x4e, 0x52,
+	0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x42,
+	0x0a, 0x0f, 0x56, 0x50, 0x4e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x2f, 0x0a, 0x07, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x07, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x73, 0x22, 0xb8, 0x01, 0x0a, 0x13, 0x56, 0x50, 0x4e, 0x45, 0x78, 0x70, 0x69, 0x72, 0x69,
+	0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x05, 0x63, 0x65,
+	0x72, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x62, 0x2e, 0x56,
+	0x50, 0x4e, 0x45, 0x78, 0x70, 0x69, 0x72, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x2e, 0x43, 0x65, 0x72, 0x74, 0x52, 0x05, 0x63, 0x65, 0x72, 0x74, 0x73, 0x1a, 0x6d,
+	0x0a, 0x04, 0x43, 0x65, 0x72, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x12,
+	0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69,
+	0x6e, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1d,
+	0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x22, 0x19, 0x0a,
+	0x17, 0x56, 0x50, 0x4e, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x54, 0x4c, 0x53, 0x4b, 0x65, 0x79,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xcf, 0x01, 0x0a, 0x1b, 0x56, 0x50, 0x4e,
+	0x43, 0x41, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x6f, 0x74, 0x61,
+	0x74, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x72, 0x6f, 0x74, 0x61,
+	0x74, 0x69, 0x6e, 0x67, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x5f,
+	0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65,
+	0x64, 0x41, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x75, 0x73, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x05, 0x75, 0x73, 0x65, 0x72, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x66, 0x65, 0x74,
+	0x63, 0x68, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x66, 0x65, 0x74, 0x63,
+	0x68, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x05,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x2b, 0x0a,
+	0x12, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x63, 0x61, 0x5f, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73,
+	0x5f, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x43,
+	0x61, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x22, 0x2d, 0x0a, 0x11, 0x56, 0x50,
+	0x4e, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x07, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x22, 0x14, 0x0a, 0x12, 0x56, 0x50, 0x4e,
+	0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x71, 0x0a, 0x15, 0x56, 0x50, 0x4e, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x10, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x44, 0x69, 0x72, 0x65, 0x63,
+	0x74, 0x69, 0x76, 0x65, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f,
+	0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x10, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x76,
+	0x65, 0x73, 0x22, 0xab, 0x01, 0x0a, 0x17, 0x56, 0x50, 0x4e, 0x52, 0x65, 0x70, 0x61, 0x63, 0x6b,
+	0x4c, 0x65, 0x61, 0x73, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c,
+	0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x22, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x52, 0x65, 0x70, 0x61, 0x63, 0x6b, 0x4c, 0x65,
+	0x61, 0x73, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x43, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x1a, 0x52, 0x0a, 0x06,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x15, 0x0a, 0x06, 0x6f, 0x6c, 0x64, 0x5f, 0x69, 0x70, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x6f, 0x6c, 0x64, 0x49, 0x70, 0x12, 0x15, 0x0a, 0x06, 0x6e, 0x65, 0x77,
+	0x5f, 0x69, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x65, 0x77, 0x49, 0x70,
+	0x22, 0x86, 0x01, 0x0a, 0x07, 0x56, 0x50, 0x4e, 0x50, 0x6f, 0x6f, 0x6c, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x63, 0x69, 0x64, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x63, 0x69, 0x64, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09,
+	0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x09, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x39, 0x0a, 0x14, 0x56, 0x50, 0x4e,
+	0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f, 0x6f, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x21, 0x0a, 0x05, 0x70, 0x6f, 0x6f, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0b, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x05, 0x70,
+	0x6f, 0x6f, 0x6c, 0x73, 0x22, 0x17, 0x0a, 0x15, 0x56, 0x50, 0x4e, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xcf, 0x01,
+	0x0a, 0x0a, 0x56, 0x50, 0x4e, 0x41, 0x43, 0x4c, 0x52, 0x75, 0x6c, 0x65, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08,
+	0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x6f, 0x6c,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x6f, 0x6f, 0x6c, 0x12, 0x16, 0x0a, 0x06,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69,
+	0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x12, 0x0a, 0x04,
+	0x70, 0x6f, 0x72, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74,
+	0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22,
+	0x3f, 0x0a, 0x17, 0x56, 0x50, 0x4e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x43, 0x4c, 0x52, 0x75, 0x6c,
+	0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x24, 0x0a, 0x05, 0x72, 0x75,
+	0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x70, 0x62, 0x2e, 0x56,
+	0x50, 0x4e, 0x41, 0x43, 0x4c, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73,
+	0x22, 0x1a, 0x0a, 0x18, 0x56, 0x50, 0x4e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x43, 0x4c,
+	0x52, 0x75, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x71, 0x0a, 0x17,
+	0x56, 0x50, 0x4e, 0x53, 0x68, 0x6f, 0x77, 0x46, 0x69, 0x72, 0x65, 0x77, 0x61, 0x6c, 0x6c, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x61, 0x63, 0x6b, 0x65,
+	0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e,
+	0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x70, 0x76, 0x34, 0x5f, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x69, 0x70, 0x76, 0x34, 0x52, 0x75, 0x6c, 0x65, 0x73,
+	0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x70, 0x76, 0x36, 0x5f, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x69, 0x70, 0x76, 0x36, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x2a,
+	0x28, 0x0a, 0x08, 0x56, 0x50, 0x4e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x0a, 0x06, 0x4e,
+	0x4f, 0x50, 0x52, 0x45, 0x46, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x55, 0x44, 0x50, 0x10, 0x01,
+	0x12, 0x07, 0x0a, 0x03, 0x54, 0x43, 0x50, 0x10, 0x02, 0x2a, 0x49, 0x0a, 0x0a, 0x56, 0x50, 0x4e,
+	0x4c, 0x5a, 0x4f, 0x50, 0x72, 0x65, 0x66, 0x12, 0x12, 0x0a, 0x0e, 0x55, 0x53, 0x45, 0x5f, 0x4c,
+	0x5a, 0x4f, 0x5f, 0x4e, 0x4f, 0x50, 0x52, 0x45, 0x46, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x55,
+	0x53, 0x45, 0x5f, 0x4c, 0x5a, 0x4f, 0x5f, 0x45, 0x4e, 0x41, 0x42, 0x4c, 0x45, 0x10, 0x01, 0x12,
+	0x13, 0x0a, 0x0f, 0x55, 0x53, 0x45, 0x5f, 0x4c, 0x5a, 0x4f, 0x5f, 0x44, 0x49, 0x53, 0x41, 0x42,
+	0x4c, 0x45, 0x10, 0x03, 0x2a, 0x51, 0x0a, 0x0f, 0x56, 0x50, 0x4e, 0x52, 0x65, 0x73, 0x6f, 0x6c,
+	0x76, 0x65, 0x72, 0x50, 0x72, 0x65, 0x66, 0x12, 0x13, 0x0a, 0x0f, 0x52, 0x45, 0x53, 0x4f, 0x4c,
+	0x56, 0x45, 0x52, 0x5f, 0x4e, 0x4f, 0x50, 0x52, 0x45, 0x46, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f,
+	0x52, 0x45, 0x53, 0x4f, 0x4c, 0x56, 0x45, 0x52, 0x5f, 0x45, 0x4e, 0x41, 0x42, 0x4c, 0x45, 0x10,
+	0x01, 0x12, 0x14, 0x0a, 0x10, 0x52, 0x45, 0x53, 0x4f, 0x4c, 0x56, 0x45, 0x52, 0x5f, 0x44, 0x49,
+	0x53, 0x41, 0x42, 0x4c, 0x45, 0x10, 0x02, 0x2a, 0x38, 0x0a, 0x09, 0x56, 0x50, 0x4e, 0x44, 0x48,
+	0x50, 0x72, 0x65, 0x66, 0x12, 0x0d, 0x0a, 0x09, 0x44, 0x48, 0x5f, 0x4e, 0x4f, 0x50, 0x52, 0x45,
+	0x46, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x44, 0x48, 0x5f, 0x47, 0x45, 0x4e, 0x45, 0x52, 0x41,
+	0x54, 0x45, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x44, 0x48, 0x5f, 0x4e, 0x4f, 0x4e, 0x45, 0x10,
+	0x02, 0x2a, 0x38, 0x0a, 0x11, 0x56, 0x50, 0x4e, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x76,
+	0x65, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x12, 0x0f, 0x0a, 0x0b, 0x53, 0x45, 0x52, 0x56, 0x45, 0x52,
+	0x5f, 0x43, 0x4f, 0x4e, 0x46, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x43, 0x4c, 0x49, 0x45, 0x4e,
+	0x54, 0x5f, 0x50, 0x52, 0x4f, 0x46, 0x49, 0x4c, 0x45, 0x10, 0x01, 0x32, 0xd8, 0x10, 0x0a, 0x0a,
+	0x56, 0x50, 0x4e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x51, 0x0a, 0x06, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x70, 0x62, 0x2e,
+	0x56, 0x50, 0x4e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x1a, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x14, 0x12, 0x12, 0x2f, 0x61, 0x70, 0x69, 0x2f,
+	0x76, 0x31, 0x2f, 0x76, 0x70, 0x6e, 0x2f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x4c, 0x0a,
+	0x04, 0x49, 0x6e, 0x69, 0x74, 0x12, 0x12, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x49, 0x6e,
+	0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x70, 0x62, 0x2e, 0x56,
+	0x50, 0x4e, 0x49, 0x6e, 0x69, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1b,
+	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x15, 0x22, 0x10, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f,
+	0x76, 0x70, 0x6e, 0x2f, 0x69, 0x6e, 0x69, 0x74, 0x3a, 0x01, 0x2a, 0x12, 0x54, 0x0a, 0x06, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x14, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x70, 0x62,
+	0x2e, 0x56, 0x50, 0x4e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x1d, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x17, 0x22, 0x12, 0x2f, 0x61, 0x70, 0x69,
+	0x2f, 0x76, 0x31, 0x2f, 0x76, 0x70, 0x6e, 0x2f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x3a, 0x01,
+	0x2a, 0x12, 0x55, 0x0a, 0x07, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x15, 0x2e, 0x70,
+	0x62, 0x2e, 0x56, 0x50, 0x4e, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x52, 0x65, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1b, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x15, 0x22, 0x13, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x76, 0x70, 0x6e,
+	0x2f, 0x72, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x49, 0x0a, 0x04, 0x4c, 0x69, 0x73, 0x74,
+	0x12, 0x12, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x4c, 0x69, 0x73,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x18, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0x12, 0x12, 0x10, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x76, 0x70, 0x6e, 0x2f, 0x6c,
+	0x69, 0x73, 0x74, 0x12, 0x59, 0x0a, 0x08, 0x45, 0x78, 0x70, 0x69, 0x72, 0x69, 0x6e, 0x67, 0x12,
+	0x16, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x45, 0x78, 0x70, 0x69, 0x72, 0x69, 0x6e, 0x67,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e,
+	0x45, 0x78, 0x70, 0x69, 0x72, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x1c, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x16, 0x12, 0x14, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76,
+	0x31, 0x2f, 0x76, 0x70, 0x6e, 0x2f, 0x65, 0x78, 0x70, 0x69, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x6e,
+	0x0a, 0x0c, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x54, 0x4c, 0x53, 0x4b, 0x65, 0x79, 0x12, 0x1a,
+	0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x54, 0x4c, 0x53,
+	0x4b, 0x65, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x70, 0x62, 0x2e,
+	0x56, 0x50, 0x4e, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x54, 0x4c, 0x53, 0x4b, 0x65, 0x79, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x25, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1f, 0x22,
+	0x1a, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x76, 0x70, 0x6e, 0x2f, 0x74, 0x6c, 0x73,
+	0x2d, 0x6b, 0x65, 0x79, 0x2f, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x3a, 0x01, 0x2a, 0x12, 0x6e,
+	0x0a, 0x0f, 0x53, 0x74, 0x61, 0x72, 0x74, 0x43, 0x41, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x18, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x43, 0x41, 0x52, 0x6f, 0x74, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x70, 0x62,
+	0x2e, 0x56, 0x50, 0x4e, 0x43, 0x41, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x20, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x1a, 0x22, 0x15, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x76, 0x70,
+	0x6e, 0x2f, 0x63, 0x61, 0x2f, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x3a, 0x01, 0x2a, 0x12, 0x75,
+	0x0a, 0x10, 0x46, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x43, 0x41, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x1e, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x46, 0x69, 0x6e, 0x69, 0x73,
+	0x68, 0x43, 0x41, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x43, 0x41, 0x52, 0x6f, 0x74,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x20, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1a, 0x22, 0x15, 0x2f, 0x61, 0x70,
+	0x69, 0x2f, 0x76, 0x31, 0x2f, 0x76, 0x70, 0x6e, 0x2f, 0x63, 0x61, 0x2f, 0x66, 0x69, 0x6e, 0x69,
+	0x73, 0x68, 0x3a, 0x01, 0x2a, 0x12, 0x6c, 0x0a, 0x10, 0x43, 0x41, 0x52, 0x6f, 0x74, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x18, 0x2e, 0x70, 0x62, 0x2e, 0x56,
+	0x50, 0x4e, 0x43, 0x41, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x43, 0x41, 0x52, 0x6f,
+	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1d, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x17, 0x12, 0x15, 0x2f, 0x61,
+	0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x76, 0x70, 0x6e, 0x2f, 0x63, 0x61, 0x2f, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x54, 0x0a, 0x06, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x12, 0x14, 0x2e,
+	0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x42, 0x61, 0x63, 0x6b,
+	0x75, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1d, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x17, 0x22, 0x12, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x76, 0x70, 0x6e, 0x2f,
+	0x62, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x3a, 0x01, 0x2a, 0x12, 0x58, 0x0a, 0x07, 0x52, 0x65, 0x73,
+	0x74, 0x6f, 0x72, 0x65, 0x12, 0x15, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x52, 0x65, 0x73,
+	0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x70, 0x62,
+	0x2e, 0x56, 0x50, 0x4e, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x1e, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x18, 0x22, 0x13, 0x2f, 0x61, 0x70,
+	0x69, 0x2f, 0x76, 0x31, 0x2f, 0x76, 0x70, 0x6e, 0x2f, 0x72, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65,
+	0x3a, 0x01, 0x2a, 0x12, 0x64, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74,
+	0x69, 0x76, 0x65, 0x73, 0x12, 0x18, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x44, 0x69, 0x72,
+	0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19,
+	0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1e, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0x18, 0x12, 0x16, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x76, 0x70, 0x6e, 0x2f, 0x64,
+	0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x73, 0x12, 0x6a, 0x0a, 0x0d, 0x53, 0x65, 0x74,
+	0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x73, 0x12, 0x1b, 0x2e, 0x70, 0x62, 0x2e,
+	0x56, 0x50, 0x4e, 0x53, 0x65, 0x74, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e,
+	0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x21, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1b, 0x22, 0x16, 0x2f, 0x61, 0x70, 0x69,
+	0x2f, 0x76, 0x31, 0x2f, 0x76, 0x70, 0x6e, 0x2f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x76,
+	0x65, 0x73, 0x3a, 0x01, 0x2a, 0x12, 0x6d, 0x0a, 0x0c, 0x52, 0x65, 0x70, 0x61, 0x63, 0x6b, 0x4c,
+	0x65, 0x61, 0x73, 0x65, 0x73, 0x12, 0x1a, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x52, 0x65,
+	0x70, 0x61, 0x63, 0x6b, 0x4c, 0x65, 0x61, 0x73, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1b, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x52, 0x65, 0x70, 0x61, 0x63, 0x6b,
+	0x4c, 0x65, 0x61, 0x73, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x24,
+	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1e, 0x22, 0x19, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f,
+	0x76, 0x70, 0x6e, 0x2f, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x73, 0x2f, 0x72, 0x65, 0x70, 0x61, 0x63,
+	0x6b, 0x3a, 0x01, 0x2a, 0x12, 0x51, 0x0a, 0x0a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x6f,
+	0x6f, 0x6c, 0x12, 0x18, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0b, 0x2e, 0x70,
+	0x62, 0x2e, 0x56, 0x50, 0x4e, 0x50, 0x6f, 0x6f, 0x6c, 0x22, 0x1c, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0x16, 0x22, 0x11, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x76, 0x70, 0x6e, 0x2f, 0x70,
+	0x6f, 0x6f, 0x6c, 0x73, 0x3a, 0x01, 0x2a, 0x12, 0x59, 0x0a, 0x09, 0x4c, 0x69, 0x73, 0x74, 0x50,
+	0x6f, 0x6f, 0x6c, 0x73, 0x12, 0x17, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x4c, 0x69, 0x73,
+	0x74, 0x50, 0x6f, 0x6f, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e,
+	0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f, 0x6f, 0x6c, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x19, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x13, 0x12,
+	0x11, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x76, 0x70, 0x6e, 0x2f, 0x70, 0x6f, 0x6f,
+	0x6c, 0x73, 0x12, 0x66, 0x0a, 0x0a, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x50, 0x6f, 0x6f, 0x6c,
+	0x12, 0x18, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x50,
+	0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x70, 0x62, 0x2e,
+	0x56, 0x50, 0x4e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x23, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1d, 0x22, 0x18, 0x2f,
+	0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x76, 0x70, 0x6e, 0x2f, 0x70, 0x6f, 0x6f, 0x6c, 0x73,
+	0x2f, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x3a, 0x01, 0x2a, 0x12, 0x52, 0x0a, 0x0a, 0x41, 0x64,
+	0x64, 0x41, 0x43, 0x4c, 0x52, 0x75, 0x6c, 0x65, 0x12, 0x18, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50,
+	0x4e, 0x41, 0x64, 0x64, 0x41, 0x43, 0x4c, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x41, 0x43, 0x4c, 0x52, 0x75,
+	0x6c, 0x65, 0x22, 0x1a, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x14, 0x22, 0x0f, 0x2f, 0x61, 0x70, 0x69,
+	0x2f, 0x76, 0x31, 0x2f, 0x76, 0x70, 0x6e, 0x2f, 0x61, 0x63, 0x6c, 0x3a, 0x01, 0x2a, 0x12, 0x60,
+	0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x43, 0x4c, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x1a,
+	0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x43, 0x4c, 0x52, 0x75,
+	0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x70, 0x62, 0x2e,
+	0x56, 0x50, 0x4e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x43, 0x4c, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x17, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x11, 0x12,
+	0x0f, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x76, 0x70, 0x6e, 0x2f, 0x61, 0x63, 0x6c,
+	0x12, 0x6d, 0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x43, 0x4c, 0x52, 0x75, 0x6c,
+	0x65, 0x12, 0x1b, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x41, 0x43, 0x4c, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c,
+	0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x43, 0x4c,
+	0x52, 0x75, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x21, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x1b, 0x22, 0x16, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x76, 0x70,
+	0x6e, 0x2f, 0x61, 0x63, 0x6c, 0x2f, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x3a, 0x01, 0x2a, 0x12,
+	0x65, 0x0a, 0x0c, 0x53, 0x68, 0x6f, 0x77, 0x46, 0x69, 0x72, 0x65, 0x77, 0x61, 0x6c, 0x6c, 0x12,
+	0x1a, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x53, 0x68, 0x6f, 0x77, 0x46, 0x69, 0x72, 0x65,
+	0x77, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x70, 0x62,
+	0x2e, 0x56, 0x50, 0x4e, 0x53, 0x68, 0x6f, 0x77, 0x46, 0x69, 0x72, 0x65, 0x77, 0x61, 0x6c, 0x6c,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1c, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x16,
+	0x12, 0x14, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x76, 0x70, 0x6e, 0x2f, 0x66, 0x69,
+	0x72, 0x65, 0x77, 0x61, 0x6c, 0x6c, 0x42, 0x22, 0x5a, 0x20, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x47, 0x6f, 0x6c, 0x64, 0x65, 0x6e, 0x52, 0x55, 0x53, 0x2f, 0x6f,
+	0x76, 0x70, 0x6d, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_vpn_proto_rawDescOnce sync.Once
+	file_vpn_proto_rawDescData = file_vpn_proto_rawDesc
+)
+
+func file_vpn_proto_rawDescGZIP() []byte {
+	file_vpn_proto_rawDescOnce.Do(func() {
+		file_vpn_proto_rawDescData = protoimpl.X.CompressGZIP(file_vpn_proto_rawDescData)
+	})
+	return file_vpn_proto_rawDescData
+}
+
+var file_vpn_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_vpn_proto_msgTypes = make([]protoimpl.MessageInfo, 42)
+var file_vpn_proto_goTypes = []interface{}{
+	(VPNProto)(0),                          // 0: pb.VPNProto
+	(VPNLZOPref)(0),                        // 1: pb.VPNLZOPref
+	(VPNResolverPref)(0),                   // 2: pb.VPNResolverPref
+	(VPNDHPref)(0),                         // 3: pb.VPNDHPref
+	(VPNDirectiveScope)(0),                 // 4: pb.VPNDirectiveScope
+	(*VPNStatusRequest)(nil),               // 5: pb.VPNStatusRequest
+	(*VPNInitRequest)(nil),                 // 6: pb.VPNInitRequest
+	(*VPNUpdateRequest)(nil),               // 7: pb.VPNUpdateRequest
+	(*VPNRestartRequest)(nil),              // 8: pb.VPNRestartRequest
+	(*VPNListRequest)(nil),                 // 9: pb.VPNListRequest
+	(*VPNExpiringRequest)(nil),             // 10: pb.VPNExpiringRequest
+	(*VPNRotateTLSKeyRequest)(nil),         // 11: pb.VPNRotateTLSKeyRequest
+	(*VPNCARotationRequest)(nil),           // 12: pb.VPNCARotationRequest
+	(*VPNFinishCARotationRequest)(nil),     // 13: pb.VPNFinishCARotationRequest
+	(*VPNBackupRequest)(nil),               // 14: pb.VPNBackupRequest
+	(*VPNRestoreRequest)(nil),              // 15: pb.VPNRestoreRequest
+	(*VPNDirectivesRequest)(nil),           // 16: pb.VPNDirectivesRequest
+	(*VPNSetDirectivesRequest)(nil),        // 17: pb.VPNSetDirectivesRequest
+	(*VPNRepackLeasesRequest)(nil),         // 18: pb.VPNRepackLeasesRequest
+	(*VPNCreatePoolRequest)(nil),           // 19: pb.VPNCreatePoolRequest
+	(*VPNListPoolsRequest)(nil),            // 20: pb.VPNListPoolsRequest
+	(*VPNDeletePoolRequest)(nil),           // 21: pb.VPNDeletePoolRequest
+	(*VPNAddACLRuleRequest)(nil),           // 22: pb.VPNAddACLRuleRequest
+	(*VPNListACLRulesRequest)(nil),         // 23: pb.VPNListACLRulesRequest
+	(*VPNDeleteACLRuleRequest)(nil),        // 24: pb.VPNDeleteACLRuleRequest
+	(*VPNShowFirewallRequest)(nil),         // 25: pb.VPNShowFirewallRequest
+	(*VPNStatusResponse)(nil),              // 26: pb.VPNStatusResponse
+	(*VPNInitResponse)(nil),                // 27: pb.VPNInitResponse
+	(*VPNUpdateResponse)(nil),              // 28: pb.VPNUpdateResponse
+	(*VPNRestartResponse)(nil),             // 29: pb.VPNRestartResponse
+	(*VPNListResponse)(nil),                // 30: pb.VPNListResponse
+	(*VPNExpiringResponse)(nil),            // 31: pb.VPNExpiringResponse
+	(*VPNRotateTLSKeyResponse)(nil),        // 32: pb.VPNRotateTLSKeyResponse
+	(*VPNCARotationStatusResponse)(nil),    // 33: pb.VPNCARotationStatusResponse
+	(*VPNBackupResponse)(nil),              // 34: pb.VPNBackupResponse
+	(*VPNRestoreResponse)(nil),             // 35: pb.VPNRestoreResponse
+	(*VPNDirectivesResponse)(nil),          // 36: pb.VPNDirectivesResponse
+	(*VPNRepackLeasesResponse)(nil),        // 37: pb.VPNRepackLeasesResponse
+	(*VPNPool)(nil),                        // 38: pb.VPNPool
+	(*VPNListPoolsResponse)(nil),           // 39: pb.VPNListPoolsResponse
+	(*VPNDeletePoolResponse)(nil),          // 40: pb.VPNDeletePoolResponse
+	(*VPNACLRule)(nil),                     // 41: pb.VPNACLRule
+	(*VPNListACLRulesResponse)(nil),        // 42: pb.VPNListACLRulesResponse
+	(*VPNDeleteACLRuleResponse)(nil),       // 43: pb.VPNDeleteACLRuleResponse
+	(*VPNShowFirewallResponse)(nil),        // 44: pb.VPNShowFirewallResponse
+	(*VPNExpiringResponse_Cert)(nil),       // 45: pb.VPNExpiringResponse.Cert
+	(*VPNRepackLeasesResponse_Change)(nil), // 46: pb.VPNRepackLeasesResponse.Change
+}
+var file_vpn_proto_depIdxs = []int32{
+	0,  // 0: pb.VPNInitRequest.proto_pref:type_name -> pb.VPNProto
+	3,  // 1: pb.VPNInitRequest.dh_pref:type_name -> pb.VPNDHPref
+	1,  // 2: pb.VPNUpdateRequest.lzo_pref:type_name -> pb.VPNLZOPref
+	3,  // 3: pb.VPNUpdateRequest.dh_pref:type_name -> pb.VPNDHPref
+	2,  // 4: pb.VPNUpdateRequest.resolver_pref:type_name -> pb.VPNResolverPref
+	4,  // 5: pb.VPNSetDirectivesRequest.scope:type_name -> pb.VPNDirectiveScope
+	26, // 6: pb.VPNListResponse.servers:type_name -> pb.VPNStatusResponse
+	45, // 7: pb.VPNExpiringResponse.certs:type_name -> pb.VPNExpiringResponse.Cert
+	46, // 8: pb.VPNRepackLeasesResponse.changes:type_name -> pb.VPNRepackLeasesResponse.Change
+	38, // 9: pb.VPNListPoolsResponse.pools:type_name -> pb.VPNPool
+	41, // 10: pb.VPNListACLRulesResponse.rules:type_name -> pb.VPNACLRule
+	5,  // 11: pb.VPNService.Status:input_type -> pb.VPNStatusRequest
+	6,  // 12: pb.VPNService.Init:input_type -> pb.VPNInitRequest
+	7,  // 13: pb.VPNService.Update:input_type -> pb.VPNUpdateRequest
+	8,  // 14: pb.VPNService.Restart:input_type -> pb.VPNRestartRequest
+	9,  // 15: pb.VPNService.List:input_type -> pb.VPNListRequest
+	10, // 16: pb.VPNService.Expiring:input_type -> pb.VPNExpiringRequest
+	11, // 17: pb.VPNService.RotateTLSKey:input_type -> pb.VPNRotateTLSKeyRequest
+	12, // 18: pb.VPNService.StartCARotation:input_type -> pb.VPNCARotationRequest
+	13, // 19: pb.VPNService.FinishCARotation:input_type -> pb.VPNFinishCARotationRequest
+	12, // 20: pb.VPNService.CARotationStatus:input_type -> pb.VPNCARotationRequest
+	14, // 21: pb.VPNService.Backup:input_type -> pb.VPNBackupRequest
+	15, // 22: pb.VPNService.Restore:input_type -> pb.VPNRestoreRequest
+	16, // 23: pb.VPNService.GetDirectives:input_type -> pb.VPNDirectivesRequest
+	17, // 24: pb.VPNService.SetDirectives:input_type -> pb.VPNSetDirectivesRequest
+	18, // 25: pb.VPNService.RepackLeases:input_type -> pb.VPNRepackLeasesRequest
+	19, // 26: pb.VPNService.CreatePool:input_type -> pb.VPNCreatePoolRequest
+	20, // 27: pb.VPNService.ListPools:input_type -> pb.VPNListPoolsRequest
+	21, // 28: pb.VPNService.DeletePool:input_type -> pb.VPNDeletePoolRequest
+	22, // 29: pb.VPNService.AddACLRule:input_type -> pb.VPNAddACLRuleRequest
+	23, // 30: pb.VPNService.ListACLRules:input_type -> pb.VPNListACLRulesRequest
+	24, // 31: pb.VPNService.DeleteACLRule:input_type -> pb.VPNDeleteACLRuleRequest
+	25, // 32: pb.VPNService.ShowFirewall:input_type -> pb.VPNShowFirewallRequest
+	26, // 33: pb.VPNService.Status:output_type -> pb.VPNStatusResponse
+	27, // 34: pb.VPNService.Init:output_type -> pb.VPNInitResponse
+	28, // 35: pb.VPNService.Update:output_type -> pb.VPNUpdateResponse
+	29, // 36: pb.VPNService.Restart:output_type -> pb.VPNRestartResponse
+	30, // 37: pb.VPNService.List:output_type -> pb.VPNListResponse
+	31, // 38: pb.VPNService.Expiring:output_type -> pb.VPNExpiringResponse
+	32, // 39: pb.VPNService.RotateTLSKey:output_type -> pb.VPNRotateTLSKeyResponse
+	33, // 40: pb.VPNService.StartCARotation:output_type -> pb.VPNCARotationStatusResponse
+	33, // 41: pb.VPNService.FinishCARotation:output_type -> pb.VPNCARotationStatusResponse
+	33, // 42: pb.VPNService.CARotationStatus:output_type -> pb.VPNCARotationStatusResponse
+	34, // 43: pb.VPNService.Backup:output_type -> pb.VPNBackupResponse
+	35, // 44: pb.VPNService.Restore:output_type -> pb.VPNRestoreResponse
+	36, // 45: pb.VPNService.GetDirectives:output_type -> pb.VPNDirectivesResponse
+	36, // 46: pb.VPNService.SetDirectives:output_type -> pb.VPNDirectivesResponse
+	37, // 47: pb.VPNService.RepackLeases:output_type -> pb.VPNRepackLeasesResponse
+	38, // 48: pb.VPNService.CreatePool:output_type -> pb.VPNPool
+	39, // 49: pb.VPNService.ListPools:output_type -> pb.VPNListPoolsResponse
+	40, // 50: pb.VPNService.DeletePool:output_type -> pb.VPNDeletePoolResponse
+	41, // 51: pb.VPNService.AddACLRule:output_type -> pb.VPNACLRule
+	42, // 52: pb.VPNService.ListACLRules:output_type -> pb.VPNListACLRulesResponse
+	43, // 53: pb.VPNService.DeleteACLRule:output_type -> pb.VPNDeleteACLRuleResponse
+	44, // 54: pb.VPNService.ShowFirewall:output_type -> pb.VPNShowFirewallResponse
+	33, // [33:55] is the sub-list for method output_type
+	11, // [11:33] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_vpn_proto_init() }
+func file_vpn_proto_init() {
+	if File_vpn_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_vpn_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VPNStatusRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vpn_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VPNInitRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vpn_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VPNUpdateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vpn_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VPNRestartRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vpn_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VPNListRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vpn_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VPNExpiringRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vpn_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VPNRotateTLSKeyRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vpn_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VPNCARotationRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vpn_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VPNFinishCARotationRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vpn_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VPNBackupRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vpn_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VPNRestoreRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vpn_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VPNDirectivesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vpn_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VPNSetDirectivesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vpn_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VPNRepackLeasesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vpn_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VPNCreatePoolRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -782,8 +3680,8 @@ func file_vpn_proto_init() {
 				return nil
 			}
 		}
-		file_vpn_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VPNInitRequest); i {
+		file_vpn_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VPNListPoolsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -794,8 +3692,8 @@ func file_vpn_proto_init() {
 				return nil
 			}
 		}
-		file_vpn_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VPNUpdateRequest); i {
+		file_vpn_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VPNDeletePoolRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -806,8 +3704,8 @@ func file_vpn_proto_init() {
 				return nil
 			}
 		}
-		file_vpn_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VPNRestartRequest); i {
+		file_vpn_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VPNAddACLRuleRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -818,7 +3716,43 @@ func file_vpn_proto_init() {
 				return nil
 			}
 		}
-		file_vpn_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+		file_vpn_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VPNListACLRulesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vpn_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VPNDeleteACLRuleRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vpn_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VPNShowFirewallRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vpn_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*VPNStatusResponse); i {
 			case 0:
 				return &v.state
@@ -830,7 +3764,7 @@ func file_vpn_proto_init() {
 				return nil
 			}
 		}
-		file_vpn_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+		file_vpn_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*VPNInitResponse); i {
 			case 0:
 				return &v.state
@@ -842,7 +3776,7 @@ func file_vpn_proto_init() {
 				return nil
 			}
 		}
-		file_vpn_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+		file_vpn_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*VPNUpdateResponse); i {
 			case 0:
 				return &v.state
@@ -854,7 +3788,7 @@ func file_vpn_proto_init() {
 				return nil
 			}
 		}
-		file_vpn_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+		file_vpn_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*VPNRestartResponse); i {
 			case 0:
 				return &v.state
@@ -866,14 +3800,218 @@ func file_vpn_proto_init() {
 				return nil
 			}
 		}
+		file_vpn_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VPNListResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vpn_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VPNExpiringResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vpn_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VPNRotateTLSKeyResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vpn_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VPNCARotationStatusResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vpn_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VPNBackupResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vpn_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VPNRestoreResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vpn_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VPNDirectivesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vpn_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VPNRepackLeasesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vpn_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VPNPool); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vpn_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VPNListPoolsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vpn_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VPNDeletePoolResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vpn_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VPNACLRule); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vpn_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VPNListACLRulesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vpn_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VPNDeleteACLRuleResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vpn_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VPNShowFirewallResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vpn_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VPNExpiringResponse_Cert); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vpn_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VPNRepackLeasesResponse_Change); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_vpn_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   8,
+			NumEnums:      5,
+			NumMessages:   42,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -26,6 +26,24 @@ type VPNServiceClient interface {
 	Init(ctx context.Context, in *VPNInitRequest, opts ...grpc.CallOption) (*VPNInitResponse, error)
 	Update(ctx context.Context, in *VPNUpdateRequest, opts ...grpc.CallOption) (*VPNUpdateResponse, error)
 	Restart(ctx context.Context, in *VPNRestartRequest, opts ...grpc.CallOption) (*VPNRestartResponse, error)
+	List(ctx context.Context, in *VPNListRequest, opts ...grpc.CallOption) (*VPNListResponse, error)
+	Expiring(ctx context.Context, in *VPNExpiringRequest, opts ...grpc.CallOption) (*VPNExpiringResponse, error)
+	RotateTLSKey(ctx context.Context, in *VPNRotateTLSKeyRequest, opts ...grpc.CallOption) (*VPNRotateTLSKeyResponse, error)
+	StartCARotation(ctx context.Context, in *VPNCARotationRequest, opts ...grpc.CallOption) (*VPNCARotationStatusResponse, error)
+	FinishCARotation(ctx context.Context, in *VPNFinishCARotationRequest, opts ...grpc.CallOption) (*VPNCARotationStatusResponse, error)
+	CARotationStatus(ctx context.Context, in *VPNCARotationRequest, opts ...grpc.CallOption) (*VPNCARotationStatusResponse, error)
+	Backup(ctx context.Context, in *VPNBackupRequest, opts ...grpc.CallOption) (*VPNBackupResponse, error)
+	Restore(ctx context.Context, in *VPNRestoreRequest, opts ...grpc.CallOption) (*VPNRestoreResponse, error)
+	GetDirectives(ctx context.Context, in *VPNDirectivesRequest, opts ...grpc.CallOption) (*VPNDirectivesResponse, error)
+	SetDirectives(ctx context.Context, in *VPNSetDirectivesRequest, opts ...grpc.CallOption) (*VPNDirectivesResponse, error)
+	RepackLeases(ctx context.Context, in *VPNRepackLeasesRequest, opts ...grpc.CallOption) (*VPNRepackLeasesResponse, error)
+	CreatePool(ctx context.Context, in *VPNCreatePoolRequest, opts ...grpc.CallOption) (*VPNPool, error)
+	ListPools(ctx context.Context, in *VPNListPoolsRequest, opts ...grpc.CallOption) (*VPNListPoolsResponse, error)
+	DeletePool(ctx context.Context, in *VPNDeletePoolRequest, opts ...grpc.CallOption) (*VPNDeletePoolResponse, error)
+	AddACLRule(ctx context.Context, in *VPNAddACLRuleRequest, opts ...grpc.CallOption) (*VPNACLRule, error)
+	ListACLRules(ctx context.Context, in *VPNListACLRulesRequest, opts ...grpc.CallOption) (*VPNListACLRulesResponse, error)
+	DeleteACLRule(ctx context.Context, in *VPNDeleteACLRuleRequest, opts ...grpc.CallOption) (*VPNDeleteACLRuleResponse, error)
+	ShowFirewall(ctx context.Context, in *VPNShowFirewallRequest, opts ...grpc.CallOption) (*VPNShowFirewallResponse, error)
 }
 
 type vPNServiceClient struct {
@@ -72,6 +90,168 @@ func (c *vPNServiceClient) Restart(ctx context.Context, in *VPNRestartRequest, o
 	return out, nil
 }
 
+func (c *vPNServiceClient) List(ctx context.Context, in *VPNListRequest, opts ...grpc.CallOption) (*VPNListResponse, error) {
+	out := new(VPNListResponse)
+	err := c.cc.Invoke(ctx, "/pb.VPNService/List", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vPNServiceClient) Expiring(ctx context.Context, in *VPNExpiringRequest, opts ...grpc.CallOption) (*VPNExpiringResponse, error) {
+	out := new(VPNExpiringResponse)
+	err := c.cc.Invoke(ctx, "/pb.VPNService/Expiring", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vPNServiceClient) RotateTLSKey(ctx context.Context, in *VPNRotateTLSKeyRequest, opts ...grpc.CallOption) (*VPNRotateTLSKeyResponse, error) {
+	out := new(VPNRotateTLSKeyResponse)
+	err := c.cc.Invoke(ctx, "/pb.VPNService/RotateTLSKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vPNServiceClient) StartCARotation(ctx context.Context, in *VPNCARotationRequest, opts ...grpc.CallOption) (*VPNCARotationStatusResponse, error) {
+	out := new(VPNCARotationStatusResponse)
+	err := c.cc.Invoke(ctx, "/pb.VPNService/StartCARotation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vPNServiceClient) FinishCARotation(ctx context.Context, in *VPNFinishCARotationRequest, opts ...grpc.CallOption) (*VPNCARotationStatusResponse, error) {
+	out := new(VPNCARotationStatusResponse)
+	err := c.cc.Invoke(ctx, "/pb.VPNService/FinishCARotation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vPNServiceClient) CARotationStatus(ctx context.Context, in *VPNCARotationRequest, opts ...grpc.CallOption) (*VPNCARotationStatusResponse, error) {
+	out := new(VPNCARotationStatusResponse)
+	err := c.cc.Invoke(ctx, "/pb.VPNService/CARotationStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vPNServiceClient) Backup(ctx context.Context, in *VPNBackupRequest, opts ...grpc.CallOption) (*VPNBackupResponse, error) {
+	out := new(VPNBackupResponse)
+	err := c.cc.Invoke(ctx, "/pb.VPNService/Backup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vPNServiceClient) Restore(ctx context.Context, in *VPNRestoreRequest, opts ...grpc.CallOption) (*VPNRestoreResponse, error) {
+	out := new(VPNRestoreResponse)
+	err := c.cc.Invoke(ctx, "/pb.VPNService/Restore", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vPNServiceClient) GetDirectives(ctx context.Context, in *VPNDirectivesRequest, opts ...grpc.CallOption) (*VPNDirectivesResponse, error) {
+	out := new(VPNDirectivesResponse)
+	err := c.cc.Invoke(ctx, "/pb.VPNService/GetDirectives", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vPNServiceClient) SetDirectives(ctx context.Context, in *VPNSetDirectivesRequest, opts ...grpc.CallOption) (*VPNDirectivesResponse, error) {
+	out := new(VPNDirectivesResponse)
+	err := c.cc.Invoke(ctx, "/pb.VPNService/SetDirectives", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vPNServiceClient) RepackLeases(ctx context.Context, in *VPNRepackLeasesRequest, opts ...grpc.CallOption) (*VPNRepackLeasesResponse, error) {
+	out := new(VPNRepackLeasesResponse)
+	err := c.cc.Invoke(ctx, "/pb.VPNService/RepackLeases", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vPNServiceClient) CreatePool(ctx context.Context, in *VPNCreatePoolRequest, opts ...grpc.CallOption) (*VPNPool, error) {
+	out := new(VPNPool)
+	err := c.cc.Invoke(ctx, "/pb.VPNService/CreatePool", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vPNServiceClient) ListPools(ctx context.Context, in *VPNListPoolsRequest, opts ...grpc.CallOption) (*VPNListPoolsResponse, error) {
+	out := new(VPNListPoolsResponse)
+	err := c.cc.Invoke(ctx, "/pb.VPNService/ListPools", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vPNServiceClient) DeletePool(ctx context.Context, in *VPNDeletePoolRequest, opts ...grpc.CallOption) (*VPNDeletePoolResponse, error) {
+	out := new(VPNDeletePoolResponse)
+	err := c.cc.Invoke(ctx, "/pb.VPNService/DeletePool", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vPNServiceClient) AddACLRule(ctx context.Context, in *VPNAddACLRuleRequest, opts ...grpc.CallOption) (*VPNACLRule, error) {
+	out := new(VPNACLRule)
+	err := c.cc.Invoke(ctx, "/pb.VPNService/AddACLRule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vPNServiceClient) ListACLRules(ctx context.Context, in *VPNListACLRulesRequest, opts ...grpc.CallOption) (*VPNListACLRulesResponse, error) {
+	out := new(VPNListACLRulesResponse)
+	err := c.cc.Invoke(ctx, "/pb.VPNService/ListACLRules", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vPNServiceClient) DeleteACLRule(ctx context.Context, in *VPNDeleteACLRuleRequest, opts ...grpc.CallOption) (*VPNDeleteACLRuleResponse, error) {
+	out := new(VPNDeleteACLRuleResponse)
+	err := c.cc.Invoke(ctx, "/pb.VPNService/DeleteACLRule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vPNServiceClient) ShowFirewall(ctx context.Context, in *VPNShowFirewallRequest, opts ...grpc.CallOption) (*VPNShowFirewallResponse, error) {
+	out := new(VPNShowFirewallResponse)
+	err := c.cc.Invoke(ctx, "/pb.VPNService/ShowFirewall", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // VPNServiceServer is the server API for VPNService service.
 // All implementations must embed UnimplementedVPNServiceServer
 // for forward compatibility
@@ -80,6 +260,24 @@ type VPNServiceServer interface {
 	Init(context.Context, *VPNInitRequest) (*VPNInitResponse, error)
 	Update(context.Context, *VPNUpdateRequest) (*VPNUpdateResponse, error)
 	Restart(context.Context, *VPNRestartRequest) (*VPNRestartResponse, error)
+	List(context.Context, *VPNListRequest) (*VPNListResponse, error)
+	Expiring(context.Context, *VPNExpiringRequest) (*VPNExpiringResponse, error)
+	RotateTLSKey(context.Context, *VPNRotateTLSKeyRequest) (*VPNRotateTLSKeyResponse, error)
+	StartCARotation(context.Context, *VPNCARotationRequest) (*VPNCARotationStatusResponse, error)
+	FinishCARotation(context.Context, *VPNFinishCARotationRequest) (*VPNCARotationStatusResponse, error)
+	CARotationStatus(context.Context, *VPNCARotationRequest) (*VPNCARotationStatusResponse, error)
+	Backup(context.Context, *VPNBackupRequest) (*VPNBackupResponse, error)
+	Restore(context.Context, *VPNRestoreRequest) (*VPNRestoreResponse, error)
+	GetDirectives(context.Context, *VPNDirectivesRequest) (*VPNDirectivesResponse, error)
+	SetDirectives(context.Context, *VPNSetDirectivesRequest) (*VPNDirectivesResponse, error)
+	RepackLeases(context.Context, *VPNRepackLeasesRequest) (*VPNRepackLeasesResponse, error)
+	CreatePool(context.Context, *VPNCreatePoolRequest) (*VPNPool, error)
+	ListPools(context.Context, *VPNListPoolsRequest) (*VPNListPoolsResponse, error)
+	DeletePool(context.Context, *VPNDeletePoolRequest) (*VPNDeletePoolResponse, error)
+	AddACLRule(context.Context, *VPNAddACLRuleRequest) (*VPNACLRule, error)
+	ListACLRules(context.Context, *VPNListACLRulesRequest) (*VPNListACLRulesResponse, error)
+	DeleteACLRule(context.Context, *VPNDeleteACLRuleRequest) (*VPNDeleteACLRuleResponse, error)
+	ShowFirewall(context.Context, *VPNShowFirewallRequest) (*VPNShowFirewallResponse, error)
 	mustEmbedUnimplementedVPNServiceServer()
 }
 
@@ -99,6 +297,60 @@ func (UnimplementedVPNServiceServer) Update(context.Context, *VPNUpdateRequest)
 func (UnimplementedVPNServiceServer) Restart(context.Context, *VPNRestartRequest) (*VPNRestartResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Restart not implemented")
 }
+func (UnimplementedVPNServiceServer) List(context.Context, *VPNListRequest) (*VPNListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedVPNServiceServer) Expiring(context.Context, *VPNExpiringRequest) (*VPNExpiringResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Expiring not implemented")
+}
+func (UnimplementedVPNServiceServer) RotateTLSKey(context.Context, *VPNRotateTLSKeyRequest) (*VPNRotateTLSKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateTLSKey not implemented")
+}
+func (UnimplementedVPNServiceServer) StartCARotation(context.Context, *VPNCARotationRequest) (*VPNCARotationStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartCARotation not implemented")
+}
+func (UnimplementedVPNServiceServer) FinishCARotation(context.Context, *VPNFinishCARotationRequest) (*VPNCARotationStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FinishCARotation not implemented")
+}
+func (UnimplementedVPNServiceServer) CARotationStatus(context.Context, *VPNCARotationRequest) (*VPNCARotationStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CARotationStatus not implemented")
+}
+func (UnimplementedVPNServiceServer) Backup(context.Context, *VPNBackupRequest) (*VPNBackupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Backup not implemented")
+}
+func (UnimplementedVPNServiceServer) Restore(context.Context, *VPNRestoreRequest) (*VPNRestoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Restore not implemented")
+}
+func (UnimplementedVPNServiceServer) GetDirectives(context.Context, *VPNDirectivesRequest) (*VPNDirectivesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDirectives not implemented")
+}
+func (UnimplementedVPNServiceServer) SetDirectives(context.Context, *VPNSetDirectivesRequest) (*VPNDirectivesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetDirectives not implemented")
+}
+func (UnimplementedVPNServiceServer) RepackLeases(context.Context, *VPNRepackLeasesRequest) (*VPNRepackLeasesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RepackLeases not implemented")
+}
+func (UnimplementedVPNServiceServer) CreatePool(context.Context, *VPNCreatePoolRequest) (*VPNPool, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePool not implemented")
+}
+func (UnimplementedVPNServiceServer) ListPools(context.Context, *VPNListPoolsRequest) (*VPNListPoolsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPools not implemented")
+}
+func (UnimplementedVPNServiceServer) DeletePool(context.Context, *VPNDeletePoolRequest) (*VPNDeletePoolResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePool not implemented")
+}
+func (UnimplementedVPNServiceServer) AddACLRule(context.Context, *VPNAddACLRuleRequest) (*VPNACLRule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddACLRule not implemented")
+}
+func (UnimplementedVPNServiceServer) ListACLRules(context.Context, *VPNListACLRulesRequest) (*VPNListACLRulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListACLRules not implemented")
+}
+func (UnimplementedVPNServiceServer) DeleteACLRule(context.Context, *VPNDeleteACLRuleRequest) (*VPNDeleteACLRuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteACLRule not implemented")
+}
+func (UnimplementedVPNServiceServer) ShowFirewall(context.Context, *VPNShowFirewallRequest) (*VPNShowFirewallResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShowFirewall not implemented")
+}
 func (UnimplementedVPNServiceServer) mustEmbedUnimplementedVPNServiceServer() {}
 
 // UnsafeVPNServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -184,6 +436,330 @@ func _VPNService_Restart_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _VPNService_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VPNListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VPNServiceServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.VPNService/List",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VPNServiceServer).List(ctx, req.(*VPNListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VPNService_Expiring_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VPNExpiringRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VPNServiceServer).Expiring(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.VPNService/Expiring",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VPNServiceServer).Expiring(ctx, req.(*VPNExpiringRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VPNService_RotateTLSKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VPNRotateTLSKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VPNServiceServer).RotateTLSKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.VPNService/RotateTLSKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VPNServiceServer).RotateTLSKey(ctx, req.(*VPNRotateTLSKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VPNService_StartCARotation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VPNCARotationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VPNServiceServer).StartCARotation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.VPNService/StartCARotation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VPNServiceServer).StartCARotation(ctx, req.(*VPNCARotationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VPNService_FinishCARotation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VPNFinishCARotationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VPNServiceServer).FinishCARotation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.VPNService/FinishCARotation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VPNServiceServer).FinishCARotation(ctx, req.(*VPNFinishCARotationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VPNService_CARotationStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VPNCARotationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VPNServiceServer).CARotationStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.VPNService/CARotationStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VPNServiceServer).CARotationStatus(ctx, req.(*VPNCARotationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VPNService_Backup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VPNBackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VPNServiceServer).Backup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.VPNService/Backup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VPNServiceServer).Backup(ctx, req.(*VPNBackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VPNService_Restore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VPNRestoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VPNServiceServer).Restore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.VPNService/Restore",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VPNServiceServer).Restore(ctx, req.(*VPNRestoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VPNService_GetDirectives_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VPNDirectivesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VPNServiceServer).GetDirectives(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.VPNService/GetDirectives",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VPNServiceServer).GetDirectives(ctx, req.(*VPNDirectivesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VPNService_SetDirectives_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VPNSetDirectivesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VPNServiceServer).SetDirectives(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.VPNService/SetDirectives",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VPNServiceServer).SetDirectives(ctx, req.(*VPNSetDirectivesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VPNService_RepackLeases_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VPNRepackLeasesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VPNServiceServer).RepackLeases(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.VPNService/RepackLeases",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VPNServiceServer).RepackLeases(ctx, req.(*VPNRepackLeasesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VPNService_CreatePool_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VPNCreatePoolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VPNServiceServer).CreatePool(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.VPNService/CreatePool",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VPNServiceServer).CreatePool(ctx, req.(*VPNCreatePoolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VPNService_ListPools_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VPNListPoolsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VPNServiceServer).ListPools(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.VPNService/ListPools",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VPNServiceServer).ListPools(ctx, req.(*VPNListPoolsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VPNService_DeletePool_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VPNDeletePoolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VPNServiceServer).DeletePool(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.VPNService/DeletePool",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VPNServiceServer).DeletePool(ctx, req.(*VPNDeletePoolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VPNService_AddACLRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VPNAddACLRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VPNServiceServer).AddACLRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.VPNService/AddACLRule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VPNServiceServer).AddACLRule(ctx, req.(*VPNAddACLRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VPNService_ListACLRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VPNListACLRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VPNServiceServer).ListACLRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.VPNService/ListACLRules",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VPNServiceServer).ListACLRules(ctx, req.(*VPNListACLRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VPNService_DeleteACLRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VPNDeleteACLRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VPNServiceServer).DeleteACLRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.VPNService/DeleteACLRule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VPNServiceServer).DeleteACLRule(ctx, req.(*VPNDeleteACLRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VPNService_ShowFirewall_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VPNShowFirewallRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VPNServiceServer).ShowFirewall(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.VPNService/ShowFirewall",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VPNServiceServer).ShowFirewall(ctx, req.(*VPNShowFirewallRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // VPNService_ServiceDesc is the grpc.ServiceDesc for VPNService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -207,6 +783,78 @@ var VPNService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Restart",
 			Handler:    _VPNService_Restart_Handler,
 		},
+		{
+			MethodName: "List",
+			Handler:    _VPNService_List_Handler,
+		},
+		{
+			MethodName: "Expiring",
+			Handler:    _VPNService_Expiring_Handler,
+		},
+		{
+			MethodName: "RotateTLSKey",
+			Handler:    _VPNService_RotateTLSKey_Handler,
+		},
+		{
+			MethodName: "StartCARotation",
+			Handler:    _VPNService_StartCARotation_Handler,
+		},
+		{
+			MethodName: "FinishCARotation",
+			Handler:    _VPNService_FinishCARotation_Handler,
+		},
+		{
+			MethodName: "CARotationStatus",
+			Handler:    _VPNService_CARotationStatus_Handler,
+		},
+		{
+			MethodName: "Backup",
+			Handler:    _VPNService_Backup_Handler,
+		},
+		{
+			MethodName: "Restore",
+			Handler:    _VPNService_Restore_Handler,
+		},
+		{
+			MethodName: "GetDirectives",
+			Handler:    _VPNService_GetDirectives_Handler,
+		},
+		{
+			MethodName: "SetDirectives",
+			Handler:    _VPNService_SetDirectives_Handler,
+		},
+		{
+			MethodName: "RepackLeases",
+			Handler:    _VPNService_RepackLeases_Handler,
+		},
+		{
+			MethodName: "CreatePool",
+			Handler:    _VPNService_CreatePool_Handler,
+		},
+		{
+			MethodName: "ListPools",
+			Handler:    _VPNService_ListPools_Handler,
+		},
+		{
+			MethodName: "DeletePool",
+			Handler:    _VPNService_DeletePool_Handler,
+		},
+		{
+			MethodName: "AddACLRule",
+			Handler:    _VPNService_AddACLRule_Handler,
+		},
+		{
+			MethodName: "ListACLRules",
+			Handler:    _VPNService_ListACLRules_Handler,
+		},
+		{
+			MethodName: "DeleteACLRule",
+			Handler:    _VPNService_DeleteACLRule_Handler,
+		},
+		{
+			MethodName: "ShowFirewall",
+			Handler:    _VPNService_ShowFirewall_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "vpn.proto",
@@ -7,6 +7,7 @@ import (
 
 	"google.golang.org/protobuf/encoding/protojson"
 
+	"github.com/GoldenRUS/ovpm"
 	"github.com/GoldenRUS/ovpm/api/pb"
 	"github.com/GoldenRUS/ovpm/bundle"
 	"github.com/asaskevich/govalidator"
@@ -37,7 +38,7 @@ func NewRESTServer(grpcPort string) (http.Handler, context.CancelFunc, error) {
 			DiscardUnknown: true,
 		},
 	}))
-	opts := []grpc.DialOption{grpc.WithInsecure()}
+	opts := []grpc.DialOption{grpc.WithInsecure(), grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(ovpm.MaxAPIMessageSize))}
 	err := pb.RegisterVPNServiceHandlerFromEndpoint(ctx, gmux, endPoint, opts)
 	if err != nil {
 		return nil, cancel, err
@@ -1,7 +1,9 @@
 package api
 
 import (
+	"io"
 	"os"
+	"strings"
 	"time"
 
 	"go.uber.org/thriftrw/ptr"
@@ -95,11 +97,16 @@ func (s *UserService) List(ctx context.Context, req *pb.UserListRequest) (*pb.Us
 	}
 	for _, user := range users {
 		isConnected, connectedSince, bytesSent, bytesReceived, tx, rx := user.ConnectionStatus()
+		dns := user.GetDNSOverride()
 		ut = append(ut, &pb.UserResponse_User{
 			ServerSerialNumber: user.GetServerSerialNumber(),
 			Username:           user.GetUsername(),
 			CreatedAt:          user.GetCreatedAt(),
 			IpNet:              user.GetIPNet(),
+			Ipv6Net:            user.GetIPv6Net(),
+			Dns:                strings.Join(dns.Servers, ","),
+			DnsDomain:          dns.Domain,
+			DnsSearch:          strings.Join(dns.Search, ","),
 			NoGw:               user.IsNoGW(),
 			HostId:             user.GetHostID(),
 			IsAdmin:            user.IsAdmin(),
@@ -111,6 +118,8 @@ func (s *UserService) List(ctx context.Context, req *pb.UserListRequest) (*pb.Us
 			Description:        user.GetDescription(),
 			Tx:                 tx,
 			Rx:                 rx,
+			Server:             user.GetServerName(),
+			Pool:               user.GetPoolName(),
 		})
 	}
 
@@ -130,7 +139,15 @@ func (s *UserService) Create(ctx context.Context, req *pb.UserCreateRequest) (*p
 	}
 
 	var ut []*pb.UserResponse_User
-	user, err := ovpm.CreateNewUser(req.Username, req.Password, req.NoGw, req.HostId, req.IsAdmin, req.Description)
+	var opts []ovpm.UserOption
+	if req.Pool != "" {
+		opts = append(opts, ovpm.WithPool(req.Pool))
+	}
+	server, err := lookupServer(req.Server)
+	if err != nil {
+		return nil, err
+	}
+	user, err := server.CreateNewUser(req.Username, req.Password, req.NoGw, req.HostId, req.IsAdmin, req.Description, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -142,6 +159,8 @@ func (s *UserService) Create(ctx context.Context, req *pb.UserCreateRequest) (*p
 		HostId:             user.GetHostID(),
 		IsAdmin:            user.IsAdmin(),
 		Description:        user.GetDescription(),
+		Server:             user.GetServerName(),
+		Pool:               user.GetPoolName(),
 	}
 	ut = append(ut, &pbUser)
 
@@ -191,10 +210,30 @@ func (s *UserService) Update(ctx context.Context, req *pb.UserUpdateRequest) (*p
 
 	// User has admin perms?
 	if perms.Contains(ovpm.UpdateAnyUserPerm) {
+		// Move the user first, so that the static ip is set on the new server.
+		// It's validated before the move, which can't be undone.
+		if req.Server != "" {
+			if err := user.CheckMove(req.Server, req.HostId); err != nil {
+				return nil, grpc.Errorf(codes.InvalidArgument, "%v", err)
+			}
+			if err := user.SetServer(req.Server); err != nil {
+				return nil, err
+			}
+		}
 		err = user.Update(req.Password, noGW, req.HostId, admin, req.Description)
 		if err != nil {
 			return nil, err
 		}
+		if req.Dns != "" || req.DnsDomain != "" || req.DnsSearch != "" {
+			if err := user.SetDNSOverride(req.Dns, req.DnsDomain, req.DnsSearch); err != nil {
+				return nil, grpc.Errorf(codes.InvalidArgument, "%v", err)
+			}
+		}
+		if req.Pool != "" {
+			if err := user.SetPool(req.Pool); err != nil {
+				return nil, grpc.Errorf(codes.InvalidArgument, "%v", err)
+			}
+		}
 		ut = append(ut, &pb.UserResponse_User{
 			Username:           user.GetUsername(),
 			ServerSerialNumber: user.GetServerSerialNumber(),
@@ -202,6 +241,8 @@ func (s *UserService) Update(ctx context.Context, req *pb.UserUpdateRequest) (*p
 			HostId:             user.GetHostID(),
 			IsAdmin:            user.IsAdmin(),
 			Description:        user.GetDescription(),
+			Server:             user.GetServerName(),
+			Pool:               user.GetPoolName(),
 		})
 		return &pb.UserResponse{Users: ut}, nil
 	}
@@ -211,6 +252,15 @@ func (s *UserService) Update(ctx context.Context, req *pb.UserUpdateRequest) (*p
 		if user.GetUsername() != username {
 			return nil, grpc.Errorf(codes.PermissionDenied, "Caller can only update their user with ovpm.UpdateSelfPerm")
 		}
+		if req.Server != "" && req.Server != user.GetServerName() {
+			return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.UpdateAnyUserPerm is required to move a user to another server.")
+		}
+		if req.Dns != "" || req.DnsDomain != "" || req.DnsSearch != "" {
+			return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.UpdateAnyUserPerm is required to change the dns settings of a user.")
+		}
+		if req.Pool != "" && req.Pool != user.GetPoolName() {
+			return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.UpdateAnyUserPerm is required to move a user to another pool.")
+		}
 
 		err = user.Update(req.Password, noGW, req.HostId, admin, req.Description)
 		if err != nil {
@@ -223,6 +273,7 @@ func (s *UserService) Update(ctx context.Context, req *pb.UserUpdateRequest) (*p
 			HostId:             user.GetHostID(),
 			IsAdmin:            user.IsAdmin(),
 			Description:        user.GetDescription(),
+			Server:             user.GetServerName(),
 		})
 		return &pb.UserResponse{Users: ut}, nil
 	}
@@ -313,7 +364,7 @@ func (s *UserService) GenConfig(ctx context.Context, req *pb.UserGenConfigReques
 	}
 
 	if perms.Contains(ovpm.GenConfigAnyUserPerm) {
-		configBlob, err := ovpm.TheServer().DumpsClientConfig(user.GetUsername())
+		configBlob, err := user.GetServer().DumpsClientConfig(user.GetUsername())
 		if err != nil {
 			return nil, err
 		}
@@ -324,7 +375,7 @@ func (s *UserService) GenConfig(ctx context.Context, req *pb.UserGenConfigReques
 		if user.GetUsername() != username {
 			return nil, grpc.Errorf(codes.PermissionDenied, "Caller can only genconfig for their user.")
 		}
-		configBlob, err := ovpm.TheServer().DumpsClientConfig(user.GetUsername())
+		configBlob, err := user.GetServer().DumpsClientConfig(user.GetUsername())
 		if err != nil {
 			return nil, err
 		}
@@ -334,14 +385,147 @@ func (s *UserService) GenConfig(ctx context.Context, req *pb.UserGenConfigReques
 	return nil, grpc.Errorf(codes.PermissionDenied, "Permissions are required for this operation.")
 }
 
+func (s *UserService) Disconnect(ctx context.Context, req *pb.UserDisconnectRequest) (*pb.UserResponse, error) {
+	logrus.Debugf("rpc call: user disconnect: %s", req.Username)
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "Can't get permset from context")
+	}
+
+	if !perms.Contains(ovpm.DisconnectAnyUserPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.DisconnectAnyUserPerm is required for this operation.")
+	}
+
+	user, err := ovpm.GetUser(req.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := user.Disconnect(); err != nil {
+		return nil, err
+	}
+
+	pbUser := pb.UserResponse_User{
+		Username:           user.GetUsername(),
+		ServerSerialNumber: user.GetServerSerialNumber(),
+		HostId:             user.GetHostID(),
+		IsAdmin:            user.IsAdmin(),
+	}
+	return &pb.UserResponse{Users: []*pb.UserResponse_User{&pbUser}}, nil
+}
+
+func (s *UserService) GetDirectives(ctx context.Context, req *pb.UserDirectivesRequest) (*pb.UserDirectivesResponse, error) {
+	logrus.Debugf("rpc call: user get directives: %s", req.Username)
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "Can't get permset from context")
+	}
+
+	if !perms.Contains(ovpm.GetAnyUserPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.GetAnyUserPerm is required for this operation.")
+	}
+
+	user, err := ovpm.GetUser(req.Username)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.UserDirectivesResponse{Username: user.GetUsername(), Directives: user.GetExtraDirectives()}, nil
+}
+
+func (s *UserService) SetDirectives(ctx context.Context, req *pb.UserSetDirectivesRequest) (*pb.UserDirectivesResponse, error) {
+	logrus.Debugf("rpc call: user set directives: %s", req.Username)
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "Can't get permset from context")
+	}
+
+	if !perms.Contains(ovpm.UpdateAnyUserPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.UpdateAnyUserPerm is required for this operation.")
+	}
+
+	user, err := ovpm.GetUser(req.Username)
+	if err != nil {
+		return nil, err
+	}
+	if err := user.SetExtraDirectives(req.Directives); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	return &pb.UserDirectivesResponse{Username: user.GetUsername(), Directives: user.GetExtraDirectives()}, nil
+}
+
+func (s *UserService) Import(stream pb.UserService_ImportServer) error {
+	logrus.Debug("rpc call: user import")
+	perms, err := permset.FromContext(stream.Context())
+	if err != nil {
+		return grpc.Errorf(codes.Unauthenticated, "Can't get permset from context")
+	}
+
+	if !perms.Contains(ovpm.CreateUserPerm) {
+		return grpc.Errorf(codes.PermissionDenied, "ovpm.CreateUserPerm is required for this operation.")
+	}
+
+	var records []ovpm.UserRecord
+	var dryRun, atomic bool
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if len(records) == 0 {
+			dryRun, atomic = req.DryRun, req.Atomic
+		}
+		rec := req.GetUser()
+		records = append(records, ovpm.UserRecord{
+			Username:    rec.GetUsername(),
+			Password:    rec.GetPassword(),
+			Description: rec.GetDescription(),
+			NoGW:        rec.GetNoGw(),
+			StaticIP:    rec.GetStaticIp(),
+			Admin:       rec.GetIsAdmin(),
+			Networks:    rec.GetNetworks(),
+			Server:      rec.GetServer(),
+			Pool:        rec.GetPool(),
+		})
+	}
+
+	report, err := ovpm.ImportUsers(records, dryRun, atomic)
+	if err != nil {
+		return grpc.Errorf(codes.FailedPrecondition, "%v", err)
+	}
+	resp := &pb.UserImportResponse{Created: report.Created, DryRun: dryRun}
+	for _, e := range report.Errors {
+		resp.Errors = append(resp.Errors, &pb.UserImportResponse_RowError{Row: int32(e.Row), Username: e.Username, Error: e.Err.Error()})
+	}
+	return stream.SendAndClose(resp)
+}
+
 type VPNService struct {
 	pb.UnimplementedVPNServiceServer
 }
 
 func (s *VPNService) Status(ctx context.Context, req *pb.VPNStatusRequest) (*pb.VPNStatusResponse, error) {
-	logrus.Debugf("rpc call: vpn status")
-	server := ovpm.TheServer()
+	logrus.Debugf("rpc call: vpn status: %s", req.Server)
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "Can't get permset from context")
+	}
 
+	if !perms.Contains(ovpm.GetVPNStatusPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.GetVPNStatusPerm is required for this operation.")
+	}
+
+	server, err := lookupServer(req.Server)
+	if err != nil {
+		return nil, err
+	}
+	return vpnStatusResponse(server), nil
+}
+
+func (s *VPNService) List(ctx context.Context, req *pb.VPNListRequest) (*pb.VPNListResponse, error) {
+	logrus.Debugf("rpc call: vpn list")
 	perms, err := permset.FromContext(ctx)
 	if err != nil {
 		return nil, grpc.Errorf(codes.Unauthenticated, "Can't get permset from context")
@@ -351,7 +535,24 @@ func (s *VPNService) Status(ctx context.Context, req *pb.VPNStatusRequest) (*pb.
 		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.GetVPNStatusPerm is required for this operation.")
 	}
 
-	response := pb.VPNStatusResponse{
+	var servers []*pb.VPNStatusResponse
+	for _, server := range ovpm.GetAllServers() {
+		servers = append(servers, vpnStatusResponse(server))
+	}
+	return &pb.VPNListResponse{Servers: servers}, nil
+}
+
+// lookupServer returns the server with the name that's given in a request.
+func lookupServer(name string) (*ovpm.Server, error) {
+	server, err := ovpm.LookupServer(name)
+	if err != nil {
+		return nil, grpc.Errorf(codes.NotFound, "%v", err)
+	}
+	return server, nil
+}
+
+func vpnStatusResponse(server *ovpm.Server) *pb.VPNStatusResponse {
+	res := &pb.VPNStatusResponse{
 		Name:         server.GetServerName(),
 		SerialNumber: server.GetSerialNumber(),
 		Hostname:     server.GetHostname(),
@@ -366,12 +567,31 @@ func (s *VPNService) Status(ctx context.Context, req *pb.VPNStatusRequest) (*pb.
 		ExpiresAt:    server.ExpiresAt().UTC().Format(time.RFC3339),
 		CaExpiresAt:  server.CAExpiresAt().UTC().Format(time.RFC3339),
 		UseLzo:       server.IsUseLZO(),
+
+		KeyAlgorithm:     server.GetKeyAlgorithm(),
+		CertValidityDays: int32(server.GetCertValidityDays()),
 	}
-	return &response, nil
+
+	res.TlsKeyMode = server.GetTLSKeyMode()
+	res.DataCiphers = server.GetDataCiphers()
+	res.AuthDigest = server.GetAuthDigest()
+	res.TlsVersionMin = server.GetTLSVersionMin()
+	res.Ipv6Net = server.GetIPv6Net()
+	res.DnsDomain = server.GetDNSDomain()
+	res.DnsSearch = server.GetDNSSearch()
+	res.Resolver = server.IsResolverEnabled()
+
+	dh := server.DHParamsStatus()
+	res.DhParams = dh.State
+	res.DhParamsError = dh.Error
+	if !dh.StartedAt.IsZero() {
+		res.DhParamsStartedAt = dh.StartedAt.UTC().Format(time.RFC3339)
+	}
+	return res
 }
 
 func (s *VPNService) Init(ctx context.Context, req *pb.VPNInitRequest) (*pb.VPNInitResponse, error) {
-	logrus.Debugf("rpc call: vpn init")
+	logrus.Debugf("rpc call: vpn init: %s", req.Server)
 	var proto string
 	switch req.ProtoPref {
 	case pb.VPNProto_TCP:
@@ -391,14 +611,44 @@ func (s *VPNService) Init(ctx context.Context, req *pb.VPNInitRequest) (*pb.VPNI
 		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.InitVPNPerm is required for this operation.")
 	}
 
-	if err := ovpm.TheServer().Init(req.Hostname, req.Port, proto, req.IpBlock, req.Dns, req.KeepalivePeriod, req.KeepaliveTimeout, req.UseLzo); err != nil {
+	var opts []ovpm.ServerOption
+	if req.CaFrom != "" {
+		opts = append(opts, ovpm.WithSharedCA(req.CaFrom))
+	}
+	opts = append(opts, ovpm.WithKeyAlgorithm(req.KeyAlgorithm, int(req.RsaKeySize)), ovpm.WithCertValidity(int(req.CertValidityDays)))
+	if req.DhPref == pb.VPNDHPref_DH_NONE {
+		opts = append(opts, ovpm.WithECDHOnly(true))
+	}
+	if req.TlsKeyMode != "" {
+		opts = append(opts, ovpm.WithTLSKeyMode(req.TlsKeyMode))
+	}
+	if req.DataCiphers != "" {
+		opts = append(opts, ovpm.WithDataCiphers(req.DataCiphers))
+	}
+	if req.AuthDigest != "" {
+		opts = append(opts, ovpm.WithAuthDigest(req.AuthDigest))
+	}
+	if req.TlsVersionMin != "" {
+		opts = append(opts, ovpm.WithTLSVersionMin(req.TlsVersionMin))
+	}
+	if req.Ipv6Block != "" {
+		opts = append(opts, ovpm.WithIPv6Network(req.Ipv6Block))
+	}
+	if req.DnsDomain != "" || req.DnsSearch != "" {
+		opts = append(opts, ovpm.WithDNSOptions("", req.DnsDomain, req.DnsSearch))
+	}
+	if req.Resolver {
+		opts = append(opts, ovpm.WithResolver(true))
+	}
+	if err := ovpm.GetServer(req.Server).Init(req.Hostname, req.Port, proto, req.IpBlock, req.Dns, req.KeepalivePeriod, req.KeepaliveTimeout, req.UseLzo, opts...); err != nil {
 		logrus.Errorf("server can not be created: %v", err)
+		return nil, grpc.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	return &pb.VPNInitResponse{}, nil
 }
 
 func (s *VPNService) Update(ctx context.Context, req *pb.VPNUpdateRequest) (*pb.VPNUpdateResponse, error) {
-	logrus.Debugf("rpc call: vpn update")
+	logrus.Debugf("rpc call: vpn update: %s", req.Server)
 	perms, err := permset.FromContext(ctx)
 	if err != nil {
 		return nil, grpc.Errorf(codes.Unauthenticated, "Can't get permset from context")
@@ -415,14 +665,50 @@ func (s *VPNService) Update(ctx context.Context, req *pb.VPNUpdateRequest) (*pb.
 	case pb.VPNLZOPref_USE_LZO_DISABLE:
 		useLzo = ptr.Bool(false)
 	}
-	if err := ovpm.TheServer().Update(req.IpBlock, req.Dns, useLzo); err != nil {
+	var opts []ovpm.ServerOption
+	switch req.DhPref {
+	case pb.VPNDHPref_DH_GENERATE:
+		opts = append(opts, ovpm.WithECDHOnly(false))
+	case pb.VPNDHPref_DH_NONE:
+		opts = append(opts, ovpm.WithECDHOnly(true))
+	}
+	if req.TlsKeyMode != "" {
+		opts = append(opts, ovpm.WithTLSKeyMode(req.TlsKeyMode))
+	}
+	if req.DataCiphers != "" {
+		opts = append(opts, ovpm.WithDataCiphers(req.DataCiphers))
+	}
+	if req.AuthDigest != "" {
+		opts = append(opts, ovpm.WithAuthDigest(req.AuthDigest))
+	}
+	if req.TlsVersionMin != "" {
+		opts = append(opts, ovpm.WithTLSVersionMin(req.TlsVersionMin))
+	}
+	if req.Ipv6Block != "" {
+		opts = append(opts, ovpm.WithIPv6Network(req.Ipv6Block))
+	}
+	if req.DnsDomain != "" || req.DnsSearch != "" {
+		opts = append(opts, ovpm.WithDNSOptions("", req.DnsDomain, req.DnsSearch))
+	}
+	switch req.ResolverPref {
+	case pb.VPNResolverPref_RESOLVER_ENABLE:
+		opts = append(opts, ovpm.WithResolver(true))
+	case pb.VPNResolverPref_RESOLVER_DISABLE:
+		opts = append(opts, ovpm.WithResolver(false))
+	}
+	server, err := lookupServer(req.Server)
+	if err != nil {
+		return nil, err
+	}
+	if err := server.Update(req.IpBlock, req.Dns, useLzo, opts...); err != nil {
 		logrus.Errorf("server can not be updated: %v", err)
+		return nil, grpc.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	return &pb.VPNUpdateResponse{}, nil
 }
 
 func (s *VPNService) Restart(ctx context.Context, req *pb.VPNRestartRequest) (*pb.VPNRestartResponse, error) {
-	logrus.Debugf("rpc call: vpn restart")
+	logrus.Debugf("rpc call: vpn restart: %s", req.Server)
 	perms, err := permset.FromContext(ctx)
 	if err != nil {
 		return nil, grpc.Errorf(codes.Unauthenticated, "Can't get permset from context")
@@ -432,10 +718,440 @@ func (s *VPNService) Restart(ctx context.Context, req *pb.VPNRestartRequest) (*p
 		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.UpdateVPNPerm is required for this operation.")
 	}
 
-	ovpm.TheServer().RestartVPNProc()
+	server, err := lookupServer(req.Server)
+	if err != nil {
+		return nil, err
+	}
+	server.RestartVPNProc()
 	return &pb.VPNRestartResponse{}, nil
 }
 
+func (s *VPNService) Expiring(ctx context.Context, req *pb.VPNExpiringRequest) (*pb.VPNExpiringResponse, error) {
+	logrus.Debugf("rpc call: vpn expiring: %d days", req.Days)
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "Can't get permset from context")
+	}
+
+	if !perms.Contains(ovpm.ListExpiringCertsPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.ListExpiringCertsPerm is required for this operation.")
+	}
+
+	if req.Days < 0 {
+		return nil, grpc.Errorf(codes.InvalidArgument, "days can not be negative")
+	}
+	within := ovpm.GetConfig().CertRenewThreshold
+	if req.Days > 0 {
+		within = time.Duration(req.Days) * 24 * time.Hour
+	}
+	certs, err := ovpm.GetExpiringCerts(within)
+	if err != nil {
+		return nil, err
+	}
+
+	var res pb.VPNExpiringResponse
+	for _, c := range certs {
+		res.Certs = append(res.Certs, &pb.VPNExpiringResponse_Cert{
+			Server:    c.Server,
+			Kind:      c.Kind,
+			Username:  c.Username,
+			ExpiresAt: c.ExpiresAt.UTC().Format(time.RFC3339),
+		})
+	}
+	return &res, nil
+}
+
+func (s *VPNService) RotateTLSKey(ctx context.Context, req *pb.VPNRotateTLSKeyRequest) (*pb.VPNRotateTLSKeyResponse, error) {
+	logrus.Debugf("rpc call: vpn rotate tls key: %s", req.Server)
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "Can't get permset from context")
+	}
+
+	if !perms.Contains(ovpm.RotateTLSKeyPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.RotateTLSKeyPerm is required for this operation.")
+	}
+
+	server, err := lookupServer(req.Server)
+	if err != nil {
+		return nil, err
+	}
+	if err := server.RotateTLSKey(); err != nil {
+		return nil, err
+	}
+	return &pb.VPNRotateTLSKeyResponse{}, nil
+}
+
+func (s *VPNService) StartCARotation(ctx context.Context, req *pb.VPNCARotationRequest) (*pb.VPNCARotationStatusResponse, error) {
+	logrus.Debugf("rpc call: vpn start ca rotation: %s", req.Server)
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "Can't get permset from context")
+	}
+
+	if !perms.Contains(ovpm.RotateCAPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.RotateCAPerm is required for this operation.")
+	}
+
+	server, err := lookupServer(req.Server)
+	if err != nil {
+		return nil, err
+	}
+	if err := server.StartCARotation(); err != nil {
+		return nil, err
+	}
+	return caRotationStatusResponse(server)
+}
+
+func (s *VPNService) FinishCARotation(ctx context.Context, req *pb.VPNFinishCARotationRequest) (*pb.VPNCARotationStatusResponse, error) {
+	logrus.Debugf("rpc call: vpn finish ca rotation: %s", req.Server)
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "Can't get permset from context")
+	}
+
+	if !perms.Contains(ovpm.RotateCAPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.RotateCAPerm is required for this operation.")
+	}
+
+	server, err := lookupServer(req.Server)
+	if err != nil {
+		return nil, err
+	}
+	if err := server.FinishCARotation(req.Force); err != nil {
+		return nil, err
+	}
+	return caRotationStatusResponse(server)
+}
+
+func (s *VPNService) CARotationStatus(ctx context.Context, req *pb.VPNCARotationRequest) (*pb.VPNCARotationStatusResponse, error) {
+	logrus.Debugf("rpc call: vpn ca rotation status: %s", req.Server)
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "Can't get permset from context")
+	}
+
+	if !perms.Contains(ovpm.GetVPNStatusPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.GetVPNStatusPerm is required for this operation.")
+	}
+
+	server, err := lookupServer(req.Server)
+	if err != nil {
+		return nil, err
+	}
+	if !server.IsInitialized() {
+		return nil, grpc.Errorf(codes.NotFound, "server not found: %s", req.Server)
+	}
+	return caRotationStatusResponse(server)
+}
+
+func caRotationStatusResponse(server *ovpm.Server) (*pb.VPNCARotationStatusResponse, error) {
+	status, err := server.CARotationStatus()
+	if err != nil {
+		return nil, err
+	}
+	res := &pb.VPNCARotationStatusResponse{
+		Rotating: status.Rotating,
+		Users:    int32(status.Users),
+		Fetched:  int32(status.Fetched),
+		Pending:  status.Pending,
+	}
+	if status.Rotating {
+		res.StartedAt = status.StartedAt.UTC().Format(time.RFC3339)
+		res.NextCaExpiresAt = server.NextCAExpiresAt().UTC().Format(time.RFC3339)
+	}
+	return res, nil
+}
+
+func (s *VPNService) Backup(ctx context.Context, req *pb.VPNBackupRequest) (*pb.VPNBackupResponse, error) {
+	logrus.Debug("rpc call: vpn backup")
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "Can't get permset from context")
+	}
+
+	if !perms.Contains(ovpm.BackupVPNPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.BackupVPNPerm is required for this operation.")
+	}
+
+	archive, err := ovpm.DumpBackup(req.Passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.VPNBackupResponse{Archive: archive}, nil
+}
+
+func (s *VPNService) Restore(ctx context.Context, req *pb.VPNRestoreRequest) (*pb.VPNRestoreResponse, error) {
+	logrus.Debugf("rpc call: vpn restore: force=%t", req.Force)
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "Can't get permset from context")
+	}
+
+	if !perms.Contains(ovpm.RestoreVPNPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.RestoreVPNPerm is required for this operation.")
+	}
+
+	if err := ovpm.RestoreBackup(req.Archive, req.Passphrase, req.Force); err != nil {
+		return nil, err
+	}
+	return &pb.VPNRestoreResponse{}, nil
+}
+
+func (s *VPNService) GetDirectives(ctx context.Context, req *pb.VPNDirectivesRequest) (*pb.VPNDirectivesResponse, error) {
+	logrus.Debugf("rpc call: vpn get directives: %s", req.Server)
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "Can't get permset from context")
+	}
+
+	if !perms.Contains(ovpm.GetVPNStatusPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.GetVPNStatusPerm is required for this operation.")
+	}
+
+	server, err := lookupServer(req.Server)
+	if err != nil {
+		return nil, err
+	}
+	if !server.IsInitialized() {
+		return nil, grpc.Errorf(codes.NotFound, "server not found: %s", req.Server)
+	}
+	return vpnDirectivesResponse(server), nil
+}
+
+func (s *VPNService) SetDirectives(ctx context.Context, req *pb.VPNSetDirectivesRequest) (*pb.VPNDirectivesResponse, error) {
+	logrus.Debugf("rpc call: vpn set directives: %s %s", req.Server, req.Scope)
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "Can't get permset from context")
+	}
+
+	if !perms.Contains(ovpm.UpdateVPNPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.UpdateVPNPerm is required for this operation.")
+	}
+
+	scope := ovpm.ServerConfScope
+	if req.Scope == pb.VPNDirectiveScope_CLIENT_PROFILE {
+		scope = ovpm.ClientProfileScope
+	}
+	server, err := lookupServer(req.Server)
+	if err != nil {
+		return nil, err
+	}
+	if err := server.SetExtraDirectives(scope, req.Directives); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	return vpnDirectivesResponse(server), nil
+}
+
+func vpnDirectivesResponse(server *ovpm.Server) *pb.VPNDirectivesResponse {
+	return &pb.VPNDirectivesResponse{
+		ServerDirectives: server.GetExtraDirectives(ovpm.ServerConfScope),
+		ClientDirectives: server.GetExtraDirectives(ovpm.ClientProfileScope),
+	}
+}
+
+func (s *VPNService) RepackLeases(ctx context.Context, req *pb.VPNRepackLeasesRequest) (*pb.VPNRepackLeasesResponse, error) {
+	logrus.Debugf("rpc call: vpn repack leases: %s", req.Server)
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "Can't get permset from context")
+	}
+
+	if !perms.Contains(ovpm.UpdateVPNPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.UpdateVPNPerm is required for this operation.")
+	}
+
+	server, err := lookupServer(req.Server)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := server.RepackLeases()
+	if err != nil {
+		return nil, err
+	}
+	var res pb.VPNRepackLeasesResponse
+	for _, change := range changes {
+		c := &pb.VPNRepackLeasesResponse_Change{Username: change.Username, NewIp: change.NewIP.String()}
+		if change.OldIP != nil {
+			c.OldIp = change.OldIP.String()
+		}
+		res.Changes = append(res.Changes, c)
+	}
+	return &res, nil
+}
+
+func (s *VPNService) CreatePool(ctx context.Context, req *pb.VPNCreatePoolRequest) (*pb.VPNPool, error) {
+	logrus.Debugf("rpc call: vpn create pool: %s %s", req.Name, req.Cidr)
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "Can't get permset from context")
+	}
+
+	if !perms.Contains(ovpm.UpdateVPNPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.UpdateVPNPerm is required for this operation.")
+	}
+
+	server, err := lookupServer(req.Server)
+	if err != nil {
+		return nil, err
+	}
+	pool, err := server.CreateNewPool(req.Name, req.Cidr)
+	if err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	return vpnPool(pool), nil
+}
+
+func (s *VPNService) ListPools(ctx context.Context, req *pb.VPNListPoolsRequest) (*pb.VPNListPoolsResponse, error) {
+	logrus.Debugf("rpc call: vpn list pools: %s", req.Server)
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "Can't get permset from context")
+	}
+
+	if !perms.Contains(ovpm.GetVPNStatusPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.GetVPNStatusPerm is required for this operation.")
+	}
+
+	server, err := lookupServer(req.Server)
+	if err != nil {
+		return nil, err
+	}
+	pools, err := server.GetPools()
+	if err != nil {
+		return nil, err
+	}
+	var res pb.VPNListPoolsResponse
+	for _, pool := range pools {
+		res.Pools = append(res.Pools, vpnPool(pool))
+	}
+	return &res, nil
+}
+
+func (s *VPNService) DeletePool(ctx context.Context, req *pb.VPNDeletePoolRequest) (*pb.VPNDeletePoolResponse, error) {
+	logrus.Debugf("rpc call: vpn delete pool: %s", req.Name)
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "Can't get permset from context")
+	}
+
+	if !perms.Contains(ovpm.UpdateVPNPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.UpdateVPNPerm is required for this operation.")
+	}
+
+	pool, err := ovpm.GetPool(req.Name)
+	if err != nil {
+		return nil, grpc.Errorf(codes.NotFound, "%v", err)
+	}
+	if err := pool.Delete(); err != nil {
+		return nil, grpc.Errorf(codes.FailedPrecondition, "%v", err)
+	}
+	return &pb.VPNDeletePoolResponse{}, nil
+}
+
+func vpnPool(pool *ovpm.Pool) *pb.VPNPool {
+	return &pb.VPNPool{
+		Name:      pool.GetName(),
+		Cidr:      pool.GetCIDR(),
+		Server:    pool.GetServer().GetServerName(),
+		Usernames: pool.GetUsernames(),
+		CreatedAt: pool.GetCreatedAt(),
+	}
+}
+
+func (s *VPNService) AddACLRule(ctx context.Context, req *pb.VPNAddACLRuleRequest) (*pb.VPNACLRule, error) {
+	logrus.Debugf("rpc call: vpn add acl rule: %s %s%s -> %s", req.Action, req.Username, req.Pool, req.Destination)
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "Can't get permset from context")
+	}
+
+	if !perms.Contains(ovpm.UpdateVPNPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.UpdateVPNPerm is required for this operation.")
+	}
+
+	rule, err := ovpm.CreateNewACLRule(req.Username, req.Pool, req.Action, req.Destination, req.Proto, req.Port)
+	if err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	return vpnACLRule(rule), nil
+}
+
+func (s *VPNService) ListACLRules(ctx context.Context, req *pb.VPNListACLRulesRequest) (*pb.VPNListACLRulesResponse, error) {
+	logrus.Debug("rpc call: vpn list acl rules")
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "Can't get permset from context")
+	}
+
+	if !perms.Contains(ovpm.GetVPNStatusPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.GetVPNStatusPerm is required for this operation.")
+	}
+
+	rules, err := ovpm.GetAllACLRules()
+	if err != nil {
+		return nil, err
+	}
+	var res pb.VPNListACLRulesResponse
+	for _, rule := range rules {
+		res.Rules = append(res.Rules, vpnACLRule(rule))
+	}
+	return &res, nil
+}
+
+func (s *VPNService) DeleteACLRule(ctx context.Context, req *pb.VPNDeleteACLRuleRequest) (*pb.VPNDeleteACLRuleResponse, error) {
+	logrus.Debugf("rpc call: vpn delete acl rule: %d", req.Id)
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "Can't get permset from context")
+	}
+
+	if !perms.Contains(ovpm.UpdateVPNPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.UpdateVPNPerm is required for this operation.")
+	}
+
+	rule, err := ovpm.GetACLRule(uint(req.Id))
+	if err != nil {
+		return nil, grpc.Errorf(codes.NotFound, "%v", err)
+	}
+	if err := rule.Delete(); err != nil {
+		return nil, err
+	}
+	return &pb.VPNDeleteACLRuleResponse{}, nil
+}
+
+func (s *VPNService) ShowFirewall(ctx context.Context, req *pb.VPNShowFirewallRequest) (*pb.VPNShowFirewallResponse, error) {
+	logrus.Debug("rpc call: vpn show firewall")
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "Can't get permset from context")
+	}
+
+	if !perms.Contains(ovpm.GetVPNStatusPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.GetVPNStatusPerm is required for this operation.")
+	}
+
+	backend, ipv4, ipv6, err := ovpm.FirewallRules()
+	if err != nil {
+		return nil, grpc.Errorf(codes.FailedPrecondition, "%v", err)
+	}
+	return &pb.VPNShowFirewallResponse{Backend: backend, Ipv4Rules: ipv4, Ipv6Rules: ipv6}, nil
+}
+
+func vpnACLRule(rule *ovpm.ACLRule) *pb.VPNACLRule {
+	return &pb.VPNACLRule{
+		Id:          uint32(rule.GetID()),
+		Username:    rule.GetUsername(),
+		Pool:        rule.GetPoolName(),
+		Action:      rule.GetAction(),
+		Destination: rule.GetDestination(),
+		Proto:       rule.GetProto(),
+		Port:        rule.GetPort(),
+		CreatedAt:   rule.GetCreatedAt(),
+	}
+}
+
 type NetworkService struct {
 	pb.UnimplementedNetworkServiceServer
 }
@@ -455,6 +1171,7 @@ func (s *NetworkService) List(ctx context.Context, req *pb.NetworkListRequest) (
 
 	networks := ovpm.GetAllNetworks()
 	for _, network := range networks {
+		dns := network.GetDNSOptions()
 		nt = append(nt, &pb.Network{
 			Name:                network.GetName(),
 			Cidr:                network.GetCIDR(),
@@ -462,6 +1179,12 @@ func (s *NetworkService) List(ctx context.Context, req *pb.NetworkListRequest) (
 			CreatedAt:           network.GetCreatedAt(),
 			AssociatedUsernames: network.GetAssociatedUsernames(),
 			Via:                 network.GetVia(),
+			Dns:                 strings.Join(dns.Servers, ","),
+			DnsDomain:           dns.Domain,
+			DnsSearch:           strings.Join(dns.Search, ","),
+			AssociatedPools:     network.GetAssociatedPoolNames(),
+			Router:              network.GetRouter(),
+			PushRoute:           network.IsRoutePushed(),
 		})
 	}
 
@@ -479,10 +1202,26 @@ func (s *NetworkService) Create(ctx context.Context, req *pb.NetworkCreateReques
 		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.CreateNetworkPerm is required for this operation.")
 	}
 
-	network, err := ovpm.CreateNewNetwork(req.Name, req.Cidr, ovpm.NetworkTypeFromString(req.Type), req.Via)
+	// Validate the dns settings before defining the network.
+	if _, err := (ovpm.DNSOptions{}).With(req.Dns, req.DnsDomain, req.DnsSearch); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	var opts []ovpm.NetworkOption
+	if req.Router != "" {
+		opts = append(opts, ovpm.WithRouter(req.Router))
+	}
+	if req.PushRoute {
+		opts = append(opts, ovpm.WithPushedRoute(true))
+	}
+	network, err := ovpm.CreateNewNetwork(req.Name, req.Cidr, ovpm.NetworkTypeFromString(req.Type), req.Via, opts...)
 	if err != nil {
 		return nil, err
 	}
+	if req.Dns != "" || req.DnsDomain != "" || req.DnsSearch != "" {
+		if err := network.SetDNSOptions(req.Dns, req.DnsDomain, req.DnsSearch); err != nil {
+			return nil, err
+		}
+	}
 
 	n := pb.Network{
 		Name:                network.GetName(),
@@ -491,6 +1230,8 @@ func (s *NetworkService) Create(ctx context.Context, req *pb.NetworkCreateReques
 		CreatedAt:           network.GetCreatedAt(),
 		AssociatedUsernames: network.GetAssociatedUsernames(),
 		Via:                 network.GetVia(),
+		Router:              network.GetRouter(),
+		PushRoute:           network.IsRoutePushed(),
 	}
 
 	return &pb.NetworkCreateResponse{Network: &n}, nil
@@ -586,7 +1327,11 @@ func (s *NetworkService) Associate(ctx context.Context, req *pb.NetworkAssociate
 	if err != nil {
 		return nil, err
 	}
-	err = network.Associate(req.Username)
+	if req.Pool != "" {
+		err = network.AssociatePool(req.Pool)
+	} else {
+		err = network.Associate(req.Username)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -610,7 +1355,11 @@ func (s *NetworkService) Dissociate(ctx context.Context, req *pb.NetworkDissocia
 		return nil, err
 	}
 
-	err = network.Dissociate(req.Username)
+	if req.Pool != "" {
+		err = network.DissociatePool(req.Pool)
+	} else {
+		err = network.Dissociate(req.Username)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -755,6 +1504,8 @@ func (s *StatisticService) GetInterfaceStats(ctx context.Context, req *pb.Interf
 func NewRPCServer() *grpc.Server {
 	var opts []grpc.ServerOption
 	opts = append(opts, grpc.UnaryInterceptor(AuthUnaryInterceptor))
+	opts = append(opts, grpc.StreamInterceptor(AuthStreamInterceptor))
+	opts = append(opts, grpc.MaxRecvMsgSize(ovpm.MaxAPIMessageSize))
 	s := grpc.NewServer(opts...)
 	//s := grpc.NewServer()
 	pb.RegisterUserServiceServer(s, &UserService{})
@@ -0,0 +1,130 @@
+package ovpm_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/GoldenRUS/ovpm"
+	"github.com/GoldenRUS/ovpm/api"
+	"github.com/GoldenRUS/ovpm/api/pb"
+	"github.com/GoldenRUS/ovpm/permset"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// adminContext returns the context of an rpc call made by an admin.
+func adminContext() context.Context {
+	ctx := permset.NewContext(context.Background(), permset.New(ovpm.AdminPerms()...))
+	return api.NewUsernameContext(ctx, "admin")
+}
+
+func TestVPNServiceInvalidCiphers(t *testing.T) {
+	// Initialize:
+	ovpm.SetupTestCase()
+	db := ovpm.CreateTestDB()
+	defer db.Cease()
+	svc := &api.VPNService{}
+	ctx := adminContext()
+
+	// Test:
+	var initTests = []*pb.VPNInitRequest{
+		{Hostname: "localhost", AuthDigest: "MD5"},
+		{Hostname: "localhost", DataCiphers: "AES$256"},
+	}
+	for _, req := range initTests {
+		if _, err := svc.Init(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Init(%v) is expected to fail with %s, got %v", req, codes.InvalidArgument, err)
+		}
+		if ovpm.TheServer().IsInitialized() {
+			t.Fatalf("server is not expected to be initialized by Init(%v)", req)
+		}
+	}
+
+	if _, err := svc.Init(ctx, &pb.VPNInitRequest{Hostname: "localhost"}); err != nil {
+		t.Fatalf("server can not be initialized: %v", err)
+	}
+	var updateTests = []*pb.VPNUpdateRequest{
+		{AuthDigest: "MD5"},
+		{DataCiphers: "AES$256"},
+	}
+	for _, req := range updateTests {
+		if _, err := svc.Update(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Update(%v) is expected to fail with %s, got %v", req, codes.InvalidArgument, err)
+		}
+	}
+	if svr := ovpm.TheServer(); svr.GetAuthDigest() == "MD5" {
+		t.Errorf("failed update is not expected to change the auth digest")
+	}
+}
+
+func TestVPNServiceInvalidKeyOptions(t *testing.T) {
+	// Initialize:
+	ovpm.SetupTestCase()
+	db := ovpm.CreateTestDB()
+	defer db.Cease()
+	svc := &api.VPNService{}
+	ctx := adminContext()
+
+	// Test:
+	var initTests = []*pb.VPNInitRequest{
+		{Hostname: "localhost", KeyAlgorithm: "dsa"},
+		{Hostname: "localhost", KeyAlgorithm: "rsa", RsaKeySize: 1024},
+		{Hostname: "localhost", CertValidityDays: 30}, // Within the renew threshold.
+	}
+	for _, req := range initTests {
+		if _, err := svc.Init(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Init(%v) is expected to fail with %s, got %v", req, codes.InvalidArgument, err)
+		}
+		if ovpm.TheServer().IsInitialized() {
+			t.Fatalf("server is not expected to be initialized by Init(%v)", req)
+		}
+	}
+}
+
+func TestServerConflictsOverRPC(t *testing.T) {
+	// Initialize:
+	ovpm.SetupTestCase()
+	db := ovpm.CreateTestDB()
+	defer db.Cease()
+	vpnSvc := &api.VPNService{}
+	userSvc := &api.UserService{}
+	ctx := adminContext()
+	if _, err := vpnSvc.Init(ctx, &pb.VPNInitRequest{Hostname: "localhost", IpBlock: "10.9.0.0/24"}); err != nil {
+		t.Fatalf("server can not be initialized: %v", err)
+	}
+	ovpm.GetTestServer("second")
+	if _, err := vpnSvc.Init(ctx, &pb.VPNInitRequest{Server: "second", Hostname: "localhost", Port: "1195", IpBlock: "10.10.0.0/24"}); err != nil {
+		t.Fatalf("second server can not be initialized: %v", err)
+	}
+	usr, err := ovpm.CreateNewUser("usr1", "1234", false, 0, false, "description")
+	if err != nil {
+		t.Fatalf("user can not be created: %v", err)
+	}
+
+	// Test:
+	if _, err := vpnSvc.Init(ctx, &pb.VPNInitRequest{Server: "third", Hostname: "localhost", Port: "1195", IpBlock: "10.11.0.0/24"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("port conflict is expected to fail with %s, got %v", codes.InvalidArgument, err)
+	}
+	if _, err := vpnSvc.Update(ctx, &pb.VPNUpdateRequest{Server: "second", IpBlock: "10.9.0.0/16"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("network overlap is expected to fail with %s, got %v", codes.InvalidArgument, err)
+	}
+
+	// Static ip that doesn't fit in the network of the new server.
+	req := &pb.UserUpdateRequest{Username: "usr1", Server: "second", HostId: ovpm.IP2HostID(net.ParseIP("10.9.0.10").To4())}
+	if _, err := userSvc.Update(ctx, req); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("invalid static ip is expected to fail with %s, got %v", codes.InvalidArgument, err)
+	}
+	moved, _ := ovpm.GetUser("usr1")
+	if moved.GetServerName() != ovpm.DefaultServerName || moved.Cert != usr.Cert {
+		t.Errorf("user is not expected to be moved when its static ip is invalid: %s", moved.GetServerName())
+	}
+
+	req.HostId = ovpm.IP2HostID(net.ParseIP("10.10.0.10").To4())
+	if _, err := userSvc.Update(ctx, req); err != nil {
+		t.Fatalf("user can not be moved: %v", err)
+	}
+	if moved, _ := ovpm.GetUser("usr1"); moved.GetServerName() != "second" || moved.GetIPNet() != "10.10.0.10/24" {
+		t.Errorf("user is expected to be moved with its static ip: %s %s", moved.GetServerName(), moved.GetIPNet())
+	}
+}
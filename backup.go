@@ -0,0 +1,332 @@
+package ovpm
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/scrypt"
+)
+
+// backupFormatVersion is the version of the backup archive format.
+const backupFormatVersion = 1
+
+// backupEncryptedMagic prefixes the passphrase encrypted backup archives.
+//
+// Archives that are not encrypted are plain gzip streams.
+const backupEncryptedMagic = "OVPMENC1"
+
+// scrypt parameters of the backup encryption key.
+const (
+	backupScryptN   = 1 << 15
+	backupScryptR   = 8
+	backupScryptP   = 1
+	backupSaltSize  = 16
+	backupKeyLength = 32
+)
+
+// backupArchive is the content of a backup.
+//
+// Rows keep their ids, so that the relations among them survive the restore.
+type backupArchive struct {
+	Version       int                 `json:"version"`
+	SchemaVersion uint                `json:"schema_version"`
+	OVPMVersion   string              `json:"ovpm_version"`
+	CreatedAt     time.Time           `json:"created_at"`
+	Servers       []dbServerModel     `json:"servers"`
+	Users         []dbUserModel       `json:"users"`
+	Revoked       []dbRevokedModel    `json:"revoked"`
+	Networks      []dbNetworkModel    `json:"networks"`
+	NetworkUsers  []backupNetworkUser `json:"network_users"`
+	Statistics    []dbStatisticModel  `json:"statistics"`
+	Leases        []dbLeaseModel      `json:"leases"`
+	Pools         []dbPoolModel       `json:"pools"`
+	NetworkPools  []backupNetworkPool `json:"network_pools"`
+	ACLRules      []dbACLRuleModel    `json:"acl_rules"`
+}
+
+// backupNetworkUser is a row of the network_users join table.
+type backupNetworkUser struct {
+	NetworkID uint `json:"network_id" gorm:"column:db_network_model_id"`
+	UserID    uint `json:"user_id" gorm:"column:db_user_model_id"`
+}
+
+// backupNetworkPool is a row of the network_pools join table.
+type backupNetworkPool struct {
+	NetworkID uint `json:"network_id" gorm:"column:db_network_model_id"`
+	PoolID    uint `json:"pool_id" gorm:"column:db_pool_model_id"`
+}
+
+// DumpBackup returns an archive of the whole state of ovpm.
+//
+// The archive is encrypted with a key derived from the passphrase,
+// unless the passphrase is empty.
+func DumpBackup(passphrase string) ([]byte, error) {
+	schemaVersion, err := db.SchemaVersion()
+	if err != nil {
+		return nil, err
+	}
+	a := backupArchive{
+		Version:       backupFormatVersion,
+		SchemaVersion: schemaVersion,
+		OVPMVersion:   Version,
+		CreatedAt:     time.Now().UTC(),
+	}
+	for _, q := range []*gorm.DB{
+		db.Order("id").Find(&a.Servers),
+		db.Order("id").Find(&a.Users),
+		db.Order("id").Find(&a.Revoked),
+		db.Order("id").Find(&a.Networks),
+		db.Table("network_users").Order("db_network_model_id, db_user_model_id").Find(&a.NetworkUsers),
+		db.Order("id").Find(&a.Statistics),
+		db.Order("id").Find(&a.Leases),
+		db.Order("id").Find(&a.Pools),
+		db.Table("network_pools").Order("db_network_model_id, db_pool_model_id").Find(&a.NetworkPools),
+		db.Order("id").Find(&a.ACLRules),
+	} {
+		if q.Error != nil {
+			return nil, fmt.Errorf("can not read the database: %v", q.Error)
+		}
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(zw).Encode(&a); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	if passphrase == "" {
+		return buf.Bytes(), nil
+	}
+	return encryptBackup(buf.Bytes(), passphrase)
+}
+
+// RestoreBackup replaces the state of ovpm with the content of the archive
+// and regenerates the files of the servers.
+//
+// Restoring on top of an initialized server requires force.
+func RestoreBackup(archive []byte, passphrase string, force bool) error {
+	a, err := readBackup(archive, passphrase)
+	if err != nil {
+		return err
+	}
+	if a.Version != backupFormatVersion {
+		return fmt.Errorf("unsupported backup format version: %d", a.Version)
+	}
+	if a.SchemaVersion > LatestSchemaVersion() {
+		return fmt.Errorf("backup is taken with a newer version of ovpm (schema version %d), upgrade ovpm first", a.SchemaVersion)
+	}
+	if isAnyServerInitialized() && !force {
+		return fmt.Errorf("ovpm is already initialized, restoring would overwrite it")
+	}
+
+	// Servers that are not in the backup should be stopped once it's restored.
+	restored := make(map[string]bool)
+	for _, s := range a.Servers {
+		restored[s.Name] = true
+	}
+	var dropped []*Server
+	for _, svr := range GetAllServers() {
+		if !restored[svr.name] {
+			dropped = append(dropped, svr)
+		}
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		// Associations are restored by their ids.
+		tx = tx.Set("gorm:save_associations", false)
+		for _, m := range []interface{}{&dbACLRuleModel{}, &dbLeaseModel{}, &dbPoolModel{}, &dbStatisticModel{}, &dbNetworkModel{}, &dbRevokedModel{}, &dbUserModel{}, &dbServerModel{}} {
+			if err := tx.Unscoped().Delete(m).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Exec("DELETE FROM network_users").Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM network_pools").Error; err != nil {
+			return err
+		}
+
+		for i := range a.Servers {
+			if err := tx.Create(&a.Servers[i]).Error; err != nil {
+				return err
+			}
+		}
+		for i := range a.Users {
+			if err := tx.Create(&a.Users[i]).Error; err != nil {
+				return err
+			}
+		}
+		for i := range a.Revoked {
+			if err := tx.Create(&a.Revoked[i]).Error; err != nil {
+				return err
+			}
+		}
+		for i := range a.Networks {
+			if err := tx.Create(&a.Networks[i]).Error; err != nil {
+				return err
+			}
+		}
+		for _, nu := range a.NetworkUsers {
+			if err := tx.Exec("INSERT INTO network_users (db_network_model_id, db_user_model_id) VALUES (?, ?)", nu.NetworkID, nu.UserID).Error; err != nil {
+				return err
+			}
+		}
+		for i := range a.Statistics {
+			if err := tx.Create(&a.Statistics[i]).Error; err != nil {
+				return err
+			}
+		}
+		for i := range a.Pools {
+			if err := tx.Create(&a.Pools[i]).Error; err != nil {
+				return err
+			}
+		}
+		for _, np := range a.NetworkPools {
+			if err := tx.Exec("INSERT INTO network_pools (db_network_model_id, db_pool_model_id) VALUES (?, ?)", np.NetworkID, np.PoolID).Error; err != nil {
+				return err
+			}
+		}
+		for i := range a.ACLRules {
+			if err := tx.Create(&a.ACLRules[i]).Error; err != nil {
+				return err
+			}
+		}
+		// Backups taken before the leases lack them; users are leased
+		// addresses again as they are needed.
+		for i := range a.Leases {
+			if err := tx.Create(&a.Leases[i]).Error; err != nil {
+				return err
+			}
+		}
+		return resetSequences(tx, "db_server_models", "db_user_models", "db_revoked_models", "db_network_models", "db_statistic_models", "db_lease_models", "db_pool_models", "db_acl_rule_models")
+	})
+	if err != nil {
+		return fmt.Errorf("can not restore the backup: %v", err)
+	}
+	for _, svr := range dropped {
+		svr.CloseMonitors()
+		svr.StopVPNProc()
+	}
+	logrus.Infof("backup taken at %s is restored: %d server(s), %d user(s), %d network(s)", a.CreatedAt.Format(time.RFC3339), len(a.Servers), len(a.Users), len(a.Networks))
+
+	// Drop the stale state of the cached servers.
+	serversMu.Lock()
+	var cached []*Server
+	for _, svr := range servers {
+		cached = append(cached, svr)
+	}
+	serversMu.Unlock()
+	for _, svr := range cached {
+		svr.Refresh()
+	}
+
+	restartAllServers()
+	return nil
+}
+
+// resetSequences moves the id sequences of the tables past the restored ids.
+//
+// Only Postgres needs it; sqlite3 and MySQL keep track of the explicitly
+// inserted ids themselves.
+func resetSequences(tx *gorm.DB, tables ...string) error {
+	if tx.Dialect().GetName() != PostgresDialect {
+		return nil
+	}
+	for _, table := range tables {
+		q := fmt.Sprintf("SELECT setval(pg_get_serial_sequence('%[1]s', 'id'), COALESCE(MAX(id), 0) + 1, false) FROM %[1]s", table)
+		if err := tx.Exec(q).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readBackup decrypts and decodes the archive.
+func readBackup(archive []byte, passphrase string) (*backupArchive, error) {
+	if bytes.HasPrefix(archive, []byte(backupEncryptedMagic)) {
+		if passphrase == "" {
+			return nil, fmt.Errorf("backup is encrypted, passphrase is required")
+		}
+		var err error
+		if archive, err = decryptBackup(archive, passphrase); err != nil {
+			return nil, err
+		}
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, fmt.Errorf("not a valid backup: %v", err)
+	}
+	defer zr.Close()
+	var a backupArchive
+	if err := json.NewDecoder(zr).Decode(&a); err != nil {
+		return nil, fmt.Errorf("not a valid backup: %v", err)
+	}
+	return &a, nil
+}
+
+// encryptBackup seals data with AES-256-GCM. The layout of the output is:
+//
+//	magic | scrypt salt | nonce | ciphertext
+func encryptBackup(data []byte, passphrase string) ([]byte, error) {
+	salt := make([]byte, backupSaltSize)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+	aead, err := backupAEAD(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	out := append([]byte(backupEncryptedMagic), salt...)
+	out = append(out, nonce...)
+	return aead.Seal(out, nonce, data, []byte(backupEncryptedMagic)), nil
+}
+
+func decryptBackup(data []byte, passphrase string) ([]byte, error) {
+	data = data[len(backupEncryptedMagic):]
+	if len(data) < backupSaltSize {
+		return nil, fmt.Errorf("not a valid backup: truncated")
+	}
+	salt, data := data[:backupSaltSize], data[backupSaltSize:]
+	aead, err := backupAEAD(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < aead.NonceSize() {
+		return nil, fmt.Errorf("not a valid backup: truncated")
+	}
+	nonce, data := data[:aead.NonceSize()], data[aead.NonceSize():]
+	plain, err := aead.Open(nil, nonce, data, []byte(backupEncryptedMagic))
+	if err != nil {
+		return nil, fmt.Errorf("can not decrypt the backup, passphrase might be wrong")
+	}
+	return plain, nil
+}
+
+func backupAEAD(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, backupScryptN, backupScryptR, backupScryptP, backupKeyLength)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,154 @@
+package ovpm
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/GoldenRUS/ovpm/supervisor"
+	"github.com/jinzhu/gorm"
+)
+
+func TestBackupRestore(t *testing.T) {
+	// Init:
+	setupTestCase()
+	createTestDB()
+	svr := TheServer()
+	svr.Init("localhost", "", UDPProto, "", "", "", "", false)
+	svr2 := GetServer("office")
+	svr2.emitToFileFunc = svr.emitToFileFunc
+	if err := svr2.Init("localhost", "1195", UDPProto, "10.10.0.0/24", "", "", "", false); err != nil {
+		t.Fatalf("office server can not be initialized: %v", err)
+	}
+
+	// Prepare:
+	usr1, _ := CreateNewUser("usr1", "1234", false, 0, true, "description")
+	usr2, _ := svr2.CreateNewUser("usr2", "1234", false, 0, false, "description")
+	usr3, _ := CreateNewUser("usr3", "1234", false, 0, false, "description")
+	usr3.Delete()
+	n, _ := CreateNewNetwork("net1", "192.168.5.0/24", ROUTE, "")
+	n.Associate("usr1")
+	db.Save(&dbStatisticModel{UserID: usr1.ID, CommonName: "usr1", ConnectedSince: time.Now().Add(-time.Hour), ConnectedUntil: time.Now()})
+
+	archive, err := DumpBackup("")
+	if err != nil {
+		t.Fatalf("can not dump backup: %v", err)
+	}
+	db.Cease()
+
+	// Test:
+	// Restore on a fresh host.
+	createTestDB()
+	defer db.Cease()
+	fs = make(map[string]string)
+	if err := RestoreBackup(archive, "", false); err != nil {
+		t.Fatalf("can not restore backup: %v", err)
+	}
+
+	if svrs := GetAllServers(); len(svrs) != 2 || svrs[1].GetServerName() != "office" || svrs[1].GetCACert() != svr2.GetCACert() {
+		t.Fatalf("servers are not restored: %v", svrs)
+	}
+	users, _ := GetAllUsers()
+	if len(users) != 2 {
+		t.Fatalf("expected 2 users, got %d", len(users))
+	}
+	u1, err := GetUser("usr1")
+	if err != nil || u1.Cert != usr1.Cert || u1.Key != usr1.Key || !u1.CheckPassword("1234") {
+		t.Errorf("usr1 is not restored correctly: %v", err)
+	}
+	if u2, err := GetUser("usr2"); err != nil || u2.GetServerName() != "office" || u2.Cert != usr2.Cert {
+		t.Errorf("usr2 is expected to be restored onto the office server: %v", err)
+	}
+	if users := GetAllNetworks()[0].GetAssociatedUsernames(); len(users) != 1 || users[0] != "usr1" {
+		t.Errorf("network associations are not restored: %v", users)
+	}
+	var revoked int
+	db.Model(&dbRevokedModel{}).Count(&revoked)
+	if revoked != 1 {
+		t.Errorf("expected 1 revoked certificate, got %d", revoked)
+	}
+	if stats, _ := GetStatisticList(); len(stats) != 1 {
+		t.Errorf("statistics are not restored: %v", stats)
+	}
+	if _, ok := fs[svr.path(vpnConfFile)]; !ok {
+		t.Error("server conf is expected to be emitted")
+	}
+
+	// New rows shouldn't collide with the restored ones.
+	if _, err := CreateNewUser("usr4", "1234", false, 0, false, "description"); err != nil {
+		t.Errorf("user can not be created after the restore: %v", err)
+	}
+
+	// Restoring onto an initialized server needs force.
+	if err := RestoreBackup(archive, "", false); err == nil {
+		t.Error("restore is expected to fail without force")
+	}
+	if err := RestoreBackup(archive, "", true); err != nil {
+		t.Fatalf("can not restore backup: %v", err)
+	}
+	if _, err := GetUser("usr4"); err == nil {
+		t.Error("usr4 is expected to be dropped by the restore")
+	}
+
+	// Servers that aren't in the backup are stopped only if it's restored.
+	svr3 := GetServer("lab")
+	svr3.emitToFileFunc = svr.emitToFileFunc
+	if err := svr3.Init("localhost", "1196", UDPProto, "10.11.0.0/24", "", "", "", false); err != nil {
+		t.Fatalf("lab server can not be initialized: %v", err)
+	}
+	db.Callback().Create().Before("gorm:create").Register("test:fail_office", func(scope *gorm.Scope) {
+		if s, ok := scope.Value.(*dbServerModel); ok && s.Name == "office" {
+			scope.Err(fmt.Errorf("write failed"))
+		}
+	})
+	err = RestoreBackup(archive, "", true)
+	db.Callback().Create().Remove("test:fail_office")
+	if err == nil {
+		t.Fatal("restore is expected to fail")
+	}
+	if !svr3.IsInitialized() || svr3.proc.Status() != supervisor.RUNNING {
+		t.Error("lab server is expected to keep running when the restore fails")
+	}
+	if err := RestoreBackup(archive, "", true); err != nil {
+		t.Fatalf("can not restore backup: %v", err)
+	}
+	if svr3.IsInitialized() || svr3.proc.Status() == supervisor.RUNNING {
+		t.Error("lab server is expected to be stopped by the restore")
+	}
+}
+
+func TestBackupEncryption(t *testing.T) {
+	// Init:
+	setupTestCase()
+	createTestDB()
+	defer db.Cease()
+	TheServer().Init("localhost", "", UDPProto, "", "", "", "", false)
+
+	// Test:
+	archive, err := DumpBackup("secret")
+	if err != nil {
+		t.Fatalf("can not dump backup: %v", err)
+	}
+	if !bytes.HasPrefix(archive, []byte(backupEncryptedMagic)) {
+		t.Fatal("backup is expected to be encrypted")
+	}
+
+	if _, err := readBackup(archive, ""); err == nil {
+		t.Error("passphrase is expected to be required")
+	}
+	if _, err := readBackup(archive, "wrong"); err == nil {
+		t.Error("wrong passphrase is expected to fail")
+	}
+	a, err := readBackup(archive, "secret")
+	if err != nil {
+		t.Fatalf("can not read backup: %v", err)
+	}
+	if a.Version != backupFormatVersion || len(a.Servers) != 1 || a.Servers[0].CAKey != TheServer().CAKey {
+		t.Errorf("backup is not read correctly: %+v", a)
+	}
+
+	if _, err := readBackup([]byte("garbage"), ""); err == nil {
+		t.Error("invalid backup is expected to fail")
+	}
+}
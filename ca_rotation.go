@@ -0,0 +1,177 @@
+package ovpm
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/GoldenRUS/ovpm/pki"
+	"github.com/sirupsen/logrus"
+)
+
+// CARotationStatus represents the progress of the CA rotation of a server.
+type CARotationStatus struct {
+	Rotating  bool
+	StartedAt time.Time
+
+	// Users that had their profiles before the rotation is started.
+	Users int
+
+	// Users that fetched their new profiles after the rotation is started.
+	Fetched int
+
+	// Usernames of the users that still use the profiles signed by the old CA.
+	Pending []string
+}
+
+// StartCARotation generates a new CA for the server without retiring the current one.
+//
+// Until the rotation is finished, the server trusts both of the CAs and the users get
+// certificates signed by the new CA. Profiles that the users already have keep working,
+// so the users can fetch their new profiles at their own pace. See FinishCARotation.
+func (svr *Server) StartCARotation() error {
+	if !svr.IsInitialized() {
+		return fmt.Errorf("you first need to create server")
+	}
+	if svr.IsRotatingCA() {
+		return fmt.Errorf("CA rotation of the server %s is already started", svr.name)
+	}
+	for _, other := range GetAllServers() {
+		if other.name != svr.name && other.CACert == svr.CACert {
+			return fmt.Errorf("CA of the server %s is shared with the server %s, it can't be rotated", svr.name, other.name)
+		}
+	}
+
+	ca, err := pki.NewCA(svr.caOptions()...)
+	if err != nil {
+		return fmt.Errorf("can not create ca creds: %s", err)
+	}
+	now := time.Now()
+	svr.NextCACert = ca.Cert
+	svr.NextCAKey = ca.Key
+	svr.CARotationStartedAt = &now
+	if err := db.Save(&svr.dbServerModel).Error; err != nil {
+		return err
+	}
+
+	// Re-issue the users under the new CA.
+	users, err := svr.GetUsers()
+	if err != nil {
+		return err
+	}
+	for _, user := range users {
+		if err := user.renew(); err != nil {
+			logrus.Errorf("can not sign user %s: %v", user.Username, err)
+		}
+	}
+	logrus.Infof("CA rotation of the server %s is started, users should fetch their new profiles", svr.name)
+	return svr.EmitWithRestart()
+}
+
+// FinishCARotation retires the old CA of the server and makes the new CA the only
+// trusted one.
+//
+// Users that haven't fetched their new profiles lose access. So unless force is
+// set, it refuses to finish while there are such users.
+func (svr *Server) FinishCARotation(force bool) error {
+	status, err := svr.CARotationStatus()
+	if err != nil {
+		return err
+	}
+	if !status.Rotating {
+		return fmt.Errorf("CA rotation of the server %s is not started", svr.name)
+	}
+	if len(status.Pending) > 0 && !force {
+		return fmt.Errorf("%d user(s) haven't fetched their new profiles yet: %v", len(status.Pending), status.Pending)
+	}
+
+	ca := &pki.CA{CertHolder: pki.CertHolder{Cert: svr.NextCACert, Key: svr.NextCAKey}}
+	srv, err := pki.NewServerCertHolder(ca, svr.pkiOptions()...)
+	if err != nil {
+		return fmt.Errorf("can not create server cert creds: %s", err)
+	}
+	svr.CACert = ca.Cert
+	svr.CAKey = ca.Key
+	svr.Cert = srv.Cert
+	svr.Key = srv.Key
+	svr.NextCACert = ""
+	svr.NextCAKey = ""
+	svr.CARotationStartedAt = nil
+	if err := db.Save(&svr.dbServerModel).Error; err != nil {
+		return err
+	}
+
+	logrus.Infof("CA rotation of the server %s is finished, the old CA is retired", svr.name)
+	return svr.EmitWithRestart()
+}
+
+// IsRotatingCA tells whether the CA rotation of the server is ongoing.
+func (svr *Server) IsRotatingCA() bool {
+	return svr.NextCACert != ""
+}
+
+// CARotationStatus returns the progress of the ongoing CA rotation of the server.
+func (svr *Server) CARotationStatus() (*CARotationStatus, error) {
+	if !svr.IsInitialized() {
+		return nil, fmt.Errorf("you first need to create server")
+	}
+	status := &CARotationStatus{Rotating: svr.IsRotatingCA()}
+	if !status.Rotating {
+		return status, nil
+	}
+	status.StartedAt = *svr.CARotationStartedAt
+
+	users, err := svr.GetUsers()
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range users {
+		// Users created during the rotation never had an old profile.
+		if user.CreatedAt.After(status.StartedAt) {
+			continue
+		}
+		status.Users++
+		if user.ConfigFetchedAt != nil && user.ConfigFetchedAt.After(status.StartedAt) {
+			status.Fetched++
+			continue
+		}
+		status.Pending = append(status.Pending, user.Username)
+	}
+	return status, nil
+}
+
+// NextCAExpiresAt returns the expiry date time of the CA that replaces the
+// current one at the end of the CA rotation.
+func (svr *Server) NextCAExpiresAt() time.Time {
+	if !svr.IsRotatingCA() {
+		return time.Time{}
+	}
+	crt, err := pki.ReadCertFromPEM(svr.NextCACert)
+	if err != nil {
+		logrus.Fatalf("can't parse cert: %v", err)
+	}
+	return crt.NotAfter
+}
+
+// caBundle returns the CAs that the server and its clients trust.
+func (svr *Server) caBundle() string {
+	if svr.IsRotatingCA() {
+		return svr.CACert + svr.NextCACert
+	}
+	return svr.CACert
+}
+
+// clientCA returns the CA that signs the client certificates of the server.
+//
+// It's the new CA while the CA rotation is ongoing.
+func (svr *Server) clientCA() (*pki.CA, error) {
+	ca, err := svr.GetSystemCA()
+	if err != nil {
+		return nil, err
+	}
+	var server dbServerModel
+	db.Where(&dbServerModel{Name: svr.name}).First(&server)
+	if server.NextCACert != "" {
+		return &pki.CA{CertHolder: pki.CertHolder{Cert: server.NextCACert, Key: server.NextCAKey}}, nil
+	}
+	return ca, nil
+}
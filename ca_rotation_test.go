@@ -0,0 +1,157 @@
+package ovpm
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GoldenRUS/ovpm/pki"
+)
+
+// signedBy tells whether the certificate is signed by the CA.
+func signedBy(t *testing.T, cert, ca string) bool {
+	crt, err := pki.ReadCertFromPEM(cert)
+	if err != nil {
+		t.Fatalf("can not parse cert: %v", err)
+	}
+	caCrt, err := pki.ReadCertFromPEM(ca)
+	if err != nil {
+		t.Fatalf("can not parse ca cert: %v", err)
+	}
+	return crt.CheckSignatureFrom(caCrt) == nil
+}
+
+func TestCARotation(t *testing.T) {
+	// Init:
+	setupTestCase()
+	createTestDB()
+	defer db.Cease()
+	svr := TheServer()
+	svr.Init("localhost", "", UDPProto, "", "", "", "", false)
+
+	// Prepare:
+	CreateNewUser("usr1", "1234", false, 0, true, "description")
+	CreateNewUser("usr2", "1234", false, 0, true, "description")
+	oldCA, serverCert := svr.CACert, svr.Cert
+
+	// Test:
+	if err := svr.FinishCARotation(false); err == nil {
+		t.Error("rotation is not expected to be finished before it's started")
+	}
+	if err := svr.StartCARotation(); err != nil {
+		t.Fatalf("can not start CA rotation: %v", err)
+	}
+	if err := svr.StartCARotation(); err == nil {
+		t.Error("rotation is not expected to be started twice")
+	}
+	svr.Refresh()
+	if !svr.IsRotatingCA() || svr.CACert != oldCA || svr.Cert != serverCert {
+		t.Fatal("server is expected to keep its CA and certificate while rotating")
+	}
+	newCA := svr.NextCACert
+
+	// Both of the CAs are trusted.
+	if bundle := fs[svr.path(caCertFile)]; !strings.Contains(bundle, oldCA) || !strings.Contains(bundle, newCA) {
+		t.Error("emitted CA file is expected to contain both of the CAs")
+	}
+	if crl := fs[svr.path(crlFile)]; strings.Count(crl, "BEGIN X509 CRL") != 2 {
+		t.Errorf("emitted CRL file is expected to contain the CRLs of both of the CAs: %s", crl)
+	}
+
+	// Users are re-issued under the new CA.
+	usr1, _ := GetUser("usr1")
+	if !signedBy(t, usr1.Cert, newCA) {
+		t.Error("users are expected to be re-issued under the new CA")
+	}
+	usr3, _ := CreateNewUser("usr3", "1234", false, 0, true, "description")
+	if !signedBy(t, usr3.Cert, newCA) {
+		t.Error("users created during the rotation are expected to be signed by the new CA")
+	}
+
+	status, err := svr.CARotationStatus()
+	if err != nil {
+		t.Fatalf("can not get rotation status: %v", err)
+	}
+	if !status.Rotating || status.Users != 2 || status.Fetched != 0 || len(status.Pending) != 2 {
+		t.Fatalf("unexpected rotation status: %+v", status)
+	}
+
+	// Fetching the profile marks the user.
+	config, err := svr.DumpsClientConfig("usr1")
+	if err != nil {
+		t.Fatalf("can not dump client config: %v", err)
+	}
+	if !strings.Contains(config, strings.TrimSpace(newCA)) || !strings.Contains(config, strings.TrimSpace(oldCA)) {
+		t.Error("client config is expected to trust both of the CAs")
+	}
+	status, _ = svr.CARotationStatus()
+	if status.Fetched != 1 || len(status.Pending) != 1 || status.Pending[0] != "usr2" {
+		t.Fatalf("unexpected rotation status after usr1 fetched: %+v", status)
+	}
+
+	// Pending users block the finish unless forced.
+	if err := svr.FinishCARotation(false); err == nil {
+		t.Error("rotation is not expected to be finished while users are pending")
+	}
+	svr.DumpsClientConfig("usr2")
+	if err := svr.FinishCARotation(false); err != nil {
+		t.Fatalf("can not finish CA rotation: %v", err)
+	}
+	svr.Refresh()
+	if svr.IsRotatingCA() || svr.CACert != newCA {
+		t.Fatal("new CA is expected to be promoted")
+	}
+	if !signedBy(t, svr.Cert, newCA) {
+		t.Error("server certificate is expected to be signed by the new CA")
+	}
+	if fs[svr.path(caCertFile)] != newCA {
+		t.Error("only the new CA is expected to be emitted")
+	}
+	if status, _ := svr.CARotationStatus(); status.Rotating {
+		t.Error("rotation is not expected to be ongoing")
+	}
+}
+
+func TestCARotationForce(t *testing.T) {
+	// Init:
+	setupTestCase()
+	createTestDB()
+	defer db.Cease()
+	svr := TheServer()
+	svr.Init("localhost", "", UDPProto, "", "", "", "", false)
+
+	// Prepare:
+	CreateNewUser("usr1", "1234", false, 0, true, "description")
+	svr.StartCARotation()
+
+	// Test:
+	if err := svr.FinishCARotation(true); err != nil {
+		t.Fatalf("forced finish is expected to succeed: %v", err)
+	}
+	if svr.IsRotatingCA() {
+		t.Error("rotation is expected to be finished")
+	}
+}
+
+func TestCARotationSharedCA(t *testing.T) {
+	// Init:
+	setupTestCase()
+	createTestDB()
+	defer db.Cease()
+	svr := TheServer()
+	svr.Init("localhost", "", UDPProto, "", "", "", "", false)
+
+	// Prepare:
+	svr2 := GetServer("second")
+	svr2.emitToFileFunc = svr.emitToFileFunc
+	if err := svr2.Init("localhost", "1195", UDPProto, "10.10.0.0/24", "", "", "", false, WithSharedCA(DefaultServerName)); err != nil {
+		t.Fatalf("second server can not be initialized: %v", err)
+	}
+
+	// Test:
+	if err := svr.StartCARotation(); err == nil {
+		t.Error("shared CA is not expected to be rotated")
+	}
+	if svr.IsRotatingCA() {
+		t.Error("failed rotation is not expected to be started")
+	}
+}
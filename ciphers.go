@@ -0,0 +1,95 @@
+package ovpm
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// cipherNameRegexp matches the OpenSSL names of the ciphers and digests, e.g. AES-256-GCM.
+var cipherNameRegexp = regexp.MustCompile(`^[A-Za-z0-9-]+$`)
+
+// authDigests are the digests that can be used for the HMAC authentication.
+var authDigests = []string{"SHA1", "SHA256", "SHA384", "SHA512"}
+
+// tlsVersions are the TLS versions that can be set as the minimum.
+var tlsVersions = []string{"1.0", "1.1", "1.2", "1.3"}
+
+// isOneOf tells whether s is in the list.
+func isOneOf(s string, list []string) bool {
+	for _, e := range list {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
+
+// WithDataCiphers sets the ciphers that the server negotiates with the clients
+// for the data channel, in the order of preference.
+//
+// ciphers is a list of OpenSSL cipher names separated by colons or commas.
+func WithDataCiphers(ciphers string) ServerOption {
+	return func(s *dbServerModel) error {
+		var names []string
+		for _, name := range strings.FieldsFunc(ciphers, func(r rune) bool { return r == ':' || r == ',' }) {
+			name = strings.TrimSpace(name)
+			if !cipherNameRegexp.MatchString(name) {
+				return fmt.Errorf("validation error: data cipher `%s` is not a valid cipher name", name)
+			}
+			names = append(names, strings.ToUpper(name))
+		}
+		if len(names) == 0 {
+			return fmt.Errorf("validation error: at least one data cipher is required")
+		}
+		s.DataCiphers = strings.Join(names, ":")
+		return nil
+	}
+}
+
+// WithAuthDigest sets the digest of the HMAC authentication of the packets.
+func WithAuthDigest(digest string) ServerOption {
+	return func(s *dbServerModel) error {
+		digest = strings.ToUpper(digest)
+		if !isOneOf(digest, authDigests) {
+			return fmt.Errorf("validation error: auth digest `%s` should be one of %s", digest, strings.Join(authDigests, ", "))
+		}
+		s.AuthDigest = digest
+		return nil
+	}
+}
+
+// WithTLSVersionMin sets the minimum TLS version that the server and the clients accept.
+func WithTLSVersionMin(version string) ServerOption {
+	return func(s *dbServerModel) error {
+		if !isOneOf(version, tlsVersions) {
+			return fmt.Errorf("validation error: tls version `%s` should be one of %s", version, strings.Join(tlsVersions, ", "))
+		}
+		s.TLSVersionMin = version
+		return nil
+	}
+}
+
+// GetDataCiphers returns the data channel ciphers of the server separated by colons.
+func (svr *Server) GetDataCiphers() string {
+	if svr.DataCiphers == "" {
+		return DefaultDataCiphers
+	}
+	return svr.DataCiphers
+}
+
+// GetAuthDigest returns the digest of the HMAC authentication of the server.
+func (svr *Server) GetAuthDigest() string {
+	if svr.AuthDigest == "" {
+		return DefaultAuthDigest
+	}
+	return svr.AuthDigest
+}
+
+// GetTLSVersionMin returns the minimum TLS version of the server.
+func (svr *Server) GetTLSVersionMin() string {
+	if svr.TLSVersionMin == "" {
+		return DefaultTLSVersionMin
+	}
+	return svr.TLSVersionMin
+}
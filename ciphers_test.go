@@ -0,0 +1,68 @@
+package ovpm
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCipherSettings(t *testing.T) {
+	// Init:
+	setupTestCase()
+	createTestDB()
+	defer db.Cease()
+	svr := TheServer()
+	svr.Init("localhost", "", UDPProto, "", "", "", "", false)
+	CreateNewUser("usr1", "1234", false, 0, true, "description")
+
+	// Test:
+	serverConf := fs[svr.path(vpnConfFile)]
+	for _, line := range []string{
+		"data-ciphers " + DefaultDataCiphers,
+		"data-ciphers-fallback AES-128-CBC",
+		"auth " + DefaultAuthDigest,
+		"tls-version-min " + DefaultTLSVersionMin,
+	} {
+		if !strings.Contains(serverConf, "\n"+line+"\n") {
+			t.Errorf("server conf is expected to contain %q", line)
+		}
+	}
+	config, _ := svr.DumpsClientConfig("usr1")
+	if strings.Contains(config, "ns-cert-type") || strings.Contains(config, "\ncipher ") {
+		t.Error("client config is not expected to contain the deprecated options")
+	}
+	for _, line := range []string{"remote-cert-tls server", "data-ciphers " + DefaultDataCiphers, "auth SHA256", "tls-version-min 1.2"} {
+		if !strings.Contains(config, "\n"+line+"\n") {
+			t.Errorf("client config is expected to contain %q", line)
+		}
+	}
+
+	// Update.
+	if err := svr.Update("", "", nil, WithDataCiphers("aes-128-gcm, CHACHA20-POLY1305"), WithAuthDigest("sha512"), WithTLSVersionMin("1.3")); err != nil {
+		t.Fatalf("server can not be updated: %v", err)
+	}
+	if svr.GetDataCiphers() != "AES-128-GCM:CHACHA20-POLY1305" || svr.GetAuthDigest() != "SHA512" || svr.GetTLSVersionMin() != "1.3" {
+		t.Errorf("unexpected cipher settings: %s %s %s", svr.GetDataCiphers(), svr.GetAuthDigest(), svr.GetTLSVersionMin())
+	}
+	if !strings.Contains(fs[svr.path(vpnConfFile)], "\ndata-ciphers AES-128-GCM:CHACHA20-POLY1305\n") {
+		t.Error("updated data ciphers are expected to be emitted")
+	}
+
+	// Validation.
+	var tests = []struct {
+		name string
+		opt  ServerOption
+	}{
+		{"no ciphers", WithDataCiphers(" : ")},
+		{"bad cipher", WithDataCiphers("AES-256-GCM:AES 128")},
+		{"bad digest", WithAuthDigest("MD5")},
+		{"bad tls version", WithTLSVersionMin("2.0")},
+	}
+	for _, tt := range tests {
+		if err := svr.Update("", "", nil, tt.opt); err == nil {
+			t.Errorf("%s: update is expected to fail", tt.name)
+		}
+	}
+	if svr.GetAuthDigest() != "SHA512" {
+		t.Error("failed update is not expected to change the settings")
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"os"
+
+	"github.com/GoldenRUS/ovpm"
+	"github.com/GoldenRUS/ovpm/api/pb"
+	"github.com/GoldenRUS/ovpm/errors"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+)
+
+func backupAction(rpcServURLStr string, file string, passphrase string) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcServURLStr)
+	if err != nil {
+		return errors.BadURL(rpcServURLStr, err)
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare service caller.
+	var vpnSvc = pb.NewVPNServiceClient(rpcConn)
+
+	res, err := vpnSvc.Backup(context.Background(), &pb.VPNBackupRequest{Passphrase: passphrase}, grpc.MaxCallRecvMsgSize(ovpm.MaxAPIMessageSize))
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+
+	if file == "-" {
+		_, err = os.Stdout.Write(res.Archive)
+	} else {
+		// Backups contain the private keys.
+		err = os.WriteFile(file, res.Archive, 0600)
+	}
+	if err != nil {
+		err := errors.UnknownFileIOError(err)
+		exit(1)
+		return err
+	}
+	if file != "-" {
+		logrus.Infof("backup is written to %s", file)
+	}
+	return nil
+}
+
+func restoreAction(rpcServURLStr string, file string, passphrase string, force bool) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcServURLStr)
+	if err != nil {
+		return errors.BadURL(rpcServURLStr, err)
+	}
+
+	var archive []byte
+	if file == "-" {
+		archive, err = io.ReadAll(os.Stdin)
+	} else {
+		archive, err = os.ReadFile(file)
+	}
+	if err != nil {
+		err := errors.UnknownFileIOError(err)
+		exit(1)
+		return err
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare service caller.
+	var vpnSvc = pb.NewVPNServiceClient(rpcConn)
+
+	_, err = vpnSvc.Restore(context.Background(), &pb.VPNRestoreRequest{Archive: archive, Passphrase: passphrase, Force: force})
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+	logrus.Infof("backup is restored from %s", file)
+	return nil
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+
+	"github.com/GoldenRUS/ovpm/api/pb"
+	"github.com/GoldenRUS/ovpm/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// readDirectives reads a block of directives from the file, or from stdin if
+// file is "-". Empty file means no directives.
+func readDirectives(file string) (string, error) {
+	var b []byte
+	var err error
+	switch file {
+	case "":
+		return "", nil
+	case "-":
+		b, err = io.ReadAll(os.Stdin)
+	default:
+		b, err = os.ReadFile(file)
+	}
+	if err != nil {
+		return "", errors.UnknownFileIOError(err)
+	}
+	return string(b), nil
+}
+
+// printDirectives prints a block of directives under the title.
+func printDirectives(title, directives string) {
+	fmt.Printf("# %s\n", title)
+	if directives == "" {
+		fmt.Println("# (none)")
+	} else {
+		fmt.Println(directives)
+	}
+}
+
+func vpnDirectivesShowAction(rpcServURLStr string, server string) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcServURLStr)
+	if err != nil {
+		return errors.BadURL(rpcServURLStr, err)
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare service caller.
+	var vpnSvc = pb.NewVPNServiceClient(rpcConn)
+
+	res, err := vpnSvc.GetDirectives(context.Background(), &pb.VPNDirectivesRequest{Server: server})
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+	printDirectives("server.conf", res.ServerDirectives)
+	fmt.Println()
+	printDirectives("client profile", res.ClientDirectives)
+	return nil
+}
+
+func vpnDirectivesSetAction(rpcServURLStr string, server string, scope pb.VPNDirectiveScope, file string) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcServURLStr)
+	if err != nil {
+		return errors.BadURL(rpcServURLStr, err)
+	}
+
+	directives, err := readDirectives(file)
+	if err != nil {
+		exit(1)
+		return err
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare service caller.
+	var vpnSvc = pb.NewVPNServiceClient(rpcConn)
+
+	_, err = vpnSvc.SetDirectives(context.Background(), &pb.VPNSetDirectivesRequest{Server: server, Scope: scope, Directives: directives})
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+	logrus.Infof("extra directives are updated")
+	if scope == pb.VPNDirectiveScope_CLIENT_PROFILE {
+		logrus.Info("users need to download their profiles again")
+	}
+	return nil
+}
+
+func userDirectivesShowAction(rpcSrvURLStr string, username string) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcSrvURLStr)
+	if err != nil {
+		return errors.BadURL(rpcSrvURLStr, err)
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare a service caller.
+	var userSvc = pb.NewUserServiceClient(rpcConn)
+
+	res, err := userSvc.GetDirectives(context.Background(), &pb.UserDirectivesRequest{Username: username})
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+	printDirectives(fmt.Sprintf("ccd of %s", res.Username), res.Directives)
+	return nil
+}
+
+func userDirectivesSetAction(rpcSrvURLStr string, username string, file string) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcSrvURLStr)
+	if err != nil {
+		return errors.BadURL(rpcSrvURLStr, err)
+	}
+
+	directives, err := readDirectives(file)
+	if err != nil {
+		exit(1)
+		return err
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare a service caller.
+	var userSvc = pb.NewUserServiceClient(rpcConn)
+
+	_, err = userSvc.SetDirectives(context.Background(), &pb.UserSetDirectivesRequest{Username: username, Directives: directives})
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+	logrus.Infof("extra directives of the user %s are updated", username)
+	return nil
+}
@@ -62,13 +62,25 @@ func netListAction(rpcServURLStr string) error {
 				usernameList = usernameList + fmt.Sprintf("%s, ", uname)
 			}
 		}
+		for _, pool := range network.AssociatedPools {
+			if usernameList != "" {
+				usernameList = usernameList + ", "
+			}
+			usernameList = usernameList + fmt.Sprintf("pool:%s", pool)
+		}
 		var cidr = network.Cidr
 		var via = network.Via
 		if via == "" {
 			via = "vpn-server"
 		}
-		if ovpm.NetworkTypeFromString(network.Type) == ovpm.ROUTE {
+		switch ovpm.NetworkTypeFromString(network.Type) {
+		case ovpm.ROUTE:
 			cidr = fmt.Sprintf("%s via %s", network.Cidr, via)
+		case ovpm.SITE:
+			cidr = fmt.Sprintf("%s via user:%s", network.Cidr, network.Router)
+			if network.PushRoute {
+				cidr = cidr + " (pushed)"
+			}
 		}
 		data := []string{fmt.Sprintf("%v", i+1), network.Name, cidr, network.Type, usernameList, network.CreatedAt}
 		table.Append(data)
@@ -146,7 +158,7 @@ func netUndefAction(rpcServURLStr string, networkName string) error {
 	return nil
 }
 
-func netDefAction(rpcServURLStr string, netName string, netCIDR string, netType string, via *string) error {
+func netDefAction(rpcServURLStr string, netName string, netCIDR string, netType string, via *string, router string, push bool, dns dnsParams) error {
 	// Parse RPC Server's URL.
 	rpcSrvURL, err := url.Parse(rpcServURLStr)
 	if err != nil {
@@ -165,8 +177,8 @@ func netDefAction(rpcServURLStr string, netName string, netCIDR string, netType
 	switch ovpm.NetworkTypeFromString(netType) {
 	case ovpm.ROUTE:
 		if via != nil {
-			if !govalidator.IsIPv4(*via) {
-				err := errors.NotIPv4(*via)
+			if !govalidator.IsIP(*via) {
+				err := errors.NotIP(*via)
 				exit(1)
 				return err
 			}
@@ -178,6 +190,12 @@ func netDefAction(rpcServURLStr string, netName string, netCIDR string, netType
 			exit(1)
 			return err
 		}
+	case ovpm.SITE:
+		if via != nil {
+			err := errors.ConflictingDemands("--via flag can only be used with --type ROUTE")
+			exit(1)
+			return err
+		}
 	default: // Means UNDEFINEDNET
 		fmt.Printf("undefined network type %s", netType)
 		fmt.Println()
@@ -192,7 +210,17 @@ func netDefAction(rpcServURLStr string, netName string, netCIDR string, netType
 	var netSvc = pb.NewNetworkServiceClient(rpcConn)
 
 	// Call the service.
-	netCreateResp, err := netSvc.Create(context.Background(), &pb.NetworkCreateRequest{Name: netName, Cidr: netCIDR, Type: netType, Via: targetVia})
+	netCreateResp, err := netSvc.Create(context.Background(), &pb.NetworkCreateRequest{
+		Name:      netName,
+		Cidr:      netCIDR,
+		Type:      netType,
+		Via:       targetVia,
+		Dns:       dns.servers,
+		DnsDomain: dns.domain,
+		DnsSearch: dns.search,
+		Router:    router,
+		PushRoute: push,
+	})
 	if err != nil {
 		logrus.Errorf("network can not be created '%s': %v", netName, err)
 		exit(1)
@@ -202,7 +230,7 @@ func netDefAction(rpcServURLStr string, netName string, netCIDR string, netType
 	return nil
 }
 
-func netAssocAction(rpcServURLStr string, netName string, username string, inBulk bool) error {
+func netAssocAction(rpcServURLStr string, netName string, username string, pool string, inBulk bool) error {
 	// Parse RPC Server's URL.
 	rpcSrvURL, err := url.Parse(rpcServURLStr)
 	if err != nil {
@@ -220,6 +248,17 @@ func netAssocAction(rpcServURLStr string, netName string, username string, inBul
 	// Prepare service callable.
 	var netSvc = pb.NewNetworkServiceClient(rpcConn)
 
+	if pool != "" {
+		_, err = netSvc.Associate(context.Background(), &pb.NetworkAssociateRequest{Name: netName, Pool: pool})
+		if err != nil {
+			err := errors.UnknownGRPCError(err)
+			exit(1)
+			return err
+		}
+		logrus.Infof("network associated: pool:%s <-> network:%s", pool, netName)
+		return nil
+	}
+
 	userNames := []string{username}
 	if inBulk {
 		var userSvc = pb.NewUserServiceClient(rpcConn)
@@ -248,7 +287,7 @@ func netAssocAction(rpcServURLStr string, netName string, username string, inBul
 	return nil
 }
 
-func netDissocAction(rpcServURLStr string, netName string, username string, inBulk bool) error {
+func netDissocAction(rpcServURLStr string, netName string, username string, pool string, inBulk bool) error {
 	// Parse RPC Server's URL.
 	rpcSrvURL, err := url.Parse(rpcServURLStr)
 	if err != nil {
@@ -266,6 +305,17 @@ func netDissocAction(rpcServURLStr string, netName string, username string, inBu
 	// Prepare service callable.
 	var netSvc = pb.NewNetworkServiceClient(rpcConn)
 
+	if pool != "" {
+		_, err = netSvc.Dissociate(context.Background(), &pb.NetworkDissociateRequest{Name: netName, Pool: pool})
+		if err != nil {
+			err := errors.UnknownGRPCError(err)
+			exit(1)
+			return err
+		}
+		logrus.Infof("network dissociated: pool:%s <-> network:%s", pool, netName)
+		return nil
+	}
+
 	userNames := []string{username}
 	if inBulk {
 		var userSvc = pb.NewUserServiceClient(rpcConn)
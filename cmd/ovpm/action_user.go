@@ -39,8 +39,8 @@ func userListAction(rpcServURLStr string) error {
 	var userSvc = pb.NewUserServiceClient(rpcConn)
 	var vpnSvc = pb.NewVPNServiceClient(rpcConn)
 
-	// Request vpn status and user list from the services.
-	vpnStatusResp, err := vpnSvc.Status(context.Background(), &pb.VPNStatusRequest{})
+	// Request vpn servers and user list from the services.
+	vpnListResp, err := vpnSvc.List(context.Background(), &pb.VPNListRequest{})
 	if err != nil {
 		err := errors.UnknownGRPCError(err)
 		exit(1)
@@ -53,8 +53,15 @@ func userListAction(rpcServURLStr string) error {
 		return err
 	}
 
+	// Serial numbers of the servers, to check whether the user certs are
+	// signed by their current server.
+	serverSerials := make(map[string]string)
+	for _, server := range vpnListResp.Servers {
+		serverSerials[server.Name] = server.SerialNumber
+	}
+
 	// Prepare table data.
-	header := []string{"#", "username", "ip", "created", "crt exp", "push gw", "admin"}
+	header := []string{"#", "username", "ip", "server", "created", "crt exp", "push gw", "admin"}
 	rows := [][]string{}
 	for i, user := range userListResp.Users {
 		isConnected := " "
@@ -71,7 +78,7 @@ func userListAction(rpcServURLStr string) error {
 		}
 
 		isValidCRT := "✘"
-		if user.ServerSerialNumber == vpnStatusResp.SerialNumber {
+		if user.ServerSerialNumber == serverSerials[user.Server] {
 			expiresAt, err := time.Parse(time.RFC3339, user.ExpiresAt)
 			if err != nil {
 				exit(1)
@@ -94,10 +101,19 @@ func userListAction(rpcServURLStr string) error {
 			createdAt = humanize.Time(t)
 		}
 
+		ipNet := fmt.Sprintf("%s %s", user.IpNet, static)
+		if user.Pool != "" {
+			ipNet += fmt.Sprintf(" (%s)", user.Pool)
+		}
+		if user.Ipv6Net != "" {
+			ipNet += "\n" + user.Ipv6Net
+		}
+
 		row := []string{
 			fmt.Sprintf("%v", i+1),
 			isConnected + " " + user.Username,
-			fmt.Sprintf("%s %s", user.IpNet, static),
+			ipNet,
+			user.Server,
 			createdAt,
 			isValidCRT,
 			isPushGW,
@@ -116,7 +132,7 @@ func userListAction(rpcServURLStr string) error {
 }
 
 // userCreateAction creates a new VPN user from the terminal.
-func userCreateAction(rpcSrvURLStr string, username string, password string, ipAddr *net.IP, noGW bool, isAdmin bool) error {
+func userCreateAction(rpcSrvURLStr string, username string, password string, ipAddr *net.IP, noGW bool, isAdmin bool, server string, pool string) error {
 	// Parse RPC Server's URL.
 	rpcSrvURL, err := url.Parse(rpcSrvURLStr)
 	if err != nil {
@@ -159,6 +175,8 @@ func userCreateAction(rpcSrvURLStr string, username string, password string, ipA
 		NoGw:     noGW,
 		HostId:   hostid,
 		IsAdmin:  isAdmin,
+		Server:   server,
+		Pool:     pool,
 	})
 	if err != nil {
 		err := errors.UnknownGRPCError(err)
@@ -171,7 +189,7 @@ func userCreateAction(rpcSrvURLStr string, username string, password string, ipA
 }
 
 // userUpdateAction creates a new VPN user from the terminal.
-func userUpdateAction(rpcSrvURLStr string, username string, password *string, ipAddr *net.IP, isStatic *bool, noGW *bool, isAdmin *bool, inBulk bool) error {
+func userUpdateAction(rpcSrvURLStr string, username string, password *string, ipAddr *net.IP, isStatic *bool, noGW *bool, isAdmin *bool, server string, pool string, dns dnsParams, inBulk bool) error {
 	// Parse RPC Server's URL.
 	rpcSrvURL, err := url.Parse(rpcSrvURLStr)
 	if err != nil {
@@ -262,6 +280,11 @@ func userUpdateAction(rpcSrvURLStr string, username string, password *string, ip
 			StaticPref: targetStaticPref,
 			HostId:     targetHostid,
 			AdminPref:  targetAdminPref,
+			Server:     server,
+			Pool:       pool,
+			Dns:        dns.servers,
+			DnsDomain:  dns.domain,
+			DnsSearch:  dns.search,
 		})
 		if err != nil {
 			err := errors.UnknownGRPCError(err)
@@ -336,6 +359,36 @@ func userRenewAction(rpcSrvURLStr string, username string) error {
 	return nil
 }
 
+func userKickAction(rpcSrvURLStr string, username string) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcSrvURLStr)
+	if err != nil {
+		return errors.BadURL(rpcSrvURLStr, err)
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare a service caller.
+	var userSvc = pb.NewUserServiceClient(rpcConn)
+
+	// Send a user disconnect request to the server.
+	userDisconnectResp, err := userSvc.Disconnect(context.Background(), &pb.UserDisconnectRequest{Username: username})
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+
+	logrus.Infof("user disconnected: %s", userDisconnectResp.Users[0].Username)
+	return nil
+}
+
 // userGenconfigAction generates ovpn configs for a VPN user.
 func userGenconfigAction(rpcSrvURLStr string, username string, outPath *string) error {
 	// Parse RPC Server's URL.
@@ -381,3 +434,174 @@ func userGenconfigAction(rpcSrvURLStr string, username string, outPath *string)
 	logrus.Infof("exported to %s", *outPath)
 	return nil
 }
+
+// userImportAction creates the users of a csv or json file, and prints the
+// rows that fail.
+func userImportAction(rpcSrvURLStr string, path string, format string, dryRun bool, atomic bool) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcSrvURLStr)
+	if err != nil {
+		return errors.BadURL(rpcSrvURLStr, err)
+	}
+
+	// Read the users from the file.
+	file, err := os.Open(path)
+	if err != nil {
+		err := errors.UnknownFileIOError(err)
+		exit(1)
+		return err
+	}
+	defer file.Close()
+	records, err := ovpm.ReadUserRecords(file, format)
+	if err != nil {
+		err := errors.UnknownFileIOError(err)
+		exit(1)
+		return err
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Stream the users to the server.
+	var userSvc = pb.NewUserServiceClient(rpcConn)
+	stream, err := userSvc.Import(context.Background())
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+	for _, rec := range records {
+		req := &pb.UserImportRequest{
+			User: &pb.UserRecord{
+				Username:    rec.Username,
+				Password:    rec.Password,
+				Description: rec.Description,
+				NoGw:        rec.NoGW,
+				StaticIp:    rec.StaticIP,
+				IsAdmin:     rec.Admin,
+				Networks:    rec.Networks,
+				Server:      rec.Server,
+				Pool:        rec.Pool,
+			},
+			DryRun: dryRun,
+			Atomic: atomic,
+		}
+		if err := stream.Send(req); err != nil {
+			break // The error is received below.
+		}
+	}
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+
+	// Print the rows that failed.
+	if len(resp.Errors) > 0 {
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"row", "username", "error"})
+		for _, e := range resp.Errors {
+			table.Append([]string{fmt.Sprintf("%d", e.Row), e.Username, e.Error})
+		}
+		table.Render()
+	}
+
+	switch {
+	case resp.DryRun:
+		logrus.Infof("%d of %d user(s) are valid", len(records)-len(resp.Errors), len(records))
+	case atomic && len(resp.Errors) > 0:
+		logrus.Errorf("no users are imported, since %d of them failed", len(resp.Errors))
+	default:
+		logrus.Infof("%d of %d user(s) are imported", len(resp.Created), len(records))
+	}
+	if len(resp.Errors) > 0 {
+		exit(1)
+	}
+	return nil
+}
+
+// userExportAction writes the users, along with their network associations,
+// in a file that userImportAction can read.
+func userExportAction(rpcSrvURLStr string, path string, format string) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcSrvURLStr)
+	if err != nil {
+		return errors.BadURL(rpcSrvURLStr, err)
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Get services.
+	var userSvc = pb.NewUserServiceClient(rpcConn)
+	var netSvc = pb.NewNetworkServiceClient(rpcConn)
+
+	userListResp, err := userSvc.List(context.Background(), &pb.UserListRequest{})
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+	netListResp, err := netSvc.List(context.Background(), &pb.NetworkListRequest{})
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+
+	// Networks of the users by their names.
+	userNetworks := make(map[string][]string)
+	for _, network := range netListResp.Networks {
+		for _, username := range network.AssociatedUsernames {
+			userNetworks[username] = append(userNetworks[username], network.Name)
+		}
+	}
+
+	var records []ovpm.UserRecord
+	for _, user := range userListResp.Users {
+		rec := ovpm.UserRecord{
+			Username:    user.Username,
+			Description: user.Description,
+			NoGW:        user.NoGw,
+			Admin:       user.IsAdmin,
+			Networks:    userNetworks[user.Username],
+			Server:      user.Server,
+			Pool:        user.Pool,
+		}
+		if user.HostId != 0 {
+			rec.StaticIP = ovpm.HostID2IP(user.HostId).String()
+		}
+		records = append(records, rec)
+	}
+
+	out := os.Stdout
+	if path != "" {
+		if out, err = os.Create(path); err != nil {
+			err := errors.UnknownFileIOError(err)
+			exit(1)
+			return err
+		}
+		defer out.Close()
+	}
+	if err := ovpm.WriteUserRecords(out, format, records); err != nil {
+		err := errors.UnknownFileIOError(err)
+		exit(1)
+		return err
+	}
+
+	if path != "" {
+		logrus.Infof("%d user(s) exported to %s", len(records), path)
+	}
+	return nil
+}
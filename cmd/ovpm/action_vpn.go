@@ -5,7 +5,10 @@ import (
 	"fmt"
 	"net/url"
 	"os"
+	"strings"
+	"time"
 
+	"github.com/GoldenRUS/ovpm"
 	"github.com/GoldenRUS/ovpm/api/pb"
 	"github.com/GoldenRUS/ovpm/errors"
 	"github.com/asaskevich/govalidator"
@@ -19,13 +22,30 @@ type vpnInitParams struct {
 	port             string
 	proto            pb.VPNProto
 	netCIDR          string
-	dnsAddr          string
+	ipv6Net          string
+	dns              dnsParams
+	resolver         bool
 	keepalivePeriod  string
 	keepaliveTimeout string
 	useLZO           bool
+	server           string
+	caFrom           string
+	keyAlgorithm     string
+	rsaKeySize       int
+	certValidity     int
+	dhNone           bool
+	tlsKeyMode       string
+	ciphers          vpnCipherParams
 }
 
-func vpnStatusAction(rpcServURLStr string) error {
+// vpnCipherParams are the cipher settings of a vpn server, empty ones are left as they are.
+type vpnCipherParams struct {
+	dataCiphers   string
+	authDigest    string
+	tlsVersionMin string
+}
+
+func vpnStatusAction(rpcServURLStr string, server string) error {
 	// Parse RPC Server's URL.
 	rpcSrvURL, err := url.Parse(rpcServURLStr)
 	if err != nil {
@@ -44,7 +64,7 @@ func vpnStatusAction(rpcServURLStr string) error {
 	var vpnSvc = pb.NewVPNServiceClient(rpcConn)
 
 	// Request vpn status and user list from the services.
-	vpnStatusResp, err := vpnSvc.Status(context.Background(), &pb.VPNStatusRequest{})
+	vpnStatusResp, err := vpnSvc.Status(context.Background(), &pb.VPNStatusRequest{Server: server})
 	if err != nil {
 		err := errors.UnknownGRPCError(err)
 		exit(1)
@@ -60,11 +80,28 @@ func vpnStatusAction(rpcServURLStr string) error {
 	table.Append([]string{"Proto", vpnStatusResp.Proto})
 	table.Append([]string{"Network", vpnStatusResp.Net})
 	table.Append([]string{"Netmask", vpnStatusResp.Mask})
+	table.Append([]string{"IPv6 Network", vpnStatusResp.Ipv6Net})
 	table.Append([]string{"Created At", vpnStatusResp.CreatedAt})
 	table.Append([]string{"DNS", vpnStatusResp.Dns})
+	table.Append([]string{"DNS Domain", vpnStatusResp.DnsDomain})
+	table.Append([]string{"DNS Search", vpnStatusResp.DnsSearch})
+	table.Append([]string{"Resolver", fmt.Sprintf("%t", vpnStatusResp.Resolver)})
 	table.Append([]string{"Cert Exp", vpnStatusResp.ExpiresAt})
 	table.Append([]string{"CA Cert Exp", vpnStatusResp.CaExpiresAt})
 	table.Append([]string{"Use LZO", fmt.Sprintf("%t", vpnStatusResp.UseLzo)})
+	table.Append([]string{"Key Algorithm", vpnStatusResp.KeyAlgorithm})
+	dhParams := vpnStatusResp.DhParams
+	switch {
+	case vpnStatusResp.DhParamsError != "":
+		dhParams = fmt.Sprintf("%s (%s)", dhParams, vpnStatusResp.DhParamsError)
+	case dhParams == ovpm.DHParamsGenerating:
+		dhParams = fmt.Sprintf("%s (since %s)", dhParams, vpnStatusResp.DhParamsStartedAt)
+	}
+	table.Append([]string{"DH Params", dhParams})
+	table.Append([]string{"TLS Key", vpnStatusResp.TlsKeyMode})
+	table.Append([]string{"Data Ciphers", vpnStatusResp.DataCiphers})
+	table.Append([]string{"Auth Digest", vpnStatusResp.AuthDigest})
+	table.Append([]string{"TLS Version Min", vpnStatusResp.TlsVersionMin})
 
 	table.Render()
 
@@ -90,15 +127,34 @@ func vpnInitAction(params vpnInitParams) error {
 	var vpnSvc = pb.NewVPNServiceClient(rpcConn)
 
 	// Request init request from vpn service.
+	dhPref := pb.VPNDHPref_DH_GENERATE
+	if params.dhNone {
+		dhPref = pb.VPNDHPref_DH_NONE
+	}
+
 	_, err = vpnSvc.Init(context.Background(), &pb.VPNInitRequest{
 		Hostname:         params.hostname,
 		Port:             params.port,
 		ProtoPref:        params.proto,
 		IpBlock:          params.netCIDR,
-		Dns:              params.dnsAddr,
+		Ipv6Block:        params.ipv6Net,
+		Dns:              params.dns.servers,
+		DnsDomain:        params.dns.domain,
+		DnsSearch:        params.dns.search,
+		Resolver:         params.resolver,
 		KeepalivePeriod:  params.keepalivePeriod,
 		KeepaliveTimeout: params.keepaliveTimeout,
 		UseLzo:           params.useLZO,
+		Server:           params.server,
+		CaFrom:           params.caFrom,
+		KeyAlgorithm:     params.keyAlgorithm,
+		RsaKeySize:       int32(params.rsaKeySize),
+		CertValidityDays: int32(params.certValidity),
+		DhPref:           dhPref,
+		TlsKeyMode:       params.tlsKeyMode,
+		DataCiphers:      params.ciphers.dataCiphers,
+		AuthDigest:       params.ciphers.authDigest,
+		TlsVersionMin:    params.ciphers.tlsVersionMin,
 	})
 	if err != nil {
 		err := errors.UnknownGRPCError(err)
@@ -108,6 +164,8 @@ func vpnInitAction(params vpnInitParams) error {
 
 	logrus.WithFields(logrus.Fields{
 		"SERVER":            "OpenVPN",
+		"NAME":              params.server,
+		"CA_FROM":           params.caFrom,
 		"CIDR":              params.netCIDR,
 		"PROTO":             params.proto,
 		"HOSTNAME":          params.hostname,
@@ -119,7 +177,7 @@ func vpnInitAction(params vpnInitParams) error {
 	return nil
 }
 
-func vpnUpdateAction(rpcServURLStr string, netCIDR *string, dnsAddr *string, useLzo *bool) error {
+func vpnUpdateAction(rpcServURLStr string, server string, netCIDR *string, ipv6Net string, dns dnsParams, useLzo *bool, resolverPref pb.VPNResolverPref, dhPref pb.VPNDHPref, tlsKeyMode string, ciphers vpnCipherParams) error {
 	// Parse RPC Server's URL.
 	rpcSrvURL, err := url.Parse(rpcServURLStr)
 	if err != nil {
@@ -164,15 +222,6 @@ func vpnUpdateAction(rpcServURLStr string, netCIDR *string, dnsAddr *string, use
 		targetNetCIDR = *netCIDR
 	}
 
-	// Set DNS address if provided.
-	var targetDNSAddr string
-	if dnsAddr != nil {
-		if !govalidator.IsIPv4(*dnsAddr) {
-			return errors.NotIPv4(*dnsAddr)
-		}
-		targetDNSAddr = *dnsAddr
-	}
-
 	// Set USE-LZO preference if provided.
 	var targetLZOPref pb.VPNLZOPref
 	if useLzo == nil {
@@ -191,9 +240,19 @@ func vpnUpdateAction(rpcServURLStr string, netCIDR *string, dnsAddr *string, use
 
 	// Request update request from vpn service.
 	_, err = vpnSvc.Update(context.Background(), &pb.VPNUpdateRequest{
-		IpBlock: targetNetCIDR,
-		Dns:     targetDNSAddr,
-		LzoPref: targetLZOPref,
+		IpBlock:       targetNetCIDR,
+		Ipv6Block:     ipv6Net,
+		Dns:           dns.servers,
+		DnsDomain:     dns.domain,
+		DnsSearch:     dns.search,
+		ResolverPref:  resolverPref,
+		LzoPref:       targetLZOPref,
+		Server:        server,
+		DhPref:        dhPref,
+		TlsKeyMode:    tlsKeyMode,
+		DataCiphers:   ciphers.dataCiphers,
+		AuthDigest:    ciphers.authDigest,
+		TlsVersionMin: ciphers.tlsVersionMin,
 	})
 	if err != nil {
 		err := errors.UnknownGRPCError(err)
@@ -203,15 +262,16 @@ func vpnUpdateAction(rpcServURLStr string, netCIDR *string, dnsAddr *string, use
 
 	logrus.WithFields(logrus.Fields{
 		"SERVER":  "OpenVPN",
+		"NAME":    server,
 		"CIDR":    targetNetCIDR,
-		"DNS":     targetDNSAddr,
+		"DNS":     dns.servers,
 		"USE_LZO": targetLZOPref.String(),
 	}).Infoln("changes applied")
 
 	return nil
 }
 
-func vpnRestartAction(rpcServURLStr string) error {
+func vpnRestartAction(rpcServURLStr string, server string) error {
 	// Parse RPC Server's URL.
 	rpcSrvURL, err := url.Parse(rpcServURLStr)
 	if err != nil {
@@ -230,7 +290,7 @@ func vpnRestartAction(rpcServURLStr string) error {
 	// Prepare service caller.
 	var vpnSvc = pb.NewVPNServiceClient(rpcConn)
 
-	_, err = vpnSvc.Restart(context.Background(), &pb.VPNRestartRequest{})
+	_, err = vpnSvc.Restart(context.Background(), &pb.VPNRestartRequest{Server: server})
 	if err != nil {
 		err := errors.UnknownGRPCError(err)
 		exit(1)
@@ -240,3 +300,479 @@ func vpnRestartAction(rpcServURLStr string) error {
 	logrus.Info("ovpm server restarted")
 	return nil
 }
+
+func vpnListAction(rpcServURLStr string) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcServURLStr)
+	if err != nil {
+		return errors.BadURL(rpcServURLStr, err)
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare service caller.
+	var vpnSvc = pb.NewVPNServiceClient(rpcConn)
+
+	vpnListResp, err := vpnSvc.List(context.Background(), &pb.VPNListRequest{})
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+
+	// Prepare table data and draw it on the terminal.
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"#", "name", "hostname", "port", "proto", "network", "netmask", "dns", "cert exp"})
+	for i, server := range vpnListResp.Servers {
+		table.Append([]string{fmt.Sprintf("%v", i+1), server.Name, server.Hostname, server.Port, server.Proto, server.Net, server.Mask, server.Dns, server.ExpiresAt})
+	}
+	table.Render()
+
+	return nil
+}
+
+func vpnExpiringAction(rpcServURLStr string, days int) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcServURLStr)
+	if err != nil {
+		return errors.BadURL(rpcServURLStr, err)
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare service caller.
+	var vpnSvc = pb.NewVPNServiceClient(rpcConn)
+
+	vpnExpiringResp, err := vpnSvc.Expiring(context.Background(), &pb.VPNExpiringRequest{Days: int32(days)})
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+
+	// Prepare table data and draw it on the terminal.
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"#", "server", "kind", "user", "expires at", "days left"})
+	for i, cert := range vpnExpiringResp.Certs {
+		daysLeft := "?"
+		if expiresAt, err := time.Parse(time.RFC3339, cert.ExpiresAt); err == nil {
+			daysLeft = fmt.Sprintf("%d", int(time.Until(expiresAt).Hours()/24))
+		}
+		table.Append([]string{fmt.Sprintf("%v", i+1), cert.Server, cert.Kind, cert.Username, cert.ExpiresAt, daysLeft})
+	}
+	table.Render()
+
+	return nil
+}
+
+func vpnCARotateAction(rpcServURLStr string, serverName string) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcServURLStr)
+	if err != nil {
+		return errors.BadURL(rpcServURLStr, err)
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare service caller.
+	var vpnSvc = pb.NewVPNServiceClient(rpcConn)
+
+	statusResp, err := vpnSvc.StartCARotation(context.Background(), &pb.VPNCARotationRequest{Server: serverName})
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+	logrus.Infof("CA rotation is started, %d user(s) should fetch their new profiles before it's finished", len(statusResp.Pending))
+	return nil
+}
+
+func vpnCAStatusAction(rpcServURLStr string, serverName string) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcServURLStr)
+	if err != nil {
+		return errors.BadURL(rpcServURLStr, err)
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare service caller.
+	var vpnSvc = pb.NewVPNServiceClient(rpcConn)
+
+	statusResp, err := vpnSvc.CARotationStatus(context.Background(), &pb.VPNCARotationRequest{Server: serverName})
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+	if !statusResp.Rotating {
+		logrus.Info("CA rotation is not started")
+		return nil
+	}
+
+	// Prepare table data and draw it on the terminal.
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"attribute", "value"})
+	table.Append([]string{"Started At", statusResp.StartedAt})
+	table.Append([]string{"New CA Expires At", statusResp.NextCaExpiresAt})
+	table.Append([]string{"Fetched", fmt.Sprintf("%d/%d", statusResp.Fetched, statusResp.Users)})
+	table.Append([]string{"Pending", strings.Join(statusResp.Pending, ", ")})
+	table.Render()
+
+	return nil
+}
+
+func vpnCAFinishAction(rpcServURLStr string, serverName string, force bool) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcServURLStr)
+	if err != nil {
+		return errors.BadURL(rpcServURLStr, err)
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare service caller.
+	var vpnSvc = pb.NewVPNServiceClient(rpcConn)
+
+	_, err = vpnSvc.FinishCARotation(context.Background(), &pb.VPNFinishCARotationRequest{Server: serverName, Force: force})
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+	logrus.Info("CA rotation is finished, the old CA is retired")
+	return nil
+}
+
+func vpnRotateTLSKeyAction(rpcServURLStr string, serverName string) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcServURLStr)
+	if err != nil {
+		return errors.BadURL(rpcServURLStr, err)
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare service caller.
+	var vpnSvc = pb.NewVPNServiceClient(rpcConn)
+
+	_, err = vpnSvc.RotateTLSKey(context.Background(), &pb.VPNRotateTLSKeyRequest{Server: serverName})
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+	logrus.Info("tls key is rotated, users should download their new profiles")
+	return nil
+}
+
+func vpnRepackLeasesAction(rpcServURLStr string, serverName string) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcServURLStr)
+	if err != nil {
+		return errors.BadURL(rpcServURLStr, err)
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare service caller.
+	var vpnSvc = pb.NewVPNServiceClient(rpcConn)
+
+	res, err := vpnSvc.RepackLeases(context.Background(), &pb.VPNRepackLeasesRequest{Server: serverName})
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+	if len(res.Changes) == 0 {
+		logrus.Info("leases are already packed, no address is changed")
+		return nil
+	}
+
+	// Prepare table data and draw it on the terminal.
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"#", "username", "old ip", "new ip"})
+	for i, change := range res.Changes {
+		table.Append([]string{fmt.Sprintf("%v", i+1), change.Username, change.OldIp, change.NewIp})
+	}
+	table.Render()
+	logrus.Infof("%d user(s) got new addresses, they should reconnect", len(res.Changes))
+	return nil
+}
+
+func vpnPoolCreateAction(rpcServURLStr string, serverName string, name string, cidr string) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcServURLStr)
+	if err != nil {
+		return errors.BadURL(rpcServURLStr, err)
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare service caller.
+	var vpnSvc = pb.NewVPNServiceClient(rpcConn)
+
+	pool, err := vpnSvc.CreatePool(context.Background(), &pb.VPNCreatePoolRequest{Server: serverName, Name: name, Cidr: cidr})
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+	logrus.Infof("pool created: %s (%s)", pool.Name, pool.Cidr)
+	return nil
+}
+
+func vpnPoolListAction(rpcServURLStr string, serverName string) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcServURLStr)
+	if err != nil {
+		return errors.BadURL(rpcServURLStr, err)
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare service caller.
+	var vpnSvc = pb.NewVPNServiceClient(rpcConn)
+
+	res, err := vpnSvc.ListPools(context.Background(), &pb.VPNListPoolsRequest{Server: serverName})
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+
+	// Prepare table data and draw it on the terminal.
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"#", "name", "cidr", "server", "users", "created at"})
+	for i, pool := range res.Pools {
+		table.Append([]string{fmt.Sprintf("%v", i+1), pool.Name, pool.Cidr, pool.Server, strings.Join(pool.Usernames, ", "), pool.CreatedAt})
+	}
+	table.Render()
+	return nil
+}
+
+func vpnPoolDeleteAction(rpcServURLStr string, name string) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcServURLStr)
+	if err != nil {
+		return errors.BadURL(rpcServURLStr, err)
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare service caller.
+	var vpnSvc = pb.NewVPNServiceClient(rpcConn)
+
+	_, err = vpnSvc.DeletePool(context.Background(), &pb.VPNDeletePoolRequest{Name: name})
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+	logrus.Infof("pool deleted: %s", name)
+	return nil
+}
+
+func vpnACLAddAction(rpcServURLStr string, username string, pool string, action string, dest string, proto string, port string) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcServURLStr)
+	if err != nil {
+		return errors.BadURL(rpcServURLStr, err)
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare service caller.
+	var vpnSvc = pb.NewVPNServiceClient(rpcConn)
+
+	rule, err := vpnSvc.AddACLRule(context.Background(), &pb.VPNAddACLRuleRequest{
+		Username:    username,
+		Pool:        pool,
+		Action:      action,
+		Destination: dest,
+		Proto:       proto,
+		Port:        port,
+	})
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+	logrus.Infof("acl rule added: #%d", rule.Id)
+	return nil
+}
+
+func vpnACLListAction(rpcServURLStr string) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcServURLStr)
+	if err != nil {
+		return errors.BadURL(rpcServURLStr, err)
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare service caller.
+	var vpnSvc = pb.NewVPNServiceClient(rpcConn)
+
+	res, err := vpnSvc.ListACLRules(context.Background(), &pb.VPNListACLRulesRequest{})
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+
+	// Prepare table data and draw it on the terminal.
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"id", "action", "user/pool", "destination", "proto", "port", "created at"})
+	for _, rule := range res.Rules {
+		subject := rule.Username
+		if rule.Pool != "" {
+			subject = fmt.Sprintf("pool:%s", rule.Pool)
+		}
+		table.Append([]string{fmt.Sprintf("%v", rule.Id), rule.Action, subject, rule.Destination, rule.Proto, rule.Port, rule.CreatedAt})
+	}
+	table.Render()
+	return nil
+}
+
+func vpnACLDeleteAction(rpcServURLStr string, id uint32) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcServURLStr)
+	if err != nil {
+		return errors.BadURL(rpcServURLStr, err)
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare service caller.
+	var vpnSvc = pb.NewVPNServiceClient(rpcConn)
+
+	_, err = vpnSvc.DeleteACLRule(context.Background(), &pb.VPNDeleteACLRuleRequest{Id: id})
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+	logrus.Infof("acl rule deleted: #%d", id)
+	return nil
+}
+
+func vpnFirewallShowAction(rpcServURLStr string) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcServURLStr)
+	if err != nil {
+		return errors.BadURL(rpcServURLStr, err)
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare service caller.
+	var vpnSvc = pb.NewVPNServiceClient(rpcConn)
+
+	res, err := vpnSvc.ShowFirewall(context.Background(), &pb.VPNShowFirewallRequest{})
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+
+	fmt.Printf("backend: %s\n", res.Backend)
+	for _, family := range []struct {
+		name  string
+		rules []string
+	}{{"IPv4", res.Ipv4Rules}, {"IPv6", res.Ipv6Rules}} {
+		fmt.Printf("\n# %s\n", family.name)
+		if len(family.rules) == 0 {
+			fmt.Println("# (no rules)")
+			continue
+		}
+		for _, rule := range family.rules {
+			fmt.Println(rule)
+		}
+	}
+	return nil
+}
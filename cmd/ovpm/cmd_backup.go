@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/GoldenRUS/ovpm"
+	"github.com/GoldenRUS/ovpm/errors"
+	"github.com/asaskevich/govalidator"
+	"github.com/urfave/cli"
+)
+
+// envBackupPassphrase is the environment variable that the passphrase
+// of the backups can be passed through instead of the command line.
+const envBackupPassphrase = "OVPM_BACKUP_PASSPHRASE"
+
+var backupCommand = cli.Command{
+	Name:  "backup",
+	Usage: "Back the whole state of ovpm up into a file.",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "file, f",
+			Usage: "path of the backup file to create, - for stdout",
+		},
+		cli.StringFlag{
+			Name:   "passphrase, p",
+			Usage:  "encrypt the backup with the passphrase",
+			EnvVar: envBackupPassphrase,
+		},
+	},
+	Action: func(c *cli.Context) error {
+		action = "backup"
+
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		if file := c.String("file"); govalidator.IsNull(file) {
+			err := errors.EmptyValue("file", file)
+			exit(1)
+			return err
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return backupAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), c.String("file"), c.String("passphrase"))
+	},
+}
+
+var restoreCommand = cli.Command{
+	Name:  "restore",
+	Usage: "Restore the state of ovpm from a backup file.",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "file, f",
+			Usage: "path of the backup file to restore, - for stdin",
+		},
+		cli.StringFlag{
+			Name:   "passphrase, p",
+			Usage:  "passphrase of the encrypted backup",
+			EnvVar: envBackupPassphrase,
+		},
+		cli.BoolFlag{
+			Name:  "force",
+			Usage: "overwrite the existing servers, users and networks",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		action = "restore"
+
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		if file := c.String("file"); govalidator.IsNull(file) {
+			err := errors.EmptyValue("file", file)
+			exit(1)
+			return err
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return restoreAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), c.String("file"), c.String("passphrase"), c.Bool("force"))
+	},
+}
+
+func init() {
+	app.Commands = append(app.Commands,
+		backupCommand,
+		restoreCommand,
+	)
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/GoldenRUS/ovpm"
+	"github.com/GoldenRUS/ovpm/api/pb"
+	"github.com/GoldenRUS/ovpm/errors"
+	"github.com/asaskevich/govalidator"
+	"github.com/urfave/cli"
+)
+
+// directivesFileFlags are the flags that provide the content of a directives block.
+var directivesFileFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "file, f",
+		Usage: "path of the file to read the directives from, - for stdin",
+	},
+	cli.BoolFlag{
+		Name:  "clear",
+		Usage: "remove all of the extra directives",
+	},
+}
+
+// checkDirectivesFileFlags validates that exactly one of --file and --clear is given.
+func checkDirectivesFileFlags(c *cli.Context) error {
+	file := c.String("file")
+	if govalidator.IsNull(file) && !c.Bool("clear") {
+		return errors.EmptyValue("file", file)
+	}
+	if !govalidator.IsNull(file) && c.Bool("clear") {
+		return errors.ConflictingDemands("--file and --clear flags are mutually exclusive")
+	}
+	return nil
+}
+
+var vpnDirectivesShowCommand = cli.Command{
+	Name:    "show",
+	Usage:   "Show the extra directives of the VPN server.",
+	Aliases: []string{"s"},
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "server",
+			Usage: fmt.Sprintf("name of the vpn server (default: %s)", ovpm.DefaultServerName),
+		},
+	},
+	Action: func(c *cli.Context) error {
+		action = "vpn:directives:show"
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return vpnDirectivesShowAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), c.String("server"))
+	},
+}
+
+var vpnDirectivesSetCommand = cli.Command{
+	Name:    "set",
+	Usage:   "Replace the extra directives of the server.conf or the client profiles.",
+	Aliases: []string{"e"},
+	Flags: append([]cli.Flag{
+		cli.StringFlag{
+			Name:  "scope",
+			Usage: fmt.Sprintf("where the directives are rendered: %s or %s", ovpm.ServerConfScope, ovpm.ClientProfileScope),
+			Value: string(ovpm.ServerConfScope),
+		},
+		cli.StringFlag{
+			Name:  "server",
+			Usage: fmt.Sprintf("name of the vpn server (default: %s)", ovpm.DefaultServerName),
+		},
+	}, directivesFileFlags...),
+	Action: func(c *cli.Context) error {
+		action = "vpn:directives:set"
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		var scope pb.VPNDirectiveScope
+		switch ovpm.DirectiveScope(c.String("scope")) {
+		case ovpm.ServerConfScope:
+			scope = pb.VPNDirectiveScope_SERVER_CONF
+		case ovpm.ClientProfileScope:
+			scope = pb.VPNDirectiveScope_CLIENT_PROFILE
+		default:
+			err := errors.ConflictingDemands(fmt.Sprintf("--scope should be either %s or %s", ovpm.ServerConfScope, ovpm.ClientProfileScope))
+			exit(1)
+			return err
+		}
+
+		if err := checkDirectivesFileFlags(c); err != nil {
+			exit(1)
+			return err
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return vpnDirectivesSetAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), c.String("server"), scope, c.String("file"))
+	},
+}
+
+var vpnDirectivesCommand = cli.Command{
+	Name:  "directives",
+	Usage: "Extra directives of the server.conf and the client profiles.",
+	Subcommands: []cli.Command{
+		vpnDirectivesShowCommand,
+		vpnDirectivesSetCommand,
+	},
+}
+
+var userDirectivesShowCmd = cli.Command{
+	Name:    "show",
+	Usage:   "Show the extra directives of the user's ccd file.",
+	Aliases: []string{"s"},
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "user, u",
+			Usage: "username of the vpn user",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		action = "user:directives:show"
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		// Validate username.
+		if username := c.String("user"); govalidator.IsNull(username) {
+			return errors.EmptyValue("username", username)
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return userDirectivesShowAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), c.String("user"))
+	},
+}
+
+var userDirectivesSetCmd = cli.Command{
+	Name:    "set",
+	Usage:   "Replace the extra directives of the user's ccd file.",
+	Aliases: []string{"e"},
+	Flags: append([]cli.Flag{
+		cli.StringFlag{
+			Name:  "user, u",
+			Usage: "username of the vpn user",
+		},
+	}, directivesFileFlags...),
+	Action: func(c *cli.Context) error {
+		action = "user:directives:set"
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		// Validate username.
+		if username := c.String("user"); govalidator.IsNull(username) {
+			return errors.EmptyValue("username", username)
+		}
+
+		if err := checkDirectivesFileFlags(c); err != nil {
+			exit(1)
+			return err
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return userDirectivesSetAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), c.String("user"), c.String("file"))
+	},
+}
+
+var userDirectivesCmd = cli.Command{
+	Name:  "directives",
+	Usage: "Extra directives of the user's ccd file.",
+	Subcommands: []cli.Command{
+		userDirectivesShowCmd,
+		userDirectivesSetCmd,
+	},
+}
@@ -30,6 +30,26 @@ var netDefineCommand = cli.Command{
 			Name:  "via, v",
 			Usage: "if network type is route, via represents route's gateway",
 		},
+		cli.StringFlag{
+			Name:  "router, r",
+			Usage: "if network type is site, router is the user that the network is behind",
+		},
+		cli.BoolFlag{
+			Name:  "push",
+			Usage: "if network type is site, push the network to the associated users",
+		},
+		cli.StringFlag{
+			Name:  "dns",
+			Usage: "comma separated DNS servers to push to the associated users",
+		},
+		cli.StringFlag{
+			Name:  "dns-domain",
+			Usage: "DOMAIN option to push to the associated users",
+		},
+		cli.StringFlag{
+			Name:  "dns-search",
+			Usage: "comma separated DOMAIN-SEARCH options to push to the associated users",
+		},
 	},
 	Action: func(c *cli.Context) error {
 		action = "net:create"
@@ -63,6 +83,19 @@ var netDefineCommand = cli.Command{
 			}
 		}
 
+		// Validate if router can be set.
+		if ovpm.NetworkTypeFromString(c.String("type")) == ovpm.SITE {
+			if netRouter := c.String("router"); govalidator.IsNull(netRouter) {
+				err := errors.EmptyValue("router", netRouter)
+				exit(1)
+				return err
+			}
+		} else if !govalidator.IsNull(c.String("router")) || c.Bool("push") {
+			err := errors.ConflictingDemands("--router and --push flags can only be used with --type SITE")
+			exit(1)
+			return err
+		}
+
 		// Validate network CIDR.
 		if netCIDR := c.String("cidr"); !govalidator.IsCIDR(netCIDR) {
 			err := errors.NotCIDR(netCIDR)
@@ -76,12 +109,19 @@ var netDefineCommand = cli.Command{
 			via = &tmp
 		}
 
+		// Validate DNS settings if provided.
+		dns, err := readDNSParams(c, false)
+		if err != nil {
+			exit(1)
+			return err
+		}
+
 		// If dry run, then don't call the action, just preprocess.
 		if c.GlobalBool("dry-run") {
 			return nil
 		}
 
-		return netDefAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), c.String("name"), c.String("cidr"), c.String("type"), via)
+		return netDefAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), c.String("name"), c.String("cidr"), c.String("type"), via, c.String("router"), c.Bool("push"), dns)
 	},
 }
 
@@ -166,7 +206,7 @@ var netUndefineCommand = cli.Command{
 var netAssociateCommand = cli.Command{
 	Name:    "assoc",
 	Aliases: []string{"a"},
-	Usage:   "Associate a user with a network.",
+	Usage:   "Associate a user or an address pool with a network.",
 	Flags: []cli.Flag{
 		cli.StringFlag{
 			Name:  "net, n",
@@ -177,6 +217,10 @@ var netAssociateCommand = cli.Command{
 			Name:  "user, u",
 			Usage: "name of the user",
 		},
+		cli.StringFlag{
+			Name:  "pool, p",
+			Usage: "name of the address pool, instead of a user",
+		},
 	},
 	Action: func(c *cli.Context) error {
 		action = "net:associate"
@@ -195,7 +239,13 @@ var netAssociateCommand = cli.Command{
 			exit(1)
 			return err
 		}
-		if username := c.String("user"); govalidator.IsNull(username) {
+		if !govalidator.IsNull(c.String("pool")) {
+			if !govalidator.IsNull(c.String("user")) {
+				err := errors.ConflictingDemands("--user and --pool flags can not be used together")
+				exit(1)
+				return err
+			}
+		} else if username := c.String("user"); govalidator.IsNull(username) {
 			err := errors.EmptyValue("username", username)
 			exit(1)
 			return err
@@ -211,14 +261,14 @@ var netAssociateCommand = cli.Command{
 			return nil
 		}
 
-		return netAssocAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), c.String("net"), c.String("user"), inBulk)
+		return netAssocAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), c.String("net"), c.String("user"), c.String("pool"), inBulk)
 	},
 }
 
 var netDissociateCommand = cli.Command{
 	Name:    "dissoc",
 	Aliases: []string{"di"},
-	Usage:   "Dissociate a user from a network.",
+	Usage:   "Dissociate a user or an address pool from a network.",
 	Flags: []cli.Flag{
 		cli.StringFlag{
 			Name:  "net, n",
@@ -229,6 +279,10 @@ var netDissociateCommand = cli.Command{
 			Name:  "user, u",
 			Usage: "name of the user",
 		},
+		cli.StringFlag{
+			Name:  "pool, p",
+			Usage: "name of the address pool, instead of a user",
+		},
 	},
 	Action: func(c *cli.Context) error {
 		action = "net:dissociate"
@@ -247,7 +301,13 @@ var netDissociateCommand = cli.Command{
 			exit(1)
 			return err
 		}
-		if username := c.String("user"); govalidator.IsNull(username) {
+		if !govalidator.IsNull(c.String("pool")) {
+			if !govalidator.IsNull(c.String("user")) {
+				err := errors.ConflictingDemands("--user and --pool flags can not be used together")
+				exit(1)
+				return err
+			}
+		} else if username := c.String("user"); govalidator.IsNull(username) {
 			err := errors.EmptyValue("username", username)
 			exit(1)
 			return err
@@ -263,7 +323,7 @@ var netDissociateCommand = cli.Command{
 			return nil
 		}
 
-		return netDissocAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), c.String("net"), c.String("user"), inBulk)
+		return netDissocAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), c.String("net"), c.String("user"), c.String("pool"), inBulk)
 	},
 }
 
@@ -59,6 +59,14 @@ var userCreateCmd = cli.Command{
 			Name:  "admin, a",
 			Usage: "this user has admin rights",
 		},
+		cli.StringFlag{
+			Name:  "server",
+			Usage: fmt.Sprintf("name of the vpn server to create the user on (default: %s)", ovpm.DefaultServerName),
+		},
+		cli.StringFlag{
+			Name:  "pool",
+			Usage: "name of the address pool to lease the user an ip address from",
+		},
 	},
 	// userCreate action has two modes. Bulk mode
 	Action: func(c *cli.Context) error {
@@ -107,6 +115,8 @@ var userCreateCmd = cli.Command{
 			ipAddr,
 			c.Bool("no-gw"),
 			c.Bool("admin"),
+			c.String("server"),
+			c.String("pool"),
 		)
 	},
 }
@@ -159,6 +169,26 @@ var userUpdateCmd = cli.Command{
 			Name:  "no-admin",
 			Usage: "this user has no admin rights",
 		},
+		cli.StringFlag{
+			Name:  "server",
+			Usage: "name of the vpn server to move the user to",
+		},
+		cli.StringFlag{
+			Name:  "pool",
+			Usage: fmt.Sprintf("name of the address pool to move the user to, or %s for the shared range", ovpm.NoPool),
+		},
+		cli.StringFlag{
+			Name:  "dns",
+			Usage: fmt.Sprintf("comma separated DNS servers to push to the user instead of the server's, or %s to clear them", ovpm.DNSNone),
+		},
+		cli.StringFlag{
+			Name:  "dns-domain",
+			Usage: fmt.Sprintf("DOMAIN option to push to the user instead of the server's, or %s to clear it", ovpm.DNSNone),
+		},
+		cli.StringFlag{
+			Name:  "dns-search",
+			Usage: fmt.Sprintf("comma separated DOMAIN-SEARCH options to push to the user instead of the server's, or %s to clear them", ovpm.DNSNone),
+		},
 	},
 	Action: func(c *cli.Context) error {
 		action = "user:update"
@@ -262,6 +292,13 @@ var userUpdateCmd = cli.Command{
 			isAdmin = &tmp
 		}
 
+		// Set DNS settings if provided.
+		dns, err := readDNSParams(c, true)
+		if err != nil {
+			exit(1)
+			return err
+		}
+
 		// If dry run, then don't call the action, just preprocess.
 		if c.GlobalBool("dry-run") {
 			return nil
@@ -274,6 +311,9 @@ var userUpdateCmd = cli.Command{
 			isStatic,
 			noGW,
 			isAdmin,
+			c.String("server"),
+			c.String("pool"),
+			dns,
 			inBulk,
 		)
 	},
@@ -343,6 +383,38 @@ var userRenewCmd = cli.Command{
 	},
 }
 
+var userKickCmd = cli.Command{
+	Name:    "kick",
+	Usage:   "Disconnect active VPN sessions of the user.",
+	Aliases: []string{"k"},
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "user, u",
+			Usage: "username of the vpn user",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		action = "user:kick"
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		// Validate username.
+		if username := c.String("user"); govalidator.IsNull(username) {
+			return errors.EmptyValue("username", username)
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return userKickAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), c.String("user"))
+	},
+}
+
 var userGenconfigCmd = cli.Command{
 	Name:    "genconfig",
 	Usage:   "Generate client config for the user. (.ovpn file)",
@@ -385,6 +457,99 @@ var userGenconfigCmd = cli.Command{
 	},
 }
 
+// userImportCmd creates the users of a csv or json file in bulk.
+var userImportCmd = cli.Command{
+	Name:    "import",
+	Usage:   "Create VPN users from a csv or json file.",
+	Aliases: []string{"i"},
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "file, f",
+			Usage: "path of the file to import (required)",
+		},
+		cli.StringFlag{
+			Name:  "format",
+			Usage: fmt.Sprintf("format of the file, %s or %s (default: by the file extension)", ovpm.CSVFormat, ovpm.JSONFormat),
+		},
+		cli.BoolFlag{
+			Name:  "validate",
+			Usage: "only validate the users, don't create them",
+		},
+		cli.BoolFlag{
+			Name:  "atomic",
+			Usage: "don't create any of the users if any of them fails",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		action = "user:import"
+
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		// Validate the file and its format.
+		path := c.String("file")
+		if govalidator.IsNull(path) {
+			err := errors.EmptyValue("file", path)
+			exit(1)
+			return err
+		}
+		format, err := userFileFormat(path, c.String("format"))
+		if err != nil {
+			exit(1)
+			return err
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return userImportAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), path, format, c.Bool("validate"), c.Bool("atomic"))
+	},
+}
+
+// userExportCmd writes the users in a file that can be imported back.
+var userExportCmd = cli.Command{
+	Name:    "export",
+	Usage:   "Export VPN users to a csv or json file.",
+	Aliases: []string{"e"},
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "file, f",
+			Usage: "path of the file to export to (default: stdout)",
+		},
+		cli.StringFlag{
+			Name:  "format",
+			Usage: fmt.Sprintf("format of the file, %s or %s (default: by the file extension)", ovpm.CSVFormat, ovpm.JSONFormat),
+		},
+	},
+	Action: func(c *cli.Context) error {
+		action = "user:export"
+
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		format, err := userFileFormat(c.String("file"), c.String("format"))
+		if err != nil {
+			exit(1)
+			return err
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return userExportAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), c.String("file"), format)
+	},
+}
+
 func init() {
 	app.Commands = append(app.Commands,
 		cli.Command{
@@ -398,6 +563,10 @@ func init() {
 				userDeleteCmd,
 				userRenewCmd,
 				userGenconfigCmd,
+				userKickCmd,
+				userDirectivesCmd,
+				userImportCmd,
+				userExportCmd,
 			},
 		},
 	)
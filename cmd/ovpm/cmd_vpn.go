@@ -5,6 +5,7 @@ import (
 	"github.com/GoldenRUS/ovpm"
 	"github.com/GoldenRUS/ovpm/api/pb"
 	"github.com/GoldenRUS/ovpm/errors"
+	"github.com/GoldenRUS/ovpm/pki"
 	"github.com/asaskevich/govalidator"
 	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli"
@@ -15,6 +16,12 @@ var vpnStatusCommand = cli.Command{
 	Name:    "status",
 	Usage:   "Show VPN status.",
 	Aliases: []string{"s"},
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "server",
+			Usage: fmt.Sprintf("name of the vpn server (default: %s)", ovpm.DefaultServerName),
+		},
+	},
 	Action: func(c *cli.Context) error {
 		// Use default port if no port is specified.
 		daemonPort := ovpm.DefaultDaemonPort
@@ -27,7 +34,7 @@ var vpnStatusCommand = cli.Command{
 			return nil
 		}
 
-		return vpnStatusAction(fmt.Sprintf("grpc://localhost:%d", daemonPort))
+		return vpnStatusAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), c.String("server"))
 	},
 }
 
@@ -54,9 +61,25 @@ var vpnInitCommand = cli.Command{
 			Usage: "VPN network to give clients IP addresses from, in the CIDR form",
 			Value: ovpm.DefaultVPNNetwork,
 		},
+		cli.StringFlag{
+			Name:  "ipv6-net",
+			Usage: "IPv6 network to give clients IPv6 addresses from in addition to the IPv4 ones, in the CIDR form (e.g. fd00:9::/64)",
+		},
 		cli.StringFlag{
 			Name:  "dns, d",
-			Usage: fmt.Sprintf("DNS server to push to clients (default: %s)", ovpm.DefaultVPNDNS),
+			Usage: fmt.Sprintf("comma separated DNS servers to push to clients (default: %s)", ovpm.DefaultVPNDNS),
+		},
+		cli.StringFlag{
+			Name:  "dns-domain",
+			Usage: "domain of the vpn network to push to clients as the DOMAIN option",
+		},
+		cli.StringFlag{
+			Name:  "dns-search",
+			Usage: "comma separated search domains to push to clients as the DOMAIN-SEARCH options",
+		},
+		cli.BoolFlag{
+			Name:  "resolver",
+			Usage: fmt.Sprintf("run the built-in resolver on the vpn gateway that answers <username>.%s and forwards the other queries to the DNS servers", ovpm.DefaultResolverZone),
 		},
 		cli.StringFlag{
 			Name:  "keepalive-period",
@@ -72,6 +95,48 @@ var vpnInitCommand = cli.Command{
 			Name:  "use-lzo, l",
 			Usage: "Used to determine whether to use the deprecated lzo compression algorithm to support older clients. (default: false)",
 		},
+		cli.StringFlag{
+			Name:  "server",
+			Usage: fmt.Sprintf("name of the vpn server (default: %s)", ovpm.DefaultServerName),
+		},
+		cli.StringFlag{
+			Name:  "ca-from",
+			Usage: "name of the vpn server to share the CA with, instead of creating a new one",
+		},
+		cli.StringFlag{
+			Name:  "key-algorithm",
+			Usage: "algorithm of the generated keys: rsa, ecdsa-p256, ecdsa-p384 or ed25519",
+			Value: string(pki.RSAKey),
+		},
+		cli.IntFlag{
+			Name:  "rsa-key-size",
+			Usage: "size of the generated RSA keys in bits (default: 2048)",
+		},
+		cli.IntFlag{
+			Name:  "cert-validity",
+			Usage: "number of days the server and client certificates are valid (default: 3650)",
+		},
+		cli.BoolFlag{
+			Name:  "dh-none",
+			Usage: "use only ECDHE for the key exchange, instead of generating DH parameters",
+		},
+		cli.StringFlag{
+			Name:  "tls-key-mode",
+			Usage: "protection of the control channel with a static key: tls-crypt, tls-auth or none",
+			Value: ovpm.TLSCryptMode,
+		},
+		cli.StringFlag{
+			Name:  "data-ciphers",
+			Usage: fmt.Sprintf("colon separated data channel ciphers in the order of preference (default: %s)", ovpm.DefaultDataCiphers),
+		},
+		cli.StringFlag{
+			Name:  "auth",
+			Usage: fmt.Sprintf("HMAC digest of the packets: SHA1, SHA256, SHA384 or SHA512 (default: %s)", ovpm.DefaultAuthDigest),
+		},
+		cli.StringFlag{
+			Name:  "tls-version-min",
+			Usage: fmt.Sprintf("minimum TLS version of the control channel: 1.0, 1.1, 1.2 or 1.3 (default: %s)", ovpm.DefaultTLSVersionMin),
+		},
 	},
 	Action: func(c *cli.Context) error {
 		action = "vpn:init"
@@ -105,10 +170,17 @@ var vpnInitCommand = cli.Command{
 			return errors.NotCIDR(netCIDR)
 		}
 
-		// Set DNS if provided.
-		dnsAddr := ovpm.DefaultVPNDNS
-		if !govalidator.IsIPv4(dnsAddr) {
-			return errors.NotIPv4(dnsAddr)
+		// Set IPv6 network if provided.
+		ipv6Net := c.String("ipv6-net")
+		if !govalidator.IsNull(ipv6Net) && !isIPv6CIDR(ipv6Net) {
+			return errors.NotCIDR(ipv6Net)
+		}
+
+		// Set DNS settings if provided.
+		dns, err := readDNSParams(c, false)
+		if err != nil {
+			exit(1)
+			return err
 		}
 
 		// Set KeepalivePeriod if provided.
@@ -125,6 +197,33 @@ var vpnInitCommand = cli.Command{
 
 		useLZO := c.Bool("use-lzo")
 
+		// Validate server names.
+		server := c.String("server")
+		if !govalidator.IsNull(server) && !govalidator.Matches(server, "^([\\w\\.]+)$") {
+			return errors.InvalidServerName(server)
+		}
+		caFrom := c.String("ca-from")
+		if !govalidator.IsNull(caFrom) && !govalidator.Matches(caFrom, "^([\\w\\.]+)$") {
+			return errors.InvalidServerName(caFrom)
+		}
+
+		// Validate key options.
+		keyAlgorithm, err := pki.ParseKeyAlgorithm(c.String("key-algorithm"))
+		if err != nil {
+			return errors.ConflictingDemands(err.Error())
+		}
+		rsaKeySize := c.Int("rsa-key-size")
+		if rsaKeySize != 0 && keyAlgorithm != pki.RSAKey {
+			return errors.ConflictingDemands("--rsa-key-size can only be used with the rsa key algorithm")
+		}
+		if rsaKeySize != 0 && rsaKeySize < pki.MinRSAKeySize {
+			return errors.ConflictingDemands(fmt.Sprintf("--rsa-key-size should be at least %d", pki.MinRSAKeySize))
+		}
+		certValidity := c.Int("cert-validity")
+		if certValidity < 0 {
+			return errors.ConflictingDemands("--cert-validity can not be negative")
+		}
+
 		// Ask for confirmation from the user about the destructive
 		// changes that are about to happen.
 		var uiConfirmed bool
@@ -164,16 +263,26 @@ var vpnInitCommand = cli.Command{
 			return nil
 		}
 
-		err := vpnInitAction(vpnInitParams{
+		err = vpnInitAction(vpnInitParams{
 			rpcServURLStr:    fmt.Sprintf("grpc://localhost:%d", daemonPort),
 			hostname:         hostname,
 			port:             port,
 			proto:            proto,
 			netCIDR:          netCIDR,
-			dnsAddr:          c.String("dns"),
+			ipv6Net:          ipv6Net,
+			dns:              dns,
+			resolver:         c.Bool("resolver"),
 			keepalivePeriod:  keepalivePeriod,
 			keepaliveTimeout: keepaliveTimeout,
 			useLZO:           useLZO,
+			server:           server,
+			caFrom:           caFrom,
+			keyAlgorithm:     string(keyAlgorithm),
+			rsaKeySize:       rsaKeySize,
+			certValidity:     certValidity,
+			dhNone:           c.Bool("dh-none"),
+			tlsKeyMode:       c.String("tls-key-mode"),
+			ciphers:          cipherParams(c),
 		})
 		if err != nil {
 			e, ok := err.(errors.Error)
@@ -200,9 +309,21 @@ var vpnUpdateCommand = cli.Command{
 			Name:  "net, n",
 			Usage: fmt.Sprintf("VPN network to give clients IP addresses from, in the CIDR form (default: %s)", ovpm.DefaultVPNNetwork),
 		},
+		cli.StringFlag{
+			Name:  "ipv6-net",
+			Usage: fmt.Sprintf("IPv6 network to give clients IPv6 addresses from in addition to the IPv4 ones, in the CIDR form, or %s to disable IPv6", ovpm.NoIPv6Network),
+		},
 		cli.StringFlag{
 			Name:  "dns, d",
-			Usage: fmt.Sprintf("DNS server to push to clients (default: %s)", ovpm.DefaultVPNDNS),
+			Usage: fmt.Sprintf("comma separated DNS servers to push to clients, or %s for the default %s", ovpm.DNSNone, ovpm.DefaultVPNDNS),
+		},
+		cli.StringFlag{
+			Name:  "dns-domain",
+			Usage: fmt.Sprintf("domain of the vpn network to push to clients as the DOMAIN option, or %s to clear it", ovpm.DNSNone),
+		},
+		cli.StringFlag{
+			Name:  "dns-search",
+			Usage: fmt.Sprintf("comma separated search domains to push to clients as the DOMAIN-SEARCH options, or %s to clear them", ovpm.DNSNone),
 		},
 		cli.BoolFlag{
 			Name:  "enable-use-lzo",
@@ -212,6 +333,42 @@ var vpnUpdateCommand = cli.Command{
 			Name:  "disable-use-lzo",
 			Usage: fmt.Sprintf("Disable use of the deprecated lzo compression algorithm to support older clients."),
 		},
+		cli.BoolFlag{
+			Name:  "enable-resolver",
+			Usage: fmt.Sprintf("run the built-in resolver that answers <username>.%s and push it to clients as their DNS server", ovpm.DefaultResolverZone),
+		},
+		cli.BoolFlag{
+			Name:  "disable-resolver",
+			Usage: "stop the built-in resolver and push the DNS servers to clients again",
+		},
+		cli.BoolFlag{
+			Name:  "dh-none",
+			Usage: "use only ECDHE for the key exchange, instead of DH parameters",
+		},
+		cli.BoolFlag{
+			Name:  "dh-generate",
+			Usage: "use generated DH parameters for the key exchange",
+		},
+		cli.StringFlag{
+			Name:  "tls-key-mode",
+			Usage: "protection of the control channel with a static key: tls-crypt, tls-auth or none",
+		},
+		cli.StringFlag{
+			Name:  "data-ciphers",
+			Usage: fmt.Sprintf("colon separated data channel ciphers in the order of preference (default: %s)", ovpm.DefaultDataCiphers),
+		},
+		cli.StringFlag{
+			Name:  "auth",
+			Usage: fmt.Sprintf("HMAC digest of the packets: SHA1, SHA256, SHA384 or SHA512 (default: %s)", ovpm.DefaultAuthDigest),
+		},
+		cli.StringFlag{
+			Name:  "tls-version-min",
+			Usage: fmt.Sprintf("minimum TLS version of the control channel: 1.0, 1.1, 1.2 or 1.3 (default: %s)", ovpm.DefaultTLSVersionMin),
+		},
+		cli.StringFlag{
+			Name:  "server",
+			Usage: fmt.Sprintf("name of the vpn server (default: %s)", ovpm.DefaultServerName),
+		},
 	},
 	Action: func(c *cli.Context) error {
 		action = "vpn:update"
@@ -226,9 +383,15 @@ var vpnUpdateCommand = cli.Command{
 			netCIDR = &net
 		}
 
-		var dnsAddr *string
-		if dns := c.String("dns"); !govalidator.IsNull(dns) {
-			dnsAddr = &dns
+		dns, err := readDNSParams(c, true)
+		if err != nil {
+			exit(1)
+			return err
+		}
+
+		ipv6Net := c.String("ipv6-net")
+		if !govalidator.IsNull(ipv6Net) && ipv6Net != ovpm.NoIPv6Network && !isIPv6CIDR(ipv6Net) {
+			return errors.NotCIDR(ipv6Net)
 		}
 
 		var useLzo *bool
@@ -245,19 +408,486 @@ var vpnUpdateCommand = cli.Command{
 			useLzo = ptr.Bool(false)
 		}
 
+		resolverPref := pb.VPNResolverPref_RESOLVER_NOPREF
+		if c.Bool("enable-resolver") && c.Bool("disable-resolver") {
+			e := fmt.Errorf("can not use --enable-resolver and --disable-resolver together")
+			fmt.Println(e.Error())
+			exit(1)
+			return e
+		}
+		if c.Bool("enable-resolver") {
+			resolverPref = pb.VPNResolverPref_RESOLVER_ENABLE
+		}
+		if c.Bool("disable-resolver") {
+			resolverPref = pb.VPNResolverPref_RESOLVER_DISABLE
+		}
+
+		dhPref := pb.VPNDHPref_DH_NOPREF
+		if c.Bool("dh-none") && c.Bool("dh-generate") {
+			e := fmt.Errorf("can not use --dh-none and --dh-generate together")
+			fmt.Println(e.Error())
+			exit(1)
+			return e
+		}
+		if c.Bool("dh-none") {
+			dhPref = pb.VPNDHPref_DH_NONE
+		}
+		if c.Bool("dh-generate") {
+			dhPref = pb.VPNDHPref_DH_GENERATE
+		}
+
 		// If dry run, then don't call the action, just preprocess.
 		if c.GlobalBool("dry-run") {
 			return nil
 		}
 
-		return vpnUpdateAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), netCIDR, dnsAddr, useLzo)
+		return vpnUpdateAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), c.String("server"), netCIDR, ipv6Net, dns, useLzo, resolverPref, dhPref, c.String("tls-key-mode"), cipherParams(c))
 	},
 }
 
+// cipherParams reads the cipher settings of the vpn server from the flags.
+func cipherParams(c *cli.Context) vpnCipherParams {
+	return vpnCipherParams{
+		dataCiphers:   c.String("data-ciphers"),
+		authDigest:    c.String("auth"),
+		tlsVersionMin: c.String("tls-version-min"),
+	}
+}
+
 var vpnRestartCommand = cli.Command{
 	Name:    "restart",
 	Usage:   "Restart VPN server.",
 	Aliases: []string{"r"},
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "server",
+			Usage: fmt.Sprintf("name of the vpn server (default: %s)", ovpm.DefaultServerName),
+		},
+	},
+	Action: func(c *cli.Context) error {
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return vpnRestartAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), c.String("server"))
+	},
+}
+
+var vpnListCommand = cli.Command{
+	Name:    "list",
+	Usage:   "List VPN servers.",
+	Aliases: []string{"l"},
+	Action: func(c *cli.Context) error {
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return vpnListAction(fmt.Sprintf("grpc://localhost:%d", daemonPort))
+	},
+}
+
+var vpnExpiringCommand = cli.Command{
+	Name:    "expiring",
+	Usage:   "List the certificates that expire soon.",
+	Aliases: []string{"e"},
+	Flags: []cli.Flag{
+		cli.IntFlag{
+			Name:  "days, d",
+			Usage: "list the certificates that expire within the given number of days (default: renew threshold of the daemon)",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		days := c.Int("days")
+		if days < 0 {
+			err := errors.ConflictingDemands("--days can not be negative")
+			exit(1)
+			return err
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return vpnExpiringAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), days)
+	},
+}
+
+var vpnRotateTLSKeyCommand = cli.Command{
+	Name:  "rotate-tls-key",
+	Usage: "Replace the static key of the control channel. Users need to download their profiles again.",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "server",
+			Usage: fmt.Sprintf("name of the vpn server (default: %s)", ovpm.DefaultServerName),
+		},
+	},
+	Action: func(c *cli.Context) error {
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return vpnRotateTLSKeyAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), c.String("server"))
+	},
+}
+
+var vpnRepackLeasesCommand = cli.Command{
+	Name:  "repack-leases",
+	Usage: "Lease the dynamic ip addresses of the users again from the start of the network, closing the gaps.",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "server",
+			Usage: fmt.Sprintf("name of the vpn server (default: %s)", ovpm.DefaultServerName),
+		},
+	},
+	Action: func(c *cli.Context) error {
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return vpnRepackLeasesAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), c.String("server"))
+	},
+}
+
+var vpnPoolCreateCommand = cli.Command{
+	Name:    "create",
+	Usage:   "Create an address pool in the network of the VPN server.",
+	Aliases: []string{"c"},
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "name, n",
+			Usage: "name of the pool",
+		},
+		cli.StringFlag{
+			Name:  "cidr, c",
+			Usage: "network of the pool in the CIDR form, e.g. 10.9.1.0/24",
+		},
+		cli.StringFlag{
+			Name:  "server",
+			Usage: fmt.Sprintf("name of the vpn server (default: %s)", ovpm.DefaultServerName),
+		},
+	},
+	Action: func(c *cli.Context) error {
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		// Validate pool name and CIDR.
+		if name := c.String("name"); govalidator.IsNull(name) {
+			err := errors.EmptyValue("name", name)
+			exit(1)
+			return err
+		}
+		if cidr := c.String("cidr"); !govalidator.IsCIDR(cidr) {
+			err := errors.NotCIDR(cidr)
+			exit(1)
+			return err
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return vpnPoolCreateAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), c.String("server"), c.String("name"), c.String("cidr"))
+	},
+}
+
+var vpnPoolListCommand = cli.Command{
+	Name:    "list",
+	Usage:   "List the address pools of the VPN server.",
+	Aliases: []string{"l"},
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "server",
+			Usage: fmt.Sprintf("name of the vpn server (default: %s)", ovpm.DefaultServerName),
+		},
+	},
+	Action: func(c *cli.Context) error {
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return vpnPoolListAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), c.String("server"))
+	},
+}
+
+var vpnPoolDeleteCommand = cli.Command{
+	Name:    "delete",
+	Usage:   "Delete an address pool that has no users.",
+	Aliases: []string{"d"},
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "name, n",
+			Usage: "name of the pool",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		// Validate pool name.
+		if name := c.String("name"); govalidator.IsNull(name) {
+			err := errors.EmptyValue("name", name)
+			exit(1)
+			return err
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return vpnPoolDeleteAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), c.String("name"))
+	},
+}
+
+var vpnPoolCommand = cli.Command{
+	Name:  "pool",
+	Usage: "Address pool operations.",
+	Subcommands: []cli.Command{
+		vpnPoolCreateCommand,
+		vpnPoolListCommand,
+		vpnPoolDeleteCommand,
+	},
+}
+
+var vpnACLAddCommand = cli.Command{
+	Name:    "add",
+	Usage:   "Add an access control rule for a user or an address pool.",
+	Aliases: []string{"a"},
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "user, u",
+			Usage: "name of the user",
+		},
+		cli.StringFlag{
+			Name:  "pool, p",
+			Usage: "name of the address pool, instead of a user",
+		},
+		cli.StringFlag{
+			Name:  "action",
+			Usage: fmt.Sprintf("%s or %s", ovpm.ACLAllow, ovpm.ACLDeny),
+		},
+		cli.StringFlag{
+			Name:  "dest, d",
+			Usage: "destination network in the CIDR form",
+		},
+		cli.StringFlag{
+			Name:  "proto",
+			Usage: fmt.Sprintf("%s, %s, %s or %s (default: %s)", ovpm.ACLTCP, ovpm.ACLUDP, ovpm.ACLICMP, ovpm.ACLAnyProto, ovpm.ACLAnyProto),
+		},
+		cli.StringFlag{
+			Name:  "port",
+			Usage: "destination port or range of ports (e.g. 8000:8080), only for tcp and udp",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		// Validate the rule.
+		if govalidator.IsNull(c.String("user")) == govalidator.IsNull(c.String("pool")) {
+			err := errors.ConflictingDemands("either --user or --pool flag should be used")
+			exit(1)
+			return err
+		}
+		if action := c.String("action"); govalidator.IsNull(action) {
+			err := errors.EmptyValue("action", action)
+			exit(1)
+			return err
+		}
+		if dest := c.String("dest"); !govalidator.IsCIDR(dest) {
+			err := errors.NotCIDR(dest)
+			exit(1)
+			return err
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return vpnACLAddAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), c.String("user"), c.String("pool"), c.String("action"), c.String("dest"), c.String("proto"), c.String("port"))
+	},
+}
+
+var vpnACLListCommand = cli.Command{
+	Name:    "list",
+	Usage:   "List the access control rules in the order they are matched.",
+	Aliases: []string{"l"},
+	Action: func(c *cli.Context) error {
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return vpnACLListAction(fmt.Sprintf("grpc://localhost:%d", daemonPort))
+	},
+}
+
+var vpnACLDeleteCommand = cli.Command{
+	Name:    "delete",
+	Usage:   "Delete an access control rule.",
+	Aliases: []string{"d"},
+	Flags: []cli.Flag{
+		cli.IntFlag{
+			Name:  "id",
+			Usage: "id of the rule (see $ovpm vpn acl list)",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		// Validate rule id.
+		if id := c.Int("id"); id <= 0 {
+			err := errors.EmptyValue("id", id)
+			exit(1)
+			return err
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return vpnACLDeleteAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), uint32(c.Int("id")))
+	},
+}
+
+var vpnACLCommand = cli.Command{
+	Name:  "acl",
+	Usage: "Access control rule operations.",
+	Subcommands: []cli.Command{
+		vpnACLAddCommand,
+		vpnACLListCommand,
+		vpnACLDeleteCommand,
+	},
+}
+
+var vpnFirewallShowCommand = cli.Command{
+	Name:    "show",
+	Usage:   "Show the firewall rules that are installed for the VPN servers.",
+	Aliases: []string{"s"},
+	Action: func(c *cli.Context) error {
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return vpnFirewallShowAction(fmt.Sprintf("grpc://localhost:%d", daemonPort))
+	},
+}
+
+var vpnFirewallCommand = cli.Command{
+	Name:  "firewall",
+	Usage: "Firewall operations.",
+	Subcommands: []cli.Command{
+		vpnFirewallShowCommand,
+	},
+}
+
+var vpnCARotateCommand = cli.Command{
+	Name:    "rotate",
+	Usage:   "Start rotating the CA of the VPN server.",
+	Aliases: []string{"r"},
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "server",
+			Usage: fmt.Sprintf("name of the vpn server (default: %s)", ovpm.DefaultServerName),
+		},
+	},
+	Action: func(c *cli.Context) error {
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return vpnCARotateAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), c.String("server"))
+	},
+}
+
+var vpnCAStatusCommand = cli.Command{
+	Name:    "status",
+	Usage:   "Show the progress of the CA rotation.",
+	Aliases: []string{"s"},
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "server",
+			Usage: fmt.Sprintf("name of the vpn server (default: %s)", ovpm.DefaultServerName),
+		},
+	},
 	Action: func(c *cli.Context) error {
 		// Use default port if no port is specified.
 		daemonPort := ovpm.DefaultDaemonPort
@@ -270,7 +900,47 @@ var vpnRestartCommand = cli.Command{
 			return nil
 		}
 
-		return vpnRestartAction(fmt.Sprintf("grpc://localhost:%d", daemonPort))
+		return vpnCAStatusAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), c.String("server"))
+	},
+}
+
+var vpnCAFinishCommand = cli.Command{
+	Name:    "finish",
+	Usage:   "Retire the old CA of the VPN server.",
+	Aliases: []string{"f"},
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "server",
+			Usage: fmt.Sprintf("name of the vpn server (default: %s)", ovpm.DefaultServerName),
+		},
+		cli.BoolFlag{
+			Name:  "force",
+			Usage: "finish even if some users haven't fetched their new profiles yet",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return vpnCAFinishAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), c.String("server"), c.Bool("force"))
+	},
+}
+
+var vpnCACommand = cli.Command{
+	Name:  "ca",
+	Usage: "CA rotation operations.",
+	Subcommands: []cli.Command{
+		vpnCARotateCommand,
+		vpnCAStatusCommand,
+		vpnCAFinishCommand,
 	},
 }
 
@@ -285,6 +955,15 @@ func init() {
 				vpnInitCommand,
 				vpnUpdateCommand,
 				vpnRestartCommand,
+				vpnListCommand,
+				vpnExpiringCommand,
+				vpnCACommand,
+				vpnRotateTLSKeyCommand,
+				vpnDirectivesCommand,
+				vpnRepackLeasesCommand,
+				vpnPoolCommand,
+				vpnACLCommand,
+				vpnFirewallCommand,
 			},
 		},
 	)
@@ -24,8 +24,9 @@ func init() {
 			Usage: "verbose output",
 		},
 		cli.IntFlag{
-			Name:  "daemon-port",
-			Usage: "port number for OVPM daemon to call",
+			Name:   "daemon-port",
+			Usage:  "port number for OVPM daemon to call",
+			EnvVar: ovpm.EnvDaemonPort,
 		},
 		cli.BoolFlag{
 			Name:  "dry-run",
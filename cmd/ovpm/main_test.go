@@ -33,6 +33,14 @@ func TestMainCmd(t *testing.T) {
 		t.Fatal("subcommand missing 'net'")
 	}
 
+	if !strings.Contains(output.String(), "backup") {
+		t.Fatal("subcommand missing 'backup'")
+	}
+
+	if !strings.Contains(output.String(), "restore") {
+		t.Fatal("subcommand missing 'restore'")
+	}
+
 	if !strings.Contains(output.String(), "help, h") {
 		t.Fatal("subcommand missing 'help'")
 	}
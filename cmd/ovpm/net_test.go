@@ -89,6 +89,18 @@ func TestNetDefineCmd(t *testing.T) {
 		t.Fatal("error is expected about incorrect via format, but we didn't got error")
 	}
 
+	// Clearing dns settings of a network that is not defined yet
+	err = app.Run([]string{"ovpm", "net", "def", "--name", "asd", "--type", "ROUTE", "--cidr", "192.168.1.1/24", "--dns-domain", "none"})
+	if err == nil {
+		t.Fatal("error is expected about clearing dns settings, but we didn't got error")
+	}
+
+	// Malformed dns
+	err = app.Run([]string{"ovpm", "net", "def", "--name", "asd", "--type", "ROUTE", "--cidr", "192.168.1.1/24", "--dns", "10.0.0.300"})
+	if err == nil {
+		t.Fatal("error is expected about dns being malformed ip, but we didn't got error")
+	}
+
 	// Ensure network name alphanumeric and dot, underscore chars are allowed
 	err = app.Run([]string{"ovpm", "net", "def", "--name", "asd.asdd5sa_fasA32", "--type", "ROUTE", "--cidr", "192.168.1.1/24"})
 	if err != nil && !strings.Contains(err.Error(), "grpc") {
@@ -38,6 +38,22 @@ func TestUserCmd(t *testing.T) {
 	if !strings.Contains(output.String(), "genconfig, g") {
 		t.Fatal("subcommand missing 'update, u'")
 	}
+
+	if !strings.Contains(output.String(), "kick, k") {
+		t.Fatal("subcommand missing 'kick, k'")
+	}
+
+	if !strings.Contains(output.String(), "directives") {
+		t.Fatal("subcommand missing 'directives'")
+	}
+
+	if !strings.Contains(output.String(), "import, i") {
+		t.Fatal("subcommand missing 'import, i'")
+	}
+
+	if !strings.Contains(output.String(), "export, e") {
+		t.Fatal("subcommand missing 'export, e'")
+	}
 }
 
 func TestUserCreateCmd(t *testing.T) {
@@ -126,6 +142,18 @@ func TestUserUpdateCmd(t *testing.T) {
 	if err == nil {
 		t.Fatal("error is expected about bulk and --static conflict")
 	}
+
+	// Malformed dns
+	err = app.Run([]string{"ovpm", "user", "update", "--username", "foo", "--dns", "10.0.0.53,foo"})
+	if err == nil {
+		t.Fatal("error is expected about dns being malformed ip, but we didn't got error")
+	}
+
+	// Malformed dns search domain
+	err = app.Run([]string{"ovpm", "user", "update", "--username", "foo", "--dns-search", "corp.example,-bad-"})
+	if err == nil {
+		t.Fatal("error is expected about dns search being malformed domain, but we didn't got error")
+	}
 }
 
 func TestUserDeleteCmd(t *testing.T) {
@@ -166,3 +194,66 @@ func TestUserGenconfigCmd(t *testing.T) {
 		t.Fatal("error is expected about missing fields, but we didn't got error")
 	}
 }
+
+func TestUserKickCmd(t *testing.T) {
+	output := new(bytes.Buffer)
+	app.Writer = output
+
+	var err error
+
+	// Empty call
+	err = app.Run([]string{"ovpm", "user", "kick"})
+	if err == nil {
+		t.Fatal("error is expected about missing fields, but we didn't got error")
+	}
+
+	// Proper call
+	err = app.Run([]string{"ovpm", "user", "kick", "--user", "sad"})
+	if err != nil {
+		t.Fatalf("error is not expected: %v", err)
+	}
+}
+
+func TestUserImportCmd(t *testing.T) {
+	output := new(bytes.Buffer)
+	app.Writer = output
+
+	var err error
+
+	// Empty call
+	err = app.Run([]string{"ovpm", "user", "import"})
+	if err == nil {
+		t.Fatal("error is expected about missing file, but we didn't got error")
+	}
+
+	// Unknown format
+	err = app.Run([]string{"ovpm", "user", "import", "--file", "users.xml", "--format", "xml"})
+	if err == nil {
+		t.Fatal("error is expected about unknown format, but we didn't got error")
+	}
+
+	// Unknown format on export
+	err = app.Run([]string{"ovpm", "user", "export", "--format", "xml"})
+	if err == nil {
+		t.Fatal("error is expected about unknown format, but we didn't got error")
+	}
+}
+
+func TestUserFileFormat(t *testing.T) {
+	var formattests = []struct {
+		path   string
+		format string
+		want   string
+	}{
+		{"users.csv", "", "csv"},
+		{"users.json", "", "json"},
+		{"USERS.JSON", "", "json"},
+		{"", "", "csv"},
+		{"users.txt", "json", "json"},
+	}
+	for _, tt := range formattests {
+		if got, err := userFileFormat(tt.path, tt.format); err != nil || got != tt.want {
+			t.Errorf("userFileFormat(%q, %q) = %q, %v; want %q", tt.path, tt.format, got, err, tt.want)
+		}
+	}
+}
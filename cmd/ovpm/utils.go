@@ -6,8 +6,12 @@ import (
 	"net"
 	"net/url"
 	"os"
+	"path/filepath"
+	"strings"
 
+	"github.com/GoldenRUS/ovpm"
 	"github.com/GoldenRUS/ovpm/errors"
+	"github.com/asaskevich/govalidator"
 	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli"
 
@@ -24,7 +28,7 @@ func emitToFile(filePath, content string, mode uint) error {
 		file.Chmod(os.FileMode(mode))
 	}
 	defer file.Close()
-	fmt.Fprintf(file, content)
+	fmt.Fprint(file, content)
 	return nil
 }
 
@@ -85,6 +89,65 @@ func stringInSlice(a string, list []string) bool {
 	return false
 }
 
+// isIPv6CIDR tells whether s is an IPv6 network in the CIDR form.
+func isIPv6CIDR(s string) bool {
+	ip, _, err := net.ParseCIDR(s)
+	return err == nil && ip.To4() == nil
+}
+
+// dnsParams are the DNS settings read from the --dns, --dns-domain and
+// --dns-search flags. Empty ones are left as they are.
+type dnsParams struct {
+	servers string
+	domain  string
+	search  string
+}
+
+// readDNSParams reads and validates the DNS settings from the flags. A setting
+// can be cleared with "none" only if allowNone is true.
+func readDNSParams(c *cli.Context, allowNone bool) (dnsParams, error) {
+	params := dnsParams{servers: c.String("dns"), domain: c.String("dns-domain"), search: c.String("dns-search")}
+	for _, setting := range []string{params.servers, params.domain, params.search} {
+		if setting == ovpm.DNSNone && !allowNone {
+			return params, errors.ConflictingDemands(fmt.Sprintf("dns settings can not be cleared with %s here", ovpm.DNSNone))
+		}
+	}
+	if params.servers != ovpm.DNSNone {
+		for _, addr := range strings.Split(params.servers, ",") {
+			if addr = strings.TrimSpace(addr); params.servers != "" && !govalidator.IsIP(addr) {
+				return params, errors.NotIP(addr)
+			}
+		}
+	}
+	if params.domain != "" && params.domain != ovpm.DNSNone && !govalidator.IsDNSName(params.domain) {
+		return params, errors.NotHostname(params.domain)
+	}
+	if params.search != ovpm.DNSNone {
+		for _, domain := range strings.Split(params.search, ",") {
+			if domain = strings.TrimSpace(domain); params.search != "" && !govalidator.IsDNSName(domain) {
+				return params, errors.NotHostname(domain)
+			}
+		}
+	}
+	return params, nil
+}
+
+// userFileFormat returns the format of a user import or export file. If the
+// format isn't given, it's guessed from the file extension, and csv is used
+// if it can't be.
+func userFileFormat(path, format string) (string, error) {
+	if format == "" {
+		if strings.EqualFold(filepath.Ext(path), "."+ovpm.JSONFormat) {
+			return ovpm.JSONFormat, nil
+		}
+		return ovpm.CSVFormat, nil
+	}
+	if format != ovpm.CSVFormat && format != ovpm.JSONFormat {
+		return "", errors.ConflictingDemands(fmt.Sprintf("--format should be either %s or %s", ovpm.CSVFormat, ovpm.JSONFormat))
+	}
+	return format, nil
+}
+
 func exit(status int) {
 	if flag.Lookup("test.v") == nil {
 		os.Exit(status)
@@ -95,7 +158,7 @@ func exit(status int) {
 
 // Prints the received message followed by the usage string.
 func failureMsg(c *cli.Context, msg string) {
-	fmt.Printf(msg)
+	fmt.Print(msg)
 	fmt.Println()
 	fmt.Println(cli.ShowSubcommandHelp(c))
 }
@@ -30,4 +30,83 @@ func TestVPNCmd(t *testing.T) {
 	if !strings.Contains(output.String(), "restart, r") {
 		t.Fatal("subcommand missing 'restart, r'")
 	}
+
+	if !strings.Contains(output.String(), "list, l") {
+		t.Fatal("subcommand missing 'list, l'")
+	}
+
+	if !strings.Contains(output.String(), "expiring, e") {
+		t.Fatal("subcommand missing 'expiring, e'")
+	}
+
+	if !strings.Contains(output.String(), "ca") {
+		t.Fatal("subcommand missing 'ca'")
+	}
+
+	if !strings.Contains(output.String(), "rotate-tls-key") {
+		t.Fatal("subcommand missing 'rotate-tls-key'")
+	}
+
+	if !strings.Contains(output.String(), "directives") {
+		t.Fatal("subcommand missing 'directives'")
+	}
+
+	if !strings.Contains(output.String(), "repack-leases") {
+		t.Fatal("subcommand missing 'repack-leases'")
+	}
+
+	if !strings.Contains(output.String(), "pool") {
+		t.Fatal("subcommand missing 'pool'")
+	}
+
+	if !strings.Contains(output.String(), "acl") {
+		t.Fatal("subcommand missing 'acl'")
+	}
+
+	if !strings.Contains(output.String(), "firewall") {
+		t.Fatal("subcommand missing 'firewall'")
+	}
+}
+
+func TestVPNDirectivesSetCmd(t *testing.T) {
+	output := new(bytes.Buffer)
+	app.Writer = output
+
+	// Neither file nor clear.
+	if err := app.Run([]string{"ovpm", "vpn", "directives", "set"}); err == nil {
+		t.Fatal("error is expected about the missing file")
+	}
+
+	// Both file and clear.
+	if err := app.Run([]string{"ovpm", "vpn", "directives", "set", "--file", "extra.conf", "--clear"}); err == nil {
+		t.Fatal("error is expected about the conflicting flags")
+	}
+
+	// Unknown scope.
+	if err := app.Run([]string{"ovpm", "vpn", "directives", "set", "--scope", "ccd", "--clear"}); err == nil {
+		t.Fatal("error is expected about the scope")
+	}
+
+	if err := app.Run([]string{"ovpm", "vpn", "directives", "set", "--scope", "client", "--clear"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestVPNUpdateCmd(t *testing.T) {
+	output := new(bytes.Buffer)
+	app.Writer = output
+
+	// Both enable and disable resolver.
+	if err := app.Run([]string{"ovpm", "vpn", "update", "--enable-resolver", "--disable-resolver"}); err == nil {
+		t.Fatal("error is expected about the conflicting flags")
+	}
+
+	// Malformed dns.
+	if err := app.Run([]string{"ovpm", "vpn", "update", "--dns", "10.0.0.53,10.0.0"}); err == nil {
+		t.Fatal("error is expected about dns being malformed ip")
+	}
+
+	if err := app.Run([]string{"ovpm", "vpn", "update", "--enable-resolver", "--dns-search", "none"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
 }
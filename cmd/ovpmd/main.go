@@ -34,6 +34,14 @@ func main() {
 			Name:  "verbose",
 			Usage: "verbose output",
 		},
+		cli.StringFlag{
+			Name:  "config, c",
+			Usage: fmt.Sprintf("path of the config file in INI, YAML or TOML format (default: %s)", ovpm.DefaultConfigPath),
+		},
+		cli.StringFlag{
+			Name:  "db-dsn",
+			Usage: "postgres://... or mysql://... DSN of the database to use instead of the sqlite3 database",
+		},
 		cli.StringFlag{
 			Name:  "port",
 			Usage: "port number for gRPC API daemon",
@@ -52,41 +60,72 @@ func main() {
 		if c.GlobalBool("verbose") {
 			logrus.SetLevel(logrus.DebugLevel)
 		}
-		db = ovpm.CreateDB("sqlite3", "")
+		config, err := ovpm.LoadConfig(c.GlobalString("config"))
+		if err != nil {
+			logrus.Fatal(err)
+		}
+		if dsn := c.GlobalString("db-dsn"); dsn != "" {
+			config.DBDSN = dsn
+		}
+		ovpm.SetConfig(config)
 		return nil
 	}
 	app.After = func(c *cli.Context) error {
-		db.Cease()
+		if db != nil {
+			db.Cease()
+		}
 		return nil
 	}
+	app.Commands = []cli.Command{
+		migrateCommand,
+	}
 	app.Action = func(c *cli.Context) error {
+		db = ovpm.CreateDB(ovpm.GetConfig().DBSource())
+
+		// Flags take precedence over the config.
+		config := ovpm.GetConfig()
 		port := c.String("port")
 		if port == "" {
-			port = "9090"
+			port = strconv.Itoa(config.DaemonPort)
 		}
 
 		webPort := c.String("web-port")
 		if webPort == "" {
-			webPort = "8080"
+			webPort = strconv.Itoa(config.WebPort)
 		}
 
 		webIP := c.String("web-ip")
 		if webIP == "" || !isValidIPv4(webIP) {
-			webIP = "0.0.0.0"
+			webIP = config.WebIP
+		}
+		if !isValidIPv4(webIP) {
+			webIP = ovpm.DefaultWebIP
 		}
 
 		if err := ovpm.InitializeFileWatcher(); err != nil {
 			log.Fatal(err)
 		}
 
+		if err := ovpm.InitializeManagement(); err != nil {
+			log.Fatal(err)
+		}
+
+		if err := ovpm.InitializeResolver(); err != nil {
+			log.Fatal(err)
+		}
+
+		renewer := ovpm.NewCertRenewer(config.CertCheckInterval, config.CertRenewThreshold, config.RenewClientCerts)
+
 		s := newServer(port, webPort, webIP)
 		s.start()
+		go renewer.Run()
 		s.WaitForInterrupt()
+		renewer.Close()
 		s.stop()
 
-		// Закрываем FileWatcher при выходе
-		if fw := ovpm.GetFileWatcher(); fw != nil {
-			fw.Close()
+		// Закрываем FileWatcher и management при выходе
+		for _, svr := range ovpm.GetAllServers() {
+			svr.CloseMonitors()
 		}
 		return nil
 	}
@@ -158,15 +197,26 @@ func (s *server) start() {
 	logrus.Infof("OVPM %s is running gRPC:%s, REST:%s ...", ovpm.Version, s.grpcPort, s.restPort)
 	go s.grpcServer.Serve(s.lis)
 	go http.Serve(s.restLis, s.restServer)
-	ovpm.TheServer().StartVPNProc()
+	servers := ovpm.GetAllServers()
+	if len(servers) == 0 {
+		// Let it complain about the missing initialization.
+		ovpm.TheServer().StartVPNProc()
+	}
+	for _, svr := range servers {
+		svr.StartVPNProc()
+	}
 }
 
 func (s *server) stop() {
 	logrus.Info("OVPM is shutting down ...")
 	s.grpcServer.Stop()
 	s.restCancel()
-	ovpm.TheServer().StopVPNProc()
-
+	for _, svr := range ovpm.GetAllServers() {
+		svr.StopVPNProc()
+	}
+	if err := ovpm.TeardownFirewall(); err != nil {
+		logrus.Errorf("can not remove the firewall rules: %v", err)
+	}
 }
 
 func (s *server) WaitForInterrupt() {
@@ -192,7 +242,7 @@ func stringInSlice(a string, list []string) bool {
 func increasePort(p string) string {
 	i, err := strconv.Atoi(p)
 	if err != nil {
-		logrus.Panicf(fmt.Sprintf("can't convert %s to int: %v", p, err))
+		logrus.Panicf("can't convert %s to int: %v", p, err)
 
 	}
 	i++
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/GoldenRUS/ovpm"
+	"github.com/olekukonko/tablewriter"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+)
+
+var migrateStatusCommand = cli.Command{
+	Name:    "status",
+	Usage:   "Show the applied and the pending migrations.",
+	Aliases: []string{"s"},
+	Action: func(c *cli.Context) error {
+		db = openDB()
+		statuses, err := db.MigrationStatus()
+		if err != nil {
+			logrus.Fatalf("can not get migration status: %v", err)
+		}
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"version", "name", "status", "applied at"})
+		for _, s := range statuses {
+			status, appliedAt := "pending", ""
+			if s.Applied {
+				status, appliedAt = "applied", s.AppliedAt.Local().Format("2006-01-02 15:04:05")
+			}
+			table.Append([]string{strconv.Itoa(int(s.Version)), s.Name, status, appliedAt})
+		}
+		table.Render()
+		return nil
+	},
+}
+
+var migrateUpCommand = cli.Command{
+	Name:    "up",
+	Usage:   "Apply the pending migrations.",
+	Aliases: []string{"u"},
+	Flags: []cli.Flag{
+		cli.UintFlag{
+			Name:  "to",
+			Usage: "schema version to migrate up to (default: latest)",
+		},
+		cli.BoolFlag{
+			Name:  "no-backup",
+			Usage: "don't back the database up before migrating",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		db = openDB()
+		if err := db.MigrateUp(c.Uint("to"), !c.Bool("no-backup")); err != nil {
+			logrus.Fatal(err)
+		}
+		printSchemaVersion()
+		return nil
+	},
+}
+
+var migrateDownCommand = cli.Command{
+	Name:    "down",
+	Usage:   "Roll the applied migrations back.",
+	Aliases: []string{"d"},
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "to",
+			Usage: "schema version to roll back to (default: previous version)",
+		},
+		cli.BoolFlag{
+			Name:  "no-backup",
+			Usage: "don't back the database up before migrating",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		db = openDB()
+		version, err := db.SchemaVersion()
+		if err != nil {
+			logrus.Fatalf("can not get schema version: %v", err)
+		}
+		if version == 0 {
+			fmt.Println("nothing to roll back")
+			return nil
+		}
+
+		target := version - 1
+		if to := c.String("to"); to != "" {
+			v, err := strconv.ParseUint(to, 10, 32)
+			if err != nil {
+				logrus.Fatalf("--to should be a schema version: %s", to)
+			}
+			target = uint(v)
+		}
+		if err := db.MigrateDown(target, !c.Bool("no-backup")); err != nil {
+			logrus.Fatal(err)
+		}
+		printSchemaVersion()
+		return nil
+	},
+}
+
+var migrateCommand = cli.Command{
+	Name:  "migrate",
+	Usage: "Manage the database schema migrations.",
+	Subcommands: []cli.Command{
+		migrateStatusCommand,
+		migrateUpCommand,
+		migrateDownCommand,
+	},
+}
+
+// openDB opens the configured database without migrating it.
+func openDB() *ovpm.DB {
+	return ovpm.OpenDB(ovpm.GetConfig().DBSource())
+}
+
+func printSchemaVersion() {
+	version, err := db.SchemaVersion()
+	if err != nil {
+		logrus.Fatalf("can not get schema version: %v", err)
+	}
+	fmt.Printf("database is at schema version %d (latest: %d)\n", version, ovpm.LatestSchemaVersion())
+}
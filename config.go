@@ -0,0 +1,231 @@
+package ovpm
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/sirupsen/logrus"
+	ini "gopkg.in/ini.v1"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// Environment variables that override the values read from the config file.
+const (
+	EnvConfigPath  = "OVPM_CONFIG"
+	EnvVarDir      = "OVPM_VAR_DIR"
+	EnvDBPath      = "OVPM_DB_PATH"
+	EnvDBDSN       = "OVPM_DB_DSN"
+	EnvOpenVPNPath = "OVPM_OPENVPN_PATH"
+	EnvFirewall    = "OVPM_FIREWALL"
+	EnvDaemonPort  = "OVPM_PORT"
+	EnvWebPort     = "OVPM_WEB_PORT"
+	EnvWebIP       = "OVPM_WEB_IP"
+)
+
+// DefaultConfigPath is the path of the config file that is read when no other path is specified.
+const DefaultConfigPath = _DefaultConfigPath
+
+// Config holds the file system layout and the listening addresses of ovpm.
+//
+// Zero values are replaced with the defaults, see DefaultConfig.
+type Config struct {
+	// VarDir is the directory that the OpenVPN files are emitted to.
+	VarDir string `ini:"var_dir" yaml:"var_dir" toml:"var_dir"`
+
+	// DBPath is the path of the sqlite3 database. It defaults to db.sqlite3 in the VarDir.
+	DBPath string `ini:"db_path" yaml:"db_path" toml:"db_path"`
+
+	// DBDSN points to a Postgres or MySQL database to be used instead of the sqlite3
+	// database at DBPath. See ParseDSN for the accepted formats.
+	DBDSN string `ini:"db_dsn" yaml:"db_dsn" toml:"db_dsn"`
+
+	// OpenVPNPath is the path of the openvpn executable. It's looked up in the PATH when empty.
+	OpenVPNPath string `ini:"openvpn_path" yaml:"openvpn_path" toml:"openvpn_path"`
+
+	// Firewall is the backend of the NAT and the forwarding rules: iptables,
+	// nftables or auto to detect it.
+	Firewall string `ini:"firewall" yaml:"firewall" toml:"firewall"`
+
+	// DaemonPort is the port of the gRPC API. It always listens on the localhost.
+	DaemonPort int `ini:"port" yaml:"port" toml:"port"`
+
+	// WebPort is the port of the REST API and the web interface.
+	WebPort int `ini:"web_port" yaml:"web_port" toml:"web_port"`
+
+	// WebIP is the address that the REST API and the web interface listen on.
+	WebIP string `ini:"web_ip" yaml:"web_ip" toml:"web_ip"`
+
+	// CertCheckInterval is the time between the checks for the expiring certificates.
+	CertCheckInterval time.Duration `ini:"cert_check_interval" yaml:"cert_check_interval" toml:"cert_check_interval"`
+
+	// CertRenewThreshold is how long before expiring the certificates are renewed.
+	CertRenewThreshold time.Duration `ini:"cert_renew_threshold" yaml:"cert_renew_threshold" toml:"cert_renew_threshold"`
+
+	// RenewClientCerts enables renewing the expiring client certificates as well.
+	// Users have to download their profiles again after the renewal.
+	RenewClientCerts bool `ini:"renew_client_certs" yaml:"renew_client_certs" toml:"renew_client_certs"`
+}
+
+var (
+	config   = DefaultConfig()
+	configMu sync.RWMutex
+)
+
+// DefaultConfig returns the config that is used unless another one is set with SetConfig.
+func DefaultConfig() *Config {
+	return &Config{
+		VarDir:     varBasePath,
+		DBPath:     _DefaultDBPath,
+		DaemonPort: DefaultDaemonPort,
+		WebPort:    DefaultWebPort,
+		WebIP:      DefaultWebIP,
+		Firewall:   AutoFirewall,
+
+		CertCheckInterval:  DefaultCertCheckInterval,
+		CertRenewThreshold: DefaultCertRenewThreshold,
+	}
+}
+
+// LoadConfig reads the config file at path and applies the environment overrides on it.
+//
+// The format of the file is determined by its extension; .yaml, .yml and .toml files
+// are read accordingly, anything else is read as an INI file.
+//
+// If path is empty, the file pointed by the OVPM_CONFIG environment variable or the
+// DefaultConfigPath is read. Missing DefaultConfigPath is not an error, defaults are
+// used instead.
+func LoadConfig(path string) (*Config, error) {
+	optional := false
+	if path == "" {
+		path = os.Getenv(EnvConfigPath)
+	}
+	if path == "" {
+		path = DefaultConfigPath
+		optional = true
+	}
+
+	c := &Config{}
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		if err := c.parse(path, data); err != nil {
+			return nil, fmt.Errorf("can not parse config file %s: %v", path, err)
+		}
+		logrus.Debugf("config file loaded: %s", path)
+	case os.IsNotExist(err) && optional:
+		logrus.Debugf("config file not found, using defaults: %s", path)
+	default:
+		return nil, fmt.Errorf("can not read config file: %v", err)
+	}
+
+	if err := c.applyEnv(); err != nil {
+		return nil, err
+	}
+	c.setDefaults()
+	return c, nil
+}
+
+// SetConfig makes c the config of the package.
+//
+// It should be called before CreateDB, since the servers that are already
+// created keep the OpenVPN executable they are created with.
+func SetConfig(c *Config) {
+	cp := *c
+	cp.setDefaults()
+
+	configMu.Lock()
+	defer configMu.Unlock()
+	config = &cp
+}
+
+// GetConfig returns a copy of the config of the package.
+func GetConfig() Config {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	return *config
+}
+
+// DBSource returns the dialect and the connection string of the configured database.
+func (c Config) DBSource() (dialect string, source string) {
+	if c.DBDSN != "" {
+		return ParseDSN(c.DBDSN)
+	}
+	return SQLite3Dialect, c.DBPath
+}
+
+func (c *Config) parse(path string, data []byte) error {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return yaml.Unmarshal(data, c)
+	case ".toml":
+		_, err := toml.Decode(string(data), c)
+		return err
+	default:
+		f, err := ini.Load(data)
+		if err != nil {
+			return err
+		}
+		return f.Section("").MapTo(c)
+	}
+}
+
+func (c *Config) applyEnv() error {
+	for env, field := range map[string]*string{
+		EnvVarDir:      &c.VarDir,
+		EnvDBPath:      &c.DBPath,
+		EnvDBDSN:       &c.DBDSN,
+		EnvOpenVPNPath: &c.OpenVPNPath,
+		EnvFirewall:    &c.Firewall,
+		EnvWebIP:       &c.WebIP,
+	} {
+		if v := os.Getenv(env); v != "" {
+			*field = v
+		}
+	}
+	for env, field := range map[string]*int{
+		EnvDaemonPort: &c.DaemonPort,
+		EnvWebPort:    &c.WebPort,
+	} {
+		if v := os.Getenv(env); v != "" {
+			port, err := strconv.Atoi(v)
+			if err != nil {
+				return fmt.Errorf("%s should be a port number: %s", env, v)
+			}
+			*field = port
+		}
+	}
+	return nil
+}
+
+func (c *Config) setDefaults() {
+	if c.VarDir == "" {
+		c.VarDir = varBasePath
+	}
+	if c.DBPath == "" {
+		c.DBPath = filepath.Join(c.VarDir, filepath.Base(_DefaultDBPath))
+	}
+	if c.DaemonPort == 0 {
+		c.DaemonPort = DefaultDaemonPort
+	}
+	if c.WebPort == 0 {
+		c.WebPort = DefaultWebPort
+	}
+	if c.WebIP == "" {
+		c.WebIP = DefaultWebIP
+	}
+	if c.CertCheckInterval == 0 {
+		c.CertCheckInterval = DefaultCertCheckInterval
+	}
+	if c.Firewall == "" {
+		c.Firewall = AutoFirewall
+	}
+	if c.CertRenewThreshold == 0 {
+		c.CertRenewThreshold = DefaultCertRenewThreshold
+	}
+}
@@ -0,0 +1,124 @@
+package ovpm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadConfig(t *testing.T) {
+	// Prepare:
+	dir := t.TempDir()
+	var configtests = []struct {
+		file    string
+		content string
+	}{
+		{"ovpm.ini", "var_dir = /tmp/ovpm\nopenvpn_path = /usr/local/sbin/openvpn\nfirewall = nftables\nport = 9191\nweb_port = 8181\nweb_ip = 127.0.0.1\ncert_renew_threshold = 168h\nrenew_client_certs = true\n"},
+		{"ovpm.yaml", "var_dir: /tmp/ovpm\nopenvpn_path: /usr/local/sbin/openvpn\nfirewall: nftables\nport: 9191\nweb_port: 8181\nweb_ip: 127.0.0.1\ncert_renew_threshold: 168h\nrenew_client_certs: true\n"},
+		{"ovpm.toml", "var_dir = \"/tmp/ovpm\"\nopenvpn_path = \"/usr/local/sbin/openvpn\"\nfirewall = \"nftables\"\nport = 9191\nweb_port = 8181\nweb_ip = \"127.0.0.1\"\ncert_renew_threshold = \"168h\"\nrenew_client_certs = true\n"},
+	}
+
+	// Test:
+	for _, tt := range configtests {
+		path := filepath.Join(dir, tt.file)
+		if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		c, err := LoadConfig(path)
+		if err != nil {
+			t.Fatalf("%s can not be loaded: %v", tt.file, err)
+		}
+		want := Config{
+			VarDir:      "/tmp/ovpm",
+			DBPath:      "/tmp/ovpm/db.sqlite3",
+			OpenVPNPath: "/usr/local/sbin/openvpn",
+			Firewall:    NftablesFirewall,
+			DaemonPort:  9191,
+			WebPort:     8181,
+			WebIP:       "127.0.0.1",
+
+			CertCheckInterval:  DefaultCertCheckInterval,
+			CertRenewThreshold: 7 * 24 * time.Hour,
+			RenewClientCerts:   true,
+		}
+		if *c != want {
+			t.Errorf("%s: got %+v, want %+v", tt.file, *c, want)
+		}
+	}
+
+	if _, err := LoadConfig(filepath.Join(dir, "missing.ini")); err == nil {
+		t.Error("missing config file is expected to be an error when it's specified explicitly")
+	}
+}
+
+func TestLoadConfigEnv(t *testing.T) {
+	// Prepare:
+	path := filepath.Join(t.TempDir(), "ovpm.ini")
+	if err := os.WriteFile(path, []byte("var_dir = /tmp/ovpm\nport = 9191\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(EnvConfigPath, path)
+	t.Setenv(EnvVarDir, "/tmp/ovpm2")
+	t.Setenv(EnvWebPort, "8282")
+
+	// Test:
+	c, err := LoadConfig("")
+	if err != nil {
+		t.Fatalf("config can not be loaded: %v", err)
+	}
+	if c.VarDir != "/tmp/ovpm2" || c.DBPath != "/tmp/ovpm2/db.sqlite3" {
+		t.Errorf("var dir is expected to be overridden by the environment: %+v", c)
+	}
+	if c.DaemonPort != 9191 || c.WebPort != 8282 || c.WebIP != DefaultWebIP {
+		t.Errorf("ports are not loaded correctly: %+v", c)
+	}
+	if c.Firewall != AutoFirewall {
+		t.Errorf("firewall backend is expected to be detected by default, got %s", c.Firewall)
+	}
+
+	if dialect, source := c.DBSource(); dialect != SQLite3Dialect || source != c.DBPath {
+		t.Errorf("sqlite3 database is expected by default, got %s %s", dialect, source)
+	}
+	t.Setenv(EnvDBDSN, "postgres://localhost/ovpm")
+	c, err = LoadConfig("")
+	if err != nil {
+		t.Fatalf("config can not be loaded: %v", err)
+	}
+	if dialect, source := c.DBSource(); dialect != PostgresDialect || source != "postgres://localhost/ovpm" {
+		t.Errorf("database is expected to be overridden by the environment, got %s %s", dialect, source)
+	}
+
+	t.Setenv(EnvDaemonPort, "port")
+	if _, err := LoadConfig(""); err == nil {
+		t.Error("invalid port is expected to be an error")
+	}
+}
+
+func TestConfigLayout(t *testing.T) {
+	// Prepare:
+	dir := t.TempDir()
+	defer SetConfig(DefaultConfig())
+	SetConfig(&Config{VarDir: dir})
+
+	// Test:
+	db := CreateDB("sqlite3", "")
+	defer db.Cease()
+	if _, err := os.Stat(filepath.Join(dir, "db.sqlite3")); err != nil {
+		t.Errorf("database is expected to be created in the var dir: %v", err)
+	}
+
+	svr := TheServer()
+	if got := svr.path(vpnConfFile); got != filepath.Join(dir, vpnConfFile) {
+		t.Errorf("server conf is expected to be in the var dir, got %s", got)
+	}
+	if got := GetServer("second").path(vpnConfFile); got != filepath.Join(dir, "servers", "second", vpnConfFile) {
+		t.Errorf("second server's conf is expected to be in its own dir, got %s", got)
+	}
+
+	fs = make(map[string]string)
+	svr.Init("localhost", "", UDPProto, "", "", "", "", false)
+	if len(fs[filepath.Join(dir, vpnConfFile)]) == 0 {
+		t.Error("server conf is expected to be emitted to the var dir")
+	}
+}
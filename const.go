@@ -1,5 +1,7 @@
 package ovpm
 
+import "time"
+
 // Version defines the version of ovpm.
 var Version = "development"
 
@@ -16,29 +18,83 @@ const (
 	// DefaultVPNDNS is the default DNS to push to clients.
 	DefaultVPNDNS = "8.8.8.8"
 
+	// DefaultResolverZone is the domain that the built-in resolver answers the names of the users in.
+	DefaultResolverZone = "vpn.internal"
+
 	// DefaultDaemonPort is the port OVPMD will listen by default if something else is not specified.
 	DefaultDaemonPort = 9090
 
+	// DefaultWebPort is the port OVPMD will serve the REST API and the web interface on by default.
+	DefaultWebPort = 8080
+
+	// DefaultWebIP is the address OVPMD will serve the REST API and the web interface on by default.
+	DefaultWebIP = "0.0.0.0"
+
+	// DefaultCertCheckInterval is the time between the checks for the expiring certificates.
+	DefaultCertCheckInterval = 12 * time.Hour
+
+	// DefaultCertRenewThreshold is how long before expiring the certificates are renewed.
+	DefaultCertRenewThreshold = 30 * 24 * time.Hour
+
+	// DefaultDHParamsBits is the size of the DH parameters generated for the servers.
+	DefaultDHParamsBits = 2048
+
+	// DefaultDataCiphers are the data channel ciphers negotiated with the clients, in the order of preference.
+	DefaultDataCiphers = "AES-256-GCM:AES-128-GCM:CHACHA20-POLY1305"
+
+	// DefaultAuthDigest is the digest of the HMAC authentication of the packets.
+	DefaultAuthDigest = "SHA256"
+
+	// DefaultTLSVersionMin is the minimum TLS version accepted on the control channel.
+	DefaultTLSVersionMin = "1.2"
+
+	// MaxAPIMessageSize is the size limit of the API messages. Backup archives
+	// don't fit in the default limit of gRPC.
+	MaxAPIMessageSize = 64 << 20
+
 	// DefaultKeepalivePeriod is the default ping period to check if the remote peer is alive.
 	DefaultKeepalivePeriod = "2"
 
 	// DefaultKeepaliveTimeout is the default ping timeout to assume that remote peer is down.
 	DefaultKeepaliveTimeout = "4"
 
+	// DefaultServerName is the name of the VPN server that is used when no server name is specified.
+	DefaultServerName = "default"
+
 	etcBasePath = "/etc/ovpm/"
 	varBasePath = "/var/db/ovpm/"
 
-	_DefaultConfigPath    = etcBasePath + "ovpm.ini"
-	_DefaultDBPath        = varBasePath + "db.sqlite3"
-	_DefaultVPNConfPath   = varBasePath + "server.conf"
-	_DefaultVPNCCDPath    = varBasePath + "ccd"
-	_DefaultCertPath      = varBasePath + "server.crt"
-	_DefaultKeyPath       = varBasePath + "server.key"
-	_DefaultCACertPath    = varBasePath + "ca.crt"
-	_DefaultCAKeyPath     = varBasePath + "ca.key"
-	_DefaultDHParamsPath  = varBasePath + "dh4096.pem"
-	_DefaultCRLPath       = varBasePath + "crl.pem"
-	_DefaultStatusLogPath = varBasePath + "openvpn-status.log"
+	_DefaultConfigPath     = etcBasePath + "ovpm.ini"
+	_DefaultDBPath         = varBasePath + "db.sqlite3"
+	_DefaultVPNConfPath    = varBasePath + vpnConfFile
+	_DefaultVPNCCDPath     = varBasePath + vpnCCDDir
+	_DefaultCertPath       = varBasePath + certFile
+	_DefaultKeyPath        = varBasePath + keyFile
+	_DefaultCACertPath     = varBasePath + caCertFile
+	_DefaultCAKeyPath      = varBasePath + caKeyFile
+	_DefaultDHParamsPath   = varBasePath + dhParamsFile
+	_DefaultTLSKeyPath     = varBasePath + tlsKeyFile
+	_DefaultCRLPath        = varBasePath + crlFile
+	_DefaultStatusLogPath  = varBasePath + statusLogFile
+	_DefaultManagementPath = varBasePath + managementFile
+)
+
+// Names of the files that are emitted for each VPN server.
+//
+// Files of the default server reside directly in the var directory of the
+// config, while the other servers have their own directories under it.
+const (
+	vpnConfFile    = "server.conf"
+	vpnCCDDir      = "ccd"
+	certFile       = "server.crt"
+	keyFile        = "server.key"
+	caCertFile     = "ca.crt"
+	caKeyFile      = "ca.key"
+	dhParamsFile   = "dh.pem"
+	tlsKeyFile     = "ta.key"
+	crlFile        = "crl.pem"
+	statusLogFile  = "openvpn-status.log"
+	managementFile = "management.sock"
 )
 
 // Testing is used to determine whether we are testing or running normally.
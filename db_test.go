@@ -10,7 +10,7 @@ func TestDBSetup(t *testing.T) {
 	// Test:
 
 	// Create database.
-	CreateDB("sqlite3", ":memory:")
+	createTestDB()
 
 	// Is database created?
 	if db == nil {
@@ -23,7 +23,7 @@ func TestDBCease(t *testing.T) {
 	Testing = true
 
 	// Prepare:
-	CreateDB("sqlite3", ":memory:")
+	createTestDB()
 	user := dbUserModel{Username: "testUser"}
 	db.Save(&user)
 
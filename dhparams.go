@@ -0,0 +1,149 @@
+package ovpm
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// States of the DH parameters of a server.
+const (
+	DHParamsPending    = "pending"    // Generation isn't started yet.
+	DHParamsGenerating = "generating" // openssl is generating the parameters.
+	DHParamsReady      = "ready"      // Parameters of the server are in use.
+	DHParamsFailed     = "failed"     // Last generation failed, it's retried on the next emit.
+	DHParamsDisabled   = "disabled"   // Server runs with "dh none", only ECDHE is used.
+)
+
+// DHParamsStatus represents the state of the DH parameters of a server.
+type DHParamsStatus struct {
+	State     string
+	StartedAt time.Time // Start of the ongoing or the last generation.
+	Error     string    // Error of the last generation if it failed.
+}
+
+// generateDHParamsFunc generates PEM encoded DH parameters of the given size.
+var generateDHParamsFunc = generateDHParams
+
+// runDHParamsGeneration runs the DH parameter generation in the background.
+var runDHParamsGeneration = func(f func()) { go f() }
+
+// generateDHParams is the implementation of generateDHParamsFunc.
+func generateDHParams(bits int) (string, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command("openssl", "dhparam", strconv.Itoa(bits))
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("openssl dhparam failed: %v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	if !strings.Contains(string(out), "BEGIN DH PARAMETERS") {
+		return "", fmt.Errorf("openssl dhparam didn't output any DH parameters")
+	}
+	return string(out), nil
+}
+
+// WithECDHOnly makes the server run with "dh none", so that only the ECDHE key
+// exchange is used and no DH parameters are needed.
+func WithECDHOnly(enabled bool) ServerOption {
+	return func(s *dbServerModel) error {
+		s.ECDHOnly = enabled
+		return nil
+	}
+}
+
+// IsECDHOnly tells whether the server runs with "dh none".
+func (svr *Server) IsECDHOnly() bool {
+	return svr.ECDHOnly
+}
+
+// DHParamsStatus returns the state of the DH parameters of the server.
+func (svr *Server) DHParamsStatus() DHParamsStatus {
+	svr.dhMu.Lock()
+	defer svr.dhMu.Unlock()
+
+	status := DHParamsStatus{StartedAt: svr.dhStartedAt}
+	switch {
+	case svr.ECDHOnly:
+		status.State = DHParamsDisabled
+	case svr.dhSerial != "" && svr.dhSerial == svr.SerialNumber:
+		status.State = DHParamsGenerating
+	case svr.DHParams != "":
+		status.State = DHParamsReady
+	case svr.dhErr != nil:
+		status.State = DHParamsFailed
+		status.Error = svr.dhErr.Error()
+	default:
+		status.State = DHParamsPending
+	}
+	return status
+}
+
+// startDHParamsGeneration generates the DH parameters of the server in the
+// background, unless a generation is already ongoing.
+//
+// The parameters are saved and the server is restarted with them once they are
+// ready. They are dropped if the server is initialized again meanwhile.
+func (svr *Server) startDHParamsGeneration() {
+	serialNumber := svr.SerialNumber
+	svr.dhMu.Lock()
+	if svr.dhSerial == serialNumber {
+		svr.dhMu.Unlock()
+		return
+	}
+	svr.dhSerial = serialNumber
+	svr.dhStartedAt = time.Now()
+	svr.dhErr = nil
+	svr.dhMu.Unlock()
+
+	logrus.Infof("generating DH parameters of the server %s, this might take a while", svr.name)
+	runDHParamsGeneration(func() {
+		params, err := generateDHParamsFunc(DefaultDHParamsBits)
+		if err == nil {
+			q := db.Model(&dbServerModel{}).Where("name = ? AND serial_number = ?", svr.name, serialNumber).UpdateColumn("dh_params", params)
+			if err = q.Error; err == nil && q.RowsAffected == 0 {
+				err = fmt.Errorf("server is initialized again during the generation")
+			}
+		}
+
+		svr.dhMu.Lock()
+		if svr.dhSerial == serialNumber {
+			svr.dhSerial = ""
+			svr.dhErr = err
+		}
+		svr.dhMu.Unlock()
+		if err != nil {
+			logrus.Errorf("can not generate DH parameters of the server %s: %v", svr.name, err)
+			return
+		}
+
+		logrus.Infof("DH parameters of the server %s are generated", svr.name)
+		svr.Refresh()
+		if err := svr.EmitWithRestart(); err != nil {
+			logrus.Errorf("can not restart the server %s with the new DH parameters: %v", svr.name, err)
+		}
+	})
+}
+
+// emitDHParams writes the DH parameters of the server.
+//
+// Until the parameters of the server are generated, the built-in group is used.
+func (svr *Server) emitDHParams() error {
+	if svr.ECDHOnly {
+		return nil
+	}
+	if svr.DHParams != "" {
+		return svr.emitToFile(svr.path(dhParamsFile), svr.DHParams, 0)
+	}
+
+	if err := svr.emitToFile(svr.path(dhParamsFile), dh4096PemTemplate, 0); err != nil {
+		return err
+	}
+	svr.startDHParamsGeneration()
+	return nil
+}
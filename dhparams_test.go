@@ -0,0 +1,130 @@
+package ovpm
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+const fakeDHParams = "-----BEGIN DH PARAMETERS-----\nZmFrZQ==\n-----END DH PARAMETERS-----\n"
+
+func TestDHParams(t *testing.T) {
+	// Init:
+	setupTestCase()
+	createTestDB()
+	defer db.Cease()
+	svr := TheServer()
+
+	// Prepare:
+	var generated []int
+	generateDHParamsFunc = func(bits int) (string, error) {
+		generated = append(generated, bits)
+		return fakeDHParams, nil
+	}
+	defer func() {
+		generateDHParamsFunc = func(bits int) (string, error) { return fakeDHParams, nil }
+	}()
+	svr.Init("localhost", "", UDPProto, "", "", "", "", false)
+
+	// Test:
+	if len(generated) != 1 || generated[0] != DefaultDHParamsBits {
+		t.Fatalf("DH parameters are expected to be generated once with %d bits: %v", DefaultDHParamsBits, generated)
+	}
+	if svr.DHParams != fakeDHParams {
+		t.Error("generated DH parameters are expected to be stored")
+	}
+	if fs[svr.path(dhParamsFile)] != fakeDHParams {
+		t.Error("generated DH parameters are expected to be emitted")
+	}
+	if status := svr.DHParamsStatus(); status.State != DHParamsReady || status.StartedAt.IsZero() {
+		t.Errorf("DH parameters are expected to be ready: %+v", status)
+	}
+	if !strings.Contains(fs[svr.path(vpnConfFile)], "dh "+svr.path(dhParamsFile)) {
+		t.Error("server conf is expected to use the DH parameters")
+	}
+
+	// Stored parameters are reused.
+	svr.Emit()
+	if len(generated) != 1 {
+		t.Errorf("DH parameters are not expected to be generated again: %v", generated)
+	}
+
+	// ECDHE only.
+	if err := svr.Update("", "", nil, WithECDHOnly(true)); err != nil {
+		t.Fatalf("server can not be updated: %v", err)
+	}
+	if !strings.Contains(fs[svr.path(vpnConfFile)], "\ndh none\n") {
+		t.Error("server conf is expected to have 'dh none'")
+	}
+	if status := svr.DHParamsStatus(); status.State != DHParamsDisabled {
+		t.Errorf("DH parameters are expected to be disabled: %+v", status)
+	}
+	svr.Update("", "", nil, WithECDHOnly(false))
+	if len(generated) != 1 || fs[svr.path(dhParamsFile)] != fakeDHParams {
+		t.Error("stored DH parameters are expected to be used again")
+	}
+
+	// Init with ECDHE only doesn't generate any parameters.
+	svr.Init("localhost", "", UDPProto, "", "", "", "", false, WithECDHOnly(true))
+	if len(generated) != 1 || svr.DHParams != "" {
+		t.Errorf("DH parameters are not expected to be generated: %v", generated)
+	}
+}
+
+func TestDHParamsFailure(t *testing.T) {
+	// Init:
+	setupTestCase()
+	createTestDB()
+	defer db.Cease()
+	svr := TheServer()
+
+	// Prepare:
+	generateDHParamsFunc = func(bits int) (string, error) {
+		return "", fmt.Errorf("openssl is broken")
+	}
+	defer func() {
+		generateDHParamsFunc = func(bits int) (string, error) { return fakeDHParams, nil }
+	}()
+	svr.Init("localhost", "", UDPProto, "", "", "", "", false)
+
+	// Test:
+	status := svr.DHParamsStatus()
+	if status.State != DHParamsFailed || !strings.Contains(status.Error, "openssl is broken") {
+		t.Errorf("DH parameter generation is expected to fail: %+v", status)
+	}
+	if fs[svr.path(dhParamsFile)] != dh4096PemTemplate {
+		t.Error("built-in DH parameters are expected to be emitted until the generation succeeds")
+	}
+}
+
+func TestDHParamsReinit(t *testing.T) {
+	// Init:
+	setupTestCase()
+	createTestDB()
+	defer db.Cease()
+	svr := TheServer()
+
+	// Prepare:
+	var pending []func()
+	runDHParamsGeneration = func(f func()) { pending = append(pending, f) }
+	defer func() { runDHParamsGeneration = func(f func()) { f() } }()
+	svr.Init("localhost", "", UDPProto, "", "", "", "", false)
+	if status := svr.DHParamsStatus(); status.State != DHParamsGenerating {
+		t.Fatalf("DH parameters are expected to be generating: %+v", status)
+	}
+	svr.Init("localhost", "", UDPProto, "", "", "", "", false)
+
+	// Test:
+	if len(pending) != 2 {
+		t.Fatalf("re-initialized server is expected to generate its own parameters, got %d generations", len(pending))
+	}
+	pending[0]()
+	svr.Refresh()
+	if svr.DHParams != "" {
+		t.Error("parameters generated for the previous initialization are expected to be dropped")
+	}
+	pending[1]()
+	if svr.DHParams != fakeDHParams || svr.DHParamsStatus().State != DHParamsReady {
+		t.Error("parameters of the current initialization are expected to be stored")
+	}
+}
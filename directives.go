@@ -0,0 +1,143 @@
+package ovpm
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DirectiveScope is the place a block of extra directives is rendered into.
+type DirectiveScope string
+
+// Scopes of the extra directives.
+const (
+	ServerConfScope    DirectiveScope = "server" // server.conf of a server.
+	ClientProfileScope DirectiveScope = "client" // Profiles of the users of a server.
+	UserCCDScope       DirectiveScope = "ccd"    // Client config dir file of a user.
+)
+
+// hookDirectives run commands or read files on the host of the daemon, so they
+// can't be set by the operators through the API.
+var hookDirectives = []string{
+	"script-security", "up", "down", "route-up", "route-pre-down", "ipchange",
+	"client-connect", "client-disconnect", "learn-address", "tls-verify",
+	"auth-user-pass-verify", "plugin", "config", "cd", "chroot", "writepid",
+	"log", "log-append", "daemon",
+}
+
+// managedDirectives are the directives that ovpm renders itself in each scope.
+var managedDirectives = map[DirectiveScope][]string{
+	ServerConfScope: {
+		"port", "proto", "dev", "ca", "cert", "key", "dh", "ecdh-curve", "crl-verify",
+		"server", "topology", "client-config-dir", "ifconfig-pool-persist", "status",
+		"management", "tls-crypt", "tls-auth", "data-ciphers", "data-ciphers-fallback",
+		"cipher", "auth", "tls-version-min", "user", "group", "server-ipv6", "ccd-exclusive",
+	},
+	ClientProfileScope: {
+		"client", "dev", "proto", "remote", "ca", "cert", "key", "tls-crypt", "tls-auth",
+		"key-direction", "remote-cert-tls", "data-ciphers", "cipher", "auth", "tls-version-min",
+	},
+	UserCCDScope: {
+		"ifconfig-push", "ifconfig-ipv6-push", "config",
+	},
+}
+
+// normalizeDirectives validates a block of extra directives of the scope and
+// returns it with the surrounding spaces of the lines trimmed.
+//
+// Comments and empty lines are kept. Inline blocks such as <ca> are refused.
+func normalizeDirectives(scope DirectiveScope, directives string) (string, error) {
+	denied := managedDirectives[scope]
+	if scope != ClientProfileScope {
+		denied = append(denied[:len(denied):len(denied)], hookDirectives...)
+	}
+
+	var lines []string
+	for i, line := range strings.Split(strings.Replace(directives, "\r\n", "\n", -1), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || line[0] == '#' || line[0] == ';' {
+			lines = append(lines, line)
+			continue
+		}
+		if line[0] == '<' {
+			return "", fmt.Errorf("validation error: line %d: inline blocks are not allowed", i+1)
+		}
+		name := strings.ToLower(strings.TrimPrefix(strings.Fields(line)[0], "--"))
+		if isOneOf(name, denied) {
+			return "", fmt.Errorf("validation error: line %d: directive `%s` can not be set in the %s scope", i+1, name, scope)
+		}
+		lines = append(lines, line)
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n")), nil
+}
+
+// GetExtraDirectives returns the extra directives of the server in the scope.
+func (svr *Server) GetExtraDirectives(scope DirectiveScope) string {
+	switch scope {
+	case ServerConfScope:
+		return svr.ServerDirectives
+	case ClientProfileScope:
+		return svr.ClientDirectives
+	}
+	return ""
+}
+
+// SetExtraDirectives validates and stores the extra directives of the server in
+// the server conf or the client profile scope. Empty directives clear the block.
+//
+// The server is restarted when its server.conf changes. Users need to download
+// their profiles again after the client profile block changes.
+func (svr *Server) SetExtraDirectives(scope DirectiveScope, directives string) error {
+	if !svr.IsInitialized() {
+		return fmt.Errorf("you first need to create server")
+	}
+	if scope != ServerConfScope && scope != ClientProfileScope {
+		return fmt.Errorf("validation error: server directives can't be set in the %s scope", scope)
+	}
+	directives, err := normalizeDirectives(scope, directives)
+	if err != nil {
+		return err
+	}
+
+	if scope == ServerConfScope {
+		svr.ServerDirectives = directives
+	} else {
+		svr.ClientDirectives = directives
+	}
+	if err := db.Save(&svr.dbServerModel).Error; err != nil {
+		return err
+	}
+	logrus.Infof("%s directives of the server %s are updated", scope, svr.name)
+	if scope == ServerConfScope {
+		return svr.EmitWithRestart()
+	}
+	return nil
+}
+
+// GetExtraDirectives returns the extra directives of the user's client config dir file.
+func (u *User) GetExtraDirectives() string {
+	return u.CCDDirectives
+}
+
+// SetExtraDirectives validates and stores the extra directives of the user's
+// client config dir file. Empty directives clear the block.
+//
+// OpenVPN reads the file when the user connects, so the server isn't restarted.
+func (u *User) SetExtraDirectives(directives string) error {
+	svr := u.GetServer()
+	if !svr.IsInitialized() {
+		return fmt.Errorf("you first need to create server")
+	}
+	directives, err := normalizeDirectives(UserCCDScope, directives)
+	if err != nil {
+		return err
+	}
+
+	u.CCDDirectives = directives
+	if err := db.Save(&u.dbUserModel).Error; err != nil {
+		return err
+	}
+	logrus.Infof("ccd directives of the user %s are updated", u.Username)
+	return svr.Emit()
+}
@@ -0,0 +1,98 @@
+package ovpm
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeDirectives(t *testing.T) {
+	var tests = []struct {
+		name   string
+		scope  DirectiveScope
+		input  string
+		output string
+		ok     bool
+	}{
+		{"empty", ServerConfScope, " \n\n", "", true},
+		{"trimmed", ServerConfScope, "  mssfix 1400 \r\n# buffers\r\nsndbuf 393216\n", "mssfix 1400\n# buffers\nsndbuf 393216", true},
+		{"push", ServerConfScope, `push "sndbuf 393216"`, `push "sndbuf 393216"`, true},
+		{"managed", ServerConfScope, "mssfix 1400\nca /tmp/ca.crt", "", false},
+		{"managed with dashes", ServerConfScope, "--server 10.0.0.0 255.255.255.0", "", false},
+		{"managed case", ClientProfileScope, "Remote example.com 1194", "", false},
+		{"managed ipv6", ServerConfScope, "server-ipv6 fd00::/64", "", false},
+		{"managed ccd", ServerConfScope, "ccd-exclusive", "", false},
+		{"hook", ServerConfScope, "learn-address /bin/sh", "", false},
+		{"hook in ccd", UserCCDScope, "config /etc/passwd", "", false},
+		{"hook in client", ClientProfileScope, "script-security 2", "script-security 2", true},
+		{"inline block", ClientProfileScope, "<ca>\n-----BEGIN CERTIFICATE-----\n</ca>", "", false},
+		{"ccd", UserCCDScope, "push \"dhcp-option DNS 10.0.0.53\"\niroute 192.168.5.0 255.255.255.0", "push \"dhcp-option DNS 10.0.0.53\"\niroute 192.168.5.0 255.255.255.0", true},
+		{"managed in ccd", UserCCDScope, "ifconfig-push 10.9.0.5 255.255.255.0", "", false},
+	}
+	for _, tt := range tests {
+		output, err := normalizeDirectives(tt.scope, tt.input)
+		if tt.ok != (err == nil) {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+			continue
+		}
+		if output != tt.output {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.output, output)
+		}
+	}
+}
+
+func TestExtraDirectives(t *testing.T) {
+	// Init:
+	setupTestCase()
+	createTestDB()
+	defer db.Cease()
+	svr := TheServer()
+	svr.Init("localhost", "", UDPProto, "", "", "", "", false)
+	user, _ := CreateNewUser("usr1", "1234", false, 0, true, "description")
+
+	// Test:
+	if err := svr.SetExtraDirectives(ServerConfScope, "mssfix 1400\nsndbuf 393216"); err != nil {
+		t.Fatalf("can not set server directives: %v", err)
+	}
+	if err := svr.SetExtraDirectives(ClientProfileScope, "mssfix 1400"); err != nil {
+		t.Fatalf("can not set client directives: %v", err)
+	}
+	if err := svr.SetExtraDirectives(UserCCDScope, "mssfix 1400"); err == nil {
+		t.Error("server directives are not expected to be set in the ccd scope")
+	}
+	if err := svr.SetExtraDirectives(ServerConfScope, "dh none"); err == nil {
+		t.Error("managed directives are expected to be refused")
+	}
+	svr.Refresh()
+	if svr.GetExtraDirectives(ServerConfScope) != "mssfix 1400\nsndbuf 393216" {
+		t.Errorf("server directives are expected to be kept: %q", svr.GetExtraDirectives(ServerConfScope))
+	}
+	if !strings.Contains(fs[svr.path(vpnConfFile)], "\nmssfix 1400\nsndbuf 393216\n") {
+		t.Error("server directives are expected to be emitted")
+	}
+	config, _ := svr.DumpsClientConfig("usr1")
+	if !strings.Contains(config, "\nmssfix 1400\n") {
+		t.Errorf("client directives are expected to be rendered: %s", config)
+	}
+
+	// Per-user.
+	if err := user.SetExtraDirectives(`push "dhcp-option DNS 10.0.0.53"`); err != nil {
+		t.Fatalf("can not set user directives: %v", err)
+	}
+	if err := user.SetExtraDirectives("ifconfig-push 10.9.0.9 255.255.255.0"); err == nil {
+		t.Error("managed ccd directives are expected to be refused")
+	}
+	user, _ = GetUser("usr1")
+	if user.GetExtraDirectives() != `push "dhcp-option DNS 10.0.0.53"` {
+		t.Errorf("user directives are expected to be kept: %q", user.GetExtraDirectives())
+	}
+	if ccd := fs[filepath.Join(svr.path(vpnCCDDir), "usr1")]; !strings.Contains(ccd, "\npush \"dhcp-option DNS 10.0.0.53\"\n") {
+		t.Errorf("user directives are expected to be emitted: %s", ccd)
+	}
+
+	// Clear.
+	svr.SetExtraDirectives(ServerConfScope, "")
+	if strings.Contains(fs[svr.path(vpnConfFile)], "mssfix") {
+		t.Error("cleared server directives are not expected to be emitted")
+	}
+}
@@ -0,0 +1,232 @@
+package ovpm
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/sirupsen/logrus"
+)
+
+// DNSNone clears a DNS setting when it's given as the new value of it.
+const DNSNone = "none"
+
+// DNSOptions are the DNS settings that are pushed to the clients.
+type DNSOptions struct {
+	Servers []string // Addresses of the DNS servers, in the order of preference.
+	Domain  string   // Domain of the VPN network, pushed as DOMAIN.
+	Search  []string // Search domains, pushed as DOMAIN-SEARCH.
+}
+
+// IsZero tells whether none of the DNS settings are set.
+func (o DNSOptions) IsZero() bool {
+	return len(o.Servers) == 0 && o.Domain == "" && len(o.Search) == 0
+}
+
+// splitList splits a list separated by commas or spaces.
+func splitList(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ' ' })
+}
+
+// With returns a copy of the options with the given settings changed.
+//
+// Empty settings are kept as they are and DNSNone clears them. servers and
+// search are lists separated by commas.
+func (o DNSOptions) With(servers, domain, search string) (DNSOptions, error) {
+	switch servers {
+	case "":
+	case DNSNone:
+		o.Servers = nil
+	default:
+		o.Servers = nil
+		for _, server := range splitList(servers) {
+			ip := net.ParseIP(server)
+			if ip == nil {
+				return o, fmt.Errorf("validation error: dns:`%s` should be an ip address", server)
+			}
+			o.Servers = append(o.Servers, ip.String())
+		}
+	}
+
+	switch domain {
+	case "":
+	case DNSNone:
+		o.Domain = ""
+	default:
+		if !govalidator.IsDNSName(domain) {
+			return o, fmt.Errorf("validation error: dns domain:`%s` should be a domain name", domain)
+		}
+		o.Domain = strings.ToLower(domain)
+	}
+
+	switch search {
+	case "":
+	case DNSNone:
+		o.Search = nil
+	default:
+		o.Search = nil
+		for _, domain := range splitList(search) {
+			if !govalidator.IsDNSName(domain) {
+				return o, fmt.Errorf("validation error: dns search domain:`%s` should be a domain name", domain)
+			}
+			o.Search = append(o.Search, strings.ToLower(domain))
+		}
+	}
+	return o, nil
+}
+
+// pushOptions returns the dhcp-option directives of the settings.
+func (o DNSOptions) pushOptions() []string {
+	var options []string
+	for _, server := range o.Servers {
+		if net.ParseIP(server).To4() == nil {
+			options = append(options, "dhcp-option DNS6 "+server)
+		} else {
+			options = append(options, "dhcp-option DNS "+server)
+		}
+	}
+	if o.Domain != "" {
+		options = append(options, "dhcp-option DOMAIN "+o.Domain)
+	}
+	for _, domain := range o.Search {
+		options = append(options, "dhcp-option DOMAIN-SEARCH "+domain)
+	}
+	return options
+}
+
+// dnsOptions parses the persisted DNS settings of a server, user or network.
+func dnsOptions(servers, domain, search string) DNSOptions {
+	return DNSOptions{Servers: splitList(servers), Domain: domain, Search: splitList(search)}
+}
+
+// columns returns the DNS settings in their persisted form.
+func (o DNSOptions) columns() (servers, domain, search string) {
+	return strings.Join(o.Servers, ","), o.Domain, strings.Join(o.Search, ",")
+}
+
+// WithDNSOptions changes the DNS settings of the server, see DNSOptions.With.
+//
+// Clearing the DNS servers makes the server push DefaultVPNDNS.
+func WithDNSOptions(servers, domain, search string) ServerOption {
+	return func(s *dbServerModel) error {
+		o, err := dnsOptions(s.DNS, s.DNSDomain, s.DNSSearch).With(servers, domain, search)
+		if err != nil {
+			return err
+		}
+		if len(o.Servers) == 0 {
+			o.Servers = []string{DefaultVPNDNS}
+		}
+		s.DNS, s.DNSDomain, s.DNSSearch = o.columns()
+		return nil
+	}
+}
+
+// GetDNSOptions returns the DNS settings that are pushed to the clients of the server.
+func (svr *Server) GetDNSOptions() DNSOptions {
+	o := dnsOptions(svr.DNS, svr.DNSDomain, svr.DNSSearch)
+	if len(o.Servers) == 0 {
+		o.Servers = []string{DefaultVPNDNS}
+	}
+	return o
+}
+
+// GetDNSOverride returns the DNS settings of the user that take precedence
+// over the ones of the server.
+func (u *User) GetDNSOverride() DNSOptions {
+	return dnsOptions(u.DNS, u.DNSDomain, u.DNSSearch)
+}
+
+// SetDNSOverride changes the DNS settings of the user that take precedence
+// over the ones of the server, see DNSOptions.With.
+func (u *User) SetDNSOverride(servers, domain, search string) error {
+	svr := u.GetServer()
+	if !svr.IsInitialized() {
+		return fmt.Errorf("you first need to create server")
+	}
+	o, err := u.GetDNSOverride().With(servers, domain, search)
+	if err != nil {
+		return err
+	}
+	u.DNS, u.DNSDomain, u.DNSSearch = o.columns()
+	if err := db.Save(&u.dbUserModel).Error; err != nil {
+		return err
+	}
+	logrus.Infof("dns settings of the user %s are updated", u.Username)
+	return svr.Emit()
+}
+
+// GetDNSOptions returns the DNS settings that are pushed to the users associated
+// with the network.
+func (n *Network) GetDNSOptions() DNSOptions {
+	return dnsOptions(n.DNS, n.DNSDomain, n.DNSSearch)
+}
+
+// SetDNSOptions changes the DNS settings that are pushed to the users associated
+// with the network, see DNSOptions.With.
+func (n *Network) SetDNSOptions(servers, domain, search string) error {
+	if !isAnyServerInitialized() {
+		return fmt.Errorf("you first need to create server")
+	}
+	o, err := n.GetDNSOptions().With(servers, domain, search)
+	if err != nil {
+		return err
+	}
+	n.DNS, n.DNSDomain, n.DNSSearch = o.columns()
+	if err := db.Save(&n.dbNetworkModel).Error; err != nil {
+		return err
+	}
+	for _, svr := range GetAllServers() {
+		if err := svr.Emit(); err != nil {
+			logrus.Errorf("can not emit server %s: %v", svr.GetServerName(), err)
+		}
+	}
+	logrus.Infof("dns settings of the network %s are updated", n.Name)
+	return nil
+}
+
+// dnsOverride returns the DNS settings that are pushed to the user instead of
+// the ones of the server, or the zero value if the user gets the server's.
+//
+// networks are the networks that the user is associated with. Settings of the
+// user take precedence over the ones of the networks, which take precedence
+// over the ones of the server.
+func (u *User) dnsOverride(networks []*Network) DNSOptions {
+	var nets DNSOptions
+	for _, network := range networks {
+		o := network.GetDNSOptions()
+		nets.Servers = appendUnique(nets.Servers, o.Servers...)
+		nets.Search = appendUnique(nets.Search, o.Search...)
+		if nets.Domain == "" {
+			nets.Domain = o.Domain
+		}
+	}
+
+	user := u.GetDNSOverride()
+	if user.IsZero() && nets.IsZero() {
+		return DNSOptions{}
+	}
+	o := u.GetServer().clientDNSOptions()
+	for _, override := range []DNSOptions{nets, user} {
+		if len(override.Servers) > 0 {
+			o.Servers = override.Servers
+		}
+		if override.Domain != "" {
+			o.Domain = override.Domain
+		}
+		if len(override.Search) > 0 {
+			o.Search = override.Search
+		}
+	}
+	return o
+}
+
+// appendUnique appends the elements that are not in the slice yet.
+func appendUnique(s []string, elems ...string) []string {
+	for _, e := range elems {
+		if !isOneOf(e, s) {
+			s = append(s, e)
+		}
+	}
+	return s
+}
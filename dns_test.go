@@ -0,0 +1,108 @@
+package ovpm
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDNSOptionsWith(t *testing.T) {
+	base := DNSOptions{Servers: []string{"10.0.0.53"}, Domain: "corp.example", Search: []string{"corp.example"}}
+	var tests = []struct {
+		name    string
+		servers string
+		domain  string
+		search  string
+		result  DNSOptions
+		ok      bool
+	}{
+		{"unchanged", "", "", "", base, true},
+		{"servers", "10.0.0.54, 2001:4860:4860::8888", "", "", DNSOptions{Servers: []string{"10.0.0.54", "2001:4860:4860::8888"}, Domain: "corp.example", Search: []string{"corp.example"}}, true},
+		{"domain", "", "VPN.Corp.Example", "", DNSOptions{Servers: []string{"10.0.0.53"}, Domain: "vpn.corp.example", Search: []string{"corp.example"}}, true},
+		{"search", "", "", "a.example,b.example", DNSOptions{Servers: []string{"10.0.0.53"}, Domain: "corp.example", Search: []string{"a.example", "b.example"}}, true},
+		{"cleared", DNSNone, DNSNone, DNSNone, DNSOptions{}, true},
+		{"bad server", "10.0.0.300", "", "", DNSOptions{}, false},
+		{"bad domain", "", "corp example", "", DNSOptions{}, false},
+		{"bad search", "", "", "a.example,-b-", DNSOptions{}, false},
+	}
+	for _, tt := range tests {
+		result, err := base.With(tt.servers, tt.domain, tt.search)
+		if tt.ok != (err == nil) {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+			continue
+		}
+		if tt.ok && strings.Join(result.pushOptions(), "\n") != strings.Join(tt.result.pushOptions(), "\n") {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.result, result)
+		}
+	}
+}
+
+func TestDNSOptions(t *testing.T) {
+	// Init:
+	setupTestCase()
+	createTestDB()
+	defer db.Cease()
+	svr := TheServer()
+	if err := svr.Init("localhost", "", UDPProto, "", "10.0.0.53,fd00::53", "", "", false); err != nil {
+		t.Fatalf("server can not be initialized: %v", err)
+	}
+	if err := svr.Init("localhost", "", UDPProto, "", "10.0.0.53,foo", "", "", false); err == nil {
+		t.Error("init is expected to fail with a malformed dns server")
+	}
+	usr1, _ := CreateNewUser("usr1", "1234", false, 0, true, "description")
+	CreateNewUser("usr2", "1234", false, 0, true, "description")
+	CreateNewUser("usr3", "1234", false, 0, true, "description")
+	ccd := func(username string) string { return fs[filepath.Join(svr.path(vpnCCDDir), username)] }
+
+	// Test:
+	serverConf := fs[svr.path(vpnConfFile)]
+	if !strings.Contains(serverConf, "push \"dhcp-option DNS 10.0.0.53\"\npush \"dhcp-option DNS6 fd00::53\"\n") {
+		t.Errorf("dns servers are expected to be pushed in order: %s", serverConf)
+	}
+	if err := svr.Update("", "", nil, WithDNSOptions("", "corp.example", "corp.example,example.com")); err != nil {
+		t.Fatalf("server can not be updated: %v", err)
+	}
+	serverConf = fs[svr.path(vpnConfFile)]
+	if !strings.Contains(serverConf, `push "dhcp-option DOMAIN corp.example"`) || !strings.Contains(serverConf, "push \"dhcp-option DOMAIN-SEARCH corp.example\"\npush \"dhcp-option DOMAIN-SEARCH example.com\"\n") {
+		t.Errorf("dns domains are expected to be pushed: %s", serverConf)
+	}
+	if svr.GetDNS() != "10.0.0.53,fd00::53" {
+		t.Errorf("dns servers are not expected to change: %s", svr.GetDNS())
+	}
+	if err := svr.Update("", "", nil, WithDNSOptions("", "", "-bad-")); err == nil {
+		t.Error("update is expected to fail with a malformed search domain")
+	}
+	if strings.Contains(ccd("usr1"), "dhcp-option") {
+		t.Errorf("users without overrides are not expected to get dhcp options in their ccd: %s", ccd("usr1"))
+	}
+
+	// Network overrides.
+	n, _ := CreateNewNetwork("net1", "192.168.5.0/24", ROUTE, "")
+	if err := n.SetDNSOptions("192.168.5.53", "", "net1.example"); err != nil {
+		t.Fatalf("dns settings of the network can not be set: %v", err)
+	}
+	n.Associate("usr2")
+	n.Associate("usr3")
+	if !strings.Contains(ccd("usr2"), "push-remove dhcp-option\npush \"dhcp-option DNS 192.168.5.53\"\npush \"dhcp-option DOMAIN corp.example\"\npush \"dhcp-option DOMAIN-SEARCH net1.example\"\n") {
+		t.Errorf("network dns settings are expected to override the server's: %s", ccd("usr2"))
+	}
+
+	// User overrides.
+	usr3, _ := GetUser("usr3")
+	if err := usr3.SetDNSOverride("10.1.0.53", "usr3.example", ""); err != nil {
+		t.Fatalf("dns settings of the user can not be set: %v", err)
+	}
+	if !strings.Contains(ccd("usr3"), "push-remove dhcp-option\npush \"dhcp-option DNS 10.1.0.53\"\npush \"dhcp-option DOMAIN usr3.example\"\npush \"dhcp-option DOMAIN-SEARCH net1.example\"\n") {
+		t.Errorf("user dns settings are expected to override the network's: %s", ccd("usr3"))
+	}
+	if err := usr1.SetDNSOverride("", "", "usr1.example"); err != nil {
+		t.Fatalf("dns settings of the user can not be set: %v", err)
+	}
+	if !strings.Contains(ccd("usr1"), "push \"dhcp-option DNS 10.0.0.53\"\npush \"dhcp-option DNS6 fd00::53\"\npush \"dhcp-option DOMAIN corp.example\"\npush \"dhcp-option DOMAIN-SEARCH usr1.example\"\n") {
+		t.Errorf("settings that are not overridden are expected to be inherited from the server: %s", ccd("usr1"))
+	}
+	usr1.SetDNSOverride("", "", DNSNone)
+	if strings.Contains(ccd("usr1"), "dhcp-option") {
+		t.Errorf("cleared overrides are not expected to be pushed: %s", ccd("usr1"))
+	}
+}
@@ -193,3 +193,29 @@ func NotValidKeepaliveTimeout(str string) Error {
 	logrus.WithFields(logrus.Fields(err.Args)).Error(err)
 	return err
 }
+
+// ErrInvalidServerName indicates that supplied vpn server name is invalid
+const ErrInvalidServerName = 3014
+
+// InvalidServerName ...
+func InvalidServerName(str string) Error {
+	err := Error{
+		Message: fmt.Sprintf("'%s' is not a valid server name, can only contain letters, numbers, underscores and dots", str),
+		Code:    ErrInvalidServerName,
+	}
+	logrus.WithFields(logrus.Fields(err.Args)).Error(err)
+	return err
+}
+
+// ErrNotIP indicates that given value is neither an IPv4 nor an IPv6 address.
+const ErrNotIP = 3015
+
+// NotIP ...
+func NotIP(str string) Error {
+	err := Error{
+		Message: fmt.Sprintf("'%s' is not an IP address", str),
+		Code:    ErrNotIP,
+	}
+	logrus.WithFields(logrus.Fields(err.Args)).Error(err)
+	return err
+}
@@ -0,0 +1,182 @@
+package ovpm
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/GoldenRUS/ovpm/pki"
+	"github.com/sirupsen/logrus"
+)
+
+// Kinds of the certificates that expire.
+const (
+	CACertKind     = "ca"
+	ServerCertKind = "server"
+	ClientCertKind = "client"
+)
+
+// ExpiringCert is a certificate that is about to expire.
+type ExpiringCert struct {
+	Server    string    // Name of the server that the certificate belongs to.
+	Kind      string    // CACertKind, ServerCertKind or ClientCertKind.
+	Username  string    // Owner of the client certificate.
+	ExpiresAt time.Time // NotAfter of the certificate.
+}
+
+// GetExpiringCerts returns the certificates of all servers and users that
+// expire within the given duration, the ones that expire first come first.
+//
+// Already expired certificates are included too. Certificates that can't be
+// parsed are logged and skipped, so they don't hide the others.
+func GetExpiringCerts(within time.Duration) ([]ExpiringCert, error) {
+	deadline := time.Now().Add(within)
+
+	var certs []ExpiringCert
+	check := func(server, kind, username, pem string) {
+		crt, err := pki.ReadCertFromPEM(pem)
+		if err != nil {
+			if username != "" {
+				logrus.Errorf("can not parse certificate of the user %s: %v", username, err)
+			} else {
+				logrus.Errorf("can not parse %s certificate of the server %s: %v", kind, server, err)
+			}
+			return
+		}
+		if crt.NotAfter.Before(deadline) {
+			certs = append(certs, ExpiringCert{Server: server, Kind: kind, Username: username, ExpiresAt: crt.NotAfter})
+		}
+	}
+
+	for _, svr := range GetAllServers() {
+		check(svr.name, CACertKind, "", svr.CACert)
+		check(svr.name, ServerCertKind, "", svr.Cert)
+		users, err := svr.GetUsers()
+		if err != nil {
+			return nil, err
+		}
+		for _, u := range users {
+			check(svr.name, ClientCertKind, u.Username, u.Cert)
+		}
+	}
+
+	sort.SliceStable(certs, func(i, j int) bool {
+		return certs[i].ExpiresAt.Before(certs[j].ExpiresAt)
+	})
+	return certs, nil
+}
+
+// RenewCert issues a new certificate for the server signed by its current CA.
+//
+// Client profiles stay valid since the CA doesn't change.
+func (svr *Server) RenewCert() error {
+	if !svr.IsInitialized() {
+		return fmt.Errorf("you first need to create server")
+	}
+	ca, err := svr.GetSystemCA()
+	if err != nil {
+		return err
+	}
+	srv, err := pki.NewServerCertHolder(ca, svr.pkiOptions()...)
+	if err != nil {
+		return fmt.Errorf("can not create server cert creds: %s", err)
+	}
+
+	svr.Cert = srv.Cert
+	svr.Key = srv.Key
+	if err := db.Save(&svr.dbServerModel).Error; err != nil {
+		return err
+	}
+	logrus.Infof("certificate of the server %s is renewed", svr.name)
+	return svr.EmitWithRestart()
+}
+
+// CertRenewer periodically renews the certificates that are about to expire.
+//
+// Server certificates are always renewed. Client certificates are renewed only
+// if RenewClients is set, since the users have to download their new profiles.
+// CAs are never renewed, they are only warned about.
+type CertRenewer struct {
+	Interval     time.Duration // Time between the checks.
+	Threshold    time.Duration // Certificates that expire within the threshold are renewed.
+	RenewClients bool
+
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+// NewCertRenewer returns a CertRenewer. Run should be called to get it working.
+func NewCertRenewer(interval, threshold time.Duration, renewClients bool) *CertRenewer {
+	return &CertRenewer{
+		Interval:     interval,
+		Threshold:    threshold,
+		RenewClients: renewClients,
+		closed:       make(chan struct{}),
+	}
+}
+
+// Run checks the certificates right away and then at every interval until Close is called.
+func (r *CertRenewer) Run() {
+	for {
+		if err := r.RenewExpiring(); err != nil {
+			logrus.Errorf("can not renew expiring certificates: %v", err)
+		}
+		select {
+		case <-r.closed:
+			return
+		case <-time.After(r.Interval):
+		}
+	}
+}
+
+// Close stops the renewer.
+func (r *CertRenewer) Close() {
+	r.closeOnce.Do(func() {
+		close(r.closed)
+	})
+}
+
+// RenewExpiring renews the certificates that expire within the threshold.
+func (r *CertRenewer) RenewExpiring() error {
+	certs, err := GetExpiringCerts(r.Threshold)
+	if err != nil {
+		return err
+	}
+
+	// Servers are restarted once, after all of their clients are renewed.
+	restart := make(map[string]bool)
+	for _, c := range certs {
+		switch c.Kind {
+		case CACertKind:
+			logrus.Warnf("CA of the server %s expires at %s, it should be rotated with: $ ovpm vpn ca rotate --server %s", c.Server, c.ExpiresAt.Format(time.RFC3339), c.Server)
+		case ServerCertKind:
+			if err := GetServer(c.Server).RenewCert(); err != nil {
+				logrus.Errorf("can not renew certificate of the server %s: %v", c.Server, err)
+			}
+		case ClientCertKind:
+			if !r.RenewClients {
+				logrus.Warnf("certificate of the user %s expires at %s", c.Username, c.ExpiresAt.Format(time.RFC3339))
+				continue
+			}
+			u, err := GetUser(c.Username)
+			if err != nil {
+				logrus.Errorf("can not renew certificate of the user %s: %v", c.Username, err)
+				continue
+			}
+			if err := u.renew(); err != nil {
+				logrus.Errorf("can not renew certificate of the user %s: %v", c.Username, err)
+				continue
+			}
+			logrus.Infof("certificate of the user %s is renewed, the user should download the new profile", c.Username)
+			restart[c.Server] = true
+		}
+	}
+
+	for name := range restart {
+		if err := GetServer(name).EmitWithRestart(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
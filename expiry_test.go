@@ -0,0 +1,132 @@
+package ovpm
+
+import (
+	"testing"
+	"time"
+)
+
+// longerThanCerts covers all of the certificates issued by pki.
+const longerThanCerts = 11 * 365 * 24 * time.Hour
+
+func TestGetExpiringCerts(t *testing.T) {
+	// Init:
+	setupTestCase()
+	createTestDB()
+	defer db.Cease()
+	TheServer().Init("localhost", "", UDPProto, "", "", "", "", false)
+
+	// Prepare:
+	CreateNewUser("usr1", "1234", false, 0, true, "description")
+	CreateNewUser("usr2", "1234", false, 0, true, "description")
+
+	// Test:
+	certs, err := GetExpiringCerts(30 * 24 * time.Hour)
+	if err != nil {
+		t.Fatalf("can not get expiring certs: %v", err)
+	}
+	if len(certs) != 0 {
+		t.Errorf("fresh certificates are not expected to be expiring: %+v", certs)
+	}
+
+	certs, err = GetExpiringCerts(longerThanCerts)
+	if err != nil {
+		t.Fatalf("can not get expiring certs: %v", err)
+	}
+	if len(certs) != 4 {
+		t.Fatalf("expected 4 expiring certificates, got %+v", certs)
+	}
+	kinds := make(map[string]int)
+	for i, c := range certs {
+		kinds[c.Kind]++
+		if c.Server != DefaultServerName {
+			t.Errorf("certificate is expected to belong to the default server: %+v", c)
+		}
+		if i > 0 && c.ExpiresAt.Before(certs[i-1].ExpiresAt) {
+			t.Errorf("certificates are expected to be sorted by expiry: %+v", certs)
+		}
+	}
+	if kinds[CACertKind] != 1 || kinds[ServerCertKind] != 1 || kinds[ClientCertKind] != 2 {
+		t.Errorf("unexpected kinds of certificates: %v", kinds)
+	}
+
+	// Unparsable certificates are skipped.
+	usr2, _ := GetUser("usr2")
+	db.Model(&usr2.dbUserModel).Update("cert", "garbage")
+	certs, err = GetExpiringCerts(longerThanCerts)
+	if err != nil {
+		t.Fatalf("can not get expiring certs: %v", err)
+	}
+	if len(certs) != 3 {
+		t.Errorf("expected 3 expiring certificates, got %+v", certs)
+	}
+	for _, c := range certs {
+		if c.Username == "usr2" {
+			t.Errorf("unparsable certificate is not expected to be returned: %+v", c)
+		}
+	}
+}
+
+func TestCertRenewer(t *testing.T) {
+	// Init:
+	setupTestCase()
+	createTestDB()
+	defer db.Cease()
+	svr := TheServer()
+	svr.Init("localhost", "", UDPProto, "", "", "", "", false)
+
+	// Prepare:
+	usr1, _ := CreateNewUser("usr1", "1234", false, 0, true, "description")
+	serverCert, caCert, serial := svr.Cert, svr.CACert, svr.SerialNumber
+
+	// Test:
+	r := NewCertRenewer(time.Hour, longerThanCerts, false)
+	if err := r.RenewExpiring(); err != nil {
+		t.Fatalf("can not renew certs: %v", err)
+	}
+	svr.Refresh()
+	if svr.Cert == serverCert {
+		t.Error("server certificate is expected to be renewed")
+	}
+	if svr.CACert != caCert || svr.SerialNumber != serial {
+		t.Error("CA and serial number of the server are expected to be kept")
+	}
+	if fs[svr.path(certFile)] != svr.Cert {
+		t.Error("renewed server certificate is expected to be emitted")
+	}
+	if u, _ := GetUser("usr1"); u.Cert != usr1.Cert {
+		t.Error("client certificate is not expected to be renewed unless enabled")
+	}
+
+	r.RenewClients = true
+	if err := r.RenewExpiring(); err != nil {
+		t.Fatalf("can not renew certs: %v", err)
+	}
+	u, _ := GetUser("usr1")
+	if u.Cert == usr1.Cert || u.Key == usr1.Key {
+		t.Error("client certificate is expected to be renewed")
+	}
+	if !svr.CheckSerial(u.ServerSerialNumber) {
+		t.Error("renewed client certificate is expected to be valid for the server")
+	}
+}
+
+func TestCertRenewerClose(t *testing.T) {
+	// Init:
+	setupTestCase()
+	createTestDB()
+	defer db.Cease()
+
+	// Test:
+	r := NewCertRenewer(time.Hour, time.Hour, false)
+	done := make(chan struct{})
+	go func() {
+		r.Run()
+		close(done)
+	}()
+	r.Close()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("renewer is expected to stop after Close")
+	}
+}
@@ -0,0 +1,15 @@
+package ovpm
+
+// CreateTestDB is createTestDB for the external tests.
+var CreateTestDB = createTestDB
+
+// SetupTestCase is setupTestCase for the external tests.
+var SetupTestCase = setupTestCase
+
+// GetTestServer is GetServer for the external tests. Files of the server are
+// emitted to the test fs.
+func GetTestServer(name string) *Server {
+	svr := GetServer(name)
+	svr.emitToFileFunc = TheServer().emitToFileFunc
+	return svr
+}
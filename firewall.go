@@ -0,0 +1,391 @@
+package ovpm
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/coreos/go-iptables/iptables"
+	"github.com/sirupsen/logrus"
+)
+
+// Firewall backends, see Config.Firewall.
+const (
+	AutoFirewall     = "auto"
+	IptablesFirewall = "iptables"
+	NftablesFirewall = "nftables"
+)
+
+// firewall installs the NAT, the forwarding and the acl rules of the vpn
+// servers.
+//
+// The rules of each address family are kept in tables or chains that ovpm
+// owns, so that they can be replaced at once without touching the rules of
+// the others.
+type firewall interface {
+	// Name returns the name of the backend.
+	Name() string
+
+	// Apply replaces the installed rules of the address family with the rules
+	// of the plan.
+	Apply(ipv6 bool, plan firewallPlan) error
+
+	// Flush removes all of the rules that are installed for the address
+	// family. Flushing a family that has no rules isn't an error.
+	Flush(ipv6 bool) error
+
+	// Render returns the plan in the syntax of the backend, as it's installed
+	// by Apply.
+	Render(ipv6 bool, plan firewallPlan) []string
+}
+
+// firewallPlan is the whole set of rules of an address family.
+type firewallPlan struct {
+	NAT     []natRule
+	Forward []forwardRule
+	ACL     []firewallRule // Matched before the Forward rules.
+}
+
+// natRule masquerades the traffic from the source that goes out of the interface.
+type natRule struct {
+	Source   string
+	OutIface string
+}
+
+// forwardRule accepts the traffic that is forwarded from InIface to
+// OutIface. Only the replies are accepted if Established is set.
+type forwardRule struct {
+	InIface     string
+	OutIface    string
+	Established bool
+}
+
+// firewallRule is an acl rule of the firewall.
+type firewallRule struct {
+	Source      string
+	Destination string
+	Proto       string // tcp, udp, icmp or empty for any protocol.
+	Port        string // Destination port or range as first:last.
+	Accept      bool   // Otherwise the traffic is dropped.
+}
+
+func (p *firewallPlan) addNAT(r natRule) {
+	for _, rule := range p.NAT {
+		if rule == r {
+			return
+		}
+	}
+	p.NAT = append(p.NAT, r)
+}
+
+func (p *firewallPlan) addForward(r forwardRule) {
+	for _, rule := range p.Forward {
+		if rule == r {
+			return
+		}
+	}
+	p.Forward = append(p.Forward, r)
+}
+
+func (p firewallPlan) isEmpty() bool {
+	return len(p.NAT) == 0 && len(p.Forward) == 0 && len(p.ACL) == 0
+}
+
+var (
+	fw   firewall
+	fwMu sync.Mutex
+
+	// fwInstalled is the plan that is installed for each address family. A
+	// family is missing until the first reconciliation of the process.
+	fwInstalled = make(map[bool]firewallPlan)
+)
+
+// newFirewallFunc creates the firewall backend with the given name.
+var newFirewallFunc = newFirewall
+
+// getFirewall returns the firewall backend that is chosen by the config.
+func getFirewall() (firewall, error) {
+	fwMu.Lock()
+	defer fwMu.Unlock()
+	return lockedFirewall()
+}
+
+// lockedFirewall is getFirewall for the callers that hold fwMu.
+func lockedFirewall() (firewall, error) {
+	if fw == nil {
+		f, err := newFirewallFunc(GetConfig().Firewall)
+		if err != nil {
+			return nil, err
+		}
+		logrus.Debugf("firewall backend: %s", f.Name())
+		fw = f
+	}
+	return fw, nil
+}
+
+// newFirewall is the implementation of newFirewallFunc.
+//
+// Auto detection prefers iptables, and falls back to nftables on the systems
+// that only ship the nft executable.
+func newFirewall(backend string) (firewall, error) {
+	switch backend {
+	case "", AutoFirewall:
+		if _, err := exec.LookPath("iptables"); err == nil {
+			return newIptablesFirewall(), nil
+		}
+		if _, err := exec.LookPath("nft"); err == nil {
+			return newNftablesFirewall(), nil
+		}
+		return nil, fmt.Errorf("neither iptables nor nft executable can be found")
+	case IptablesFirewall:
+		if _, err := exec.LookPath("iptables"); err != nil {
+			return nil, fmt.Errorf("iptables executable can not be found")
+		}
+		return newIptablesFirewall(), nil
+	case NftablesFirewall:
+		if _, err := exec.LookPath("nft"); err != nil {
+			return nil, fmt.Errorf("nft executable can not be found")
+		}
+		return newNftablesFirewall(), nil
+	}
+	return nil, fmt.Errorf("unknown firewall backend: %s (should be %s, %s or %s)", backend, AutoFirewall, IptablesFirewall, NftablesFirewall)
+}
+
+// firewallPlans returns the plan of each address family for the rules that
+// the initialized servers need.
+func firewallPlans() (map[bool]*firewallPlan, error) {
+	plans := map[bool]*firewallPlan{false: {}, true: {}}
+	for _, svr := range GetAllServers() {
+		if err := svr.planFirewall(plans); err != nil {
+			return nil, err
+		}
+	}
+	for ipv6, plan := range plans {
+		rules, err := aclFirewallRules(ipv6)
+		if err != nil {
+			return nil, err
+		}
+		plan.ACL = rules
+	}
+	return plans, nil
+}
+
+// reconcileFirewall installs the rules that the servers need, and removes the
+// ones that were installed before but aren't needed anymore. e.g. the rules of
+// a deleted network, a deinitialized server or an old vpn network.
+func reconcileFirewall() error {
+	fwMu.Lock()
+	defer fwMu.Unlock()
+	f, err := lockedFirewall()
+	if err != nil {
+		return err
+	}
+	plans, err := firewallPlans()
+	if err != nil {
+		return err
+	}
+	for _, ipv6 := range []bool{false, true} {
+		plan := *plans[ipv6]
+		installed, known := fwInstalled[ipv6]
+		if plan.isEmpty() {
+			if known && installed.isEmpty() {
+				continue
+			}
+			// Rules might be left behind by a previous run that didn't
+			// stop cleanly, so the family is flushed once even if it's
+			// not known to have rules.
+			if err := f.Flush(ipv6); err != nil {
+				if known {
+					return err
+				}
+				logrus.Debugf("can not flush the firewall rules: %v", err)
+			}
+		} else if err := f.Apply(ipv6, plan); err != nil {
+			return err
+		}
+		fwInstalled[ipv6] = plan
+	}
+	return nil
+}
+
+// TeardownFirewall removes all of the firewall rules that are installed by
+// ovpm. They are installed again with the next emit of a server.
+func TeardownFirewall() error {
+	fwMu.Lock()
+	defer fwMu.Unlock()
+	for _, ipv6 := range []bool{false, true} {
+		if plan, ok := fwInstalled[ipv6]; !ok || plan.isEmpty() {
+			continue
+		}
+		if err := fw.Flush(ipv6); err != nil {
+			return err
+		}
+		fwInstalled[ipv6] = firewallPlan{}
+	}
+	logrus.Info("firewall rules are removed")
+	return nil
+}
+
+// FirewallRules returns the name of the firewall backend and the rules that
+// ovpm installs with it for IPv4 and IPv6, in the syntax of the backend.
+func FirewallRules() (backend string, ipv4, ipv6 []string, err error) {
+	fwMu.Lock()
+	defer fwMu.Unlock()
+	f, err := lockedFirewall()
+	if err != nil {
+		return "", nil, nil, err
+	}
+	plans, err := firewallPlans()
+	if err != nil {
+		return "", nil, nil, err
+	}
+	if !plans[false].isEmpty() {
+		ipv4 = f.Render(false, *plans[false])
+	}
+	if !plans[true].isEmpty() {
+		ipv6 = f.Render(true, *plans[true])
+	}
+	return f.Name(), ipv4, ipv6, nil
+}
+
+// Chains that ovpm owns in the nat and the filter tables. They are rebuilt on
+// every reconciliation, and the built-in chains jump to them.
+const (
+	natChain     = "OVPM-POSTROUTING"
+	forwardChain = "OVPM-FORWARD"
+)
+
+// iptablesFirewall is the firewall backend that uses iptables and ip6tables.
+//
+// NAT rules are kept in natChain in the nat table, and the acl and the
+// forwarding rules are kept in forwardChain in the filter table.
+type iptablesFirewall struct {
+	tables map[bool]*iptables.IPTables
+}
+
+func newIptablesFirewall() *iptablesFirewall {
+	return &iptablesFirewall{tables: make(map[bool]*iptables.IPTables)}
+}
+
+func (f *iptablesFirewall) Name() string {
+	return IptablesFirewall
+}
+
+func (f *iptablesFirewall) table(ipv6 bool) (*iptables.IPTables, error) {
+	if ipt, ok := f.tables[ipv6]; ok {
+		return ipt, nil
+	}
+	proto := iptables.ProtocolIPv4
+	if ipv6 {
+		proto = iptables.ProtocolIPv6
+	}
+	ipt, err := iptables.NewWithProtocol(proto)
+	if err != nil {
+		return nil, fmt.Errorf("can not create new iptables object: %v", err)
+	}
+	f.tables[ipv6] = ipt
+	return ipt, nil
+}
+
+func (f *iptablesFirewall) Apply(ipv6 bool, plan firewallPlan) error {
+	ipt, err := f.table(ipv6)
+	if err != nil {
+		return err
+	}
+	if err := iptablesRestoreFunc(ipv6, strings.Join(f.Render(ipv6, plan), "\n")+"\n"); err != nil {
+		return err
+	}
+	if err := ipt.InsertUnique("nat", "POSTROUTING", 1, "-j", natChain); err != nil {
+		return fmt.Errorf("can not jump to %s from POSTROUTING: %v", natChain, err)
+	}
+	if err := ipt.InsertUnique("filter", "FORWARD", 1, "-j", forwardChain); err != nil {
+		return fmt.Errorf("can not jump to %s from FORWARD: %v", forwardChain, err)
+	}
+	return nil
+}
+
+func (f *iptablesFirewall) Flush(ipv6 bool) error {
+	ipt, err := f.table(ipv6)
+	if err != nil {
+		return err
+	}
+	for _, c := range []struct{ table, parent, chain string }{
+		{"nat", "POSTROUTING", natChain},
+		{"filter", "FORWARD", forwardChain},
+	} {
+		if err := ipt.DeleteIfExists(c.table, c.parent, "-j", c.chain); err != nil {
+			return fmt.Errorf("can not delete the jump to %s from %s: %v", c.chain, c.parent, err)
+		}
+		if err := ipt.ClearAndDeleteChain(c.table, c.chain); err != nil {
+			return fmt.Errorf("can not delete %s: %v", c.chain, err)
+		}
+	}
+	return nil
+}
+
+// Render returns the input of iptables-restore that fills the chains.
+func (f *iptablesFirewall) Render(ipv6 bool, plan firewallPlan) []string {
+	lines := []string{"*nat", fmt.Sprintf(":%s - [0:0]", natChain)}
+	for _, r := range plan.NAT {
+		lines = append(lines, fmt.Sprintf("-A %s -s %s -o %s -j MASQUERADE", natChain, r.Source, r.OutIface))
+	}
+	lines = append(lines, "COMMIT", "*filter", fmt.Sprintf(":%s - [0:0]", forwardChain))
+	for _, r := range plan.ACL {
+		lines = append(lines, fmt.Sprintf("-A %s %s", forwardChain, strings.Join(iptablesRulespec(r, ipv6), " ")))
+	}
+	for _, r := range plan.Forward {
+		if r.Established {
+			lines = append(lines, fmt.Sprintf("-A %s -i %s -o %s -m state --state RELATED,ESTABLISHED -j ACCEPT", forwardChain, r.InIface, r.OutIface))
+		} else {
+			lines = append(lines, fmt.Sprintf("-A %s -i %s -o %s -j ACCEPT", forwardChain, r.InIface, r.OutIface))
+		}
+	}
+	return append(lines, "COMMIT")
+}
+
+// iptablesRulespec returns the iptables rule specification of the acl rule.
+func iptablesRulespec(r firewallRule, ipv6 bool) []string {
+	spec := []string{"-s", r.Source, "-d", r.Destination}
+	switch r.Proto {
+	case ACLTCP, ACLUDP:
+		spec = append(spec, "-p", r.Proto)
+		if r.Port != "" {
+			spec = append(spec, "--dport", r.Port)
+		}
+	case ACLICMP:
+		if ipv6 {
+			spec = append(spec, "-p", "ipv6-icmp")
+		} else {
+			spec = append(spec, "-p", "icmp")
+		}
+	}
+	if r.Accept {
+		return append(spec, "-j", "ACCEPT")
+	}
+	return append(spec, "-j", "DROP")
+}
+
+// iptablesRestoreFunc feeds the input to iptables-restore.
+var iptablesRestoreFunc = iptablesRestore
+
+// iptablesRestore is the implementation of iptablesRestoreFunc.
+//
+// Chains that are declared in the input are flushed and filled in a single
+// transaction, so that the traffic never goes through a partial set of rules.
+// Other chains are left as they are.
+func iptablesRestore(ipv6 bool, input string) error {
+	restore := "iptables-restore"
+	if ipv6 {
+		restore = "ip6tables-restore"
+	}
+	var stderr bytes.Buffer
+	cmd := exec.Command(restore, "--noflush")
+	cmd.Stdin = strings.NewReader(input)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s failed: %v: %s", restore, err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
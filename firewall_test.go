@@ -0,0 +1,284 @@
+package ovpm
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testFirewall is the firewall backend of the tests.
+var testFirewall *recordingFirewall
+
+// recordingFirewall is a fake firewall backend that records the plans
+// instead of installing them.
+type recordingFirewall struct {
+	plans   map[bool]firewallPlan
+	flushes int
+}
+
+func newRecordingFirewall() *recordingFirewall {
+	return &recordingFirewall{plans: make(map[bool]firewallPlan)}
+}
+
+func (f *recordingFirewall) Name() string {
+	return "recording"
+}
+
+func (f *recordingFirewall) Apply(ipv6 bool, plan firewallPlan) error {
+	f.plans[ipv6] = plan
+	return nil
+}
+
+func (f *recordingFirewall) Flush(ipv6 bool) error {
+	delete(f.plans, ipv6)
+	f.flushes++
+	return nil
+}
+
+func (f *recordingFirewall) Render(ipv6 bool, plan firewallPlan) []string {
+	var lines []string
+	for _, r := range plan.NAT {
+		lines = append(lines, fmt.Sprintf("nat %s -> %s", r.Source, r.OutIface))
+	}
+	return lines
+}
+
+// hasNAT tells whether the source is masqueraded behind the interface.
+func (f *recordingFirewall) hasNAT(ipv6 bool, source, iface string) bool {
+	for _, r := range f.plans[ipv6].NAT {
+		if r == (natRule{Source: source, OutIface: iface}) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestEmitFirewall(t *testing.T) {
+	// Init:
+	setupTestCase()
+	createTestDB()
+	defer db.Cease()
+	svr := TheServer()
+	if err := svr.Init("localhost", "", UDPProto, "10.9.0.0/16", "", "", "", false); err != nil {
+		t.Fatalf("server can not be initialized: %v", err)
+	}
+	lo := interfaceOfIP(&net.IPNet{IP: net.ParseIP("127.0.0.1"), Mask: net.CIDRMask(8, 32)})
+	if lo == nil {
+		t.Skip("loopback interface can not be found")
+	}
+	svr.CreateNewPool("staff", "10.9.2.0/24")
+	CreateNewUser("usr1", "1234", false, 0, true, "description")
+	CreateNewUser("usr2", "1234", false, 0, true, "description")
+	n, err := CreateNewNetwork("local", "127.0.0.0/8", SERVERNET, "")
+	if err != nil {
+		t.Fatalf("network can not be created: %v", err)
+	}
+
+	// Test:
+	n.Associate("usr1")
+	n.AssociatePool("staff")
+	usr1, _ := GetUser("usr1")
+	usr2, _ := GetUser("usr2")
+	for _, source := range []string{usr1.getIP().String(), "10.9.2.0/24"} {
+		if !testFirewall.hasNAT(false, source, lo.Name) {
+			t.Errorf("%s is expected to be masqueraded towards the network: %v", source, testFirewall.plans[false].NAT)
+		}
+	}
+	if testFirewall.hasNAT(false, usr2.getIP().String(), lo.Name) {
+		t.Errorf("users that are not associated are not expected to be masqueraded: %v", testFirewall.plans[false].NAT)
+	}
+
+	n, _ = GetNetwork("local")
+	n.Dissociate("usr1")
+	n.DissociatePool("staff")
+	if len(testFirewall.plans[false].NAT) != 0 {
+		t.Errorf("dissociated users and pools are expected to be removed: %v", testFirewall.plans[false].NAT)
+	}
+
+	// Rules of a deleted network are removed.
+	n.Associate("usr1")
+	n, _ = GetNetwork("local")
+	if err := n.Delete(); err != nil {
+		t.Fatalf("network can not be deleted: %v", err)
+	}
+	if len(testFirewall.plans[false].NAT) != 0 {
+		t.Errorf("rules of the deleted network are expected to be removed: %v", testFirewall.plans[false].NAT)
+	}
+}
+
+func TestReconcileFirewall(t *testing.T) {
+	// Init:
+	setupTestCase()
+	createTestDB()
+	defer db.Cease()
+	defer func(f func(*Server) (string, string)) { natInterfacesFunc = f }(natInterfacesFunc)
+	// The vpn interface keeps the address of the first network.
+	natInterfacesFunc = func(svr *Server) (string, string) {
+		if svr.ipNet().String() == "10.9.0.0/24" {
+			return "eth0", "tun0"
+		}
+		return "eth0", ""
+	}
+	svr := TheServer()
+	if err := svr.Init("localhost", "", UDPProto, "10.9.0.0/24", "", "", "", false, WithIPv6Network("fd00:9::/64")); err != nil {
+		t.Fatalf("server can not be initialized: %v", err)
+	}
+
+	// Test:
+	expected := firewallPlan{
+		NAT:     []natRule{{Source: "10.9.0.1/24", OutIface: "eth0"}},
+		Forward: []forwardRule{{InIface: "eth0", OutIface: "tun0", Established: true}, {InIface: "tun0", OutIface: "eth0"}},
+	}
+	if !reflect.DeepEqual(testFirewall.plans[false], expected) {
+		t.Errorf("unexpected plan:\n%+v\nexpected:\n%+v", testFirewall.plans[false], expected)
+	}
+	if !testFirewall.hasNAT(true, "fd00:9::/64", "eth0") {
+		t.Errorf("IPv6 network is expected to be masqueraded: %v", testFirewall.plans[true].NAT)
+	}
+	if _, ipv4, _, err := FirewallRules(); err != nil || !reflect.DeepEqual(ipv4, []string{"nat 10.9.0.1/24 -> eth0"}) {
+		t.Errorf("plan is expected to be rendered by the backend: %v %v", ipv4, err)
+	}
+
+	// Rules of the old network are removed when the network changes.
+	if err := svr.Update("10.10.0.0/24", "", nil); err != nil {
+		t.Fatalf("server can not be updated: %v", err)
+	}
+	if len(testFirewall.plans) != 0 {
+		t.Errorf("rules of the old network are expected to be removed: %v", testFirewall.plans)
+	}
+
+	// Rules of a deinitialized server are removed.
+	natInterfacesFunc = func(*Server) (string, string) { return "eth0", "tun0" }
+	svr.Emit()
+	if !testFirewall.hasNAT(false, "10.10.0.1/24", "eth0") {
+		t.Errorf("new network is expected to be masqueraded: %v", testFirewall.plans[false].NAT)
+	}
+	if err := svr.Deinit(); err != nil {
+		t.Fatalf("server can not be deinitialized: %v", err)
+	}
+	if len(testFirewall.plans) != 0 {
+		t.Errorf("rules of the deinitialized server are expected to be removed: %v", testFirewall.plans)
+	}
+
+	// Rules are removed on teardown, and installed again with the next emit.
+	svr.Init("localhost", "", UDPProto, "10.9.0.0/24", "", "", "", false)
+	flushes := testFirewall.flushes
+	if err := TeardownFirewall(); err != nil {
+		t.Fatalf("firewall can not be torn down: %v", err)
+	}
+	if len(testFirewall.plans) != 0 || testFirewall.flushes != flushes+1 {
+		t.Errorf("only the installed rules are expected to be flushed: %v", testFirewall.plans)
+	}
+	svr.Emit()
+	if !testFirewall.hasNAT(false, "10.9.0.1/24", "eth0") {
+		t.Errorf("rules are expected to be installed again: %v", testFirewall.plans)
+	}
+}
+
+func TestIptablesFirewall(t *testing.T) {
+	plan := firewallPlan{
+		NAT:     []natRule{{Source: "10.9.0.1/24", OutIface: "eth0"}},
+		Forward: []forwardRule{{InIface: "eth0", OutIface: "tun0", Established: true}, {InIface: "tun0", OutIface: "eth0"}},
+		ACL:     []firewallRule{{Source: "10.9.0.2", Destination: "192.168.1.0/24"}},
+	}
+	expected := []string{
+		"*nat",
+		":OVPM-POSTROUTING - [0:0]",
+		"-A OVPM-POSTROUTING -s 10.9.0.1/24 -o eth0 -j MASQUERADE",
+		"COMMIT",
+		"*filter",
+		":OVPM-FORWARD - [0:0]",
+		"-A OVPM-FORWARD -s 10.9.0.2 -d 192.168.1.0/24 -j DROP",
+		"-A OVPM-FORWARD -i eth0 -o tun0 -m state --state RELATED,ESTABLISHED -j ACCEPT",
+		"-A OVPM-FORWARD -i tun0 -o eth0 -j ACCEPT",
+		"COMMIT",
+	}
+	if got := newIptablesFirewall().Render(false, plan); !reflect.DeepEqual(got, expected) {
+		t.Errorf("unexpected iptables-restore input:\n%s\nexpected:\n%s", strings.Join(got, "\n"), strings.Join(expected, "\n"))
+	}
+}
+
+func TestFirewallRuleRendering(t *testing.T) {
+	var ruletests = []struct {
+		rule     firewallRule
+		ipv6     bool
+		iptables string
+		nft      string
+	}{
+		{firewallRule{Source: "10.9.0.2", Destination: "192.168.1.0/24", Proto: ACLTCP, Port: "8000:8080", Accept: true}, false,
+			"-s 10.9.0.2 -d 192.168.1.0/24 -p tcp --dport 8000:8080 -j ACCEPT",
+			"ip saddr 10.9.0.2 ip daddr 192.168.1.0/24 tcp dport 8000-8080 accept"},
+		{firewallRule{Source: "10.9.1.0/24", Destination: "0.0.0.0/0"}, false,
+			"-s 10.9.1.0/24 -d 0.0.0.0/0 -j DROP",
+			"ip saddr 10.9.1.0/24 ip daddr 0.0.0.0/0 drop"},
+		{firewallRule{Source: "10.9.0.2", Destination: "10.0.0.0/8", Proto: ACLUDP}, false,
+			"-s 10.9.0.2 -d 10.0.0.0/8 -p udp -j DROP",
+			"ip saddr 10.9.0.2 ip daddr 10.0.0.0/8 meta l4proto udp drop"},
+		{firewallRule{Source: "fd00:9::2", Destination: "fd00:1::/64", Proto: ACLICMP, Accept: true}, true,
+			"-s fd00:9::2 -d fd00:1::/64 -p ipv6-icmp -j ACCEPT",
+			"ip6 saddr fd00:9::2 ip6 daddr fd00:1::/64 meta l4proto ipv6-icmp accept"},
+	}
+	for _, tt := range ruletests {
+		if got := strings.Join(iptablesRulespec(tt.rule, tt.ipv6), " "); got != tt.iptables {
+			t.Errorf("iptables rule of %+v is expected to be %q, got %q", tt.rule, tt.iptables, got)
+		}
+		if got := nftRule(tt.rule, tt.ipv6); got != tt.nft {
+			t.Errorf("nft rule of %+v is expected to be %q, got %q", tt.rule, tt.nft, got)
+		}
+	}
+}
+
+func TestNftablesFirewall(t *testing.T) {
+	// Prepare:
+	var scripts []string
+	defer func(f func(string) error) { runNftFunc = f }(runNftFunc)
+	runNftFunc = func(script string) error {
+		scripts = append(scripts, script)
+		return nil
+	}
+	f := newNftablesFirewall()
+	plan := firewallPlan{
+		NAT:     []natRule{{Source: "10.9.0.0/24", OutIface: "eth0"}},
+		Forward: []forwardRule{{InIface: "eth0", OutIface: "tun0", Established: true}},
+		ACL:     []firewallRule{{Source: "10.9.0.2", Destination: "192.168.1.0/24"}},
+	}
+
+	// Test:
+	f.Apply(false, plan)
+	f.Flush(true)
+	if len(scripts) != 2 {
+		t.Fatalf("each change is expected to run a single script, got %d script(s)", len(scripts))
+	}
+	table := `table ip ovpm {
+	chain postrouting {
+		type nat hook postrouting priority 100; policy accept;
+		ip saddr 10.9.0.0/24 oifname "eth0" masquerade
+	}
+	chain forward {
+		type filter hook forward priority 0; policy accept;
+		jump acl
+		iifname "eth0" oifname "tun0" ct state related,established accept
+	}
+	chain acl {
+		ip saddr 10.9.0.2 ip daddr 192.168.1.0/24 drop
+	}
+}
+`
+	if expected := "table ip ovpm {}\ndelete table ip ovpm\n" + table; scripts[0] != expected {
+		t.Errorf("unexpected nft script:\n%s\nexpected:\n%s", scripts[0], expected)
+	}
+	if expected := "table ip6 ovpm {}\ndelete table ip6 ovpm\n"; scripts[1] != expected {
+		t.Errorf("table is expected to be deleted on flush:\n%s", scripts[1])
+	}
+	if got := strings.Join(f.Render(false, plan), "\n") + "\n"; got != table {
+		t.Errorf("table is expected to be rendered as it's installed:\n%s", got)
+	}
+}
+
+func TestNewFirewall(t *testing.T) {
+	if _, err := newFirewall("pf"); err == nil {
+		t.Error("unknown firewall backend is expected to be refused")
+	}
+}
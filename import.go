@@ -0,0 +1,416 @@
+package ovpm
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jinzhu/gorm"
+	"github.com/sirupsen/logrus"
+)
+
+// Formats of the user import and export files.
+const (
+	CSVFormat  = "csv"
+	JSONFormat = "json"
+)
+
+// UserRecord is a user in the import and export files.
+//
+// Passwords are only read. Users that are imported without a password get a
+// random one, so they can't log in before it's changed.
+type UserRecord struct {
+	Username    string   `json:"username"`
+	Password    string   `json:"password,omitempty"`
+	Description string   `json:"description,omitempty"`
+	NoGW        bool     `json:"no_gw"`
+	StaticIP    string   `json:"static_ip,omitempty"`
+	Admin       bool     `json:"admin"`
+	Networks    []string `json:"networks,omitempty"` // Names of the associated networks.
+	Server      string   `json:"server,omitempty"`
+	Pool        string   `json:"pool,omitempty"`
+}
+
+// userRecordColumns are the columns of the csv files. Networks are separated
+// by semicolons in their column.
+var userRecordColumns = []string{"username", "password", "description", "no_gw", "static_ip", "admin", "networks", "server", "pool"}
+
+// ReadUserRecords reads the users from a CSV or a JSON file.
+//
+// CSV files start with a header of the column names. Columns can be in any
+// order and only the username column is required.
+func ReadUserRecords(r io.Reader, format string) ([]UserRecord, error) {
+	switch format {
+	case JSONFormat:
+		var records []UserRecord
+		dec := json.NewDecoder(r)
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&records); err != nil {
+			return nil, fmt.Errorf("can not decode json: %v", err)
+		}
+		return records, nil
+	case CSVFormat:
+		return readUserCSV(r)
+	}
+	return nil, fmt.Errorf("unknown format: %s (should be %s or %s)", format, CSVFormat, JSONFormat)
+}
+
+func readUserCSV(r io.Reader) ([]UserRecord, error) {
+	rows, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("can not read csv: %v", err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("csv header is missing")
+	}
+	header := rows[0]
+	for _, column := range header {
+		if !isOneOf(column, userRecordColumns) {
+			return nil, fmt.Errorf("unknown csv column: %s (should be one of %s)", column, strings.Join(userRecordColumns, ", "))
+		}
+	}
+	if !isOneOf("username", header) {
+		return nil, fmt.Errorf("csv column username is missing")
+	}
+
+	var records []UserRecord
+	for i, row := range rows[1:] {
+		var rec UserRecord
+		for j, column := range header {
+			value := strings.TrimSpace(row[j])
+			var err error
+			switch column {
+			case "username":
+				rec.Username = value
+			case "password":
+				rec.Password = value
+			case "description":
+				rec.Description = value
+			case "no_gw":
+				rec.NoGW, err = parseCSVBool(value)
+			case "static_ip":
+				rec.StaticIP = value
+			case "admin":
+				rec.Admin, err = parseCSVBool(value)
+			case "networks":
+				for _, name := range strings.Split(value, ";") {
+					if name = strings.TrimSpace(name); name != "" {
+						rec.Networks = append(rec.Networks, name)
+					}
+				}
+			case "server":
+				rec.Server = value
+			case "pool":
+				rec.Pool = value
+			}
+			if err != nil {
+				return nil, fmt.Errorf("row %d: %s: %v", i+1, column, err)
+			}
+		}
+		records = append(records, rec)
+	}
+	return records, nil
+}
+
+func parseCSVBool(s string) (bool, error) {
+	if s == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(s)
+}
+
+// WriteUserRecords writes the users in a format that ReadUserRecords reads.
+func WriteUserRecords(w io.Writer, format string, records []UserRecord) error {
+	switch format {
+	case JSONFormat:
+		if records == nil {
+			records = []UserRecord{}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(records)
+	case CSVFormat:
+		cw := csv.NewWriter(w)
+		cw.Write(userRecordColumns)
+		for _, rec := range records {
+			cw.Write([]string{
+				rec.Username,
+				rec.Password,
+				rec.Description,
+				strconv.FormatBool(rec.NoGW),
+				rec.StaticIP,
+				strconv.FormatBool(rec.Admin),
+				strings.Join(rec.Networks, ";"),
+				rec.Server,
+				rec.Pool,
+			})
+		}
+		cw.Flush()
+		return cw.Error()
+	}
+	return fmt.Errorf("unknown format: %s (should be %s or %s)", format, CSVFormat, JSONFormat)
+}
+
+// ImportError is the error of a row of an import.
+type ImportError struct {
+	Row      int // Index of the record, starting from 1.
+	Username string
+	Err      error
+}
+
+func (e ImportError) Error() string {
+	return fmt.Sprintf("row %d (%s): %v", e.Row, e.Username, e.Err)
+}
+
+// ImportReport is the result of ImportUsers.
+type ImportReport struct {
+	Created []string // Names of the created users.
+	Errors  []ImportError
+}
+
+// userImport is a validated record.
+type userImport struct {
+	record   UserRecord
+	server   *Server
+	hostID   uint32
+	networks []*Network
+
+	user        dbUserModel // Built user, see build.
+	leaseHostID uint32      // Address to lease if the user doesn't have a static one.
+}
+
+// ImportUsers creates the users of the records, and associates them with
+// their networks. Servers of the users are emitted once, after all of the
+// users are created.
+//
+// Records are validated before any of the users are created. Rows that fail
+// are reported and skipped, unless atomic is set, in which case none of the
+// users are created if any row fails. If dryRun is set, the records are only
+// validated.
+//
+// Each user is written in a transaction of its own, or all of them in a
+// single transaction if atomic is set.
+func ImportUsers(records []UserRecord, dryRun, atomic bool) (*ImportReport, error) {
+	if !isAnyServerInitialized() {
+		return nil, fmt.Errorf("you first need to create server")
+	}
+
+	report := &ImportReport{}
+	var imports []*userImport
+	usernames := make(map[string]bool)
+	staticIPs := make(map[string]bool)
+	for i, rec := range records {
+		imp, err := prepareImport(rec, usernames, staticIPs)
+		if err != nil {
+			report.Errors = append(report.Errors, ImportError{Row: i + 1, Username: rec.Username, Err: err})
+		}
+		imports = append(imports, imp)
+	}
+	if dryRun || (atomic && len(report.Errors) > 0) {
+		return report, nil
+	}
+
+	// Static ips of the records are reserved, so that the users before them
+	// aren't leased their addresses.
+	reserved := make(map[string][]uint32)
+	for _, imp := range imports {
+		if imp != nil && imp.hostID != 0 {
+			reserved[imp.server.name] = append(reserved[imp.server.name], imp.hostID)
+		}
+	}
+
+	// Users are built before the transactions, which only write them.
+	var built []int
+	for i, imp := range imports {
+		if imp == nil {
+			continue
+		}
+		if err := imp.build(reserved[imp.server.name]); err != nil {
+			report.Errors = append(report.Errors, ImportError{Row: i + 1, Username: imp.record.Username, Err: err})
+			if atomic {
+				logrus.Infof("user import is cancelled: %v", err)
+				return report, nil
+			}
+			continue
+		}
+		if imp.leaseHostID != 0 {
+			reserved[imp.server.name] = append(reserved[imp.server.name], imp.leaseHostID)
+		}
+		built = append(built, i)
+	}
+
+	var written []int
+	if atomic {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			for _, i := range built {
+				if err := imports[i].write(tx); err != nil {
+					report.Errors = append(report.Errors, ImportError{Row: i + 1, Username: imports[i].record.Username, Err: err})
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			logrus.Infof("user import is rolled back: %v", err)
+			if len(report.Errors) == 0 {
+				return report, fmt.Errorf("user import is rolled back: %v", err)
+			}
+			return report, nil
+		}
+		written = built
+	} else {
+		for _, i := range built {
+			if err := db.Transaction(imports[i].write); err != nil {
+				report.Errors = append(report.Errors, ImportError{Row: i + 1, Username: imports[i].record.Username, Err: err})
+				continue
+			}
+			written = append(written, i)
+		}
+	}
+
+	emit := make(map[string]*Server)
+	for _, i := range written {
+		imp := imports[i]
+		logrus.Infof("user created: %s", imp.user.Username)
+		report.Created = append(report.Created, imp.user.Username)
+		emit[imp.server.name] = imp.server
+	}
+
+	for _, svr := range emit {
+		if err := svr.EmitWithRestart(); err != nil {
+			return report, err
+		}
+	}
+	logrus.Infof("%d user(s) imported", len(report.Created))
+	return report, nil
+}
+
+// prepareImport validates the record against the database and the records
+// before it, whose usernames and static ips are collected in the given maps.
+func prepareImport(rec UserRecord, usernames, staticIPs map[string]bool) (*userImport, error) {
+	if err := validateUsername(rec.Username); err != nil {
+		return nil, err
+	}
+	if usernames[rec.Username] {
+		return nil, fmt.Errorf("user %s is repeated", rec.Username)
+	}
+	usernames[rec.Username] = true
+	if _, err := GetUser(rec.Username); err == nil {
+		return nil, fmt.Errorf("user %s already exists", rec.Username)
+	}
+
+	svr, err := LookupServer(rec.Server)
+	if err != nil {
+		return nil, err
+	}
+	if !svr.IsInitialized() {
+		return nil, fmt.Errorf("server not found: %s", svr.GetServerName())
+	}
+	imp := &userImport{record: rec, server: svr}
+	if rec.StaticIP != "" {
+		ip := net.ParseIP(rec.StaticIP).To4()
+		if ip == nil {
+			return nil, fmt.Errorf("validation error: `%s` must be an IPv4 address", rec.StaticIP)
+		}
+		imp.hostID = IP2HostID(ip)
+		if err := imp.server.checkStaticHostID(imp.hostID); err != nil {
+			return nil, err
+		}
+		key := imp.server.name + "/" + ip.String()
+		if staticIPs[key] {
+			return nil, fmt.Errorf("ip %s is repeated", ip)
+		}
+		staticIPs[key] = true
+	}
+	if rec.Pool != "" && rec.Pool != NoPool {
+		pool, err := GetPool(rec.Pool)
+		if err != nil {
+			return nil, err
+		}
+		if pool.ServerID != imp.server.ID {
+			return nil, fmt.Errorf("pool %s doesn't belong to the server of the user", rec.Pool)
+		}
+	}
+	for _, name := range rec.Networks {
+		n, err := GetNetwork(name)
+		if err != nil {
+			return nil, err
+		}
+		imp.networks = append(imp.networks, n)
+	}
+	return imp, nil
+}
+
+// build builds the user of the record and picks its address without writing
+// them to the database. Dynamic users aren't given the reserved host ids.
+func (imp *userImport) build(reserved []uint32) error {
+	rec := imp.record
+	password := rec.Password
+	if password == "" {
+		password = uuid.New().String()
+	}
+	user, err := imp.server.newUser(rec.Username, password, rec.NoGW, imp.hostID, rec.Admin, rec.Description, WithPool(rec.Pool))
+	if err != nil {
+		return err
+	}
+	imp.user = user
+	if imp.hostID == 0 {
+		imp.leaseHostID, err = imp.server.freeHostID(&User{dbUserModel: user}, reserved...)
+	}
+	return err
+}
+
+// write writes the built user with its lease and network associations.
+func (imp *userImport) write(tx *gorm.DB) error {
+	if err := tx.Create(&imp.user).Error; err != nil {
+		return fmt.Errorf("can not create user in database: %v", err)
+	}
+	if imp.leaseHostID != 0 {
+		lease := dbLeaseModel{ServerID: imp.server.ID, UserID: imp.user.ID, HostID: imp.leaseHostID}
+		if err := tx.Create(&lease).Error; err != nil {
+			return fmt.Errorf("can not lease %s to the user %s: %v", HostID2IP(imp.leaseHostID), imp.user.Username, err)
+		}
+	}
+	for _, n := range imp.networks {
+		if err := tx.Model(&n.dbNetworkModel).Association("Users").Append(&imp.user).Error; err != nil {
+			return fmt.Errorf("user can not be associated with the network %s: %v", n.Name, err)
+		}
+	}
+	return nil
+}
+
+// ExportUsers returns the records of all of the users, in the order they
+// are created.
+func ExportUsers() ([]UserRecord, error) {
+	users, err := GetAllUsers()
+	if err != nil {
+		return nil, err
+	}
+	networks := GetAllNetworks()
+	var records []UserRecord
+	for _, u := range users {
+		rec := UserRecord{
+			Username:    u.Username,
+			Description: u.Description,
+			NoGW:        u.NoGW,
+			Admin:       u.Admin,
+			Server:      u.GetServerName(),
+			Pool:        u.GetPoolName(),
+		}
+		if u.HostID != 0 {
+			rec.StaticIP = HostID2IP(u.HostID).String()
+		}
+		for _, n := range networks {
+			if isOneOf(u.Username, n.GetAssociatedUsernames()) {
+				rec.Networks = append(rec.Networks, n.Name)
+			}
+		}
+		records = append(records, rec)
+	}
+	return records, nil
+}
@@ -0,0 +1,314 @@
+package ovpm
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+)
+
+func TestUserRecords(t *testing.T) {
+	records := []UserRecord{
+		{Username: "usr1", Password: "1234", Description: "first, user", NoGW: true, StaticIP: "10.9.0.7", Admin: true, Networks: []string{"lan", "dmz"}, Server: "office", Pool: "staff"},
+		{Username: "usr2"},
+	}
+	for _, format := range []string{CSVFormat, JSONFormat} {
+		var buf bytes.Buffer
+		if err := WriteUserRecords(&buf, format, records); err != nil {
+			t.Fatalf("%s: records can not be written: %v", format, err)
+		}
+		got, err := ReadUserRecords(&buf, format)
+		if err != nil {
+			t.Fatalf("%s: records can not be read: %v", format, err)
+		}
+		if !reflect.DeepEqual(got, records) {
+			t.Errorf("%s: records are expected to round trip:\n%v\ngot:\n%v", format, records, got)
+		}
+	}
+
+	// Columns can be left out or reordered.
+	got, err := ReadUserRecords(strings.NewReader("admin,username,networks\n1,usr1, lan ; dmz\n,usr2,\n"), CSVFormat)
+	if err != nil {
+		t.Fatalf("records can not be read: %v", err)
+	}
+	expected := []UserRecord{{Username: "usr1", Admin: true, Networks: []string{"lan", "dmz"}}, {Username: "usr2"}}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("unexpected records: %v", got)
+	}
+
+	var badtests = []struct {
+		format string
+		in     string
+	}{
+		{CSVFormat, ""}, // No header.
+		{CSVFormat, "username,email\nusr1,a@b.c\n"},     // Unknown column.
+		{CSVFormat, "description\nfirst user\n"},        // No username column.
+		{CSVFormat, "username,no_gw\nusr1,sometimes\n"}, // Not a bool.
+		{JSONFormat, `[{"username": "usr1", "email": "a@b.c"}]`},
+		{"xml", "<users/>"},
+	}
+	for _, tt := range badtests {
+		if _, err := ReadUserRecords(strings.NewReader(tt.in), tt.format); err == nil {
+			t.Errorf("%s %q is not expected to be read", tt.format, tt.in)
+		}
+	}
+}
+
+func TestImportUsers(t *testing.T) {
+	// Init:
+	setupTestCase()
+	createTestDB()
+	defer db.Cease()
+	svr := TheServer()
+	if err := svr.Init("localhost", "", UDPProto, "10.9.0.0/16", "", "", "", false); err != nil {
+		t.Fatalf("server can not be initialized: %v", err)
+	}
+	if _, err := svr.CreateNewPool("staff", "10.9.2.0/24"); err != nil {
+		t.Fatalf("pool can not be created: %v", err)
+	}
+	if _, err := CreateNewNetwork("lan", "192.168.1.0/24", SERVERNET, ""); err != nil {
+		t.Fatalf("network can not be created: %v", err)
+	}
+	emits := 0
+	emitToFileFunc := svr.emitToFileFunc
+	svr.emitToFileFunc = func(path, content string, mode uint) error {
+		if path == svr.path(vpnConfFile) {
+			emits++
+		}
+		return emitToFileFunc(path, content, mode)
+	}
+	CreateNewUser("usr0", "1234", false, 0, false, "")
+	emitsPerUser := emits
+	emits = 0
+
+	records := []UserRecord{
+		{Username: "usr1", Description: "first", StaticIP: "10.9.0.7", Networks: []string{"lan"}},
+		{Username: "usr2", NoGW: true, Admin: true, Pool: "staff"},
+		{Username: "usr 3"},                                 // Invalid username.
+		{Username: "usr1"},                                  // Repeated.
+		{Username: "usr0"},                                  // Already exists.
+		{Username: "usr4", StaticIP: "10.10.0.7"},           // Out of the vpn network.
+		{Username: "usr5", StaticIP: "10.9.0.7"},            // Repeated ip.
+		{Username: "usr6", Pool: "missing"},                 // Missing pool.
+		{Username: "usr7", Networks: []string{"missing"}},   // Missing network.
+		{Username: "usr8", Server: "missing"},               // Missing server.
+		{Username: "usr9", Password: "1234", StaticIP: "x"}, // Not an ip.
+	}
+	failed := []int{3, 4, 5, 6, 7, 8, 9, 10, 11}
+	rows := func(report *ImportReport) []int {
+		var rows []int
+		for _, e := range report.Errors {
+			rows = append(rows, e.Row)
+		}
+		return rows
+	}
+
+	// Test:
+	for _, atomic := range []bool{false, true} {
+		report, err := ImportUsers(records, true, atomic)
+		if err != nil {
+			t.Fatalf("users can not be validated: %v", err)
+		}
+		if !reflect.DeepEqual(rows(report), failed) || len(report.Created) != 0 {
+			t.Errorf("unexpected dry run report: %v", report)
+		}
+	}
+	report, err := ImportUsers(records, false, true)
+	if err != nil {
+		t.Fatalf("users can not be imported: %v", err)
+	}
+	if len(report.Created) != 0 || emits != 0 {
+		t.Errorf("no users are expected to be imported atomically if any of them fails: %v", report)
+	}
+	if users, _ := GetAllUsers(); len(users) != 1 {
+		t.Errorf("no users are expected to be created by a dry run or a failed atomic import: %d", len(users))
+	}
+
+	report, err = ImportUsers(records, false, false)
+	if err != nil {
+		t.Fatalf("users can not be imported: %v", err)
+	}
+	if !reflect.DeepEqual(report.Created, []string{"usr1", "usr2"}) || !reflect.DeepEqual(rows(report), failed) {
+		t.Errorf("valid users are expected to be imported: %v", report)
+	}
+	if emits != emitsPerUser {
+		t.Errorf("server is expected to be emitted once for all of the users, got %d emits", emits)
+	}
+	usr1, err := GetUser("usr1")
+	if err != nil {
+		t.Fatalf("imported user can not be fetched: %v", err)
+	}
+	if usr1.getIP().String() != "10.9.0.7" || usr1.GetDescription() != "first" {
+		t.Errorf("imported user is expected to have its static ip and description: %s %s", usr1.getIP(), usr1.GetDescription())
+	}
+	lan, _ := GetNetwork("lan")
+	if !reflect.DeepEqual(lan.GetAssociatedUsernames(), []string{"usr1"}) {
+		t.Errorf("imported user is expected to be associated with its network: %v", lan.GetAssociatedUsernames())
+	}
+
+	// Exported users can be imported back.
+	exported, err := ExportUsers()
+	if err != nil {
+		t.Fatalf("users can not be exported: %v", err)
+	}
+	expected := []UserRecord{
+		{Username: "usr0", Server: DefaultServerName},
+		{Username: "usr1", Description: "first", StaticIP: "10.9.0.7", Networks: []string{"lan"}, Server: DefaultServerName},
+		{Username: "usr2", NoGW: true, Admin: true, Server: DefaultServerName, Pool: "staff"},
+	}
+	if !reflect.DeepEqual(exported, expected) {
+		t.Errorf("unexpected export:\n%v\nexpected:\n%v", exported, expected)
+	}
+	for _, u := range []string{"usr0", "usr1", "usr2"} {
+		if u, err := GetUser(u); err == nil {
+			u.Delete()
+		}
+	}
+	report, err = ImportUsers(exported, false, true)
+	if err != nil || len(report.Created) != 3 || len(report.Errors) != 0 {
+		t.Errorf("exported users are expected to be imported back: %v %v", report, err)
+	}
+	if reimported, _ := ExportUsers(); !reflect.DeepEqual(reimported, expected) {
+		t.Errorf("users are expected to round trip:\n%v", reimported)
+	}
+}
+
+func TestImportUsersRollback(t *testing.T) {
+	// Init:
+	setupTestCase()
+	createTestDB()
+	defer db.Cease()
+	svr := TheServer()
+	if err := svr.Init("localhost", "", UDPProto, "10.9.0.0/16", "", "", "", false); err != nil {
+		t.Fatalf("server can not be initialized: %v", err)
+	}
+	if _, err := svr.CreateNewPool("tiny", "10.9.2.0/30"); err != nil {
+		t.Fatalf("pool can not be created: %v", err)
+	}
+	if _, err := CreateNewNetwork("lan", "192.168.1.0/24", SERVERNET, ""); err != nil {
+		t.Fatalf("network can not be created: %v", err)
+	}
+
+	// Test:
+	// Records are valid, but the pool runs out of addresses while they are
+	// created.
+	var records []UserRecord
+	for _, username := range []string{"usr1", "usr2", "usr3", "usr4", "usr5"} {
+		records = append(records, UserRecord{Username: username, Pool: "tiny", Networks: []string{"lan"}})
+	}
+	report, err := ImportUsers(records, false, true)
+	if err != nil {
+		t.Fatalf("users can not be imported: %v", err)
+	}
+	if len(report.Created) != 0 || len(report.Errors) != 1 {
+		t.Errorf("import is expected to fail at the first user that can't be leased an address: %v", report)
+	}
+	if users, _ := GetAllUsers(); len(users) != 0 {
+		t.Errorf("users are expected to be rolled back: %d", len(users))
+	}
+	if lan, _ := GetNetwork("lan"); len(lan.GetAssociatedUsernames()) != 0 {
+		t.Errorf("network associations are expected to be rolled back: %v", lan.GetAssociatedUsernames())
+	}
+	var leases int
+	db.Model(&dbLeaseModel{}).Count(&leases)
+	if leases != 0 {
+		t.Errorf("leases are expected to be released: %d", leases)
+	}
+}
+
+func TestImportUsersStaticIPs(t *testing.T) {
+	// Init:
+	setupTestCase()
+	createTestDB()
+	defer db.Cease()
+	svr := TheServer()
+	if err := svr.Init("localhost", "", UDPProto, "10.9.0.0/16", "", "", "", false); err != nil {
+		t.Fatalf("server can not be initialized: %v", err)
+	}
+	if _, err := CreateNewUser("usr0", "1234", false, 0, false, ""); err != nil {
+		t.Fatalf("user can not be created: %v", err)
+	}
+
+	// Test:
+	// Address leased to an existing user is refused.
+	report, err := ImportUsers([]UserRecord{{Username: "usr1"}, {Username: "usr2", StaticIP: "10.9.0.2"}}, false, true)
+	if err != nil {
+		t.Fatalf("users can not be imported: %v", err)
+	}
+	if len(report.Created) != 0 || len(report.Errors) != 1 || report.Errors[0].Row != 2 {
+		t.Errorf("static ip leased to usr0 is expected to be refused: %v", report)
+	}
+	if usr0, _ := GetUser("usr0"); usr0.getIP().String() != "10.9.0.2" {
+		t.Errorf("lease of usr0 is not expected to change, got %s", usr0.getIP())
+	}
+
+	// Users of the import aren't leased the static ips of the later rows.
+	report, err = ImportUsers([]UserRecord{{Username: "usr1"}, {Username: "usr2", StaticIP: "10.9.0.3"}}, false, true)
+	if err != nil || len(report.Errors) != 0 {
+		t.Fatalf("users can not be imported: %v %v", report, err)
+	}
+	usr1, _ := GetUser("usr1")
+	usr2, _ := GetUser("usr2")
+	if usr1.getIP().String() != "10.9.0.4" || usr2.getIP().String() != "10.9.0.3" {
+		t.Errorf("unexpected addresses: %s %s", usr1.getIP(), usr2.getIP())
+	}
+}
+
+func TestImportUsersWriteFailure(t *testing.T) {
+	// Init:
+	setupTestCase()
+	createTestDB()
+	defer db.Cease()
+	svr := TheServer()
+	if err := svr.Init("localhost", "", UDPProto, "10.9.0.0/16", "", "", "", false); err != nil {
+		t.Fatalf("server can not be initialized: %v", err)
+	}
+	if _, err := CreateNewNetwork("lan", "192.168.1.0/24", SERVERNET, ""); err != nil {
+		t.Fatalf("network can not be created: %v", err)
+	}
+	// usr3 can't be written to the database.
+	db.Callback().Create().Before("gorm:create").Register("test:fail_usr3", func(scope *gorm.Scope) {
+		if u, ok := scope.Value.(*dbUserModel); ok && u.Username == "usr3" {
+			scope.Err(fmt.Errorf("write failed"))
+		}
+	})
+	defer db.Callback().Create().Remove("test:fail_usr3")
+	var records []UserRecord
+	for _, username := range []string{"usr1", "usr2", "usr3"} {
+		records = append(records, UserRecord{Username: username, Networks: []string{"lan"}})
+	}
+	count := func(model interface{}) int {
+		var n int
+		db.Model(model).Count(&n)
+		return n
+	}
+
+	// Test:
+	report, err := ImportUsers(records, false, true)
+	if err != nil {
+		t.Fatalf("users can not be imported: %v", err)
+	}
+	if len(report.Created) != 0 || len(report.Errors) != 1 || report.Errors[0].Row != 3 {
+		t.Errorf("import is expected to fail at usr3: %v", report)
+	}
+	if users, leases := count(&dbUserModel{}), count(&dbLeaseModel{}); users != 0 || leases != 0 {
+		t.Errorf("atomic import is expected to be rolled back: %d users, %d leases", users, leases)
+	}
+	if lan, _ := GetNetwork("lan"); len(lan.GetAssociatedUsernames()) != 0 {
+		t.Errorf("network associations are expected to be rolled back: %v", lan.GetAssociatedUsernames())
+	}
+
+	report, err = ImportUsers(records, false, false)
+	if err != nil {
+		t.Fatalf("users can not be imported: %v", err)
+	}
+	if !reflect.DeepEqual(report.Created, []string{"usr1", "usr2"}) || len(report.Errors) != 1 {
+		t.Errorf("users except usr3 are expected to be imported: %v", report)
+	}
+	if users, leases := count(&dbUserModel{}), count(&dbLeaseModel{}); users != 2 || leases != 2 {
+		t.Errorf("nothing of usr3 is expected to be written: %d users, %d leases", users, leases)
+	}
+}
@@ -0,0 +1,133 @@
+package ovpm
+
+import (
+	"fmt"
+	"math/big"
+	"net"
+)
+
+// NoIPv6Network disables IPv6 on a server when passed to WithIPv6Network.
+const NoIPv6Network = "none"
+
+// Prefix lengths of the IPv6 networks that OpenVPN can draw addresses from.
+const (
+	minIPv6PrefixLen = 64
+	maxIPv6PrefixLen = 112
+)
+
+// WithIPv6Network makes the server dual-stack, giving the clients addresses from
+// the IPv6 network in addition to the IPv4 ones.
+//
+// A user gets the address at the same offset in both of the networks, so the
+// IPv6 network can't be smaller than the IPv4 one.
+func WithIPv6Network(cidr string) ServerOption {
+	return func(s *dbServerModel) error {
+		if cidr == NoIPv6Network {
+			s.IPv6Net = ""
+			return nil
+		}
+		ip, ipnet, err := net.ParseCIDR(cidr)
+		if err != nil || ip.To4() != nil {
+			return fmt.Errorf("validation error: ipv6 network `%s` should be an IPv6 network in the CIDR form", cidr)
+		}
+		if ones, _ := ipnet.Mask.Size(); ones < minIPv6PrefixLen || ones > maxIPv6PrefixLen {
+			return fmt.Errorf("validation error: prefix length of the ipv6 network `%s` should be between /%d and /%d", cidr, minIPv6PrefixLen, maxIPv6PrefixLen)
+		}
+		s.IPv6Net = ipnet.String()
+		return nil
+	}
+}
+
+// GetIPv6Net returns the IPv6 network of the server in the CIDR form, or an
+// empty string if the server is IPv4 only.
+func (svr *Server) GetIPv6Net() string {
+	return svr.IPv6Net
+}
+
+// ipv6Net returns the IPv6 network of the server, or nil if it doesn't have one.
+func (svr *Server) ipv6Net() *net.IPNet {
+	if svr.IPv6Net == "" {
+		return nil
+	}
+	_, ipnet, err := net.ParseCIDR(svr.IPv6Net)
+	if err != nil {
+		return nil
+	}
+	return ipnet
+}
+
+// checkIPv6Net validates the IPv6 network of the server model against its IPv4
+// network and the networks of the other servers.
+func (svr *Server) checkIPv6Net(s *dbServerModel) error {
+	if s.IPv6Net == "" {
+		return nil
+	}
+	_, ipnet, err := net.ParseCIDR(s.IPv6Net)
+	if err != nil {
+		return fmt.Errorf("can not parse ipv6 network %s: %v", s.IPv6Net, err)
+	}
+	ones6, bits6 := ipnet.Mask.Size()
+	ones4, bits4 := net.IPMask(net.ParseIP(s.Mask).To4()).Size()
+	if bits6-ones6 < bits4-ones4 {
+		return fmt.Errorf("validation error: ipv6 network `%s` is smaller than the ipv4 network %s/%d", s.IPv6Net, s.Net, ones4)
+	}
+	// Not using GetAllServers, it would refresh the server and discard the
+	// changes that are being validated.
+	var others []dbServerModel
+	if err := db.Where("name <> ?", svr.name).Find(&others).Error; err != nil {
+		return err
+	}
+	for _, other := range others {
+		if other.IPv6Net == "" {
+			continue
+		}
+		if _, otherNet, err := net.ParseCIDR(other.IPv6Net); err == nil && (otherNet.Contains(ipnet.IP) || ipnet.Contains(otherNet.IP)) {
+			return fmt.Errorf("validation error: ipv6 network `%s` overlaps with the network of the server %s", ipnet, other.Name)
+		}
+	}
+	return nil
+}
+
+// ipv6AtOffset returns the address of the network at the given offset.
+func ipv6AtOffset(ipnet *net.IPNet, offset uint32) net.IP {
+	n := new(big.Int).SetBytes(ipnet.IP.To16())
+	n.Add(n, big.NewInt(int64(offset)))
+	ip := make(net.IP, net.IPv6len)
+	return n.FillBytes(ip)
+}
+
+// ipv6ServerIP returns the IPv6 address of the server, or nil if it doesn't have one.
+func (svr *Server) ipv6ServerIP() net.IP {
+	ipnet := svr.ipv6Net()
+	if ipnet == nil {
+		return nil
+	}
+	return ipv6AtOffset(ipnet, 1)
+}
+
+// getIPv6 returns user's vpn IPv6 addr, or nil if the server is IPv4 only.
+//
+// It's at the same offset in the IPv6 network as the user's IPv4 addr is in
+// the IPv4 network.
+func (u *User) getIPv6() net.IP {
+	svr := u.GetServer()
+	ipnet := svr.ipv6Net()
+	ip := u.getIP()
+	if ipnet == nil || ip == nil {
+		return nil
+	}
+	network := net.ParseIP(svr.Net).To4().Mask(net.IPMask(net.ParseIP(svr.Mask).To4()))
+	return ipv6AtOffset(ipnet, IP2HostID(ip)-IP2HostID(network))
+}
+
+// GetIPv6Net returns user's vpn IPv6 network. (e.g. fd00::2/64)
+//
+// It's empty if the server is IPv4 only.
+func (u *User) GetIPv6Net() string {
+	ip := u.getIPv6()
+	if ip == nil {
+		return ""
+	}
+	ipn := net.IPNet{IP: ip, Mask: u.GetServer().ipv6Net().Mask}
+	return ipn.String()
+}
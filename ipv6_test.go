@@ -0,0 +1,112 @@
+package ovpm
+
+import (
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestIPv6Network(t *testing.T) {
+	// Init:
+	setupTestCase()
+	createTestDB()
+	defer db.Cease()
+	svr := TheServer()
+	if err := svr.Init("localhost", "", UDPProto, "", "2001:4860:4860::8888", "", "", false, WithIPv6Network("fd00:9:0:0::1/64")); err != nil {
+		t.Fatalf("dual-stack server can not be initialized: %v", err)
+	}
+	CreateNewUser("usr1", "1234", false, 0, true, "description")
+	CreateNewUser("usr2", "1234", true, IP2HostID(net.ParseIP("10.9.0.10").To4()), true, "description")
+	ccd := func(username string) string { return fs[filepath.Join(svr.path(vpnCCDDir), username)] }
+
+	// Test:
+	if svr.GetIPv6Net() != "fd00:9::/64" {
+		t.Errorf("ipv6 network is expected to be stored in the canonical form: %s", svr.GetIPv6Net())
+	}
+	serverConf := fs[svr.path(vpnConfFile)]
+	if !strings.Contains(serverConf, "\nserver-ipv6 fd00:9::/64\n") {
+		t.Error("server conf is expected to contain server-ipv6")
+	}
+	if !strings.Contains(serverConf, `push "dhcp-option DNS6 2001:4860:4860::8888"`) {
+		t.Error("ipv6 dns is expected to be pushed as DNS6")
+	}
+	if !strings.Contains(ccd("usr1"), "\nifconfig-ipv6-push fd00:9::2/64 fd00:9::1\n") || !strings.Contains(ccd("usr1"), "redirect-gateway def1 ipv6 bypass-dhcp") {
+		t.Errorf("dynamic user is expected to get the ipv6 addr at the same offset: %s", ccd("usr1"))
+	}
+	usr2, _ := GetUser("usr2")
+	if usr2.GetIPv6Net() != "fd00:9::a/64" || !strings.Contains(ccd("usr2"), "ifconfig-ipv6-push fd00:9::a/64 fd00:9::1") {
+		t.Errorf("static user is expected to get the ipv6 addr at the same offset: %s", usr2.GetIPv6Net())
+	}
+
+	// IPv6 routes.
+	if _, err := CreateNewNetwork("net6", "2001:db8:1::/48", ROUTE, "10.9.0.1"); err == nil {
+		t.Error("via is expected to be of the same ip version with the network")
+	}
+	n, err := CreateNewNetwork("net6", "2001:db8:1::/48", ROUTE, "fd00:9::1")
+	if err != nil {
+		t.Fatalf("ipv6 network can not be created: %v", err)
+	}
+	n.Associate("usr1")
+	if !strings.Contains(ccd("usr1"), `push "route-ipv6 2001:db8:1::/48 fd00:9::1"`) {
+		t.Errorf("ipv6 route is expected to be pushed: %s", ccd("usr1"))
+	}
+	s, _ := CreateNewNetwork("srv6", "2001:db8:2::/64", SERVERNET, "")
+	s.Associate("usr2")
+	if !strings.Contains(ccd("usr2"), `push "route-ipv6 2001:db8:2::/64"`) {
+		t.Errorf("ipv6 servernet is expected to be pushed: %s", ccd("usr2"))
+	}
+
+	// Disabling IPv6.
+	if err := svr.Update("", "", nil, WithIPv6Network(NoIPv6Network)); err != nil {
+		t.Fatalf("server can not be updated: %v", err)
+	}
+	if strings.Contains(fs[svr.path(vpnConfFile)], "\nserver-ipv6") || strings.Contains(ccd("usr1"), "ipv6") {
+		t.Errorf("ipv4 only server is not expected to use ipv6: %s", ccd("usr1"))
+	}
+
+	// Enabling IPv6 again.
+	if err := svr.Update("", "", nil, WithIPv6Network("fd00:10::/64")); err != nil {
+		t.Fatalf("server can not be updated: %v", err)
+	}
+	if svr.GetIPv6Net() != "fd00:10::/64" || !strings.Contains(fs[svr.path(vpnConfFile)], "\nserver-ipv6 fd00:10::/64\n") {
+		t.Errorf("ipv6 network is expected to be enabled by the update: %s", svr.GetIPv6Net())
+	}
+}
+
+func TestIPv6NetworkValidation(t *testing.T) {
+	// Init:
+	setupTestCase()
+	createTestDB()
+	defer db.Cease()
+	svr := TheServer()
+	svr.Init("localhost", "", UDPProto, "", "", "", "", false, WithIPv6Network("fd00:9::/64"))
+
+	// Test:
+	var tests = []struct {
+		name    string
+		ipblock string
+		ipv6Net string
+	}{
+		{"ipv4", "", "10.10.0.0/24"},
+		{"not cidr", "", "fd00:10::"},
+		{"too large", "", "fd00:10::/48"},
+		{"too small", "", "fd00:10::/120"},
+		{"smaller than ipv4", "10.0.0.0/8", "fd00:10::/112"},
+		{"overlapping", "10.10.0.0/24", "fd00:9::/80"},
+	}
+	for _, tt := range tests {
+		svr2 := GetServer("second")
+		svr2.emitToFileFunc = svr.emitToFileFunc
+		if err := svr2.Init("localhost", "1195", UDPProto, tt.ipblock, "", "", "", false, WithIPv6Network(tt.ipv6Net)); err == nil {
+			t.Errorf("%s: init is expected to fail", tt.name)
+			svr2.Deinit()
+		}
+	}
+	if err := svr.Update("10.0.0.0/8", "", nil, WithIPv6Network("fd00:9::/112")); err == nil {
+		t.Error("ipv4 network is not expected to outgrow the ipv6 network")
+	}
+	if svr.GetIPv6Net() != "fd00:9::/64" || svr.Net != "10.9.0.0" {
+		t.Error("failed update is not expected to change the networks")
+	}
+}
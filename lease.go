@@ -0,0 +1,328 @@
+package ovpm
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/jinzhu/gorm"
+	"github.com/sirupsen/logrus"
+)
+
+// dbLeaseModel is database model for the dynamic ip addresses that are leased to the users.
+//
+// Leases are sticky: a user keeps its address until it's deleted, gets a static
+// ip address or moves to another server, or the leases of its server are
+// repacked. Users with static ip addresses don't have leases, and leased
+// addresses can't be given to the other users as static ones.
+type dbLeaseModel struct {
+	gorm.Model
+	ServerID uint   `gorm:"unique_index:idx_lease_server_host"`
+	UserID   uint   `gorm:"unique_index"`
+	HostID   uint32 `gorm:"unique_index:idx_lease_server_host"` // Leased ip address, see HostID2IP.
+}
+
+// userRange returns the range of the addresses that the users of the server can have.
+//
+// The network address, the address of the server itself and the broadcast
+// address are never given to the users.
+func (svr *Server) userRange() hostRange {
+	r := cidrRange(svr.ipNet())
+	return hostRange{r.first + 2, r.last - 1}
+}
+
+// allocateLease leases the lowest free address of the user's pool to the user
+// and returns its host id.
+//
+// Previous lease of the user, if any, is released.
+func (svr *Server) allocateLease(u *User) (uint32, error) {
+	hostID, err := svr.freeHostID(u)
+	if err != nil {
+		return 0, err
+	}
+	u.releaseLease()
+	if err := db.Create(&dbLeaseModel{ServerID: svr.ID, UserID: u.ID, HostID: hostID}).Error; err != nil {
+		return 0, fmt.Errorf("can not lease %s to the user %s: %v", HostID2IP(hostID), u.Username, err)
+	}
+	logrus.Debugf("ip address %s is leased to the user %s", HostID2IP(hostID), u.Username)
+	return hostID, nil
+}
+
+// freeHostID returns the lowest free address of the user's pool, ignoring the
+// current lease of the user. Reserved host ids are skipped like the static ones.
+func (svr *Server) freeHostID(u *User, reserved ...uint32) (uint32, error) {
+	used := make(map[uint32]bool)
+	for _, hostID := range append(svr.getStaticHostIDs(), reserved...) {
+		used[hostID] = true
+	}
+	var leases []dbLeaseModel
+	if err := db.Where("server_id = ? AND user_id <> ?", svr.ID, u.ID).Find(&leases).Error; err != nil {
+		return 0, err
+	}
+	for _, lease := range leases {
+		used[lease.HostID] = true
+	}
+
+	r, excluded, err := svr.leaseRange(u.PoolID)
+	if err != nil {
+		return 0, err
+	}
+	for hostID := r.first; hostID <= r.last; hostID++ {
+		if !used[hostID] && !inRanges(excluded, hostID) {
+			return hostID, nil
+		}
+	}
+	if u.PoolID != 0 {
+		return 0, fmt.Errorf("no free ip address is left in the pool %s", u.GetPoolName())
+	}
+	return 0, fmt.Errorf("no free ip address is left in the vpn network %s", svr.ipNet())
+}
+
+func inRanges(ranges []hostRange, hostID uint32) bool {
+	for _, r := range ranges {
+		if r.contains(hostID) {
+			return true
+		}
+	}
+	return false
+}
+
+// leasedHostID returns the host id of the address that is leased to the user.
+//
+// Users that don't have a lease on their server yet are given one.
+func (u *User) leasedHostID() (uint32, error) {
+	svr := u.GetServer()
+	var lease dbLeaseModel
+	q := db.Where("user_id = ?", u.ID).First(&lease)
+	if q.Error != nil && !q.RecordNotFound() {
+		return 0, q.Error
+	}
+	if q.Error == nil && lease.ServerID == svr.ID {
+		return lease.HostID, nil
+	}
+	return svr.allocateLease(u)
+}
+
+// releaseLease gives the leased address of the user back to the pool.
+func (u *User) releaseLease() {
+	db.Unscoped().Where("user_id = ?", u.ID).Delete(&dbLeaseModel{})
+}
+
+// checkNotLeased returns an error naming the holder if the address is leased
+// to a user other than the one with the given id.
+func (svr *Server) checkNotLeased(hostID uint32, userID uint) error {
+	var lease dbLeaseModel
+	q := db.Where("server_id = ? AND host_id = ? AND user_id <> ?", svr.ID, hostID, userID).First(&lease)
+	if q.RecordNotFound() {
+		return nil
+	}
+	if q.Error != nil {
+		return q.Error
+	}
+	var holder dbUserModel
+	if err := db.Unscoped().First(&holder, lease.UserID).Error; err != nil {
+		return err
+	}
+	return fmt.Errorf("ip %s is leased to the user %s", HostID2IP(hostID), holder.Username)
+}
+
+// releaseLeases gives all the addresses leased from the server back to the pool.
+func (svr *Server) releaseLeases() {
+	db.Unscoped().Where("server_id = ?", svr.ID).Delete(&dbLeaseModel{})
+}
+
+// LeaseChange is a user whose dynamic ip address is changed.
+type LeaseChange struct {
+	Username string
+	OldIP    net.IP // Nil if the user didn't have a lease.
+	NewIP    net.IP
+}
+
+// RepackLeases leases the dynamic ip addresses of the users of the server
+// again from the start of their pools in the order of their creation, closing
+// the gaps that deleted users left behind.
+//
+// It returns the users whose addresses are changed. Their sessions keep the
+// old addresses until they reconnect.
+func (svr *Server) RepackLeases() ([]LeaseChange, error) {
+	if !svr.IsInitialized() {
+		return nil, fmt.Errorf("server is not initialized")
+	}
+	users, err := svr.GetUsers()
+	if err != nil {
+		return nil, err
+	}
+	var leases []dbLeaseModel
+	if err := db.Where("server_id = ?", svr.ID).Find(&leases).Error; err != nil {
+		return nil, err
+	}
+	old := make(map[uint]uint32)
+	for _, lease := range leases {
+		old[lease.UserID] = lease.HostID
+	}
+	static := make(map[uint32]bool)
+	type leaseRange struct {
+		hostRange
+		excluded []hostRange
+	}
+	ranges := make(map[uint]leaseRange)
+	for _, u := range users {
+		if u.HostID != 0 {
+			static[u.HostID] = true
+			continue
+		}
+		if _, ok := ranges[u.PoolID]; !ok {
+			r, excluded, err := svr.leaseRange(u.PoolID)
+			if err != nil {
+				return nil, err
+			}
+			ranges[u.PoolID] = leaseRange{r, excluded}
+		}
+	}
+
+	var changes []LeaseChange
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("server_id = ?", svr.ID).Delete(&dbLeaseModel{}).Error; err != nil {
+			return err
+		}
+		// Next address to lease in each pool.
+		next := make(map[uint]uint32)
+		for _, u := range users {
+			if u.HostID != 0 {
+				continue
+			}
+			r := ranges[u.PoolID]
+			hostID, ok := next[u.PoolID]
+			if !ok {
+				hostID = r.first
+			}
+			for static[hostID] || inRanges(r.excluded, hostID) {
+				hostID++
+			}
+			if hostID > r.last {
+				return fmt.Errorf("no free ip address is left for the user %s", u.Username)
+			}
+			if err := tx.Create(&dbLeaseModel{ServerID: svr.ID, UserID: u.ID, HostID: hostID}).Error; err != nil {
+				return err
+			}
+			if old[u.ID] != hostID {
+				change := LeaseChange{Username: u.Username, NewIP: HostID2IP(hostID)}
+				if old[u.ID] != 0 {
+					change.OldIP = HostID2IP(old[u.ID])
+				}
+				changes = append(changes, change)
+			}
+			next[u.PoolID] = hostID + 1
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can not repack the leases: %v", err)
+	}
+	logrus.Infof("leases of the server %s are repacked: %d user(s) got new addresses", svr.name, len(changes))
+
+	if len(changes) > 0 {
+		if err := svr.Emit(); err != nil {
+			return nil, err
+		}
+	}
+	return changes, nil
+}
+
+// moveAddresses moves the pools, the static addresses and the leases of the
+// users from the old network of the server into its current one.
+//
+// Pools and addresses keep their offsets in the network, see checkPoolsFit. Static addresses
+// that don't fit become dynamic, and the users whose leases don't fit are
+// leased new addresses the next time they are needed.
+func (svr *Server) moveAddresses(old *net.IPNet) error {
+	users, err := svr.GetUsers()
+	if err != nil {
+		return err
+	}
+	var leases []dbLeaseModel
+	if err := db.Where("server_id = ?", svr.ID).Order("host_id").Find(&leases).Error; err != nil {
+		return err
+	}
+
+	ipnet := svr.ipNet()
+	offset := IP2HostID(ipnet.IP.Mask(ipnet.Mask)) - IP2HostID(old.IP.Mask(old.Mask))
+	r := svr.userRange()
+	taken := make(map[uint32]bool)
+	move := func(hostID uint32) (uint32, bool) {
+		hostID += offset
+		if !r.contains(hostID) || taken[hostID] {
+			return 0, false
+		}
+		taken[hostID] = true
+		return hostID, true
+	}
+
+	pools, err := svr.GetPools()
+	if err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, p := range pools {
+			if err := tx.Model(&p.dbPoolModel).Updates(dbPoolModel{CIDR: p.movedCIDR(offset)}).Error; err != nil {
+				return err
+			}
+		}
+		for _, u := range users {
+			if u.HostID == 0 {
+				continue
+			}
+			hostID, ok := move(u.HostID)
+			if !ok {
+				logrus.Warnf("static ip address %s of the user %s doesn't fit in the network %s, it's made dynamic", HostID2IP(u.HostID), u.Username, ipnet)
+			}
+			if err := tx.Model(&u.dbUserModel).Update("host_id", hostID).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Unscoped().Where("server_id = ?", svr.ID).Delete(&dbLeaseModel{}).Error; err != nil {
+			return err
+		}
+		for _, lease := range leases {
+			hostID, ok := move(lease.HostID)
+			if !ok {
+				continue
+			}
+			if err := tx.Create(&dbLeaseModel{ServerID: svr.ID, UserID: lease.UserID, HostID: hostID}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// movedCIDR returns the network of the pool moved by the offset.
+func (p *Pool) movedCIDR(offset uint32) string {
+	_, ipnet, err := net.ParseCIDR(p.CIDR)
+	if err != nil {
+		return p.CIDR
+	}
+	ipnet.IP = HostID2IP(IP2HostID(ipnet.IP) + offset)
+	return ipnet.String()
+}
+
+// checkPoolsFit returns an error if a pool of the server doesn't fit in the
+// new network when it's moved from the old one with the same offset.
+func (svr *Server) checkPoolsFit(old, cur *net.IPNet) error {
+	pools, err := svr.GetPools()
+	if err != nil {
+		return err
+	}
+	offset := IP2HostID(cur.IP.Mask(cur.Mask)) - IP2HostID(old.IP.Mask(old.Mask))
+	curRange := cidrRange(cur)
+	for _, p := range pools {
+		_, ipnet, err := net.ParseCIDR(p.movedCIDR(offset))
+		if err != nil {
+			return err
+		}
+		if r := cidrRange(ipnet); !curRange.contains(r.first) || !curRange.contains(r.last) {
+			return fmt.Errorf("validation error: pool %s would be out of the network %s as %s, it should be deleted first", p.Name, cur, ipnet)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package ovpm
+
+import (
+	"net"
+	"testing"
+)
+
+func TestRepackLeases(t *testing.T) {
+	// Init:
+	setupTestCase()
+	createTestDB()
+	defer db.Cease()
+	svr := TheServer()
+	if err := svr.Init("localhost", "", UDPProto, "10.9.0.0/24", "", "", "", false); err != nil {
+		t.Fatalf("server can not be initialized: %v", err)
+	}
+	for _, username := range []string{"usr1", "usr2"} {
+		CreateNewUser(username, "1234", false, 0, true, "description")
+	}
+	CreateNewUser("usr5", "1234", false, IP2HostID(net.ParseIP("10.9.0.4").To4()), true, "description")
+	CreateNewUser("usr3", "1234", false, 0, true, "description")
+	ipOf := func(username string) string {
+		u, err := GetUser(username)
+		if err != nil {
+			t.Fatalf("user %s can not be fetched: %v", username, err)
+		}
+		return u.getIP().String()
+	}
+
+	// Test:
+	usr2, _ := GetUser("usr2")
+	usr2.Delete()
+	if ipOf("usr1") != "10.9.0.2" || ipOf("usr3") != "10.9.0.5" {
+		t.Fatalf("addresses are expected to stick: %s %s", ipOf("usr1"), ipOf("usr3"))
+	}
+	changes, err := svr.RepackLeases()
+	if err != nil {
+		t.Fatalf("leases can not be repacked: %v", err)
+	}
+	if len(changes) != 1 || changes[0].Username != "usr3" || changes[0].OldIP.String() != "10.9.0.5" || changes[0].NewIP.String() != "10.9.0.3" {
+		t.Errorf("unexpected changes: %+v", changes)
+	}
+	if ipOf("usr1") != "10.9.0.2" || ipOf("usr3") != "10.9.0.3" || ipOf("usr5") != "10.9.0.4" {
+		t.Errorf("leases are expected to be packed around the static addresses: %s %s", ipOf("usr3"), ipOf("usr5"))
+	}
+	if changes, _ := svr.RepackLeases(); len(changes) != 0 {
+		t.Errorf("packed leases are not expected to change: %+v", changes)
+	}
+}
+
+func TestUpdateMovesAddresses(t *testing.T) {
+	// Init:
+	setupTestCase()
+	createTestDB()
+	defer db.Cease()
+	svr := TheServer()
+	if err := svr.Init("localhost", "", UDPProto, "10.9.0.0/24", "", "", "", false); err != nil {
+		t.Fatalf("server can not be initialized: %v", err)
+	}
+	CreateNewUser("usr1", "1234", false, 0, true, "description")
+	CreateNewUser("usr2", "1234", false, 0, true, "description")
+	CreateNewUser("usr3", "1234", false, IP2HostID(net.ParseIP("10.9.0.100").To4()), true, "description")
+	CreateNewUser("usr4", "1234", false, IP2HostID(net.ParseIP("10.9.0.20").To4()), true, "description")
+	usr1, _ := GetUser("usr1")
+	usr1.Delete()
+	user := func(username string) *User {
+		u, err := GetUser(username)
+		if err != nil {
+			t.Fatalf("user %s can not be fetched: %v", username, err)
+		}
+		return u
+	}
+
+	// Test:
+	if err := svr.Update("", "", nil, WithDNSOptions("", "corp.example", "")); err != nil {
+		t.Fatalf("server can not be updated: %v", err)
+	}
+	if user("usr3").GetHostID() == 0 {
+		t.Error("static addresses are not expected to be reset when the network doesn't change")
+	}
+
+	if err := svr.Update("172.16.4.0/26", "", nil); err != nil {
+		t.Fatalf("server can not be updated: %v", err)
+	}
+	if ip := user("usr2").getIP().String(); ip != "172.16.4.3" {
+		t.Errorf("lease is expected to keep its offset in the new network, got %s", ip)
+	}
+	if ip := user("usr4").getIP().String(); ip != "172.16.4.20" || user("usr4").GetHostID() == 0 {
+		t.Errorf("static address is expected to keep its offset in the new network, got %s", ip)
+	}
+	if u := user("usr3"); u.GetHostID() != 0 || u.getIP().String() != "172.16.4.2" {
+		t.Errorf("static address that doesn't fit is expected to become dynamic, got %s", u.getIP())
+	}
+}
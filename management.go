@@ -0,0 +1,211 @@
+package ovpm
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/GoldenRUS/ovpm/mgmt"
+	"github.com/sirupsen/logrus"
+)
+
+// managementByteCountInterval is the interval in seconds that OpenVPN is asked to
+// report the traffic counters of the connected clients.
+const managementByteCountInterval = 5
+
+// managementRetryInterval is the duration to wait before reconnecting to the
+// management interface.
+const managementRetryInterval = time.Second
+
+// managementEnabled tells whether the servers should keep a connection to
+// the management interfaces of their OpenVPN processes.
+var managementEnabled bool
+
+// Management keeps a connection to the management interface of the
+// OpenVPN process and reconnects whenever the process is restarted.
+type Management struct {
+	network string
+	address string
+
+	mu     sync.RWMutex
+	client *mgmt.Client
+
+	hMu               sync.RWMutex
+	clientHandlers    []func(mgmt.ClientEvent)
+	byteCountHandlers []func(mgmt.ByteCountEvent)
+
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+// InitializeManagement starts maintaining the connections to the OpenVPN
+// management interfaces of the servers.
+//
+// Servers that are started later connect to their management interfaces
+// as they are started.
+func InitializeManagement() error {
+	serversMu.Lock()
+	managementEnabled = true
+	serversMu.Unlock()
+
+	for _, svr := range GetAllServers() {
+		svr.startMonitors()
+	}
+	return nil
+}
+
+// Management returns the connection to the management interface of the server.
+//
+// It returns nil if the management interface is not initialized for the server yet.
+func (svr *Server) Management() *Management {
+	svr.monitorMu.Lock()
+	defer svr.monitorMu.Unlock()
+	return svr.management
+}
+
+// NewManagement returns a Management for the management interface listening
+// on address. Run should be called to get it connected.
+func NewManagement(network, address string) *Management {
+	return &Management{
+		network: network,
+		address: address,
+		closed:  make(chan struct{}),
+	}
+}
+
+// Run connects to the management interface and keeps the connection alive
+// until Close is called.
+func (m *Management) Run() {
+	for {
+		c, err := mgmt.Dial(m.network, m.address)
+		if err != nil {
+			logrus.Debugf("can not connect to the OpenVPN management interface: %v", err)
+		} else {
+			logrus.Debugf("connected to the OpenVPN management interface at %s", m.address)
+			m.attach(c)
+			select {
+			case <-c.Done():
+				logrus.Debug("OpenVPN management interface connection is lost")
+			case <-m.closed:
+			}
+			m.detach()
+		}
+
+		select {
+		case <-m.closed:
+			return
+		case <-time.After(managementRetryInterval):
+		}
+	}
+}
+
+// Close disconnects from the management interface and stops reconnecting.
+func (m *Management) Close() {
+	m.closeOnce.Do(func() {
+		close(m.closed)
+	})
+	m.detach()
+}
+
+// IsConnected tells whether the management interface is reachable at the moment.
+func (m *Management) IsConnected() bool {
+	return m.getClient() != nil
+}
+
+// Kill disconnects the VPN sessions of the given common name.
+func (m *Management) Kill(commonName string) error {
+	c := m.getClient()
+	if c == nil {
+		return fmt.Errorf("OpenVPN management interface is not connected")
+	}
+	return c.Kill(commonName)
+}
+
+// Status returns the live status of the OpenVPN server.
+func (m *Management) Status() (*mgmt.Status, error) {
+	c := m.getClient()
+	if c == nil {
+		return nil, fmt.Errorf("OpenVPN management interface is not connected")
+	}
+	return c.Status()
+}
+
+// OnClientEvent registers fn to be called on every >CLIENT: event received
+// from OpenVPN. Registrations survive reconnects.
+func (m *Management) OnClientEvent(fn func(mgmt.ClientEvent)) {
+	m.hMu.Lock()
+	defer m.hMu.Unlock()
+	m.clientHandlers = append(m.clientHandlers, fn)
+}
+
+// OnByteCount registers fn to be called on every >BYTECOUNT_CLI: event received
+// from OpenVPN. Registrations survive reconnects.
+func (m *Management) OnByteCount(fn func(mgmt.ByteCountEvent)) {
+	m.hMu.Lock()
+	defer m.hMu.Unlock()
+	m.byteCountHandlers = append(m.byteCountHandlers, fn)
+}
+
+func (m *Management) getClient() *mgmt.Client {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.client
+}
+
+func (m *Management) attach(c *mgmt.Client) {
+	c.OnClientEvent(func(e mgmt.ClientEvent) {
+		m.hMu.RLock()
+		defer m.hMu.RUnlock()
+		for _, fn := range m.clientHandlers {
+			fn(e)
+		}
+	})
+	c.OnByteCount(func(e mgmt.ByteCountEvent) {
+		m.hMu.RLock()
+		defer m.hMu.RUnlock()
+		for _, fn := range m.byteCountHandlers {
+			fn(e)
+		}
+	})
+	if err := c.ByteCount(managementByteCountInterval); err != nil {
+		logrus.Debugf("can not enable bytecount reports: %v", err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.client = c
+}
+
+func (m *Management) detach() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.client != nil {
+		m.client.Close()
+		m.client = nil
+	}
+}
+
+func logClientEvent(e mgmt.ClientEvent) {
+	switch e.Type {
+	case "ESTABLISHED":
+		logrus.WithFields(logrus.Fields{"CommonName": e.CommonName(), "RealAddress": e.Env["untrusted_ip"]}).Info("vpn client connected")
+	case "DISCONNECT":
+		logrus.WithFields(logrus.Fields{"CommonName": e.CommonName()}).Info("vpn client disconnected")
+	}
+}
+
+// clEntriesFromStatus converts the client list of a management status
+// report to status log entries.
+func clEntriesFromStatus(s *mgmt.Status) []clEntry {
+	var cl []clEntry
+	for _, c := range s.Clients {
+		cl = append(cl, clEntry{
+			CommonName:     c.CommonName,
+			RealAddress:    c.RealAddress,
+			BytesReceived:  c.BytesReceived,
+			BytesSent:      c.BytesSent,
+			ConnectedSince: c.ConnectedSince,
+		})
+	}
+	return cl
+}
@@ -0,0 +1,163 @@
+package ovpm
+
+import (
+	"io"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/GoldenRUS/ovpm/mgmt"
+	"github.com/GoldenRUS/ovpm/mgmt/mgmttest"
+)
+
+func setupTestManagement(t *testing.T) *mgmttest.Server {
+	sock := filepath.Join(t.TempDir(), "management.sock")
+	srv, err := mgmttest.NewServer("unix", sock)
+	if err != nil {
+		t.Fatalf("can not start fake management server: %v", err)
+	}
+	srv.Handle("bytecount", func(string) []string {
+		return []string{"SUCCESS: bytecount interval changed"}
+	})
+
+	m := NewManagement("unix", sock)
+	go m.Run()
+
+	svr := TheServer()
+	svr.monitorMu.Lock()
+	svr.management = m
+	svr.monitorMu.Unlock()
+
+	t.Cleanup(func() {
+		svr.CloseMonitors()
+		srv.Close()
+	})
+
+	for i := 0; !m.IsConnected(); i++ {
+		if i > 100 {
+			t.Fatal("management is expected to connect to the fake server")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return srv
+}
+
+func TestManagementKill(t *testing.T) {
+	// Prepare:
+	srv := setupTestManagement(t)
+	srv.Handle("kill", func(args string) []string {
+		if args == "usr1" {
+			return []string{"SUCCESS: common name 'usr1' found, 1 client(s) killed"}
+		}
+		return []string{"ERROR: common name '" + args + "' not found"}
+	})
+
+	// Test:
+	if err := TheServer().Management().Kill("usr1"); err != nil {
+		t.Errorf("expected to kill usr1 but got an error instead: %v", err)
+	}
+	if err := TheServer().Management().Kill("usr2"); err != mgmt.ErrNotFound {
+		t.Errorf("expected %v, got %v", mgmt.ErrNotFound, err)
+	}
+}
+
+func TestManagementClientEvents(t *testing.T) {
+	// Prepare:
+	srv := setupTestManagement(t)
+	events := make(chan mgmt.ClientEvent, 1)
+	TheServer().Management().OnClientEvent(func(e mgmt.ClientEvent) {
+		events <- e
+	})
+
+	// Test:
+	srv.Notify(">CLIENT:DISCONNECT,3", ">CLIENT:ENV,common_name=usr1", ">CLIENT:ENV,END")
+	select {
+	case e := <-events:
+		if e.Type != "DISCONNECT" || e.CommonName() != "usr1" {
+			t.Errorf("unexpected event: %+v", e)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("client event is expected to be forwarded")
+	}
+}
+
+func TestManagementConnectionStatus(t *testing.T) {
+	// Init:
+	db := createTestDB()
+	defer db.Cease()
+	svr := TheServer()
+	svr.Init("localhost", "", UDPProto, "", "", "", "", false)
+
+	// Prepare:
+	srv := setupTestManagement(t)
+	srv.Handle("status", func(string) []string {
+		return []string{
+			"HEADER\tCLIENT_LIST\tCommon Name\tReal Address\tVirtual Address\tVirtual IPv6 Address\tBytes Received\tBytes Sent\tConnected Since\tConnected Since (time_t)\tUsername\tClient ID\tPeer ID\tData Channel Cipher",
+			"CLIENT_LIST\tusr1\t1.1.1.1:51412\t10.9.0.2\t\t7\t9\tMon Mar 26 13:20:10 2018\t1522070410\tUNDEF\t4\t0\tAES-256-GCM",
+			"END",
+		}
+	})
+	origOpenFunc := svr.openFunc
+	defer func() { svr.openFunc = origOpenFunc }()
+	svr.openFunc = func(path string) (io.Reader, error) {
+		t.Fatal("status log is not expected to be read when the management interface is connected")
+		return nil, nil
+	}
+	usr1, err := CreateNewUser("usr1", "1234", true, 0, false, "description")
+	if err != nil {
+		t.Fatalf("user creation failed: %v", err)
+	}
+
+	// Test:
+	isConnected, connectedSince, bytesSent, bytesReceived, _, _ := usr1.ConnectionStatus()
+	if !isConnected {
+		t.Fatal("usr1 is expected to be connected")
+	}
+	if !connectedSince.Equal(time.Unix(1522070410, 0)) || bytesSent != 9 || bytesReceived != 7 {
+		t.Errorf("unexpected connection status: %s %d %d", connectedSince, bytesSent, bytesReceived)
+	}
+
+	users, err := svr.GetConnectedUsers()
+	if err != nil {
+		t.Fatalf("can not get connected users: %v", err)
+	}
+	if len(users) != 1 || users[0].GetUsername() != "usr1" {
+		t.Errorf("usr1 is expected to be the only connected user: %+v", users)
+	}
+}
+
+func TestUserDisconnect(t *testing.T) {
+	// Init:
+	db := createTestDB()
+	defer db.Cease()
+	TheServer().Init("localhost", "", UDPProto, "", "", "", "", false)
+
+	// Prepare:
+	usr1, err := CreateNewUser("usr1", "1234", false, 0, false, "description")
+	if err != nil {
+		t.Fatalf("user creation failed: %v", err)
+	}
+
+	// Test:
+	// Management is not initialized yet.
+	if err := usr1.Disconnect(); err == nil {
+		t.Error("error is expected when the management interface is not initialized")
+	}
+
+	srv := setupTestManagement(t)
+	killed := false
+	srv.Handle("kill", func(args string) []string {
+		if args == "usr1" && !killed {
+			killed = true
+			return []string{"SUCCESS: common name 'usr1' found, 1 client(s) killed"}
+		}
+		return []string{"ERROR: common name '" + args + "' not found"}
+	})
+
+	if err := usr1.Disconnect(); err != nil {
+		t.Errorf("expected to disconnect usr1 but got an error instead: %v", err)
+	}
+	if err := usr1.Disconnect(); err == nil {
+		t.Error("error is expected when the user is not connected")
+	}
+}
@@ -0,0 +1,377 @@
+// Package mgmt implements a client for the OpenVPN management interface.
+//
+// The management interface is a line based protocol spoken over a TCP or
+// a unix domain socket. Commands are answered either with a single
+// "SUCCESS: ..." / "ERROR: ..." line or with a multi line block that is
+// terminated by "END". Lines starting with '>' are real-time notifications
+// that can arrive at any time, even in between the lines of a command
+// response.
+package mgmt
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultTimeout is the default duration to wait for a command response.
+const DefaultTimeout = 5 * time.Second
+
+var (
+	// ErrClosed is returned when a command is issued over a closed connection.
+	ErrClosed = errors.New("management connection is closed")
+
+	// ErrTimeout is returned when OpenVPN doesn't respond to a command in
+	// time. The connection is closed then, since a late response can't be
+	// told apart from the response of the next command.
+	ErrTimeout = errors.New("management command timed out")
+
+	// ErrNotFound is returned when the target of a command (e.g. a client
+	// that is going to be killed) doesn't exist.
+	ErrNotFound = errors.New("not found")
+)
+
+// CommandError is an error reported by OpenVPN as a response to a command.
+type CommandError struct {
+	Command string
+	Message string
+}
+
+func (e *CommandError) Error() string {
+	return fmt.Sprintf("management command %q failed: %s", e.Command, e.Message)
+}
+
+// ClientEvent represents a real-time >CLIENT: notification.
+type ClientEvent struct {
+	// Type is one of CONNECT, REAUTH, ESTABLISHED, DISCONNECT or ADDRESS.
+	Type string
+
+	CID uint64 // Client ID
+	KID uint64 // Key ID, only present on CONNECT and REAUTH.
+
+	// Addr is the address that is assigned to the client.
+	// Only present on ADDRESS.
+	Addr string
+
+	// Env is the environment block that is sent along with the
+	// notification. Not present on ADDRESS.
+	Env map[string]string
+}
+
+// CommonName returns the common name of the client that the event belongs to.
+func (e ClientEvent) CommonName() string {
+	return e.Env["common_name"]
+}
+
+// ByteCountEvent represents a real-time >BYTECOUNT_CLI: notification.
+type ByteCountEvent struct {
+	CID           uint64 // Client ID
+	BytesReceived uint64 // Bytes received from the client.
+	BytesSent     uint64 // Bytes sent to the client.
+}
+
+// Client is a connection to the OpenVPN management interface.
+//
+// It is safe to use a Client from multiple goroutines. Commands are
+// serialized over the connection.
+type Client struct {
+	// Timeout is the duration to wait for a command response.
+	Timeout time.Duration
+
+	conn  net.Conn
+	cmdMu sync.Mutex  // serializes commands
+	lines chan string // response lines
+
+	hMu              sync.RWMutex
+	clientHandlers   []func(ClientEvent)
+	byteCountHandler []func(ByteCountEvent)
+
+	pending *ClientEvent // client event that is being received
+
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// Dial connects to the OpenVPN management interface listening on address.
+//
+// network is either "unix" or "tcp".
+func Dial(network, address string) (*Client, error) {
+	conn, err := net.DialTimeout(network, address, DefaultTimeout)
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(conn), nil
+}
+
+// NewClient returns a client that talks over the already established conn.
+func NewClient(conn net.Conn) *Client {
+	c := &Client{
+		Timeout: DefaultTimeout,
+		conn:    conn,
+		lines:   make(chan string, 64),
+		done:    make(chan struct{}),
+	}
+	go c.read()
+	return c
+}
+
+// Close closes the management connection.
+func (c *Client) Close() error {
+	var err error
+	c.closeOnce.Do(func() {
+		err = c.conn.Close()
+	})
+	return err
+}
+
+// Done returns a channel that's closed when the connection is gone.
+func (c *Client) Done() <-chan struct{} {
+	return c.done
+}
+
+// OnClientEvent registers fn to be called on every >CLIENT: notification.
+//
+// Handlers are called from the connection's reader goroutine, so they
+// should return quickly and must not issue commands on the same client.
+func (c *Client) OnClientEvent(fn func(ClientEvent)) {
+	c.hMu.Lock()
+	defer c.hMu.Unlock()
+	c.clientHandlers = append(c.clientHandlers, fn)
+}
+
+// OnByteCount registers fn to be called on every >BYTECOUNT_CLI: notification.
+//
+// The same restrictions as OnClientEvent apply.
+func (c *Client) OnByteCount(fn func(ByteCountEvent)) {
+	c.hMu.Lock()
+	defer c.hMu.Unlock()
+	c.byteCountHandler = append(c.byteCountHandler, fn)
+}
+
+// Kill disconnects all clients that have the given common name.
+//
+// ErrNotFound is returned if there is no such client connected.
+func (c *Client) Kill(commonName string) error {
+	_, err := c.exec("kill " + quote(commonName))
+	if err, ok := err.(*CommandError); ok && strings.Contains(err.Message, "not found") {
+		return ErrNotFound
+	}
+	return err
+}
+
+// ByteCount makes OpenVPN report the traffic counters of the connected
+// clients every interval seconds. Setting interval to 0 turns it off.
+func (c *Client) ByteCount(interval int) error {
+	_, err := c.exec(fmt.Sprintf("bytecount %d", interval))
+	return err
+}
+
+// Status returns the current status of the OpenVPN server.
+func (c *Client) Status() (*Status, error) {
+	lines, err := c.execMulti("status 3")
+	if err != nil {
+		return nil, err
+	}
+	return parseStatus(lines)
+}
+
+// exec issues a command that responds with a single SUCCESS or ERROR line
+// and returns the message of the response.
+func (c *Client) exec(cmd string) (string, error) {
+	c.cmdMu.Lock()
+	defer c.cmdMu.Unlock()
+
+	if err := c.send(cmd); err != nil {
+		return "", err
+	}
+	line, err := c.next()
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case strings.HasPrefix(line, "SUCCESS:"):
+		return strings.TrimSpace(strings.TrimPrefix(line, "SUCCESS:")), nil
+	case strings.HasPrefix(line, "ERROR:"):
+		return "", &CommandError{Command: cmd, Message: strings.TrimSpace(strings.TrimPrefix(line, "ERROR:"))}
+	}
+	return "", fmt.Errorf("unexpected response to %q: %s", cmd, line)
+}
+
+// execMulti issues a command that responds with a block of lines terminated
+// with END and returns the lines of the block.
+func (c *Client) execMulti(cmd string) ([]string, error) {
+	c.cmdMu.Lock()
+	defer c.cmdMu.Unlock()
+
+	if err := c.send(cmd); err != nil {
+		return nil, err
+	}
+	var lines []string
+	for {
+		line, err := c.next()
+		if err != nil {
+			return nil, err
+		}
+		if len(lines) == 0 && strings.HasPrefix(line, "ERROR:") {
+			return nil, &CommandError{Command: cmd, Message: strings.TrimSpace(strings.TrimPrefix(line, "ERROR:"))}
+		}
+		if line == "END" {
+			return lines, nil
+		}
+		lines = append(lines, line)
+	}
+}
+
+func (c *Client) send(cmd string) error {
+	select {
+	case <-c.done:
+		return ErrClosed
+	default:
+	}
+
+	c.conn.SetWriteDeadline(time.Now().Add(c.timeout()))
+	if _, err := fmt.Fprintf(c.conn, "%s\n", cmd); err != nil {
+		return fmt.Errorf("can not send management command %q: %v", cmd, err)
+	}
+	return nil
+}
+
+func (c *Client) next() (string, error) {
+	select {
+	case line := <-c.lines:
+		return line, nil
+	case <-c.done:
+		return "", ErrClosed
+	case <-time.After(c.timeout()):
+		c.Close()
+		return "", ErrTimeout
+	}
+}
+
+func (c *Client) timeout() time.Duration {
+	if c.Timeout > 0 {
+		return c.Timeout
+	}
+	return DefaultTimeout
+}
+
+// read reads the connection line by line, dispatches notifications
+// and queues the rest as the command responses.
+func (c *Client) read() {
+	defer close(c.done)
+	defer c.Close()
+
+	scanner := bufio.NewScanner(c.conn)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.HasPrefix(line, ">") {
+			c.notify(line[1:])
+			continue
+		}
+		select {
+		case c.lines <- line:
+		default:
+			logrus.Debugf("management: nobody is waiting for the response, dropping: %s", line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		logrus.Debugf("management: connection is lost: %v", err)
+	}
+}
+
+// notify handles a real-time notification.
+func (c *Client) notify(line string) {
+	typ, body := line, ""
+	if i := strings.Index(line, ":"); i >= 0 {
+		typ, body = line[:i], line[i+1:]
+	}
+
+	switch typ {
+	case "CLIENT":
+		c.notifyClient(body)
+	case "BYTECOUNT_CLI":
+		fields := strings.Split(body, ",")
+		if len(fields) != 3 {
+			logrus.Debugf("management: malformed notification: %s", line)
+			return
+		}
+		e := ByteCountEvent{
+			CID:           parseUint(fields[0]),
+			BytesReceived: parseUint(fields[1]),
+			BytesSent:     parseUint(fields[2]),
+		}
+		c.hMu.RLock()
+		defer c.hMu.RUnlock()
+		for _, fn := range c.byteCountHandler {
+			fn(e)
+		}
+	default:
+		logrus.Debugf("management: %s", line)
+	}
+}
+
+// notifyClient handles the >CLIENT: notifications.
+//
+// Apart from ADDRESS, the client notifications consist of a header line
+// followed by ENV lines which are terminated by ">CLIENT:ENV,END".
+func (c *Client) notifyClient(body string) {
+	fields := strings.Split(body, ",")
+	switch fields[0] {
+	case "ENV":
+		if c.pending == nil {
+			return
+		}
+		kv := strings.Join(fields[1:], ",")
+		if kv == "END" {
+			c.dispatch(*c.pending)
+			c.pending = nil
+			return
+		}
+		if i := strings.Index(kv, "="); i >= 0 {
+			c.pending.Env[kv[:i]] = kv[i+1:]
+		}
+	case "ADDRESS":
+		if len(fields) < 3 {
+			return
+		}
+		c.dispatch(ClientEvent{Type: fields[0], CID: parseUint(fields[1]), Addr: fields[2]})
+	default:
+		e := &ClientEvent{Type: fields[0], Env: make(map[string]string)}
+		if len(fields) > 1 {
+			e.CID = parseUint(fields[1])
+		}
+		if len(fields) > 2 {
+			e.KID = parseUint(fields[2])
+		}
+		c.pending = e
+	}
+}
+
+func (c *Client) dispatch(e ClientEvent) {
+	c.hMu.RLock()
+	defer c.hMu.RUnlock()
+	for _, fn := range c.clientHandlers {
+		fn(e)
+	}
+}
+
+// quote quotes s as a single management command argument.
+func quote(s string) string {
+	if !strings.ContainsAny(s, " \t\"\\") {
+		return s
+	}
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	return `"` + r.Replace(s) + `"`
+}
+
+func parseUint(s string) uint64 {
+	i, _ := strconv.ParseUint(strings.TrimSpace(s), 10, 64)
+	return i
+}
@@ -0,0 +1,246 @@
+package mgmt_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GoldenRUS/ovpm/mgmt"
+	"github.com/GoldenRUS/ovpm/mgmt/mgmttest"
+)
+
+var statusOutput = []string{
+	"TITLE\tOpenVPN 2.6.3 x86_64-pc-linux-gnu [SSL (OpenSSL)] [LZO] [LZ4] [EPOLL] [MH/PKTINFO] [AEAD]",
+	"TIME\tMon Mar 26 13:26:10 2018\t1522070770",
+	"HEADER\tCLIENT_LIST\tCommon Name\tReal Address\tVirtual Address\tVirtual IPv6 Address\tBytes Received\tBytes Sent\tConnected Since\tConnected Since (time_t)\tUsername\tClient ID\tPeer ID\tData Channel Cipher",
+	"CLIENT_LIST\tusr1\t1.1.1.1:51412\t10.9.0.2\t\t3871\t3924\tMon Mar 26 13:20:10 2018\t1522070410\tUNDEF\t4\t0\tAES-256-GCM",
+	"CLIENT_LIST\tusr2\t2.2.2.2:1194\t10.9.0.3\t\t100\t200\tMon Mar 26 13:25:10 2018\t1522070710\tUNDEF\t7\t1\tAES-256-GCM",
+	"HEADER\tROUTING_TABLE\tVirtual Address\tCommon Name\tReal Address\tLast Ref\tLast Ref (time_t)",
+	"ROUTING_TABLE\t10.9.0.2\tusr1\t1.1.1.1:51412\tMon Mar 26 13:26:09 2018\t1522070769",
+	"GLOBAL_STATS\tMax bcast/mcast queue length\t0",
+	"END",
+}
+
+func setupFakeServer(t *testing.T) (*mgmttest.Server, *mgmt.Client) {
+	srv, err := mgmttest.NewServer("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("can not start fake management server: %v", err)
+	}
+	c, err := mgmt.Dial("tcp", srv.Addr().String())
+	if err != nil {
+		srv.Close()
+		t.Fatalf("can not dial fake management server: %v", err)
+	}
+	c.Timeout = time.Second
+	t.Cleanup(func() {
+		c.Close()
+		srv.Close()
+	})
+	return srv, c
+}
+
+func TestClientKill(t *testing.T) {
+	// Prepare:
+	srv, c := setupFakeServer(t)
+	srv.Handle("kill", func(args string) []string {
+		if args == "usr1" || args == `"usr 2"` {
+			return []string{"SUCCESS: common name '" + args + "' found, 1 client(s) killed"}
+		}
+		return []string{"ERROR: common name '" + args + "' not found"}
+	})
+
+	// Test:
+	var killtests = []struct {
+		commonName string
+		err        error
+	}{
+		{"usr1", nil},
+		{"usr 2", nil},
+		{"nobody", mgmt.ErrNotFound},
+	}
+	for _, tt := range killtests {
+		if err := c.Kill(tt.commonName); err != tt.err {
+			t.Errorf("Kill(%q) returned %v, want %v", tt.commonName, err, tt.err)
+		}
+	}
+}
+
+func TestClientStatus(t *testing.T) {
+	// Prepare:
+	srv, c := setupFakeServer(t)
+	srv.Handle("status", func(args string) []string {
+		if args != "3" {
+			return []string{"ERROR: status version is expected to be 3"}
+		}
+		return statusOutput
+	})
+
+	// Test:
+	s, err := c.Status()
+	if err != nil {
+		t.Fatalf("can not get status: %v", err)
+	}
+	if len(s.Clients) != 2 {
+		t.Fatalf("expected 2 clients, got %d", len(s.Clients))
+	}
+	if !s.Time.Equal(time.Unix(1522070770, 0)) {
+		t.Errorf("unexpected status time: %s", s.Time)
+	}
+
+	cl := s.Clients[0]
+	if cl.CommonName != "usr1" || cl.RealAddress != "1.1.1.1:51412" || cl.VirtualAddress != "10.9.0.2" {
+		t.Errorf("client is not parsed correctly: %+v", cl)
+	}
+	if cl.BytesReceived != 3871 || cl.BytesSent != 3924 {
+		t.Errorf("traffic counters are not parsed correctly: %+v", cl)
+	}
+	if !cl.ConnectedSince.Equal(time.Unix(1522070410, 0)) {
+		t.Errorf("connected since is not parsed correctly: %s", cl.ConnectedSince)
+	}
+	if cl.CID != 4 || s.Clients[1].CID != 7 {
+		t.Errorf("client ids are not parsed correctly: %+v", s.Clients)
+	}
+
+	if len(s.Routes) != 1 || s.Routes[0].CommonName != "usr1" {
+		t.Errorf("routing table is not parsed correctly: %+v", s.Routes)
+	}
+}
+
+func TestParseStatus(t *testing.T) {
+	s, err := mgmt.ParseStatus(strings.NewReader(strings.Join(statusOutput, "\n")))
+	if err != nil {
+		t.Fatalf("can not parse status: %v", err)
+	}
+	if len(s.Clients) != 2 || len(s.Routes) != 1 {
+		t.Errorf("status is not parsed correctly: %+v", s)
+	}
+}
+
+func TestClientByteCount(t *testing.T) {
+	// Prepare:
+	srv, c := setupFakeServer(t)
+	srv.Handle("bytecount", func(args string) []string {
+		return []string{"SUCCESS: bytecount interval changed"}
+	})
+	events := make(chan mgmt.ByteCountEvent, 1)
+	c.OnByteCount(func(e mgmt.ByteCountEvent) {
+		events <- e
+	})
+
+	// Test:
+	if err := c.ByteCount(5); err != nil {
+		t.Fatalf("can not enable bytecount: %v", err)
+	}
+	if cmds := srv.Commands(); len(cmds) != 1 || cmds[0] != "bytecount 5" {
+		t.Errorf("unexpected commands: %v", cmds)
+	}
+
+	srv.Notify(">BYTECOUNT_CLI:4,3871,3924")
+	select {
+	case e := <-events:
+		if e != (mgmt.ByteCountEvent{CID: 4, BytesReceived: 3871, BytesSent: 3924}) {
+			t.Errorf("unexpected event: %+v", e)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("bytecount event is not received")
+	}
+}
+
+func TestClientEvents(t *testing.T) {
+	// Prepare:
+	srv, c := setupFakeServer(t)
+	events := make(chan mgmt.ClientEvent, 2)
+	c.OnClientEvent(func(e mgmt.ClientEvent) {
+		events <- e
+	})
+
+	// Test:
+	srv.Notify(
+		">CLIENT:ESTABLISHED,4",
+		">CLIENT:ENV,common_name=usr1",
+		">CLIENT:ENV,untrusted_ip=1.1.1.1",
+		">CLIENT:ENV,END",
+		">CLIENT:ADDRESS,4,10.9.0.2,1",
+	)
+
+	select {
+	case e := <-events:
+		if e.Type != "ESTABLISHED" || e.CID != 4 || e.CommonName() != "usr1" || e.Env["untrusted_ip"] != "1.1.1.1" {
+			t.Errorf("unexpected event: %+v", e)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("client event is not received")
+	}
+	select {
+	case e := <-events:
+		if e.Type != "ADDRESS" || e.CID != 4 || e.Addr != "10.9.0.2" {
+			t.Errorf("unexpected event: %+v", e)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("client event is not received")
+	}
+}
+
+func TestClientNotificationsInResponse(t *testing.T) {
+	// Prepare:
+	srv, c := setupFakeServer(t)
+	srv.Handle("status", func(args string) []string {
+		// Notifications might be interleaved with the response lines.
+		out := append([]string{}, statusOutput[:4]...)
+		out = append(out, ">BYTECOUNT_CLI:4,1,2")
+		return append(out, statusOutput[4:]...)
+	})
+
+	// Test:
+	s, err := c.Status()
+	if err != nil {
+		t.Fatalf("can not get status: %v", err)
+	}
+	if len(s.Clients) != 2 {
+		t.Errorf("expected 2 clients, got %d", len(s.Clients))
+	}
+}
+
+func TestClientClosed(t *testing.T) {
+	// Prepare:
+	srv, c := setupFakeServer(t)
+	srv.Close()
+
+	// Test:
+	select {
+	case <-c.Done():
+	case <-time.After(time.Second):
+		t.Fatal("client is expected to notice the closed connection")
+	}
+	if err := c.Kill("usr1"); err != mgmt.ErrClosed {
+		t.Errorf("expected %v, got %v", mgmt.ErrClosed, err)
+	}
+}
+
+func TestClientTimeout(t *testing.T) {
+	// Prepare:
+	srv, c := setupFakeServer(t)
+	c.Timeout = 100 * time.Millisecond
+	srv.Handle("kill", func(args string) []string {
+		if args == "slow" {
+			time.Sleep(300 * time.Millisecond)
+		}
+		return []string{"SUCCESS: common name '" + args + "' found, 1 client(s) killed"}
+	})
+
+	// Test:
+	if err := c.Kill("slow"); err != mgmt.ErrTimeout {
+		t.Fatalf("expected %v, got %v", mgmt.ErrTimeout, err)
+	}
+	select {
+	case <-c.Done():
+	case <-time.After(time.Second):
+		t.Fatal("connection is expected to be closed after a timeout")
+	}
+
+	// The late response of the timed out command is never read as the
+	// response of the next one.
+	if err := c.Kill("usr1"); err != mgmt.ErrClosed {
+		t.Errorf("expected %v, got %v", mgmt.ErrClosed, err)
+	}
+}
@@ -0,0 +1,133 @@
+// Package mgmttest provides a fake OpenVPN management interface for tests.
+package mgmttest
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+)
+
+// Greeting is the notification that is sent to each new connection.
+const Greeting = ">INFO:OpenVPN Management Interface Version 3 -- type 'help' for more info"
+
+// Server is a fake management interface that answers the commands
+// with the canned responses registered with Handle.
+type Server struct {
+	Listener net.Listener
+
+	mu       sync.Mutex
+	handlers map[string]func(args string) []string
+	conns    []net.Conn
+	commands []string
+	wg       sync.WaitGroup
+}
+
+// NewServer starts a fake management interface listening on address.
+//
+// network is either "unix" or "tcp". Use "127.0.0.1:0" as the address
+// to listen on a random tcp port.
+func NewServer(network, address string) (*Server, error) {
+	l, err := net.Listen(network, address)
+	if err != nil {
+		return nil, err
+	}
+	s := &Server{
+		Listener: l,
+		handlers: make(map[string]func(string) []string),
+	}
+	s.wg.Add(1)
+	go s.serve()
+	return s, nil
+}
+
+// Addr returns the address the server is listening on.
+func (s *Server) Addr() net.Addr {
+	return s.Listener.Addr()
+}
+
+// Handle registers fn as the responder for the command cmd.
+//
+// fn receives the arguments of the command and returns the response
+// lines. Unhandled commands are answered with an error.
+func (s *Server) Handle(cmd string, fn func(args string) []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[cmd] = fn
+}
+
+// Commands returns the commands received so far.
+func (s *Server) Commands() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.commands...)
+}
+
+// Notify writes lines to all connected clients.
+func (s *Server) Notify(lines ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, conn := range s.conns {
+		for _, line := range lines {
+			fmt.Fprintf(conn, "%s\r\n", line)
+		}
+	}
+}
+
+// Close shuts the server and all of its connections down.
+func (s *Server) Close() error {
+	err := s.Listener.Close()
+	s.mu.Lock()
+	for _, conn := range s.conns {
+		conn.Close()
+	}
+	s.mu.Unlock()
+	s.wg.Wait()
+	return err
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.Listener.Accept()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.conns = append(s.conns, conn)
+		fmt.Fprintf(conn, "%s\r\n", Greeting)
+		s.mu.Unlock()
+
+		s.wg.Add(1)
+		go s.handle(conn)
+	}
+}
+
+func (s *Server) handle(conn net.Conn) {
+	defer s.wg.Done()
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		cmd, args := line, ""
+		if i := strings.Index(line, " "); i >= 0 {
+			cmd, args = line[:i], line[i+1:]
+		}
+
+		s.mu.Lock()
+		s.commands = append(s.commands, line)
+		fn, ok := s.handlers[cmd]
+		s.mu.Unlock()
+
+		resp := []string{fmt.Sprintf("ERROR: unknown command [%s], enter 'help' for more options", cmd)}
+		if ok {
+			resp = fn(args)
+		}
+
+		s.mu.Lock()
+		for _, r := range resp {
+			fmt.Fprintf(conn, "%s\r\n", r)
+		}
+		s.mu.Unlock()
+	}
+}
@@ -0,0 +1,145 @@
+package mgmt
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Status is the parsed output of the "status 3" command.
+type Status struct {
+	Title   string
+	Time    time.Time
+	Clients []ClientInfo
+	Routes  []RouteInfo
+}
+
+// ClientInfo is a row of the CLIENT_LIST section of the status output.
+type ClientInfo struct {
+	CommonName         string
+	RealAddress        string
+	VirtualAddress     string
+	VirtualIPv6Address string
+	BytesReceived      uint64
+	BytesSent          uint64
+	ConnectedSince     time.Time
+	Username           string
+	CID                uint64 // Client ID
+	PeerID             uint64
+	Cipher             string
+}
+
+// RouteInfo is a row of the ROUTING_TABLE section of the status output.
+type RouteInfo struct {
+	VirtualAddress string
+	CommonName     string
+	RealAddress    string
+	LastRef        time.Time
+}
+
+// ParseStatus parses a status report in the version 3 (tab delimited) format.
+//
+// This is both the output of the "status 3" command and the content of the
+// file written by the --status directive when --status-version is 3.
+func ParseStatus(r io.Reader) (*Status, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "END" {
+			break
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return parseStatus(lines)
+}
+
+func parseStatus(lines []string) (*Status, error) {
+	var s Status
+
+	// Columns of the sections are described by the HEADER rows, so rely
+	// on them rather than the column positions that differ among the
+	// OpenVPN versions.
+	headers := make(map[string]map[string]int)
+	for _, line := range lines {
+		fields := strings.Split(line, "\t")
+		switch fields[0] {
+		case "TITLE":
+			if len(fields) > 1 {
+				s.Title = fields[1]
+			}
+		case "TIME":
+			if len(fields) > 2 {
+				s.Time = parseUnix(fields[2])
+			}
+		case "HEADER":
+			if len(fields) < 2 {
+				continue
+			}
+			cols := make(map[string]int)
+			for i, name := range fields[2:] {
+				cols[name] = i + 1
+			}
+			headers[fields[1]] = cols
+		case "CLIENT_LIST":
+			r := row{fields, headers["CLIENT_LIST"]}
+			s.Clients = append(s.Clients, ClientInfo{
+				CommonName:         r.get("Common Name", 1),
+				RealAddress:        r.get("Real Address", 2),
+				VirtualAddress:     r.get("Virtual Address", 3),
+				VirtualIPv6Address: r.get("Virtual IPv6 Address", 4),
+				BytesReceived:      parseUint(r.get("Bytes Received", 5)),
+				BytesSent:          parseUint(r.get("Bytes Sent", 6)),
+				ConnectedSince:     parseUnix(r.get("Connected Since (time_t)", 8)),
+				Username:           r.get("Username", 9),
+				CID:                parseUint(r.get("Client ID", 10)),
+				PeerID:             parseUint(r.get("Peer ID", 11)),
+				Cipher:             r.get("Data Channel Cipher", 12),
+			})
+		case "ROUTING_TABLE":
+			r := row{fields, headers["ROUTING_TABLE"]}
+			s.Routes = append(s.Routes, RouteInfo{
+				VirtualAddress: r.get("Virtual Address", 1),
+				CommonName:     r.get("Common Name", 2),
+				RealAddress:    r.get("Real Address", 3),
+				LastRef:        parseUnix(r.get("Last Ref (time_t)", 5)),
+			})
+		}
+	}
+	return &s, nil
+}
+
+// row is a tab delimited status row along with its column indexes.
+type row struct {
+	fields []string
+	cols   map[string]int
+}
+
+// get returns the value of the named column. If there was no HEADER
+// describing the row, the value at the fallback position is returned.
+func (r row) get(name string, fallback int) string {
+	i, ok := r.cols[name]
+	if !ok {
+		if r.cols != nil {
+			return ""
+		}
+		i = fallback
+	}
+	if i >= len(r.fields) {
+		return ""
+	}
+	return r.fields[i]
+}
+
+func parseUnix(s string) time.Time {
+	sec, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	if err != nil || sec == 0 {
+		return time.Time{}
+	}
+	return time.Unix(sec, 0)
+}
@@ -0,0 +1,211 @@
+package ovpm
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/sirupsen/logrus"
+)
+
+// migration is a versioned step of the database schema.
+//
+// Migrations should never refer to the models of the package, since the
+// models keep changing after the migration is released. They should declare
+// snapshots of the models they work on instead.
+type migration struct {
+	Version uint
+	Name    string
+	Up      func(tx *gorm.DB) error
+	Down    func(tx *gorm.DB) error
+}
+
+// dbSchemaMigrationModel records an applied migration.
+type dbSchemaMigrationModel struct {
+	Version   uint `gorm:"primary_key;auto_increment:false"`
+	Name      string
+	AppliedAt time.Time
+}
+
+func (dbSchemaMigrationModel) TableName() string {
+	return "schema_migrations"
+}
+
+// MigrationStatus represents the state of a migration on the database.
+type MigrationStatus struct {
+	Version   uint
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// LatestSchemaVersion returns the schema version that this version of ovpm works with.
+func LatestSchemaVersion() uint {
+	if len(migrations) == 0 {
+		return 0
+	}
+	return migrations[len(migrations)-1].Version
+}
+
+// SchemaVersion returns the version of the last migration applied to the database.
+func (db *DB) SchemaVersion() (uint, error) {
+	if err := db.AutoMigrate(&dbSchemaMigrationModel{}).Error; err != nil {
+		return 0, fmt.Errorf("can not create schema_migrations table: %v", err)
+	}
+	var last dbSchemaMigrationModel
+	err := db.Order("version DESC").First(&last).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return last.Version, nil
+}
+
+// MigrationStatus returns the known migrations along with whether they are applied.
+func (db *DB) MigrationStatus() ([]MigrationStatus, error) {
+	if _, err := db.SchemaVersion(); err != nil {
+		return nil, err
+	}
+	var applied []dbSchemaMigrationModel
+	if err := db.Find(&applied).Error; err != nil {
+		return nil, err
+	}
+	appliedAt := make(map[uint]time.Time)
+	for _, m := range applied {
+		appliedAt[m.Version] = m.AppliedAt
+	}
+
+	var statuses []MigrationStatus
+	for _, m := range migrations {
+		at, ok := appliedAt[m.Version]
+		statuses = append(statuses, MigrationStatus{
+			Version:   m.Version,
+			Name:      m.Name,
+			Applied:   ok,
+			AppliedAt: at,
+		})
+	}
+	return statuses, nil
+}
+
+// MigrateUp applies the pending migrations up to and including the target version.
+//
+// All of the pending migrations are applied if target is 0. The database is
+// backed up before the first migration is applied unless backup is false.
+func (db *DB) MigrateUp(target uint, backup bool) error {
+	if target == 0 {
+		target = LatestSchemaVersion()
+	}
+	if target > LatestSchemaVersion() {
+		return fmt.Errorf("unknown schema version: %d", target)
+	}
+	version, err := db.SchemaVersion()
+	if err != nil {
+		return err
+	}
+
+	var pending []migration
+	for _, m := range migrations {
+		if m.Version > version && m.Version <= target {
+			pending = append(pending, m)
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+	if backup && !db.isEmpty(version) {
+		if _, err := db.Backup(); err != nil {
+			return fmt.Errorf("can not back the database up: %v", err)
+		}
+	}
+
+	for _, m := range pending {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := m.Up(tx); err != nil {
+				return err
+			}
+			return tx.Create(&dbSchemaMigrationModel{Version: m.Version, Name: m.Name, AppliedAt: time.Now()}).Error
+		})
+		if err != nil {
+			return fmt.Errorf("migration %d (%s) failed: %v", m.Version, m.Name, err)
+		}
+		logrus.Infof("database migrated to version %d (%s)", m.Version, m.Name)
+	}
+	return nil
+}
+
+// MigrateDown rolls the applied migrations above the target version back.
+//
+// The database is backed up before the first migration is rolled back unless
+// backup is false.
+func (db *DB) MigrateDown(target uint, backup bool) error {
+	version, err := db.SchemaVersion()
+	if err != nil {
+		return err
+	}
+	if target >= version {
+		return nil
+	}
+	if backup {
+		if _, err := db.Backup(); err != nil {
+			return fmt.Errorf("can not back the database up: %v", err)
+		}
+	}
+
+	for i := len(migrations) - 1; i >= 0; i-- {
+		m := migrations[i]
+		if m.Version > version || m.Version <= target {
+			continue
+		}
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := m.Down(tx); err != nil {
+				return err
+			}
+			return tx.Delete(&dbSchemaMigrationModel{Version: m.Version}).Error
+		})
+		if err != nil {
+			return fmt.Errorf("rollback of migration %d (%s) failed: %v", m.Version, m.Name, err)
+		}
+		logrus.Infof("migration %d (%s) is rolled back", m.Version, m.Name)
+	}
+	return nil
+}
+
+// Backup copies the database next to itself and returns the path of the copy.
+//
+// Only sqlite3 databases can be backed up. Use the tools of the database,
+// e.g. pg_dump or mysqldump, to back the others up.
+func (db *DB) Backup() (string, error) {
+	if db.Dialect().GetName() != SQLite3Dialect {
+		logrus.Warnf("automatic backups are not supported on %s, make sure to back the database up yourself", db.Dialect().GetName())
+		return "", nil
+	}
+	if db.source == "" || db.source == ":memory:" {
+		return "", nil
+	}
+
+	version, err := db.SchemaVersion()
+	if err != nil {
+		return "", err
+	}
+	path := fmt.Sprintf("%s.v%d.%s.bak", db.source, version, time.Now().Format("20060102150405"))
+	if _, err := os.Stat(path); err == nil {
+		return "", fmt.Errorf("backup file already exists: %s", path)
+	}
+	if err := db.Exec("VACUUM INTO ?", path).Error; err != nil {
+		return "", err
+	}
+	logrus.Infof("database is backed up to %s", path)
+	return path, nil
+}
+
+// isEmpty tells whether there is nothing worth backing up in the database.
+//
+// Databases created before the migrations have no schema version but do
+// have the tables.
+func (db *DB) isEmpty(version uint) bool {
+	return version == 0 && !db.HasTable("db_server_models")
+}
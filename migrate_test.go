@@ -0,0 +1,200 @@
+package ovpm
+
+import (
+	"fmt"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+)
+
+func TestMigrateUpDown(t *testing.T) {
+	// Initialize:
+	Testing = true
+	dbPath := filepath.Join(t.TempDir(), "db.sqlite3")
+	d := OpenDB("sqlite3", dbPath)
+	defer d.Cease()
+
+	// Test:
+	if v, err := d.SchemaVersion(); err != nil || v != 0 {
+		t.Fatalf("new database is expected to be at version 0, got %d: %v", v, err)
+	}
+	if err := d.MigrateUp(0, true); err != nil {
+		t.Fatalf("can not migrate up: %v", err)
+	}
+	if v, _ := d.SchemaVersion(); v != LatestSchemaVersion() {
+		t.Errorf("database is expected to be at version %d, got %d", LatestSchemaVersion(), v)
+	}
+	if backups, _ := filepath.Glob(dbPath + ".*.bak"); len(backups) != 0 {
+		t.Errorf("empty database is not expected to be backed up: %v", backups)
+	}
+	for _, table := range []string{"db_server_models", "db_user_models", "db_revoked_models", "db_network_models", "network_users", "db_statistic_models", "db_lease_models", "db_pool_models", "network_pools", "db_acl_rule_models"} {
+		if !d.HasTable(table) {
+			t.Errorf("table %s is expected to be created", table)
+		}
+	}
+	statuses, err := d.MigrationStatus()
+	if err != nil {
+		t.Fatalf("can not get migration status: %v", err)
+	}
+	for _, s := range statuses {
+		if !s.Applied || s.AppliedAt.IsZero() {
+			t.Errorf("migration %d is expected to be applied: %+v", s.Version, s)
+		}
+	}
+
+	// Migrating again is a no-op.
+	if err := d.MigrateUp(0, true); err != nil {
+		t.Fatalf("can not migrate up: %v", err)
+	}
+
+	if err := d.MigrateDown(0, true); err != nil {
+		t.Fatalf("can not migrate down: %v", err)
+	}
+	if v, _ := d.SchemaVersion(); v != 0 {
+		t.Errorf("database is expected to be at version 0, got %d", v)
+	}
+	if d.HasTable("db_user_models") {
+		t.Error("tables are expected to be dropped")
+	}
+	if backups, _ := filepath.Glob(fmt.Sprintf("%s.v%d.*.bak", dbPath, LatestSchemaVersion())); len(backups) != 1 {
+		t.Errorf("database is expected to be backed up before the rollback: %v", backups)
+	}
+}
+
+func TestMigrateLegacyDB(t *testing.T) {
+	// Initialize:
+	Testing = true
+	dbPath := filepath.Join(t.TempDir(), "db.sqlite3")
+
+	// Prepare:
+	// Databases used to be auto migrated.
+	d := OpenDB("sqlite3", dbPath)
+	d.AutoMigrate(&dbUserModel{}, &dbServerModel{}, &dbRevokedModel{}, &dbNetworkModel{}, &dbStatisticModel{})
+	d.Save(&dbUserModel{Username: "usr1"})
+	d.Cease()
+
+	// Test:
+	d = CreateDB("sqlite3", dbPath)
+	defer d.Cease()
+	if v, _ := d.SchemaVersion(); v != LatestSchemaVersion() {
+		t.Errorf("database is expected to be at version %d, got %d", LatestSchemaVersion(), v)
+	}
+	var user dbUserModel
+	if err := d.Where(&dbUserModel{Username: "usr1"}).First(&user).Error; err != nil {
+		t.Errorf("existing user is expected to be kept: %v", err)
+	}
+	if backups, _ := filepath.Glob(dbPath + ".v0.*.bak"); len(backups) != 1 {
+		t.Errorf("database is expected to be backed up before the migration: %v", backups)
+	}
+}
+
+func TestMigrateSteps(t *testing.T) {
+	// Initialize:
+	Testing = true
+	d := openTestDB()
+	defer d.Cease()
+
+	// Prepare:
+	type itemV2 struct {
+		ID   uint
+		Name string
+	}
+	orig := migrations
+	defer func() { migrations = orig }()
+	base := LatestSchemaVersion()
+	migrations = append(migrations[:len(migrations):len(migrations)],
+		migration{
+			Version: base + 1,
+			Name:    "items",
+			Up:      func(tx *gorm.DB) error { return tx.Table("items").CreateTable(&itemV2{}).Error },
+			Down:    func(tx *gorm.DB) error { return tx.DropTable("items").Error },
+		},
+		migration{
+			Version: base + 2,
+			Name:    "broken",
+			Up: func(tx *gorm.DB) error {
+				if err := tx.Table("broken_items").CreateTable(&itemV2{}).Error; err != nil {
+					return err
+				}
+				return fmt.Errorf("broken migration")
+			},
+			Down: func(tx *gorm.DB) error { return nil },
+		},
+	)
+
+	// Test:
+	if err := d.MigrateUp(base, false); err != nil {
+		t.Fatalf("can not migrate up: %v", err)
+	}
+	if v, _ := d.SchemaVersion(); v != base {
+		t.Errorf("database is expected to be at version %d, got %d", base, v)
+	}
+
+	if err := d.MigrateUp(0, false); err == nil {
+		t.Error("broken migration is expected to fail")
+	}
+	if v, _ := d.SchemaVersion(); v != base+1 {
+		t.Errorf("database is expected to be at version %d, got %d", base+1, v)
+	}
+	if !d.HasTable("items") || d.HasTable("broken_items") {
+		t.Error("broken migration is expected to be rolled back")
+	}
+	if err := d.MigrateUp(base+3, false); err == nil {
+		t.Error("unknown version is expected to be an error")
+	}
+
+	if err := d.MigrateDown(base, false); err != nil {
+		t.Fatalf("can not migrate down: %v", err)
+	}
+	if v, _ := d.SchemaVersion(); v != base || d.HasTable("items") || !d.HasTable("db_user_models") {
+		t.Errorf("only the items migration is expected to be rolled back, version %d", v)
+	}
+}
+
+func TestMigrateIPLeases(t *testing.T) {
+	// Initialize:
+	Testing = true
+	d := openTestDB()
+	defer d.Cease()
+
+	// Prepare:
+	if err := d.MigrateUp(10, false); err != nil {
+		t.Fatalf("can not migrate up: %v", err)
+	}
+	d.Create(&serverV1{Name: DefaultServerName, Net: "10.9.0.0", Mask: "255.255.255.0"})
+	d.Create(&serverV1{Name: "other", Net: "10.10.0.0", Mask: "255.255.255.0"})
+	for _, u := range []userV1{
+		{Username: "usr1"},
+		{Username: "usr2", ServerID: 1, HostID: IP2HostID(net.ParseIP("10.9.0.3").To4())},
+		{Username: "usr3", ServerID: 1},
+		{Username: "usr4", ServerID: 2},
+		{Username: "usr5", ServerID: 1},
+	} {
+		d.Create(&u)
+	}
+
+	// Test:
+	if err := d.MigrateUp(11, false); err != nil {
+		t.Fatalf("can not migrate up: %v", err)
+	}
+	var leases []leaseV11
+	d.Order("user_id").Find(&leases)
+	expected := map[uint]string{1: "10.9.0.2", 3: "10.9.0.4", 4: "10.10.0.2", 5: "10.9.0.5"}
+	if len(leases) != len(expected) {
+		t.Fatalf("dynamic users are expected to be leased their addresses, got %+v", leases)
+	}
+	for _, lease := range leases {
+		if ip := HostID2IP(lease.HostID).String(); ip != expected[lease.UserID] {
+			t.Errorf("user %d is expected to keep the address %s, got %s", lease.UserID, expected[lease.UserID], ip)
+		}
+	}
+
+	if err := d.MigrateDown(10, false); err != nil {
+		t.Fatalf("can not migrate down: %v", err)
+	}
+	if d.HasTable("db_lease_models") {
+		t.Error("lease table is expected to be dropped")
+	}
+}
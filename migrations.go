@@ -0,0 +1,576 @@
+package ovpm
+
+import (
+	"net"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// migrations are the steps of the database schema in the order they are applied.
+//
+// Released migrations must never be edited; add a new one instead.
+var migrations = []migration{
+	{
+		Version: 1,
+		Name:    "initial schema",
+		Up:      migrateInitialSchemaUp,
+		Down:    migrateInitialSchemaDown,
+	},
+	{
+		Version: 2,
+		Name:    "ca rotation",
+		Up:      migrateCARotationUp,
+		Down:    migrateCARotationDown,
+	},
+	{
+		Version: 3,
+		Name:    "key algorithms",
+		Up:      migrateKeyAlgorithmsUp,
+		Down:    migrateKeyAlgorithmsDown,
+	},
+	{
+		Version: 4,
+		Name:    "dh params",
+		Up:      migrateDHParamsUp,
+		Down:    migrateDHParamsDown,
+	},
+	{
+		Version: 5,
+		Name:    "tls key",
+		Up:      migrateTLSKeyUp,
+		Down:    migrateTLSKeyDown,
+	},
+	{
+		Version: 6,
+		Name:    "cipher settings",
+		Up:      migrateCipherSettingsUp,
+		Down:    migrateCipherSettingsDown,
+	},
+	{
+		Version: 7,
+		Name:    "extra directives",
+		Up:      migrateExtraDirectivesUp,
+		Down:    migrateExtraDirectivesDown,
+	},
+	{
+		Version: 8,
+		Name:    "ipv6",
+		Up:      migrateIPv6Up,
+		Down:    migrateIPv6Down,
+	},
+	{
+		Version: 9,
+		Name:    "dns options",
+		Up:      migrateDNSOptionsUp,
+		Down:    migrateDNSOptionsDown,
+	},
+	{
+		Version: 10,
+		Name:    "resolver",
+		Up:      migrateResolverUp,
+		Down:    migrateResolverDown,
+	},
+	{
+		Version: 11,
+		Name:    "ip leases",
+		Up:      migrateIPLeasesUp,
+		Down:    migrateIPLeasesDown,
+	},
+	{
+		Version: 12,
+		Name:    "address pools",
+		Up:      migrateAddressPoolsUp,
+		Down:    migrateAddressPoolsDown,
+	},
+	{
+		Version: 13,
+		Name:    "acl rules",
+		Up:      migrateACLRulesUp,
+		Down:    migrateACLRulesDown,
+	},
+	{
+		Version: 14,
+		Name:    "site networks",
+		Up:      migrateSiteNetworksUp,
+		Down:    migrateSiteNetworksDown,
+	},
+}
+
+// Snapshots of the models as of migration 1.
+type (
+	serverV1 struct {
+		gorm.Model
+		Name             string `gorm:"unique_index"`
+		SerialNumber     string
+		Hostname         string
+		Port             string
+		Proto            string
+		Cert             string `gorm:"type:text"`
+		Key              string `gorm:"type:text"`
+		CACert           string `gorm:"type:text"`
+		CAKey            string `gorm:"type:text"`
+		Net              string
+		Mask             string
+		CRL              string `gorm:"type:text"`
+		DNS              string
+		KeepalivePeriod  string
+		KeepaliveTimeout string
+		UseLZO           bool
+	}
+	userV1 struct {
+		gorm.Model
+		ServerID           uint
+		Username           string `gorm:"unique_index"`
+		Cert               string `gorm:"type:text"`
+		ServerSerialNumber string
+		Hash               string
+		Key                string `gorm:"type:text"`
+		NoGW               bool
+		HostID             uint32
+		Admin              bool
+		AuthToken          string
+		Description        string
+	}
+	revokedV1 struct {
+		gorm.Model
+		ServerID     uint
+		SerialNumber string
+	}
+	networkV1 struct {
+		gorm.Model
+		ServerID uint
+		Name     string `gorm:"unique_index"`
+		CIDR     string
+		Type     uint
+		Via      string
+	}
+	networkUserV1 struct {
+		DbNetworkModelID uint `gorm:"primary_key;auto_increment:false"`
+		DbUserModelID    uint `gorm:"primary_key;auto_increment:false"`
+	}
+	statisticV1 struct {
+		gorm.Model
+		UserID         uint
+		ConnectedSince time.Time
+		ConnectedUntil time.Time
+		BytesReceived  uint64
+		BytesSent      uint64
+		CommonName     string
+		RealAddress    string
+	}
+)
+
+func (serverV1) TableName() string      { return "db_server_models" }
+func (userV1) TableName() string        { return "db_user_models" }
+func (revokedV1) TableName() string     { return "db_revoked_models" }
+func (networkV1) TableName() string     { return "db_network_models" }
+func (networkUserV1) TableName() string { return "network_users" }
+func (statisticV1) TableName() string   { return "db_statistic_models" }
+
+// migrateInitialSchemaUp creates the tables that used to be auto migrated.
+//
+// The tables of the databases created before the migrations are adopted as they are.
+func migrateInitialSchemaUp(tx *gorm.DB) error {
+	return tx.AutoMigrate(
+		&serverV1{},
+		&userV1{},
+		&revokedV1{},
+		&networkV1{},
+		&networkUserV1{},
+		&statisticV1{},
+	).Error
+}
+
+func migrateInitialSchemaDown(tx *gorm.DB) error {
+	return tx.DropTableIfExists(
+		&statisticV1{},
+		&networkUserV1{},
+		&networkV1{},
+		&revokedV1{},
+		&userV1{},
+		&serverV1{},
+	).Error
+}
+
+// Snapshots of the columns added by migration 2.
+type (
+	serverCARotationV2 struct {
+		NextCACert          string `gorm:"type:text"`
+		NextCAKey           string `gorm:"type:text"`
+		CARotationStartedAt *time.Time
+	}
+	userConfigFetchedV2 struct {
+		ConfigFetchedAt *time.Time
+	}
+)
+
+func (serverCARotationV2) TableName() string  { return "db_server_models" }
+func (userConfigFetchedV2) TableName() string { return "db_user_models" }
+
+// migrateCARotationUp adds the columns that keep track of the CA rotations.
+func migrateCARotationUp(tx *gorm.DB) error {
+	return tx.AutoMigrate(&serverCARotationV2{}, &userConfigFetchedV2{}).Error
+}
+
+func migrateCARotationDown(tx *gorm.DB) error {
+	for _, column := range []string{"next_ca_cert", "next_ca_key", "ca_rotation_started_at"} {
+		if err := tx.Model(&serverCARotationV2{}).DropColumn(column).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Model(&userConfigFetchedV2{}).DropColumn("config_fetched_at").Error
+}
+
+// serverKeyAlgorithmV3 is a snapshot of the columns added by migration 3.
+type serverKeyAlgorithmV3 struct {
+	KeyAlgorithm     string
+	RSAKeySize       int
+	CertValidityDays int
+}
+
+func (serverKeyAlgorithmV3) TableName() string { return "db_server_models" }
+
+// migrateKeyAlgorithmsUp adds the columns that select how the keys and
+// certificates of the servers are generated. Existing servers keep RSA.
+func migrateKeyAlgorithmsUp(tx *gorm.DB) error {
+	return tx.AutoMigrate(&serverKeyAlgorithmV3{}).Error
+}
+
+func migrateKeyAlgorithmsDown(tx *gorm.DB) error {
+	for _, column := range []string{"key_algorithm", "rsa_key_size", "cert_validity_days"} {
+		if err := tx.Model(&serverKeyAlgorithmV3{}).DropColumn(column).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// serverDHParamsV4 is a snapshot of the columns added by migration 4.
+type serverDHParamsV4 struct {
+	DHParams string `gorm:"type:text"`
+	ECDHOnly bool
+}
+
+func (serverDHParamsV4) TableName() string { return "db_server_models" }
+
+// migrateDHParamsUp adds the columns of the per server DH parameters. Existing
+// servers get their parameters generated on the next emit.
+func migrateDHParamsUp(tx *gorm.DB) error {
+	return tx.AutoMigrate(&serverDHParamsV4{}).Error
+}
+
+func migrateDHParamsDown(tx *gorm.DB) error {
+	for _, column := range []string{"dh_params", "ecdh_only"} {
+		if err := tx.Model(&serverDHParamsV4{}).DropColumn(column).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// serverTLSKeyV5 is a snapshot of the columns added by migration 5.
+type serverTLSKeyV5 struct {
+	TLSKey     string `gorm:"type:text"`
+	TLSKeyMode string
+}
+
+func (serverTLSKeyV5) TableName() string { return "db_server_models" }
+
+// migrateTLSKeyUp adds the columns of the static keys. Existing servers are left
+// without a key, since it would break the profiles the users already have.
+func migrateTLSKeyUp(tx *gorm.DB) error {
+	return tx.AutoMigrate(&serverTLSKeyV5{}).Error
+}
+
+func migrateTLSKeyDown(tx *gorm.DB) error {
+	for _, column := range []string{"tls_key", "tls_key_mode"} {
+		if err := tx.Model(&serverTLSKeyV5{}).DropColumn(column).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// serverCiphersV6 is a snapshot of the columns added by migration 6.
+type serverCiphersV6 struct {
+	DataCiphers   string
+	AuthDigest    string
+	TLSVersionMin string
+}
+
+func (serverCiphersV6) TableName() string { return "db_server_models" }
+
+// migrateCipherSettingsUp adds the cipher settings. Empty columns render the defaults.
+func migrateCipherSettingsUp(tx *gorm.DB) error {
+	return tx.AutoMigrate(&serverCiphersV6{}).Error
+}
+
+func migrateCipherSettingsDown(tx *gorm.DB) error {
+	for _, column := range []string{"data_ciphers", "auth_digest", "tls_version_min"} {
+		if err := tx.Model(&serverCiphersV6{}).DropColumn(column).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Snapshots of the columns added by migration 7.
+type (
+	serverDirectivesV7 struct {
+		ServerDirectives string `gorm:"type:text"`
+		ClientDirectives string `gorm:"type:text"`
+	}
+	userDirectivesV7 struct {
+		CCDDirectives string `gorm:"type:text"`
+	}
+)
+
+func (serverDirectivesV7) TableName() string { return "db_server_models" }
+func (userDirectivesV7) TableName() string   { return "db_user_models" }
+
+func migrateExtraDirectivesUp(tx *gorm.DB) error {
+	return tx.AutoMigrate(&serverDirectivesV7{}, &userDirectivesV7{}).Error
+}
+
+func migrateExtraDirectivesDown(tx *gorm.DB) error {
+	for _, column := range []string{"server_directives", "client_directives"} {
+		if err := tx.Model(&serverDirectivesV7{}).DropColumn(column).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Model(&userDirectivesV7{}).DropColumn("ccd_directives").Error
+}
+
+// serverIPv6V8 is a snapshot of the columns added by migration 8.
+type serverIPv6V8 struct {
+	IPv6Net string
+}
+
+func (serverIPv6V8) TableName() string { return "db_server_models" }
+
+// migrateIPv6Up adds the IPv6 networks of the servers. Existing servers stay IPv4 only.
+func migrateIPv6Up(tx *gorm.DB) error {
+	return tx.AutoMigrate(&serverIPv6V8{}).Error
+}
+
+func migrateIPv6Down(tx *gorm.DB) error {
+	return tx.Model(&serverIPv6V8{}).DropColumn("ipv6_net").Error
+}
+
+// Snapshots of the columns added by migration 9. Servers already had the DNS column.
+type (
+	serverDNSV9 struct {
+		DNSDomain string
+		DNSSearch string
+	}
+	userDNSV9 struct {
+		DNS       string
+		DNSDomain string
+		DNSSearch string
+	}
+	networkDNSV9 struct {
+		DNS       string
+		DNSDomain string
+		DNSSearch string
+	}
+)
+
+func (serverDNSV9) TableName() string  { return "db_server_models" }
+func (userDNSV9) TableName() string    { return "db_user_models" }
+func (networkDNSV9) TableName() string { return "db_network_models" }
+
+func migrateDNSOptionsUp(tx *gorm.DB) error {
+	return tx.AutoMigrate(&serverDNSV9{}, &userDNSV9{}, &networkDNSV9{}).Error
+}
+
+func migrateDNSOptionsDown(tx *gorm.DB) error {
+	for _, column := range []string{"dns_domain", "dns_search"} {
+		if err := tx.Model(&serverDNSV9{}).DropColumn(column).Error; err != nil {
+			return err
+		}
+	}
+	for _, column := range []string{"dns", "dns_domain", "dns_search"} {
+		if err := tx.Model(&userDNSV9{}).DropColumn(column).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&networkDNSV9{}).DropColumn(column).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// serverResolverV10 is a snapshot of the columns added by migration 10.
+type serverResolverV10 struct {
+	Resolver bool
+}
+
+func (serverResolverV10) TableName() string { return "db_server_models" }
+
+func migrateResolverUp(tx *gorm.DB) error {
+	return tx.AutoMigrate(&serverResolverV10{}).Error
+}
+
+func migrateResolverDown(tx *gorm.DB) error {
+	return tx.Model(&serverResolverV10{}).DropColumn("resolver").Error
+}
+
+// Snapshots of the models as of migration 11.
+type (
+	leaseV11 struct {
+		gorm.Model
+		ServerID uint   `gorm:"unique_index:idx_lease_server_host"`
+		UserID   uint   `gorm:"unique_index"`
+		HostID   uint32 `gorm:"unique_index:idx_lease_server_host"`
+	}
+	serverNetV11 struct {
+		ID   uint
+		Name string
+		Net  string
+		Mask string
+	}
+	userHostV11 struct {
+		ID       uint
+		ServerID uint
+		HostID   uint32
+	}
+)
+
+func (leaseV11) TableName() string     { return "db_lease_models" }
+func (serverNetV11) TableName() string { return "db_server_models" }
+func (userHostV11) TableName() string  { return "db_user_models" }
+
+// migrateIPLeasesUp creates the lease table and leases the users the addresses
+// that they used to be given, so that nobody's address changes.
+func migrateIPLeasesUp(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&leaseV11{}).Error; err != nil {
+		return err
+	}
+	var servers []serverNetV11
+	if err := tx.Find(&servers).Error; err != nil {
+		return err
+	}
+	for _, s := range servers {
+		q := tx.Where("server_id = ?", s.ID)
+		if s.Name == DefaultServerName {
+			q = tx.Where("server_id = ? OR server_id = 0", s.ID)
+		}
+		var users []userHostV11
+		if err := q.Order("id").Find(&users).Error; err != nil {
+			return err
+		}
+		static := make(map[uint32]bool)
+		for _, u := range users {
+			if u.HostID != 0 {
+				static[u.HostID] = true
+			}
+		}
+
+		// Dynamic addresses used to be handed out in the order of the user ids
+		// from the start of the network, skipping the static ones.
+		mask := net.IPMask(net.ParseIP(s.Mask).To4())
+		network := net.ParseIP(s.Net).To4().Mask(mask)
+		if network == nil {
+			continue
+		}
+		ones, bits := mask.Size()
+		hostID := IP2HostID(network) + 2
+		last := IP2HostID(network) + uint32(1<<uint(bits-ones)) - 2
+		for _, u := range users {
+			if u.HostID != 0 {
+				continue
+			}
+			for static[hostID] {
+				hostID++
+			}
+			if hostID > last {
+				break
+			}
+			if err := tx.Create(&leaseV11{ServerID: s.ID, UserID: u.ID, HostID: hostID}).Error; err != nil {
+				return err
+			}
+			hostID++
+		}
+	}
+	return nil
+}
+
+func migrateIPLeasesDown(tx *gorm.DB) error {
+	return tx.DropTable(&leaseV11{}).Error
+}
+
+// Snapshots of the models as of migration 12.
+type (
+	poolV12 struct {
+		gorm.Model
+		ServerID uint
+		Name     string `gorm:"unique_index"`
+		CIDR     string
+	}
+	userPoolV12 struct {
+		PoolID uint
+	}
+	networkPoolV12 struct {
+		DbNetworkModelID uint `gorm:"primary_key;auto_increment:false"`
+		DbPoolModelID    uint `gorm:"primary_key;auto_increment:false"`
+	}
+)
+
+func (poolV12) TableName() string        { return "db_pool_models" }
+func (userPoolV12) TableName() string    { return "db_user_models" }
+func (networkPoolV12) TableName() string { return "network_pools" }
+
+// migrateAddressPoolsUp adds the address pools. Existing users stay in the shared range.
+func migrateAddressPoolsUp(tx *gorm.DB) error {
+	return tx.AutoMigrate(&poolV12{}, &userPoolV12{}, &networkPoolV12{}).Error
+}
+
+func migrateAddressPoolsDown(tx *gorm.DB) error {
+	if err := tx.DropTable(&networkPoolV12{}, &poolV12{}).Error; err != nil {
+		return err
+	}
+	return tx.Model(&userPoolV12{}).DropColumn("pool_id").Error
+}
+
+// Snapshot of the model as of migration 13.
+type aclRuleV13 struct {
+	gorm.Model
+	UserID      uint
+	PoolID      uint
+	Action      string
+	Destination string
+	Proto       string
+	Port        string
+}
+
+func (aclRuleV13) TableName() string { return "db_acl_rule_models" }
+
+func migrateACLRulesUp(tx *gorm.DB) error {
+	return tx.AutoMigrate(&aclRuleV13{}).Error
+}
+
+func migrateACLRulesDown(tx *gorm.DB) error {
+	return tx.DropTable(&aclRuleV13{}).Error
+}
+
+// networkSiteV14 is a snapshot of the columns added by migration 14.
+type networkSiteV14 struct {
+	RouterID  uint
+	PushRoute bool
+}
+
+func (networkSiteV14) TableName() string { return "db_network_models" }
+
+func migrateSiteNetworksUp(tx *gorm.DB) error {
+	return tx.AutoMigrate(&networkSiteV14{}).Error
+}
+
+func migrateSiteNetworksDown(tx *gorm.DB) error {
+	for _, column := range []string{"router_id", "push_route"} {
+		if err := tx.Model(&networkSiteV14{}).DropColumn(column).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
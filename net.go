@@ -4,14 +4,12 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
-	"log"
 	"net"
 
 	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/asaskevich/govalidator"
-	"github.com/coreos/go-iptables/iptables"
 	"github.com/jinzhu/gorm"
 )
 
@@ -23,6 +21,7 @@ const (
 	UNDEFINEDNET NetworkType = iota
 	SERVERNET
 	ROUTE
+	SITE
 )
 
 var networkTypes = [...]struct {
@@ -33,6 +32,7 @@ var networkTypes = [...]struct {
 	{UNDEFINEDNET, "UNDEFINEDNET", "unknown network type"},
 	{SERVERNET, "SERVERNET", "network behind vpn server"},
 	{ROUTE, "ROUTE", "network to be pushed as route"},
+	{SITE, "SITE", "network behind a vpn user (site-to-site)"},
 }
 
 // NetworkTypeFromString returns string representation of the network type.
@@ -87,13 +87,21 @@ func IsNetworkType(s string) bool {
 type dbNetworkModel struct {
 	gorm.Model
 	ServerID uint
-	Server   dbServerModel
+	Server   dbServerModel `json:"-"`
 
 	Name  string `gorm:"unique_index"`
 	CIDR  string
 	Type  NetworkType
 	Via   string
-	Users []*dbUserModel `gorm:"many2many:network_users;"`
+	Users []*dbUserModel `gorm:"many2many:network_users;" json:"-"`
+	Pools []*dbPoolModel `gorm:"many2many:network_pools;" json:"-"`
+
+	DNS       string // Comma separated DNS servers pushed to the associated users.
+	DNSDomain string // DOMAIN option pushed to the associated users.
+	DNSSearch string // Comma separated DOMAIN-SEARCH options pushed to the associated users.
+
+	RouterID  uint // User that the SITE network is behind.
+	PushRoute bool // Whether the SITE network is pushed to the associated users.
 }
 
 // Network represents a VPN related network.
@@ -103,7 +111,7 @@ type Network struct {
 
 // GetNetwork returns a network specified by its name.
 func GetNetwork(name string) (*Network, error) {
-	if svr := TheServer(); !svr.IsInitialized() {
+	if !isAnyServerInitialized() {
 		return nil, fmt.Errorf("you first need to create server")
 	}
 	// Validate user input.
@@ -112,7 +120,7 @@ func GetNetwork(name string) (*Network, error) {
 	}
 
 	var network dbNetworkModel
-	db.Preload("Users").Where(&dbNetworkModel{Name: name}).First(&network)
+	db.Preload("Users").Preload("Pools").Where(&dbNetworkModel{Name: name}).First(&network)
 
 	if db.NewRecord(&network) {
 		return nil, fmt.Errorf("network not found %s", name)
@@ -125,16 +133,43 @@ func GetNetwork(name string) (*Network, error) {
 func GetAllNetworks() []*Network {
 	var networks []*Network
 	var dbNetworks []*dbNetworkModel
-	db.Preload("Users").Find(&dbNetworks)
+	db.Preload("Users").Preload("Pools").Find(&dbNetworks)
 	for _, n := range dbNetworks {
 		networks = append(networks, &Network{dbNetworkModel: *n})
 	}
 	return networks
 }
 
+// NetworkOption sets an optional attribute of a network that is being defined.
+type NetworkOption func(n *dbNetworkModel) error
+
+// WithRouter sets the user that the SITE network is behind. The user's
+// client routes the traffic between the vpn and the network.
+func WithRouter(username string) NetworkOption {
+	return func(n *dbNetworkModel) error {
+		user, err := GetUser(username)
+		if err != nil {
+			return err
+		}
+		n.RouterID = user.ID
+		return nil
+	}
+}
+
+// WithPushedRoute pushes the route of the SITE network to the users that are
+// associated with it.
+func WithPushedRoute(push bool) NetworkOption {
+	return func(n *dbNetworkModel) error {
+		n.PushRoute = push
+		return nil
+	}
+}
+
 // CreateNewNetwork creates a new network definition in the system.
-func CreateNewNetwork(name, cidr string, nettype NetworkType, via string) (*Network, error) {
-	if svr := TheServer(); !svr.IsInitialized() {
+//
+// SITE networks require a router, see WithRouter.
+func CreateNewNetwork(name, cidr string, nettype NetworkType, via string, opts ...NetworkOption) (*Network, error) {
+	if !isAnyServerInitialized() {
 		return nil, fmt.Errorf("you first need to create server")
 	}
 
@@ -149,8 +184,8 @@ func CreateNewNetwork(name, cidr string, nettype NetworkType, via string) (*Netw
 		return nil, fmt.Errorf("validation error: `%s` must be a network in the CIDR form", cidr)
 	}
 
-	if via != "" && !govalidator.IsIPv4(via) {
-		return nil, fmt.Errorf("validation error: `%s` must be a network in the IPv4 form", via)
+	if via != "" && !govalidator.IsIP(via) {
+		return nil, fmt.Errorf("validation error: `%s` must be an ip address", via)
 	}
 
 	if nettype == UNDEFINEDNET {
@@ -162,11 +197,11 @@ func CreateNewNetwork(name, cidr string, nettype NetworkType, via string) (*Netw
 		return nil, fmt.Errorf("can not parse CIDR %s: %v", cidr, err)
 	}
 
-	// Overwrite via with the parsed IPv4 string.
+	// Overwrite via with the parsed IP string.
 	if nettype == ROUTE && via != "" {
-		viaIP := net.ParseIP(via).To4()
-		if err != nil {
-			return nil, fmt.Errorf("can not parse IPv4 %s: %v", via, err)
+		viaIP := net.ParseIP(via)
+		if (viaIP.To4() == nil) != (ipnet.IP.To4() == nil) {
+			return nil, fmt.Errorf("validation error: via `%s` and the network `%s` must be of the same ip version", via, cidr)
 		}
 		via = viaIP.String()
 
@@ -181,12 +216,31 @@ func CreateNewNetwork(name, cidr string, nettype NetworkType, via string) (*Netw
 		Users: []*dbUserModel{},
 		Via:   via,
 	}
+	for _, opt := range opts {
+		if err := opt(&network); err != nil {
+			return nil, err
+		}
+	}
+	if nettype == SITE {
+		if network.RouterID == 0 {
+			return nil, fmt.Errorf("validation error: SITE networks need a router user")
+		}
+		router := (&Network{dbNetworkModel: network}).getRouter()
+		if router == nil {
+			return nil, fmt.Errorf("router of the network can not be found")
+		}
+		if vpnNet := router.GetServer().ipNet(); vpnNet.Contains(ipnet.IP) || ipnet.Contains(vpnNet.IP) {
+			return nil, fmt.Errorf("validation error: `%s` overlaps with the vpn network %s", ipnet, vpnNet)
+		}
+	} else if network.RouterID != 0 || network.PushRoute {
+		return nil, fmt.Errorf("validation error: router can only be set for SITE networks")
+	}
 	db.Save(&network)
 
 	if db.NewRecord(&network) {
 		return nil, fmt.Errorf("can not create network in the db")
 	}
-	TheServer().EmitWithRestart()
+	restartAllServers()
 	logrus.Infof("network defined: %s (%s)", network.Name, network.CIDR)
 	return &Network{dbNetworkModel: network}, nil
 
@@ -194,20 +248,20 @@ func CreateNewNetwork(name, cidr string, nettype NetworkType, via string) (*Netw
 
 // Delete deletes a network definition in the system.
 func (n *Network) Delete() error {
-	svr := TheServer()
-	if !svr.IsInitialized() {
+	if !isAnyServerInitialized() {
 		return fmt.Errorf("you first need to create server")
 	}
 
+	db.Model(&n.dbNetworkModel).Association("Pools").Clear()
 	db.Unscoped().Delete(n.dbNetworkModel)
-	svr.EmitWithRestart()
+	restartAllServers()
 	logrus.Infof("network deleted: %s", n.Name)
 	return nil
 }
 
 // Associate allows the given user access to this network.
 func (n *Network) Associate(username string) error {
-	if svr := TheServer(); !svr.IsInitialized() {
+	if !isAnyServerInitialized() {
 		return fmt.Errorf("you first need to create server")
 	}
 	user, err := GetUser(username)
@@ -233,15 +287,14 @@ func (n *Network) Associate(username string) error {
 	if userAssoc.Error != nil {
 		return fmt.Errorf("association failed: %v", userAssoc.Error)
 	}
-	TheServer().EmitWithRestart()
+	user.GetServer().EmitWithRestart()
 	logrus.Infof("user '%s' is associated with the network '%s'", user.GetUsername(), n.Name)
 	return nil
 }
 
 // Dissociate breaks up the given users association to the said network.
 func (n *Network) Dissociate(username string) error {
-	svr := TheServer()
-	if !svr.IsInitialized() {
+	if !isAnyServerInitialized() {
 		return fmt.Errorf("you first need to create server")
 	}
 
@@ -268,11 +321,76 @@ func (n *Network) Dissociate(username string) error {
 	if userAssoc.Error != nil {
 		return fmt.Errorf("disassociation failed: %v", userAssoc.Error)
 	}
-	svr.EmitWithRestart()
+	user.GetServer().EmitWithRestart()
 	logrus.Infof("user '%s' is dissociated with the network '%s'", user.GetUsername(), n.Name)
 	return nil
 }
 
+// AssociatePool allows all of the users of the given pool access to this network.
+func (n *Network) AssociatePool(name string) error {
+	pool, err := GetPool(name)
+	if err != nil {
+		return err
+	}
+	if n.includesPool(pool.ID) {
+		return fmt.Errorf("pool %s is already associated with the network %s", pool.Name, n.Name)
+	}
+
+	poolAssoc := db.Model(&n.dbNetworkModel).Association("Pools")
+	poolAssoc.Append(&pool.dbPoolModel)
+	if poolAssoc.Error != nil {
+		return fmt.Errorf("association failed: %v", poolAssoc.Error)
+	}
+	pool.GetServer().EmitWithRestart()
+	logrus.Infof("pool '%s' is associated with the network '%s'", pool.Name, n.Name)
+	return nil
+}
+
+// DissociatePool breaks up the given pool's association to the said network.
+func (n *Network) DissociatePool(name string) error {
+	pool, err := GetPool(name)
+	if err != nil {
+		return err
+	}
+	if !n.includesPool(pool.ID) {
+		return fmt.Errorf("pool %s is already not associated with the network %s", pool.Name, n.Name)
+	}
+
+	poolAssoc := db.Model(&n.dbNetworkModel).Association("Pools")
+	poolAssoc.Delete(&pool.dbPoolModel)
+	if poolAssoc.Error != nil {
+		return fmt.Errorf("disassociation failed: %v", poolAssoc.Error)
+	}
+	pool.GetServer().EmitWithRestart()
+	logrus.Infof("pool '%s' is dissociated with the network '%s'", pool.Name, n.Name)
+	return nil
+}
+
+// GetAssociatedPoolNames returns the names of network's associated pools.
+func (n *Network) GetAssociatedPoolNames() []string {
+	var names []string
+	for _, p := range n.Pools {
+		names = append(names, p.Name)
+	}
+	return names
+}
+
+// includes tells whether the user is associated with the network, either
+// by itself or through its pool.
+func (n *Network) includes(u *User) bool {
+	return n.includesPool(u.PoolID) || isOneOf(u.Username, n.GetAssociatedUsernames())
+}
+
+// includesPool tells whether the pool with the given id is associated with the network.
+func (n *Network) includesPool(poolID uint) bool {
+	for _, p := range n.Pools {
+		if p.ID == poolID {
+			return true
+		}
+	}
+	return false
+}
+
 // GetName returns network's name.
 func (n *Network) GetName() string {
 	return n.Name
@@ -317,6 +435,57 @@ func (n *Network) GetVia() string {
 	return n.Via
 }
 
+// GetRouter returns the name of the user that the SITE network is behind, or
+// an empty string for the other types of networks.
+func (n *Network) GetRouter() string {
+	if u := n.getRouter(); u != nil {
+		return u.Username
+	}
+	return ""
+}
+
+// IsRoutePushed tells whether the SITE network is pushed to the associated users.
+func (n *Network) IsRoutePushed() bool {
+	return n.PushRoute
+}
+
+func (n *Network) getRouter() *User {
+	if n.RouterID == 0 {
+		return nil
+	}
+	var user dbUserModel
+	if err := db.First(&user, n.RouterID).Error; err != nil {
+		return nil
+	}
+	return &User{dbUserModel: user}
+}
+
+// siteNetworks returns the SITE networks that are behind the users of the server.
+func (svr *Server) siteNetworks() []*Network {
+	var networks []*Network
+	for _, n := range GetAllNetworks() {
+		if n.Type != SITE {
+			continue
+		}
+		if router := n.getRouter(); router != nil && router.GetServer().name == svr.name {
+			networks = append(networks, n)
+		}
+	}
+	return networks
+}
+
+// routedNetworks returns the names of the SITE networks that the user is the
+// router of.
+func (u *User) routedNetworks() []string {
+	var networks []dbNetworkModel
+	db.Where("router_id = ?", u.ID).Order("name").Find(&networks)
+	var names []string
+	for _, n := range networks {
+		names = append(names, n.Name)
+	}
+	return names
+}
+
 // interfaceOfIP returns a network interface that has the given IP.
 func interfaceOfIP(ipnet *net.IPNet) *net.Interface {
 	ifaces, err := net.Interfaces()
@@ -377,7 +546,8 @@ func routedInterface(network string, flags net.Flags) *net.Interface {
 func getOutboundInterface() *net.Interface {
 	conn, err := net.Dial("udp", "8.8.8.8:80")
 	if err != nil {
-		log.Fatal(err)
+		logrus.Debugf("can not find the outbound interface: %v", err)
+		return nil
 	}
 	defer conn.Close()
 
@@ -412,9 +582,7 @@ func hasRoutableIP(network string, ifi *net.Interface) (net.IP, bool) {
 }
 
 // vpnInterface returns the interface which belongs to the VPN server.
-func vpnInterface() *net.Interface {
-	svr := TheServer()
-
+func (svr *Server) vpnInterface() *net.Interface {
 	mask := net.IPMask(net.ParseIP(svr.Mask))
 	prefix := net.ParseIP(svr.Net)
 	netw := prefix.Mask(mask).To4()
@@ -453,11 +621,11 @@ func routableIP(network string, ip net.IP) net.IP {
 }
 
 // ensureNatEnabled launches a goroutine that constantly tries to enable nat.
-func ensureNatEnabled() {
+func (svr *Server) ensureNatEnabled() {
 	// Nat enablerer
 	go func() {
 		for {
-			err := enableNat()
+			err := svr.enableNat()
 			if err == nil {
 				logrus.Debug("nat is enabled")
 				return
@@ -471,53 +639,48 @@ func ensureNatEnabled() {
 }
 
 // enableNat is an idempotent command that ensures nat is enabled for the vpn server.
-func enableNat() error {
+func (svr *Server) enableNat() error {
 	if Testing {
 		return nil
 	}
-	// rif := routedInterface("ip", net.FlagUp|net.FlagBroadcast)
-	// if rif == nil {
-	// 	return fmt.Errorf("can not get routable network interface")
-	// }
-	rif := getOutboundInterface()
-	if rif == nil {
+	rif, vpnIfc := natInterfacesFunc(svr)
+	if rif == "" {
 		return fmt.Errorf("can not get default gw interface")
 	}
-
-	vpnIfc := vpnInterface()
-	if vpnIfc == nil {
+	if vpnIfc == "" {
 		return fmt.Errorf("can not get vpn network interface on the system")
 	}
 
 	// Enable ip forwarding.
-	TheServer().emitToFile("/proc/sys/net/ipv4/ip_forward", "1", 0)
-	ipt, err := iptables.NewWithProtocol(iptables.ProtocolIPv4)
-	if err != nil {
-		return fmt.Errorf("can not create new iptables object: %v", err)
+	svr.emitToFile("/proc/sys/net/ipv4/ip_forward", "1", 0)
+	if svr.ipv6Net() != nil {
+		emitToFile("/proc/sys/net/ipv6/conf/all/forwarding", "1", 0)
 	}
+	return reconcileFirewall()
+}
 
-	svr := TheServer()
-
-	mask := net.IPMask(net.ParseIP(svr.Mask))
-	prefix := net.ParseIP(svr.Net)
-	netw := prefix.Mask(mask).To4()
-	netw[3] = byte(1) // Server is always gets xxx.xxx.xxx.1
-	ipnet := net.IPNet{IP: netw, Mask: mask}
-
-	// Append iptables nat rules.
-	if err := ipt.AppendUnique("nat", "POSTROUTING", "-s", ipnet.String(), "-o", rif.Name, "-j", "MASQUERADE"); err != nil {
-		return err
-	}
+// natInterfacesFunc returns the names of the outbound interface of the host
+// and the vpn interface of the server. Either is empty if it's not up.
+var natInterfacesFunc = natInterfaces
 
-	if err := ipt.AppendUnique("filter", "FORWARD", "-i", rif.Name, "-o", vpnIfc.Name, "-m", "state", "--state", "RELATED,ESTABLISHED", "-j", "ACCEPT"); err != nil {
-		return err
+// natInterfaces is the implementation of natInterfacesFunc.
+func natInterfaces(svr *Server) (rif, vpnIfc string) {
+	// rif := routedInterface("ip", net.FlagUp|net.FlagBroadcast)
+	if ifc := getOutboundInterface(); ifc != nil {
+		rif = ifc.Name
 	}
-
-	if err := ipt.AppendUnique("filter", "FORWARD", "-i", vpnIfc.Name, "-o", rif.Name, "-j", "ACCEPT"); err != nil {
-		return err
+	if ifc := svr.vpnInterface(); ifc != nil {
+		vpnIfc = ifc.Name
 	}
-	return nil
+	return rif, vpnIfc
+}
 
+// planNat masquerades the source behind the outbound interface and accepts
+// the traffic forwarded between it and the vpn interface.
+func planNat(plan *firewallPlan, source, rif, vpnIfc string) {
+	plan.addNAT(natRule{Source: source, OutIface: rif})
+	plan.addForward(forwardRule{InIface: rif, OutIface: vpnIfc, Established: true})
+	plan.addForward(forwardRule{InIface: vpnIfc, OutIface: rif})
 }
 
 // HostID2IP converts a host id (32-bit unsigned integer) to an IP address.
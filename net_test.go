@@ -3,14 +3,16 @@ package ovpm
 import (
 	"fmt"
 	"net"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 )
 
 func TestVPNCreateNewNetwork(t *testing.T) {
 	// Initialize:
 	setupTestCase()
-	CreateDB("sqlite3", ":memory:")
+	createTestDB()
 	defer db.Cease()
 	TheServer().Init("localhost", "", UDPProto, "", "", "", "", false)
 
@@ -78,7 +80,7 @@ func TestVPNCreateNewNetwork(t *testing.T) {
 func TestVPNDeleteNetwork(t *testing.T) {
 	// Initialize:
 	setupTestCase()
-	CreateDB("sqlite3", ":memory:")
+	createTestDB()
 	defer db.Cease()
 	TheServer().Init("localhost", "", UDPProto, "", "", "", "", false)
 
@@ -116,7 +118,7 @@ func TestVPNDeleteNetwork(t *testing.T) {
 func TestVPNGetNetwork(t *testing.T) {
 	// Initialize:
 	setupTestCase()
-	CreateDB("sqlite3", ":memory:")
+	createTestDB()
 	defer db.Cease()
 	TheServer().Init("localhost", "", UDPProto, "", "", "", "", false)
 
@@ -151,7 +153,7 @@ func TestVPNGetNetwork(t *testing.T) {
 func TestVPNGetAllNetworks(t *testing.T) {
 	// Initialize:
 	setupTestCase()
-	CreateDB("sqlite3", ":memory:")
+	createTestDB()
 	defer db.Cease()
 	TheServer().Init("localhost", "", UDPProto, "", "", "", "", false)
 
@@ -197,7 +199,7 @@ func TestVPNGetAllNetworks(t *testing.T) {
 func TestNetAssociate(t *testing.T) {
 	// Initialize:
 	setupTestCase()
-	CreateDB("sqlite3", ":memory:")
+	createTestDB()
 	defer db.Cease()
 	TheServer().Init("localhost", "", UDPProto, "", "", "", "", false)
 
@@ -242,7 +244,7 @@ func TestNetAssociate(t *testing.T) {
 func TestNetDissociate(t *testing.T) {
 	// Initialize:
 	setupTestCase()
-	CreateDB("sqlite3", ":memory:")
+	createTestDB()
 	defer db.Cease()
 
 	if err := TheServer().Init("localhost", "", UDPProto, "", "", "", "", false); err != nil {
@@ -295,7 +297,7 @@ func TestNetDissociate(t *testing.T) {
 func TestNetGetAssociatedUsers(t *testing.T) {
 	// Initialize:
 	setupTestCase()
-	CreateDB("sqlite3", ":memory:")
+	createTestDB()
 	defer db.Cease()
 	TheServer().Init("localhost", "", UDPProto, "", "", "", "", false)
 
@@ -326,10 +328,87 @@ func TestNetGetAssociatedUsers(t *testing.T) {
 	}
 }
 
+func TestSiteNetwork(t *testing.T) {
+	// Initialize:
+	setupTestCase()
+	createTestDB()
+	defer db.Cease()
+	svr := TheServer()
+	svr.Init("localhost", "", UDPProto, "10.9.0.0/24", "", "", "", false, WithIPv6Network("fd00:9::/64"))
+	CreateNewUser("branch", "1234", false, 0, true, "description")
+	CreateNewUser("usr1", "1234", false, 0, true, "description")
+	CreateNewUser("usr2", "1234", false, 0, true, "description")
+
+	// Test:
+	var sitetests = []struct {
+		cidr    string
+		nettype NetworkType
+		opts    []NetworkOption
+		ok      bool
+	}{
+		{"192.168.50.0/24", SITE, nil, false},                                    // Without a router.
+		{"192.168.50.0/24", SITE, []NetworkOption{WithRouter("missing")}, false}, // Unknown router.
+		{"10.9.0.0/25", SITE, []NetworkOption{WithRouter("branch")}, false},      // Overlaps with the vpn network.
+		{"192.168.50.0/24", ROUTE, []NetworkOption{WithRouter("branch")}, false}, // Router for another type.
+		{"192.168.50.0/24", SERVERNET, []NetworkOption{WithPushedRoute(true)}, false},
+	}
+	for _, tt := range sitetests {
+		if _, err := CreateNewNetwork("site", tt.cidr, tt.nettype, "", tt.opts...); (err == nil) != tt.ok {
+			t.Errorf("network %s (%s) is expected to be created: %t, got %v", tt.cidr, tt.nettype, tt.ok, err)
+		}
+	}
+
+	n, err := CreateNewNetwork("site", "192.168.50.0/24", SITE, "", WithRouter("branch"), WithPushedRoute(true))
+	if err != nil {
+		t.Fatalf("site network can not be created: %v", err)
+	}
+	if n.GetRouter() != "branch" || !n.IsRoutePushed() {
+		t.Errorf("router of the network is expected to be branch and its route pushed: %s %t", n.GetRouter(), n.IsRoutePushed())
+	}
+	CreateNewNetwork("site6", "fd00:50::/64", SITE, "", WithRouter("branch"))
+	n.Associate("usr1")
+	n.Associate("branch")
+
+	conf := fs[svr.path(vpnConfFile)]
+	for _, line := range []string{"route 192.168.50.0 255.255.255.0\n", "route-ipv6 fd00:50::/64\n"} {
+		if !strings.Contains(conf, line) {
+			t.Errorf("server conf is expected to route %q to the vpn", line)
+		}
+	}
+	ccd := func(username string) string { return fs[filepath.Join(svr.path(vpnCCDDir), username)] }
+	if !strings.Contains(ccd("branch"), "iroute 192.168.50.0 255.255.255.0\n") || !strings.Contains(ccd("branch"), "iroute-ipv6 fd00:50::/64\n") {
+		t.Errorf("ccd of the router is expected to have the iroutes:\n%s", ccd("branch"))
+	}
+	if strings.Contains(ccd("branch"), `push "route 192.168.50.0`) {
+		t.Errorf("network is not expected to be pushed to its router:\n%s", ccd("branch"))
+	}
+	if !strings.Contains(ccd("usr1"), `push "route 192.168.50.0 255.255.255.0"`) {
+		t.Errorf("network is expected to be pushed to the associated users:\n%s", ccd("usr1"))
+	}
+	if strings.Contains(ccd("usr2"), "192.168.50.0") || strings.Contains(ccd("usr1"), "fd00:50::") {
+		t.Errorf("network is only expected to be pushed to the associated users if it's enabled")
+	}
+
+	// Router can't be deleted before its networks.
+	branch, _ := GetUser("branch")
+	if err := branch.Delete(); err == nil {
+		t.Error("router of a network is not expected to be deleted")
+	}
+	n.Delete()
+	n, _ = GetNetwork("site6")
+	n.Delete()
+	if err := branch.Delete(); err != nil {
+		t.Errorf("user is expected to be deleted after its networks: %v", err)
+	}
+	if strings.Contains(fs[svr.path(vpnConfFile)], "192.168.50.0") {
+		t.Error("routes of the deleted networks are expected to be removed")
+	}
+}
+
 func TestNetworkTypeFromString(t *testing.T) {
 	// Initialize:
 	setupTestCase()
-	CreateDB("sqlite3", ":memory:")
+	createTestDB()
 	defer db.Cease()
 	TheServer().Init("localhost", "", UDPProto, "", "", "", "", false)
 
@@ -344,6 +423,7 @@ func TestNetworkTypeFromString(t *testing.T) {
 	}{
 		{"servernet", args{"SERVERNET"}, SERVERNET},
 		{"route", args{"ROUTE"}, ROUTE},
+		{"site", args{"SITE"}, SITE},
 		{"unknown", args{"aasdfsafdASDF"}, UNDEFINEDNET},
 	}
 	for _, tt := range tests {
@@ -358,7 +438,7 @@ func TestNetworkTypeFromString(t *testing.T) {
 func TestGetAllNetworkTypes(t *testing.T) {
 	// Initialize:
 	setupTestCase()
-	CreateDB("sqlite3", ":memory:")
+	createTestDB()
 	defer db.Cease()
 	TheServer().Init("localhost", "", UDPProto, "", "", "", "", false)
 
@@ -367,7 +447,7 @@ func TestGetAllNetworkTypes(t *testing.T) {
 		name string
 		want []NetworkType
 	}{
-		{"default", []NetworkType{UNDEFINEDNET, SERVERNET, ROUTE}},
+		{"default", []NetworkType{UNDEFINEDNET, SERVERNET, ROUTE, SITE}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -381,7 +461,7 @@ func TestGetAllNetworkTypes(t *testing.T) {
 func TestIsNetworkType(t *testing.T) {
 	// Initialize:
 	setupTestCase()
-	CreateDB("sqlite3", ":memory:")
+	createTestDB()
 	defer db.Cease()
 	TheServer().Init("localhost", "", UDPProto, "", "", "", "", false)
 
@@ -396,6 +476,7 @@ func TestIsNetworkType(t *testing.T) {
 	}{
 		{"servernet", args{"SERVERNET"}, true},
 		{"route", args{"ROUTE"}, true},
+		{"site", args{"SITE"}, true},
 		{"invalid", args{"ADSF"}, false},
 	}
 	for _, tt := range tests {
@@ -410,7 +491,7 @@ func TestIsNetworkType(t *testing.T) {
 func TestIncrementIP(t *testing.T) {
 	// Initialize:
 	setupTestCase()
-	CreateDB("sqlite3", ":memory:")
+	createTestDB()
 	defer db.Cease()
 	TheServer().Init("localhost", "", UDPProto, "", "", "", "", false)
 
@@ -444,7 +525,7 @@ func TestIncrementIP(t *testing.T) {
 func Test_routableIP(t *testing.T) {
 	// Initialize:
 	setupTestCase()
-	CreateDB("sqlite3", ":memory:")
+	createTestDB()
 	defer db.Cease()
 	TheServer().Init("localhost", "", UDPProto, "", "", "", "", false)
 
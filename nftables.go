@@ -0,0 +1,126 @@
+package ovpm
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// nftTable is the nftables table that ovpm owns, in the ip and ip6 families.
+const nftTable = "ovpm"
+
+// nftablesFirewall is the firewall backend that uses nftables.
+//
+// The table of the address family is replaced as a whole in a single nft
+// transaction.
+type nftablesFirewall struct{}
+
+func newNftablesFirewall() *nftablesFirewall {
+	return &nftablesFirewall{}
+}
+
+func (f *nftablesFirewall) Name() string {
+	return NftablesFirewall
+}
+
+func (f *nftablesFirewall) Apply(ipv6 bool, plan firewallPlan) error {
+	return runNftFunc(nftScript(ipv6, plan))
+}
+
+func (f *nftablesFirewall) Flush(ipv6 bool) error {
+	return runNftFunc(nftDeleteTable(ipv6))
+}
+
+// Render returns the definition of the table.
+func (f *nftablesFirewall) Render(ipv6 bool, plan firewallPlan) []string {
+	return strings.Split(strings.TrimSuffix(nftTableDef(ipv6, plan), "\n"), "\n")
+}
+
+// nftFamily returns the nftables family of the table.
+func nftFamily(ipv6 bool) string {
+	if ipv6 {
+		return "ip6"
+	}
+	return "ip"
+}
+
+// nftScript returns the nft script that replaces the table of the family.
+func nftScript(ipv6 bool, plan firewallPlan) string {
+	return nftDeleteTable(ipv6) + nftTableDef(ipv6, plan)
+}
+
+// nftDeleteTable returns the nft statements that delete the table of the
+// family. The table is declared before it's deleted, so that the deletion
+// doesn't fail if it doesn't exist.
+func nftDeleteTable(ipv6 bool) string {
+	family := nftFamily(ipv6)
+	return fmt.Sprintf("table %s %s {}\ndelete table %s %s\n", family, nftTable, family, nftTable)
+}
+
+// nftTableDef returns the definition of the table of the family with the
+// rules of the plan.
+func nftTableDef(ipv6 bool, plan firewallPlan) string {
+	family := nftFamily(ipv6)
+	var b strings.Builder
+	fmt.Fprintf(&b, "table %s %s {\n", family, nftTable)
+	fmt.Fprintf(&b, "\tchain postrouting {\n\t\ttype nat hook postrouting priority 100; policy accept;\n")
+	for _, r := range plan.NAT {
+		fmt.Fprintf(&b, "\t\t%s saddr %s oifname %q masquerade\n", family, r.Source, r.OutIface)
+	}
+	fmt.Fprintf(&b, "\t}\n")
+	fmt.Fprintf(&b, "\tchain forward {\n\t\ttype filter hook forward priority 0; policy accept;\n\t\tjump acl\n")
+	for _, r := range plan.Forward {
+		if r.Established {
+			fmt.Fprintf(&b, "\t\tiifname %q oifname %q ct state related,established accept\n", r.InIface, r.OutIface)
+		} else {
+			fmt.Fprintf(&b, "\t\tiifname %q oifname %q accept\n", r.InIface, r.OutIface)
+		}
+	}
+	fmt.Fprintf(&b, "\t}\n")
+	fmt.Fprintf(&b, "\tchain acl {\n")
+	for _, r := range plan.ACL {
+		fmt.Fprintf(&b, "\t\t%s\n", nftRule(r, ipv6))
+	}
+	fmt.Fprintf(&b, "\t}\n}\n")
+	return b.String()
+}
+
+// nftRule returns the nftables statement of the acl rule.
+func nftRule(r firewallRule, ipv6 bool) string {
+	family := nftFamily(ipv6)
+	stmt := fmt.Sprintf("%s saddr %s %s daddr %s", family, r.Source, family, r.Destination)
+	switch r.Proto {
+	case ACLTCP, ACLUDP:
+		if r.Port != "" {
+			stmt += fmt.Sprintf(" %s dport %s", r.Proto, strings.Replace(r.Port, ":", "-", 1))
+		} else {
+			stmt += " meta l4proto " + r.Proto
+		}
+	case ACLICMP:
+		if ipv6 {
+			stmt += " meta l4proto ipv6-icmp"
+		} else {
+			stmt += " meta l4proto icmp"
+		}
+	}
+	if r.Accept {
+		return stmt + " accept"
+	}
+	return stmt + " drop"
+}
+
+// runNftFunc runs the nft script.
+var runNftFunc = runNft
+
+// runNft is the implementation of runNftFunc.
+func runNft(script string) error {
+	var stderr bytes.Buffer
+	cmd := exec.Command("nft", "-f", "-")
+	cmd.Stdin = strings.NewReader(script)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("nft failed: %v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
@@ -14,12 +14,18 @@ const (
 	RenewAnyUserPerm
 	GenConfigAnyUserPerm
 	GenConfigSelfPerm
+	DisconnectAnyUserPerm
 
 	// VPN permissions
 	GetVPNStatusPerm
 	InitVPNPerm
 	UpdateVPNPerm
 	RestartVPNPerm
+	ListExpiringCertsPerm
+	RotateCAPerm
+	RotateTLSKeyPerm
+	BackupVPNPerm
+	RestoreVPNPerm
 
 	// Network permissions
 	ListNetworksPerm
@@ -46,10 +52,16 @@ func AdminPerms() []permset.Perm {
 		RenewAnyUserPerm,
 		GenConfigAnyUserPerm,
 		GenConfigSelfPerm,
+		DisconnectAnyUserPerm,
 		GetVPNStatusPerm,
 		InitVPNPerm,
 		UpdateVPNPerm,
 		RestartVPNPerm,
+		ListExpiringCertsPerm,
+		RotateCAPerm,
+		RotateTLSKeyPerm,
+		BackupVPNPerm,
+		RestoreVPNPerm,
 		ListNetworksPerm,
 		CreateNetworkPerm,
 		DeleteNetworkPerm,
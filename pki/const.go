@@ -4,6 +4,8 @@ package pki
 const (
 	PEMCertificateBlockType   string = "CERTIFICATE"
 	PEMRSAPrivateKeyBlockType        = "RSA PRIVATE KEY"
+	PEMECPrivateKeyBlockType         = "EC PRIVATE KEY"
+	PEMPrivateKeyBlockType           = "PRIVATE KEY"
 	PEMx509CRLBlockType              = "X509 CRL"
 	PEMCSRBlockType                  = "CERTIFICATE REQUEST"
 )
@@ -2,6 +2,10 @@
 package pki
 
 import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
@@ -17,9 +21,117 @@ import (
 
 const (
 	_CrtExpireYears = 10
-	_CrtKeyLength   = 2024
+	_CrtKeyLength   = 2048
 )
 
+// KeyAlgorithm is the algorithm of the generated private keys.
+type KeyAlgorithm string
+
+// Supported key algorithms.
+const (
+	RSAKey       KeyAlgorithm = "rsa"
+	ECDSAP256Key KeyAlgorithm = "ecdsa-p256"
+	ECDSAP384Key KeyAlgorithm = "ecdsa-p384"
+	Ed25519Key   KeyAlgorithm = "ed25519"
+)
+
+// MinRSAKeySize is the smallest RSA key size that can be generated.
+const MinRSAKeySize = 2048
+
+// ParseKeyAlgorithm returns the key algorithm with the given name.
+//
+// Empty name stands for RSAKey.
+func ParseKeyAlgorithm(name string) (KeyAlgorithm, error) {
+	switch alg := KeyAlgorithm(name); alg {
+	case "":
+		return RSAKey, nil
+	case RSAKey, ECDSAP256Key, ECDSAP384Key, Ed25519Key:
+		return alg, nil
+	}
+	return "", fmt.Errorf("unknown key algorithm: %s", name)
+}
+
+// ECDHCurve returns the OpenSSL name of the curve that matches the key algorithm
+// for the ECDH key exchange, or "" if there is no such curve.
+func (alg KeyAlgorithm) ECDHCurve() string {
+	switch alg {
+	case ECDSAP256Key:
+		return "prime256v1"
+	case ECDSAP384Key:
+		return "secp384r1"
+	}
+	return ""
+}
+
+// Option sets an optional attribute of the generated keys and certificates.
+type Option func(o *options)
+
+type options struct {
+	algorithm KeyAlgorithm
+	rsaBits   int
+	validity  time.Duration
+}
+
+// WithKeyAlgorithm sets the algorithm of the generated key. It defaults to RSAKey.
+func WithKeyAlgorithm(alg KeyAlgorithm) Option {
+	return func(o *options) {
+		if alg != "" {
+			o.algorithm = alg
+		}
+	}
+}
+
+// WithRSAKeySize sets the size of the generated RSA key in bits. It defaults to 2048.
+func WithRSAKeySize(bits int) Option {
+	return func(o *options) {
+		if bits != 0 {
+			o.rsaBits = bits
+		}
+	}
+}
+
+// WithValidity sets how long the generated certificate is valid. It defaults to 10 years.
+//
+// Certificates signed by a CA never outlive it, their validity is cut at the expiry of the CA.
+func WithValidity(d time.Duration) Option {
+	return func(o *options) {
+		if d != 0 {
+			o.validity = d
+		}
+	}
+}
+
+func newOptions(opts []Option) options {
+	o := options{
+		algorithm: RSAKey,
+		rsaBits:   _CrtKeyLength,
+		validity:  time.Duration(24*365*_CrtExpireYears) * time.Hour,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// newKey generates a private key as described by the options.
+func (o options) newKey() (crypto.Signer, error) {
+	switch o.algorithm {
+	case RSAKey:
+		if o.rsaBits < MinRSAKeySize {
+			return nil, fmt.Errorf("RSA key size should be at least %d bits: %d", MinRSAKeySize, o.rsaBits)
+		}
+		return rsa.GenerateKey(rand.Reader, o.rsaBits)
+	case ECDSAP256Key:
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case ECDSAP384Key:
+		return ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	case Ed25519Key:
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		return key, err
+	}
+	return nil, fmt.Errorf("unknown key algorithm: %s", o.algorithm)
+}
+
 // CertHolder encapsulates a public certificate and the corresponding private key.
 type CertHolder struct {
 	Cert string // PEM Encoded Certificate
@@ -34,14 +146,17 @@ type CA struct {
 
 // NewCA returns a newly generated CA.
 //
-// This will generate a public/private RSA keypair and a authority certificate signed by itself.
-func NewCA() (*CA, error) {
+// This will generate a public/private keypair and a authority certificate signed by itself.
+// The keypair is RSA unless another algorithm is given with WithKeyAlgorithm.
+func NewCA(opts ...Option) (*CA, error) {
+	o := newOptions(opts)
+
 	type basicConstraints struct {
 		IsCA       bool `asn1:"optional"`
 		MaxPathLen int  `asn1:"optional,default:-1"`
 	}
 
-	key, err := rsa.GenerateKey(rand.Reader, _CrtKeyLength)
+	key, err := o.newKey()
 	if err != nil {
 		return nil, fmt.Errorf("private key cannot be created: %s", err)
 	}
@@ -53,8 +168,7 @@ func NewCA() (*CA, error) {
 
 	names := pkix.Name{CommonName: "CA"}
 	var csrTemplate = x509.CertificateRequest{
-		Subject:            names,
-		SignatureAlgorithm: x509.SHA512WithRSA,
+		Subject: names,
 		ExtraExtensions: []pkix.Extension{
 			{
 				Id:       asn1.ObjectIdentifier{2, 5, 29, 19},
@@ -63,6 +177,9 @@ func NewCA() (*CA, error) {
 			},
 		},
 	}
+	if o.algorithm == RSAKey {
+		csrTemplate.SignatureAlgorithm = x509.SHA512WithRSA
+	}
 
 	csrCertificate, err := x509.CreateCertificateRequest(rand.Reader, &csrTemplate, key)
 	if err != nil {
@@ -85,31 +202,31 @@ func NewCA() (*CA, error) {
 		SerialNumber:          serial,
 		Subject:               names,
 		NotBefore:             now.Add(-10 * time.Minute).UTC(),
-		NotAfter:              now.Add(time.Duration(24*365*_CrtExpireYears) * time.Hour).UTC(),
+		NotAfter:              now.Add(o.validity).UTC(),
 		BasicConstraintsValid: true,
-		IsCA:     true,
-		KeyUsage: x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
 		//ExtKeyUsage: []x509.ExtKeyUsage{x509.KeyUsageCertSign, x509.ExtKeyUsageClientAuth},
 	}
 
 	// Sign the certificate authority
-	certificate, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	certificate, err := x509.CreateCertificate(rand.Reader, &template, &template, key.Public(), key)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate certificate error: %s", err)
 	}
 
 	var request bytes.Buffer
-	var privateKey bytes.Buffer
 	if err := pem.Encode(&request, &pem.Block{Type: PEMCertificateBlockType, Bytes: certificate}); err != nil {
 		return nil, err
 	}
-	if err := pem.Encode(&privateKey, &pem.Block{Type: PEMRSAPrivateKeyBlockType, Bytes: x509.MarshalPKCS1PrivateKey(key)}); err != nil {
+	privateKey, err := encodeKeyToPEM(key)
+	if err != nil {
 		return nil, err
 	}
 
 	return &CA{
 		CertHolder: CertHolder{
-			Key:  privateKey.String(),
+			Key:  privateKey,
 			Cert: request.String(),
 		},
 		CSR: string(csr),
@@ -117,25 +234,20 @@ func NewCA() (*CA, error) {
 
 }
 
-// NewServerCertHolder generates a RSA key-pair and a x509 certificate signed by the CA for the server.
-func NewServerCertHolder(ca *CA) (*CertHolder, error) {
-	return newCert(ca, true, "localhost")
+// NewServerCertHolder generates a key-pair and a x509 certificate signed by the CA for the server.
+func NewServerCertHolder(ca *CA, opts ...Option) (*CertHolder, error) {
+	return newCert(ca, true, "localhost", newOptions(opts))
 }
 
-// NewClientCertHolder generates a RSA key-pair and a x509 certificate signed by the CA for the client.
-func NewClientCertHolder(ca *CA, username string) (*CertHolder, error) {
-	return newCert(ca, false, username)
+// NewClientCertHolder generates a key-pair and a x509 certificate signed by the CA for the client.
+func NewClientCertHolder(ca *CA, username string, opts ...Option) (*CertHolder, error) {
+	return newCert(ca, false, username, newOptions(opts))
 }
 
-// newCert generates a RSA key-pair and a x509 certificate signed by the CA.
-func newCert(ca *CA, server bool, cn string) (*CertHolder, error) {
+// newCert generates a key-pair and a x509 certificate signed by the CA.
+func newCert(ca *CA, server bool, cn string, o options) (*CertHolder, error) {
 	// Get CA private key
-	block, _ := pem.Decode([]byte(ca.Key))
-	if block == nil {
-		return nil, fmt.Errorf("failed to parse ca private key")
-	}
-
-	caKey, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	caKey, err := ReadKeyFromPEM(ca.Key)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse ca private key: %s", err)
 	}
@@ -146,7 +258,7 @@ func newCert(ca *CA, server bool, cn string) (*CertHolder, error) {
 	}
 
 	// Create new cert's key
-	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	key, err := o.newKey()
 	if err != nil {
 		return nil, fmt.Errorf("private key cannot be created: %s", err)
 	}
@@ -162,9 +274,13 @@ func newCert(ca *CA, server bool, cn string) (*CertHolder, error) {
 	}
 
 	now := time.Now()
+	notAfter := now.Add(o.validity)
+	if notAfter.After(caCert.NotAfter) {
+		notAfter = caCert.NotAfter
+	}
 	tml := x509.Certificate{
 		NotBefore:    now.Add(-10 * time.Minute).UTC(),
-		NotAfter:     now.Add(time.Duration(24*365*_CrtExpireYears) * time.Hour).UTC(),
+		NotAfter:     notAfter.UTC(),
 		SerialNumber: serial,
 		Subject: pkix.Name{
 			CommonName:   cn,
@@ -182,7 +298,10 @@ func newCert(ca *CA, server bool, cn string) (*CertHolder, error) {
 	}
 
 	if server {
-		tml.KeyUsage = x509.KeyUsageDigitalSignature | x509.KeyUsageKeyAgreement | x509.KeyUsageKeyEncipherment
+		tml.KeyUsage = x509.KeyUsageDigitalSignature | x509.KeyUsageKeyAgreement
+		if o.algorithm == RSAKey {
+			tml.KeyUsage |= x509.KeyUsageKeyEncipherment
+		}
 		tml.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
 		val, err := asn1.Marshal(asn1.BitString{Bytes: []byte{0x40}, BitLength: 2}) // setting nsCertType to Server Type
 		if err != nil {
@@ -193,15 +312,15 @@ func newCert(ca *CA, server bool, cn string) (*CertHolder, error) {
 	}
 
 	// Sign with CA's private key
-	cert, err := x509.CreateCertificate(rand.Reader, &tml, caCert, &key.PublicKey, caKey)
+	cert, err := x509.CreateCertificate(rand.Reader, &tml, caCert, key.Public(), caKey)
 	if err != nil {
 		return nil, fmt.Errorf("certificate cannot be created: %s", err)
 	}
 
-	priKeyPem := pem.EncodeToMemory(&pem.Block{
-		Type:  PEMRSAPrivateKeyBlockType,
-		Bytes: x509.MarshalPKCS1PrivateKey(key),
-	})
+	priKeyPem, err := encodeKeyToPEM(key)
+	if err != nil {
+		return nil, err
+	}
 
 	certPem := pem.EncodeToMemory(&pem.Block{
 		Type:  PEMCertificateBlockType,
@@ -209,7 +328,7 @@ func newCert(ca *CA, server bool, cn string) (*CertHolder, error) {
 	})
 
 	return &CertHolder{
-		Key:  string(priKeyPem[:]),
+		Key:  priKeyPem,
 		Cert: string(certPem[:]),
 	}, nil
 }
@@ -221,12 +340,7 @@ func NewCRL(ca *CA, serials ...*big.Int) (string, error) {
 		return "", err
 	}
 
-	block, _ := pem.Decode([]byte(ca.Key))
-	if block == nil {
-		return "", fmt.Errorf("failed to parse ca private key")
-	}
-
-	priv, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	priv, err := ReadKeyFromPEM(ca.Key)
 	if err != nil {
 		return "", fmt.Errorf("failed to parse ca private key: %s", err)
 	}
@@ -255,7 +369,49 @@ func NewCRL(ca *CA, serials ...*big.Int) (string, error) {
 // ReadCertFromPEM decodes a PEM encoded string into a x509.Certificate.
 func ReadCertFromPEM(s string) (*x509.Certificate, error) {
 	block, _ := pem.Decode([]byte(s))
-	var cert *x509.Certificate
-	cert, _ = x509.ParseCertificate(block.Bytes)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM encoded certificate is found")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("can not parse certificate: %v", err)
+	}
 	return cert, nil
 }
+
+// ReadKeyFromPEM decodes a PEM encoded private key.
+//
+// Keys can be encoded in PKCS#8 as well as in the older PKCS#1 (RSA) and SEC 1 (EC) forms.
+func ReadKeyFromPEM(s string) (crypto.Signer, error) {
+	block, _ := pem.Decode([]byte(s))
+	if block == nil {
+		return nil, fmt.Errorf("no PEM encoded key is found")
+	}
+
+	switch block.Type {
+	case PEMRSAPrivateKeyBlockType:
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case PEMECPrivateKeyBlockType:
+		return x509.ParseECPrivateKey(block.Bytes)
+	case PEMPrivateKeyBlockType:
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("unsupported private key type: %T", key)
+		}
+		return signer, nil
+	}
+	return nil, fmt.Errorf("unsupported PEM block type: %s", block.Type)
+}
+
+// encodeKeyToPEM encodes the private key in PKCS#8.
+func encodeKeyToPEM(key crypto.Signer) (string, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return "", fmt.Errorf("can not marshal private key: %v", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: PEMPrivateKeyBlockType, Bytes: der})), nil
+}
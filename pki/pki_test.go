@@ -1,6 +1,10 @@
 package pki_test
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	cryptorand "crypto/rand"
+	"crypto/rsa"
 	"crypto/x509"
 	"encoding/pem"
 	"fmt"
@@ -38,7 +42,7 @@ func TestNewCA(t *testing.T) {
 	}{
 		{"ca.CSR", ca.CSR, pki.PEMCSRBlockType},
 		{"ca.CertHolder.Cert", ca.CertHolder.Cert, pki.PEMCertificateBlockType},
-		{"ca.CertHolder.Key", ca.CertHolder.Key, pki.PEMRSAPrivateKeyBlockType},
+		{"ca.CertHolder.Key", ca.CertHolder.Key, pki.PEMPrivateKeyBlockType},
 	}
 
 	// Is PEM encoded properly?
@@ -87,7 +91,7 @@ func TestNewCertHolders(t *testing.T) {
 			typ   string // expected pem block type
 		}{
 			{tt.name + "CertHolder.Cert", tt.certHolder.Cert, pki.PEMCertificateBlockType},
-			{tt.name + "CertHolder.Key", tt.certHolder.Key, pki.PEMRSAPrivateKeyBlockType},
+			{tt.name + "CertHolder.Key", tt.certHolder.Key, pki.PEMPrivateKeyBlockType},
 		}
 
 		// Is PEM encoded properly?
@@ -167,6 +171,113 @@ func TestNewCRL(t *testing.T) {
 	}
 }
 
+func TestKeyAlgorithms(t *testing.T) {
+	var algorithmtests = []struct {
+		alg     pki.KeyAlgorithm
+		bits    int
+		keyType string // expected type of the public key
+		curve   string // expected ECDH curve
+	}{
+		{"", 0, "*rsa.PublicKey", ""},
+		{pki.RSAKey, 3072, "*rsa.PublicKey", ""},
+		{pki.ECDSAP256Key, 0, "*ecdsa.PublicKey", "prime256v1"},
+		{pki.ECDSAP384Key, 0, "*ecdsa.PublicKey", "secp384r1"},
+		{pki.Ed25519Key, 0, "ed25519.PublicKey", ""},
+	}
+	for _, tt := range algorithmtests {
+		opts := []pki.Option{pki.WithKeyAlgorithm(tt.alg), pki.WithRSAKeySize(tt.bits)}
+		ca, err := pki.NewCA(opts...)
+		if err != nil {
+			t.Fatalf("can not create CA with %s: %v", tt.keyType, err)
+		}
+		sch, err := pki.NewServerCertHolder(ca, opts...)
+		if err != nil {
+			t.Fatalf("can not create server cert holder with %s: %v", tt.keyType, err)
+		}
+		cch, err := pki.NewClientCertHolder(ca, "test-user", opts...)
+		if err != nil {
+			t.Fatalf("can not create client cert holder with %s: %v", tt.keyType, err)
+		}
+
+		caCrt, _ := pki.ReadCertFromPEM(ca.Cert)
+		for _, ch := range []pki.CertHolder{ca.CertHolder, *sch, *cch} {
+			crt, _ := pki.ReadCertFromPEM(ch.Cert)
+			if typ := fmt.Sprintf("%T", crt.PublicKey); typ != tt.keyType {
+				t.Errorf("public key is expected to be %s but it is %s", tt.keyType, typ)
+			}
+			if err := crt.CheckSignatureFrom(caCrt); err != nil {
+				t.Errorf("%s certificate is expected to be signed by the CA: %v", tt.keyType, err)
+			}
+			if !isPEMEncodedProperly(t, ch.Key, pki.PEMPrivateKeyBlockType) {
+				t.Errorf("%s key is not PEM encoded properly: %s", tt.keyType, ch.Key)
+			}
+		}
+		if _, err := pki.NewCRL(ca); err != nil {
+			t.Errorf("can not create CRL with %s: %v", tt.keyType, err)
+		}
+
+		if curve := tt.alg.ECDHCurve(); curve != tt.curve {
+			t.Errorf("ECDH curve of %s is expected to be %q but it is %q", tt.alg, tt.curve, curve)
+		}
+	}
+
+	if _, err := pki.NewCA(pki.WithRSAKeySize(1024)); err == nil {
+		t.Error("RSA keys smaller than the minimum are not expected to be generated")
+	}
+	if _, err := pki.ParseKeyAlgorithm("dsa"); err == nil {
+		t.Error("unknown key algorithms are not expected to be parsed")
+	}
+}
+
+func TestWithValidity(t *testing.T) {
+	ca, err := pki.NewCA(pki.WithValidity(48 * time.Hour))
+	if err != nil {
+		t.Fatalf("can not create CA: %v", err)
+	}
+	cch, err := pki.NewClientCertHolder(ca, "test-user", pki.WithValidity(24*time.Hour))
+	if err != nil {
+		t.Fatalf("can not create client cert holder: %v", err)
+	}
+	// Certificates don't outlive their CA.
+	sch, err := pki.NewServerCertHolder(ca, pki.WithValidity(72*time.Hour))
+	if err != nil {
+		t.Fatalf("can not create server cert holder: %v", err)
+	}
+
+	for _, tt := range []struct {
+		cert     string
+		validity time.Duration
+	}{
+		{ca.Cert, 48 * time.Hour},
+		{cch.Cert, 24 * time.Hour},
+		{sch.Cert, 48 * time.Hour},
+	} {
+		crt, _ := pki.ReadCertFromPEM(tt.cert)
+		if d := time.Until(crt.NotAfter); d > tt.validity || d < tt.validity-time.Hour {
+			t.Errorf("certificate is expected to be valid for %s but it is valid until %s", tt.validity, crt.NotAfter)
+		}
+	}
+}
+
+func TestReadKeyFromPEM(t *testing.T) {
+	// Keys encoded in the older forms are still read.
+	rsaKey, _ := rsa.GenerateKey(cryptorand.Reader, 2048)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), cryptorand.Reader)
+	ecDER, _ := x509.MarshalECPrivateKey(ecKey)
+
+	for _, block := range []*pem.Block{
+		{Type: pki.PEMRSAPrivateKeyBlockType, Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)},
+		{Type: pki.PEMECPrivateKeyBlockType, Bytes: ecDER},
+	} {
+		if _, err := pki.ReadKeyFromPEM(string(pem.EncodeToMemory(block))); err != nil {
+			t.Errorf("%s is expected to be read: %v", block.Type, err)
+		}
+	}
+	if _, err := pki.ReadKeyFromPEM("garbage"); err == nil {
+		t.Error("garbage is not expected to be read as a key")
+	}
+}
+
 func TestReadCertFromPEM(t *testing.T) {
 	// Initialize:
 	ca, _ := pki.NewCA()
@@ -183,6 +294,15 @@ func TestReadCertFromPEM(t *testing.T) {
 	if crt == nil {
 		t.Fatalf("cert is expected to be 'not nil' but it's 'nil' instead")
 	}
+
+	// Garbage and bad DER should be errors.
+	if _, err := pki.ReadCertFromPEM("garbage"); err == nil {
+		t.Error("garbage is not expected to be read as a cert")
+	}
+	bad := string(pem.EncodeToMemory(&pem.Block{Type: pki.PEMCertificateBlockType, Bytes: []byte("garbage")}))
+	if _, err := pki.ReadCertFromPEM(bad); err == nil {
+		t.Error("bad DER is not expected to be read as a cert")
+	}
 }
 
 // isPEMEncodedProperly takes an PEM encoded string s and the expected block type typ (e.g. "RSA PRIVATE KEY") and returns whether it can be decodable.
@@ -223,6 +343,17 @@ func isPEMEncodedProperly(t *testing.T, s string, typ string) bool {
 			return false
 		}
 
+		if key == nil {
+			t.Logf("couldn't parse private key %+v", block)
+			return false
+		}
+	case pki.PEMPrivateKeyBlockType:
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			t.Logf("private key parse failed %+v: %v", block, err)
+			return false
+		}
+
 		if key == nil {
 			t.Logf("couldn't parse private key %+v", block)
 			return false
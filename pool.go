@@ -0,0 +1,328 @@
+package ovpm
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/jinzhu/gorm"
+	"github.com/sirupsen/logrus"
+)
+
+// NoPool is the pool name that puts a user back in the shared address range
+// of its server.
+const NoPool = "none"
+
+// dbPoolModel is database model for the named address pools of the servers.
+//
+// Pools are sub-ranges of the server's network. Users that are assigned to a
+// pool are leased addresses from it, the other users from the rest of the network.
+type dbPoolModel struct {
+	gorm.Model
+	ServerID uint
+	Name     string `gorm:"unique_index"`
+	CIDR     string
+}
+
+// Pool is a named range of addresses in the network of a server that the
+// users can be assigned to, e.g. contractors in 10.9.1.0/24.
+type Pool struct {
+	dbPoolModel
+}
+
+// UserOption sets an optional attribute of a user when it's created.
+type UserOption func(u *dbUserModel) error
+
+// WithPool assigns the user to the pool with the given name, which should
+// belong to the server of the user.
+func WithPool(name string) UserOption {
+	return func(u *dbUserModel) error {
+		if name == "" || name == NoPool {
+			u.PoolID = 0
+			return nil
+		}
+		pool, err := GetPool(name)
+		if err != nil {
+			return err
+		}
+		if pool.ServerID != u.ServerID {
+			return fmt.Errorf("pool %s doesn't belong to the server of the user", name)
+		}
+		u.PoolID = pool.ID
+		return nil
+	}
+}
+
+// hostRange is an inclusive range of host ids.
+type hostRange struct {
+	first, last uint32
+}
+
+func (r hostRange) contains(hostID uint32) bool {
+	return r.first <= hostID && hostID <= r.last
+}
+
+func (r hostRange) overlaps(o hostRange) bool {
+	return r.first <= o.last && o.first <= r.last
+}
+
+// cidrRange returns the range of the addresses in the network.
+func cidrRange(ipnet *net.IPNet) hostRange {
+	first := IP2HostID(ipnet.IP.Mask(ipnet.Mask))
+	ones, bits := ipnet.Mask.Size()
+	return hostRange{first, first + uint32(1<<uint(bits-ones)) - 1}
+}
+
+// GetPool returns the pool with the given name.
+func GetPool(name string) (*Pool, error) {
+	if govalidator.IsNull(name) {
+		return nil, fmt.Errorf("validation error: %s can not be null", name)
+	}
+	var pool dbPoolModel
+	q := db.Where(&dbPoolModel{Name: name}).First(&pool)
+	if q.RecordNotFound() {
+		return nil, fmt.Errorf("pool not found: %s", name)
+	}
+	if q.Error != nil {
+		return nil, q.Error
+	}
+	return &Pool{dbPoolModel: pool}, nil
+}
+
+// GetPools returns the pools of the server.
+func (svr *Server) GetPools() ([]*Pool, error) {
+	var pools []*Pool
+	var dbPools []*dbPoolModel
+	if err := db.Where("server_id = ?", svr.ID).Order("id").Find(&dbPools).Error; err != nil {
+		return nil, err
+	}
+	for _, p := range dbPools {
+		pools = append(pools, &Pool{dbPoolModel: *p})
+	}
+	return pools, nil
+}
+
+// CreateNewPool creates a new pool of the default server.
+func CreateNewPool(name, cidr string) (*Pool, error) {
+	return TheServer().CreateNewPool(name, cidr)
+}
+
+// CreateNewPool creates a new address pool in the network of the server.
+//
+// Users that are not assigned to the pool but are leased addresses in its
+// range are leased new addresses outside of it.
+func (svr *Server) CreateNewPool(name, cidr string) (*Pool, error) {
+	if !svr.IsInitialized() {
+		return nil, fmt.Errorf("you first need to create server")
+	}
+	if govalidator.IsNull(name) {
+		return nil, fmt.Errorf("validation error: %s can not be null", name)
+	}
+	if !govalidator.Matches(name, "^([\\w\\.]+)$") { // allow alphanumeric, underscore and dot
+		return nil, fmt.Errorf("validation error: `%s` can only contain letters, numbers, underscores and dots", name)
+	}
+	if name == NoPool {
+		return nil, fmt.Errorf("validation error: pool name %s is reserved", name)
+	}
+	if _, err := GetPool(name); err == nil {
+		return nil, fmt.Errorf("pool %s already exists", name)
+	}
+	_, ipnet, err := net.ParseCIDR(cidr)
+	if err != nil || ipnet.IP.To4() == nil {
+		return nil, fmt.Errorf("validation error: `%s` must be an IPv4 network in the CIDR form", cidr)
+	}
+	serverNet := svr.ipNet()
+	ones, _ := ipnet.Mask.Size()
+	serverOnes, _ := serverNet.Mask.Size()
+	if ones < serverOnes || !serverNet.Contains(ipnet.IP) {
+		return nil, fmt.Errorf("validation error: `%s` is out of the vpn network %s", ipnet, serverNet)
+	}
+	pools, err := svr.GetPools()
+	if err != nil {
+		return nil, err
+	}
+	for _, other := range pools {
+		if cidrRange(ipnet).overlaps(other.hostRange()) {
+			return nil, fmt.Errorf("validation error: `%s` overlaps with the pool %s", ipnet, other.Name)
+		}
+	}
+
+	pool := dbPoolModel{ServerID: svr.ID, Name: name, CIDR: ipnet.String()}
+	if err := db.Create(&pool).Error; err != nil {
+		return nil, fmt.Errorf("can not create pool in the db: %v", err)
+	}
+	p := &Pool{dbPoolModel: pool}
+	logrus.Infof("pool defined: %s (%s)", p.Name, p.CIDR)
+
+	// Addresses in the pool belong to its users from now on.
+	var leases []dbLeaseModel
+	r := p.hostRange()
+	if err := db.Where("server_id = ? AND host_id BETWEEN ? AND ?", svr.ID, r.first, r.last).Find(&leases).Error; err != nil {
+		return nil, err
+	}
+	for _, lease := range leases {
+		if err := db.Unscoped().Delete(&lease).Error; err != nil {
+			return nil, fmt.Errorf("can not take back the lease %s for the pool %s: %v", HostID2IP(lease.HostID), p.Name, err)
+		}
+		logrus.Infof("lease %s is taken back for the pool %s", HostID2IP(lease.HostID), p.Name)
+	}
+	if len(leases) > 0 {
+		if err := svr.EmitWithRestart(); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// Delete deletes the pool along with its acl rules. Pools that have users
+// can't be deleted.
+func (p *Pool) Delete() error {
+	var count int
+	db.Model(&dbUserModel{}).Where("pool_id = ?", p.ID).Count(&count)
+	if count > 0 {
+		return fmt.Errorf("pool %s still has %d user(s), they should be assigned to another pool first", p.Name, count)
+	}
+
+	// Firewall rules of the pool are removed as the networks are dissociated.
+	if err := db.Exec("DELETE FROM network_pools WHERE db_pool_model_id = ?", p.ID).Error; err != nil {
+		return fmt.Errorf("can not dissociate the networks of the pool %s: %v", p.Name, err)
+	}
+	if err := p.deleteACLRules(); err != nil {
+		return fmt.Errorf("can not delete the acl rules of the pool %s: %v", p.Name, err)
+	}
+	if err := db.Unscoped().Delete(&p.dbPoolModel).Error; err != nil {
+		return fmt.Errorf("can not delete pool from the db: %v", err)
+	}
+	logrus.Infof("pool deleted: %s", p.Name)
+	if svr := p.GetServer(); svr.IsInitialized() {
+		return svr.Emit()
+	}
+	return nil
+}
+
+// GetName returns the name of the pool.
+func (p *Pool) GetName() string {
+	return p.Name
+}
+
+// GetCIDR returns the network of the pool in the CIDR form.
+func (p *Pool) GetCIDR() string {
+	return p.CIDR
+}
+
+// GetCreatedAt returns the creation time of the pool.
+func (p *Pool) GetCreatedAt() string {
+	return p.CreatedAt.Format(time.UnixDate)
+}
+
+// GetServer returns the server that the pool belongs to.
+func (p *Pool) GetServer() *Server {
+	svr, err := getServerByID(p.ServerID)
+	if err != nil {
+		return TheServer()
+	}
+	return svr
+}
+
+// GetUsernames returns the names of the users that are assigned to the pool.
+func (p *Pool) GetUsernames() []string {
+	var usernames []string
+	db.Model(&dbUserModel{}).Where("pool_id = ?", p.ID).Order("id").Pluck("username", &usernames)
+	return usernames
+}
+
+// hostRange returns the addresses of the pool that can be leased.
+func (p *Pool) hostRange() hostRange {
+	_, ipnet, err := net.ParseCIDR(p.CIDR)
+	if err != nil {
+		return hostRange{1, 0}
+	}
+	return cidrRange(ipnet)
+}
+
+// leaseRange returns the range that the users of the pool with the given id
+// are leased addresses from, and the ranges in it that they can't be leased.
+// Zero id is the shared range of the server that excludes all of the pools.
+func (svr *Server) leaseRange(poolID uint) (hostRange, []hostRange, error) {
+	r := svr.userRange()
+	pools, err := svr.GetPools()
+	if err != nil {
+		return r, nil, err
+	}
+	var excluded []hostRange
+	for _, p := range pools {
+		switch {
+		case poolID == 0:
+			excluded = append(excluded, p.hostRange())
+		case p.ID == poolID:
+			pr := p.hostRange()
+			if pr.first > r.first {
+				r.first = pr.first
+			}
+			if pr.last < r.last {
+				r.last = pr.last
+			}
+			return r, nil, nil
+		}
+	}
+	if poolID != 0 {
+		return r, nil, fmt.Errorf("pool of the user can not be found in the server %s", svr.name)
+	}
+	return r, excluded, nil
+}
+
+// deletePools deletes the pools of the server with their acl rules and
+// unassigns their users.
+func (svr *Server) deletePools() {
+	pools, _ := svr.GetPools()
+	for _, p := range pools {
+		db.Model(&dbUserModel{}).Where("pool_id = ?", p.ID).Update("pool_id", 0)
+		db.Exec("DELETE FROM network_pools WHERE db_pool_model_id = ?", p.ID)
+		p.deleteACLRules()
+		db.Unscoped().Delete(&p.dbPoolModel)
+	}
+}
+
+// SetPool assigns the user to the pool with the given name and leases it an
+// address from there. NoPool puts the user back in the shared range.
+//
+// Users with static addresses keep them.
+func (u *User) SetPool(name string) error {
+	svr := u.GetServer()
+	if !svr.IsInitialized() {
+		return fmt.Errorf("you first need to create server")
+	}
+	oldPoolID := u.PoolID
+	model := dbUserModel{ServerID: svr.ID}
+	if err := WithPool(name)(&model); err != nil {
+		return err
+	}
+	if model.PoolID == oldPoolID {
+		return nil
+	}
+	u.PoolID = model.PoolID
+	db.Model(&u.dbUserModel).Update("pool_id", u.PoolID)
+	if u.HostID == 0 {
+		if _, err := svr.allocateLease(u); err != nil {
+			u.PoolID = oldPoolID
+			db.Model(&u.dbUserModel).Update("pool_id", u.PoolID)
+			return err
+		}
+	}
+	logrus.Infof("user %s is assigned to the pool %s", u.Username, name)
+	return svr.EmitWithRestart()
+}
+
+// GetPoolName returns the name of the pool that the user is assigned to,
+// or an empty string if it's in the shared range.
+func (u *User) GetPoolName() string {
+	if u.PoolID == 0 {
+		return ""
+	}
+	var pool dbPoolModel
+	if err := db.First(&pool, u.PoolID).Error; err != nil {
+		return ""
+	}
+	return pool.Name
+}
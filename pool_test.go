@@ -0,0 +1,105 @@
+package ovpm
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPools(t *testing.T) {
+	// Init:
+	setupTestCase()
+	createTestDB()
+	defer db.Cease()
+	svr := TheServer()
+	if err := svr.Init("localhost", "", UDPProto, "10.9.0.0/16", "", "", "", false); err != nil {
+		t.Fatalf("server can not be initialized: %v", err)
+	}
+	usr1, _ := CreateNewUser("usr1", "1234", false, 0, true, "description")
+	ipOf := func(username string) string {
+		u, err := GetUser(username)
+		if err != nil {
+			t.Fatalf("user %s can not be fetched: %v", username, err)
+		}
+		return u.getIP().String()
+	}
+
+	// Test:
+	var pooltests = []struct {
+		name string
+		cidr string
+		ok   bool
+	}{
+		{"staff", "10.9.2.0/24", true},
+		{"contractors", "10.9.0.0/24", true},
+		{"staff", "10.9.3.0/24", false},         // Duplicate name.
+		{"outside", "10.10.0.0/24", false},      // Out of the server's network.
+		{"overlapping", "10.9.2.128/25", false}, // Overlaps with staff.
+		{"wide", "10.8.0.0/15", false},          // Wider than the server's network.
+		{NoPool, "10.9.4.0/24", false},          // Reserved name.
+	}
+	for _, tt := range pooltests {
+		if _, err := svr.CreateNewPool(tt.name, tt.cidr); (err == nil) != tt.ok {
+			t.Errorf("pool %s %s is expected to be created: %t, got %v", tt.name, tt.cidr, tt.ok, err)
+		}
+	}
+	if ipOf("usr1") != "10.9.1.0" {
+		t.Errorf("users out of the pools are expected to be moved out of a new pool's range, got %s", ipOf("usr1"))
+	}
+
+	usr2, err := CreateNewUser("usr2", "1234", false, 0, true, "description", WithPool("contractors"))
+	if err != nil {
+		t.Fatalf("user can not be created in the pool: %v", err)
+	}
+	CreateNewUser("usr3", "1234", false, 0, true, "description", WithPool("staff"))
+	if ipOf("usr2") != "10.9.0.2" || ipOf("usr3") != "10.9.2.0" {
+		t.Errorf("users are expected to be leased addresses from their pools: %s %s", ipOf("usr2"), ipOf("usr3"))
+	}
+	if _, err := CreateNewUser("usr4", "1234", false, 0, true, "description", WithPool("missing")); err == nil {
+		t.Error("user is not expected to be created in a missing pool")
+	}
+	usr1.SetPool("staff")
+	if ipOf("usr1") != "10.9.2.1" || usr1.GetPoolName() != "staff" {
+		t.Errorf("user is expected to be moved to its new pool, got %s", ipOf("usr1"))
+	}
+
+	// Pools can be associated with networks.
+	n, _ := CreateNewNetwork("lab", "192.168.5.0/24", ROUTE, "")
+	if err := n.AssociatePool("staff"); err != nil {
+		t.Fatalf("pool can not be associated: %v", err)
+	}
+	ccd := func(username string) string { return fs[filepath.Join(svr.path(vpnCCDDir), username)] }
+	if !strings.Contains(ccd("usr3"), "route 192.168.5.0 255.255.255.0") || strings.Contains(ccd("usr2"), "192.168.5.0") {
+		t.Errorf("routes of the network are expected to be pushed to the users of the pool only: %s", ccd("usr3"))
+	}
+
+	// Pools with users can't be deleted.
+	staff, _ := GetPool("staff")
+	if err := staff.Delete(); err == nil {
+		t.Error("pool with users is not expected to be deleted")
+	}
+	usr1.SetPool(NoPool)
+	usr3, _ := GetUser("usr3")
+	usr3.SetPool(NoPool)
+	if err := staff.Delete(); err != nil {
+		t.Errorf("pool can not be deleted: %v", err)
+	}
+	if _, err := GetPool("staff"); err == nil {
+		t.Error("deleted pool is not expected to be fetched")
+	}
+	if n, _ := GetNetwork("lab"); len(n.GetAssociatedPoolNames()) != 0 {
+		t.Errorf("deleted pool is expected to be dissociated: %v", n.GetAssociatedPoolNames())
+	}
+
+	// Pools move with the network.
+	if err := svr.Update("10.20.0.0/25", "", nil); err == nil {
+		t.Error("network that the pools don't fit in is expected to be refused")
+	}
+	if err := svr.Update("10.20.0.0/16", "", nil); err != nil {
+		t.Fatalf("server can not be updated: %v", err)
+	}
+	contractors, _ := GetPool("contractors")
+	if contractors.GetCIDR() != "10.20.0.0/24" || ipOf(usr2.Username) != "10.20.0.2" {
+		t.Errorf("pool and its leases are expected to move with the network: %s %s", contractors.GetCIDR(), ipOf(usr2.Username))
+	}
+}
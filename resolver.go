@@ -0,0 +1,186 @@
+package ovpm
+
+import (
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/GoldenRUS/ovpm/resolver"
+	"github.com/sirupsen/logrus"
+)
+
+// resolverPort is the port that the built-in resolvers listen on.
+const resolverPort = "53"
+
+// resolverRetryInterval is the duration to wait before trying to listen again.
+// The gateway address is missing until OpenVPN brings the tun device up.
+const resolverRetryInterval = 2 * time.Second
+
+// resolverEnabled tells whether the servers should run their built-in resolvers
+// if they are configured to.
+var resolverEnabled bool
+
+// InitializeResolver starts the built-in resolvers of the servers that are
+// configured to run one, see WithResolver.
+//
+// Servers that are started later start their resolvers as they are started.
+func InitializeResolver() error {
+	serversMu.Lock()
+	resolverEnabled = true
+	serversMu.Unlock()
+
+	for _, svr := range GetAllServers() {
+		if err := svr.startMonitors(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WithResolver makes the server run the built-in resolver on its VPN gateway
+// address and push it to the clients instead of its DNS servers.
+//
+// The resolver answers <username>.vpn.internal with the addresses of the users
+// of the server and forwards the other queries to the DNS servers of the server.
+func WithResolver(enabled bool) ServerOption {
+	return func(s *dbServerModel) error {
+		s.Resolver = enabled
+		return nil
+	}
+}
+
+// IsResolverEnabled tells whether the server runs the built-in resolver.
+func (svr *Server) IsResolverEnabled() bool {
+	return svr.Resolver
+}
+
+// gatewayIP returns the VPN address of the server.
+func (svr *Server) gatewayIP() net.IP {
+	return HostID2IP(IP2HostID(svr.ipNet().IP) + 1)
+}
+
+// resolverAddr returns the address that the resolver of the server listens on,
+// or an empty string if it doesn't run one.
+func (svr *Server) resolverAddr() string {
+	if !svr.IsInitialized() || !svr.IsResolverEnabled() {
+		return ""
+	}
+	return net.JoinHostPort(svr.gatewayIP().String(), resolverPort)
+}
+
+// clientDNSOptions returns the DNS settings that are pushed to the clients
+// of the server, which point to the resolver if it's enabled.
+func (svr *Server) clientDNSOptions() DNSOptions {
+	o := svr.GetDNSOptions()
+	if svr.IsResolverEnabled() {
+		o.Servers = []string{svr.gatewayIP().String()}
+	}
+	return o
+}
+
+// lookupUser returns the VPN addresses of the user of the server, or nil if
+// the server has no such user. Usernames are matched case insensitively.
+func (svr *Server) lookupUser(username string) []net.IP {
+	users, err := svr.GetUsers()
+	if err != nil {
+		logrus.Errorf("resolver: users of the server %s can not be fetched: %v", svr.name, err)
+		return nil
+	}
+	for _, user := range users {
+		if !strings.EqualFold(user.Username, username) {
+			continue
+		}
+		ips := []net.IP{user.getIP()}
+		if ip := user.getIPv6(); ip != nil {
+			ips = append(ips, ip)
+		}
+		return ips
+	}
+	return nil
+}
+
+// resolverUpstreams returns the DNS servers of the server that the resolver
+// forwards the queries to.
+func (svr *Server) resolverUpstreams() []string {
+	svr = GetServer(svr.name)
+	gateway := svr.gatewayIP().String()
+	var upstreams []string
+	for _, server := range svr.GetDNSOptions().Servers {
+		// Don't forward the queries to the resolver itself.
+		if server == gateway {
+			continue
+		}
+		upstreams = append(upstreams, net.JoinHostPort(server, "53"))
+	}
+	return upstreams
+}
+
+// resolverRunner keeps the resolver of a server listening on its address
+// until it's closed.
+type resolverRunner struct {
+	addr string
+	srv  *resolver.Server
+
+	mu   sync.Mutex
+	conn net.PacketConn
+
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func (svr *Server) newResolverRunner(addr string) *resolverRunner {
+	return &resolverRunner{
+		addr: addr,
+		srv: &resolver.Server{
+			Zone:      DefaultResolverZone,
+			Lookup:    svr.lookupUser,
+			Upstreams: svr.resolverUpstreams,
+		},
+		closed: make(chan struct{}),
+	}
+}
+
+// Run listens on the address and serves the queries until Close is called.
+func (r *resolverRunner) Run() {
+	for {
+		conn, err := net.ListenPacket("udp4", r.addr)
+		if err == nil {
+			r.mu.Lock()
+			select {
+			case <-r.closed:
+				r.mu.Unlock()
+				conn.Close()
+				return
+			default:
+			}
+			r.conn = conn
+			r.mu.Unlock()
+
+			logrus.Infof("resolver is listening on %s", r.addr)
+			err = r.srv.Serve(conn)
+			conn.Close()
+		}
+		if err != nil {
+			logrus.Debugf("resolver can not serve on %s: %v", r.addr, err)
+		}
+
+		select {
+		case <-r.closed:
+			return
+		case <-time.After(resolverRetryInterval):
+		}
+	}
+}
+
+// Close stops the resolver.
+func (r *resolverRunner) Close() {
+	r.closeOnce.Do(func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		close(r.closed)
+		if r.conn != nil {
+			r.conn.Close()
+		}
+	})
+}
@@ -0,0 +1,210 @@
+// Package resolver implements a small split-DNS server.
+//
+// Queries for the names in the zone of the server are answered from a lookup
+// function, all the other queries are forwarded to the upstream servers as
+// they are. Only DNS over UDP is supported.
+package resolver
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// DefaultTimeout is the default duration to wait for an upstream server to answer.
+const DefaultTimeout = 3 * time.Second
+
+// TTL is the time to live of the answers for the names in the zone, in seconds.
+// It's short since dynamic addresses of the users change as users come and go.
+const TTL = 60
+
+// maxMessageSize is the size of the largest message that is read, large
+// enough for the EDNS0 responses of the upstream servers.
+const maxMessageSize = 4096
+
+// Server is a split-DNS server.
+type Server struct {
+	// Zone is the domain that the server is authoritative for, e.g. vpn.internal.
+	Zone string
+
+	// Lookup returns the addresses of the host in the zone, or nil if there
+	// is no such host. host is the part of the name before the zone, in lower
+	// case.
+	Lookup func(host string) []net.IP
+
+	// Upstreams returns the addresses of the servers to forward the other
+	// queries to, in the host:port form. They are tried in order.
+	Upstreams func() []string
+
+	// Timeout is the duration to wait for an upstream server to answer.
+	// Zero means DefaultTimeout.
+	Timeout time.Duration
+}
+
+// Serve answers the queries that are read from conn until conn is closed.
+//
+// It returns nil when conn is closed.
+func (s *Server) Serve(conn net.PacketConn) error {
+	for {
+		b := make([]byte, maxMessageSize)
+		n, addr, err := conn.ReadFrom(b)
+		if errors.Is(err, net.ErrClosed) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		go func() {
+			res, err := s.handle(b[:n])
+			if err != nil {
+				logrus.Debugf("resolver: query from %s is dropped: %v", addr, err)
+				return
+			}
+			if _, err := conn.WriteTo(res, addr); err != nil {
+				logrus.Debugf("resolver: can not answer %s: %v", addr, err)
+			}
+		}()
+	}
+}
+
+// handle returns the response to the query.
+func (s *Server) handle(query []byte) ([]byte, error) {
+	var p dnsmessage.Parser
+	hdr, err := p.Start(query)
+	if err != nil {
+		return nil, err
+	}
+	q, err := p.Question()
+	if err != nil {
+		return nil, err
+	}
+	host, ok := s.hostOf(q.Name.String())
+	if !ok {
+		return s.forward(hdr, q, query)
+	}
+	return s.answer(hdr, q, host)
+}
+
+// hostOf returns the part of the name before the zone, or false if the name
+// isn't in the zone.
+func (s *Server) hostOf(name string) (string, bool) {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	zone := strings.ToLower(strings.Trim(s.Zone, "."))
+	if name == zone {
+		return "", true
+	}
+	host := strings.TrimSuffix(name, "."+zone)
+	return host, host != name
+}
+
+// answer returns the authoritative response to the query for the host in the zone.
+func (s *Server) answer(hdr dnsmessage.Header, q dnsmessage.Question, host string) ([]byte, error) {
+	var ips []net.IP
+	if host != "" {
+		ips = s.Lookup(host)
+	}
+
+	rcode := dnsmessage.RCodeSuccess
+	if host != "" && len(ips) == 0 {
+		rcode = dnsmessage.RCodeNameError
+	}
+	b := dnsmessage.NewBuilder(nil, dnsmessage.Header{
+		ID:                 hdr.ID,
+		Response:           true,
+		Authoritative:      true,
+		RecursionDesired:   hdr.RecursionDesired,
+		RecursionAvailable: true,
+		RCode:              rcode,
+	})
+	b.EnableCompression()
+	if err := b.StartQuestions(); err != nil {
+		return nil, err
+	}
+	if err := b.Question(q); err != nil {
+		return nil, err
+	}
+	if err := b.StartAnswers(); err != nil {
+		return nil, err
+	}
+	rh := dnsmessage.ResourceHeader{Name: q.Name, Type: q.Type, Class: dnsmessage.ClassINET, TTL: TTL}
+	for _, ip := range ips {
+		var err error
+		switch ip4 := ip.To4(); {
+		case q.Type == dnsmessage.TypeA && ip4 != nil:
+			var r dnsmessage.AResource
+			copy(r.A[:], ip4)
+			err = b.AResource(rh, r)
+		case q.Type == dnsmessage.TypeAAAA && ip4 == nil:
+			var r dnsmessage.AAAAResource
+			copy(r.AAAA[:], ip.To16())
+			err = b.AAAAResource(rh, r)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return b.Finish()
+}
+
+// forward sends the query to the upstream servers and returns the first
+// response. It's SERVFAIL if none of them answers.
+func (s *Server) forward(hdr dnsmessage.Header, q dnsmessage.Question, query []byte) ([]byte, error) {
+	timeout := s.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	var upstreams []string
+	if s.Upstreams != nil {
+		upstreams = s.Upstreams()
+	}
+	for _, upstream := range upstreams {
+		res, err := exchange(upstream, query, timeout)
+		if err != nil {
+			logrus.Debugf("resolver: upstream %s failed: %v", upstream, err)
+			continue
+		}
+		return res, nil
+	}
+
+	b := dnsmessage.NewBuilder(nil, dnsmessage.Header{
+		ID:               hdr.ID,
+		Response:         true,
+		RecursionDesired: hdr.RecursionDesired,
+		RCode:            dnsmessage.RCodeServerFailure,
+	})
+	if err := b.StartQuestions(); err != nil {
+		return nil, err
+	}
+	if err := b.Question(q); err != nil {
+		return nil, err
+	}
+	return b.Finish()
+}
+
+// exchange sends the query to the server and returns the response with the same ID.
+func exchange(server string, query []byte, timeout time.Duration) ([]byte, error) {
+	conn, err := net.DialTimeout("udp", server, timeout)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(timeout))
+	if _, err := conn.Write(query); err != nil {
+		return nil, err
+	}
+	b := make([]byte, maxMessageSize)
+	for {
+		n, err := conn.Read(b)
+		if err != nil {
+			return nil, err
+		}
+		// Skip the stray responses.
+		if n >= 2 && b[0] == query[0] && b[1] == query[1] {
+			return b[:n], nil
+		}
+	}
+}
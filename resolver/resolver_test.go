@@ -0,0 +1,117 @@
+package resolver
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// listen starts serving on a local UDP port and returns its address.
+func listen(t *testing.T, serve func(conn net.PacketConn)) string {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("can not listen: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	go serve(conn)
+	return conn.LocalAddr().String()
+}
+
+// query sends a query for the name to the server and returns the parsed response.
+func query(t *testing.T, server, name string, qtype dnsmessage.Type) *dnsmessage.Message {
+	q := dnsmessage.Message{
+		Header:    dnsmessage.Header{ID: 4242, RecursionDesired: true},
+		Questions: []dnsmessage.Question{{Name: dnsmessage.MustNewName(name), Type: qtype, Class: dnsmessage.ClassINET}},
+	}
+	b, err := q.Pack()
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := exchange(server, b, time.Second)
+	if err != nil {
+		t.Fatalf("%s: no response: %v", name, err)
+	}
+	var m dnsmessage.Message
+	if err := m.Unpack(res); err != nil {
+		t.Fatalf("%s: can not parse the response: %v", name, err)
+	}
+	return &m
+}
+
+func TestServer(t *testing.T) {
+	// Upstream answers everything with 192.0.2.1.
+	upstream := listen(t, func(conn net.PacketConn) {
+		b := make([]byte, 512)
+		for {
+			n, addr, err := conn.ReadFrom(b)
+			if err != nil {
+				return
+			}
+			var m dnsmessage.Message
+			if err := m.Unpack(b[:n]); err != nil {
+				continue
+			}
+			m.Header.Response = true
+			m.Answers = []dnsmessage.Resource{{
+				Header: dnsmessage.ResourceHeader{Name: m.Questions[0].Name, Type: dnsmessage.TypeA, Class: dnsmessage.ClassINET, TTL: 300},
+				Body:   &dnsmessage.AResource{A: [4]byte{192, 0, 2, 1}},
+			}}
+			res, _ := m.Pack()
+			conn.WriteTo(res, addr)
+		}
+	})
+	// Nothing listens on the dead upstream.
+	dead := listen(t, func(conn net.PacketConn) {})
+
+	s := &Server{
+		Zone: "vpn.internal",
+		Lookup: func(host string) []net.IP {
+			if host == "joe" {
+				return []net.IP{net.ParseIP("10.9.0.2"), net.ParseIP("fd00:9::2")}
+			}
+			return nil
+		},
+		Upstreams: func() []string { return []string{dead, upstream} },
+		Timeout:   200 * time.Millisecond,
+	}
+	server := listen(t, func(conn net.PacketConn) { s.Serve(conn) })
+
+	// Test:
+	m := query(t, server, "Joe.VPN.internal.", dnsmessage.TypeA)
+	if m.ID != 4242 || !m.Authoritative || m.RCode != dnsmessage.RCodeSuccess || len(m.Answers) != 1 {
+		t.Fatalf("A record of the user is expected: %+v", m)
+	}
+	if a := m.Answers[0].Body.(*dnsmessage.AResource).A; net.IP(a[:]).String() != "10.9.0.2" {
+		t.Errorf("unexpected address: %v", a)
+	}
+	m = query(t, server, "joe.vpn.internal.", dnsmessage.TypeAAAA)
+	if len(m.Answers) != 1 || net.IP(m.Answers[0].Body.(*dnsmessage.AAAAResource).AAAA[:]).String() != "fd00:9::2" {
+		t.Errorf("AAAA record of the user is expected: %+v", m.Answers)
+	}
+	m = query(t, server, "joe.vpn.internal.", dnsmessage.TypeMX)
+	if m.RCode != dnsmessage.RCodeSuccess || len(m.Answers) != 0 {
+		t.Errorf("empty answer is expected for the other types: %+v", m)
+	}
+	m = query(t, server, "jane.vpn.internal.", dnsmessage.TypeA)
+	if m.RCode != dnsmessage.RCodeNameError {
+		t.Errorf("NXDOMAIN is expected for the unknown users: %v", m.RCode)
+	}
+	m = query(t, server, "example.com.", dnsmessage.TypeA)
+	if m.Authoritative || len(m.Answers) != 1 || m.Answers[0].Body.(*dnsmessage.AResource).A != [4]byte{192, 0, 2, 1} {
+		t.Errorf("other names are expected to be forwarded: %+v", m)
+	}
+	m = query(t, server, "notvpn.internal.", dnsmessage.TypeA)
+	if len(m.Answers) != 1 {
+		t.Errorf("names that only end like the zone are expected to be forwarded: %+v", m)
+	}
+
+	// No upstream.
+	s2 := &Server{Zone: s.Zone, Lookup: s.Lookup, Upstreams: func() []string { return []string{dead} }, Timeout: s.Timeout}
+	server2 := listen(t, func(conn net.PacketConn) { s2.Serve(conn) })
+	m = query(t, server2, "example.com.", dnsmessage.TypeA)
+	if m.RCode != dnsmessage.RCodeServerFailure {
+		t.Errorf("SERVFAIL is expected without an upstream: %v", m.RCode)
+	}
+}
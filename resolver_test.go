@@ -0,0 +1,53 @@
+package ovpm
+
+import (
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestResolver(t *testing.T) {
+	// Init:
+	setupTestCase()
+	createTestDB()
+	defer db.Cease()
+	svr := TheServer()
+	if err := svr.Init("localhost", "", UDPProto, "", "10.0.0.53", "", "", false, WithResolver(true), WithIPv6Network("fd00:9::/64")); err != nil {
+		t.Fatalf("server can not be initialized: %v", err)
+	}
+	usr1, _ := CreateNewUser("usr1", "1234", false, 0, true, "description")
+	CreateNewUser("usr2", "1234", false, IP2HostID(net.ParseIP("10.9.0.10").To4()), true, "description")
+
+	// Test:
+	serverConf := fs[svr.path(vpnConfFile)]
+	if !strings.Contains(serverConf, `push "dhcp-option DNS 10.9.0.1"`) || strings.Contains(serverConf, "10.0.0.53") {
+		t.Errorf("resolver is expected to be pushed instead of the dns servers: %s", serverConf)
+	}
+	if upstreams := svr.resolverUpstreams(); len(upstreams) != 1 || upstreams[0] != "10.0.0.53:53" {
+		t.Errorf("dns servers are expected to be the upstreams of the resolver: %v", upstreams)
+	}
+	if svr.resolverAddr() != "10.9.0.1:53" {
+		t.Errorf("resolver is expected to listen on the gateway: %s", svr.resolverAddr())
+	}
+	if ips := svr.lookupUser("USR2"); len(ips) != 2 || ips[0].String() != "10.9.0.10" || ips[1].String() != "fd00:9::a" {
+		t.Errorf("addresses of the user are expected: %v", ips)
+	}
+	if ips := svr.lookupUser("nobody"); ips != nil {
+		t.Errorf("unknown users are not expected to resolve: %v", ips)
+	}
+
+	// Overrides inherit the resolver.
+	usr1.SetDNSOverride("", "usr1.example", "")
+	if ccd := fs[filepath.Join(svr.path(vpnCCDDir), "usr1")]; !strings.Contains(ccd, "push \"dhcp-option DNS 10.9.0.1\"\npush \"dhcp-option DOMAIN usr1.example\"\n") {
+		t.Errorf("user is expected to keep the resolver: %s", ccd)
+	}
+
+	// Disable.
+	if err := svr.Update("", "", nil, WithResolver(false)); err != nil {
+		t.Fatalf("server can not be updated: %v", err)
+	}
+	if !strings.Contains(fs[svr.path(vpnConfFile)], `push "dhcp-option DNS 10.0.0.53"`) || svr.resolverAddr() != "" {
+		t.Errorf("dns servers are expected to be pushed again: %q %s", svr.resolverAddr(), fs[svr.path(vpnConfFile)])
+	}
+}
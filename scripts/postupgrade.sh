@@ -1,5 +1,7 @@
 #!/bin/bash
 systemctl daemon-reload
+# Migrate the database schema explicitly, the database is backed up before.
+/sbin/ovpmd migrate up || exit 1
 if [ "`systemctl is-active ovpmd`" != "active" ]
 then
     systemctl restart ovpmd
@@ -3,6 +3,7 @@ package ovpm
 import (
 	"errors"
 	"fmt"
+	"io"
 	"log"
 	"sync"
 	"time"
@@ -11,11 +12,8 @@ import (
 	"github.com/jinzhu/gorm"
 )
 
-var (
-	globalFileWatcher *FileWatcher
-	fileWatcherOnce   sync.Once
-	fileWatcherMu     sync.RWMutex
-)
+// fileWatcherEnabled включает слежение за status log файлами серверов
+var fileWatcherEnabled bool
 
 type FileWatcher struct {
 	filename    string
@@ -45,25 +43,31 @@ type dbStatisticModel struct {
 	RealAddress    string
 }
 
-// GetFileWatcher возвращает глобальный экземпляр FileWatcher
+// GetFileWatcher возвращает FileWatcher сервера по умолчанию
 func GetFileWatcher() *FileWatcher {
-	fileWatcherMu.RLock()
-	defer fileWatcherMu.RUnlock()
-	return globalFileWatcher
+	return TheServer().FileWatcher()
+}
+
+// FileWatcher возвращает FileWatcher сервера, либо nil если он ещё не запущен
+func (svr *Server) FileWatcher() *FileWatcher {
+	svr.monitorMu.Lock()
+	defer svr.monitorMu.Unlock()
+	return svr.fileWatcher
 }
 
-// InitializeFileWatcher инициализирует глобальный FileWatcher
+// InitializeFileWatcher запускает FileWatcher для всех серверов.
+// Серверы, запущенные позже, получают свой FileWatcher при старте.
 func InitializeFileWatcher() error {
+	serversMu.Lock()
+	fileWatcherEnabled = true
+	serversMu.Unlock()
+
 	var initErr error
-	fileWatcherOnce.Do(func() {
-		fw, err := NewStatisticFileWatcher()
-		if err != nil {
+	for _, svr := range GetAllServers() {
+		if err := svr.startMonitors(); err != nil && initErr == nil {
 			initErr = err
-			return
 		}
-		globalFileWatcher = fw
-		go fw.Watch()
-	})
+	}
 	return initErr
 }
 
@@ -86,7 +90,11 @@ func NewFileWatcher(filename string) (*FileWatcher, error) {
 }
 
 func NewStatisticFileWatcher() (*FileWatcher, error) {
-	return NewFileWatcher(_DefaultStatusLogPath)
+	return TheServer().newStatisticFileWatcher()
+}
+
+func (svr *Server) newStatisticFileWatcher() (*FileWatcher, error) {
+	return NewFileWatcher(svr.path(statusLogFile))
 }
 
 func (fw *FileWatcher) Watch() {
@@ -137,7 +145,7 @@ func (fw *FileWatcher) getNewValue() {
 	fw.mu.Lock()
 	defer fw.mu.Unlock()
 
-	data, lastUpdate := ConnectionList()
+	data, lastUpdate := readStatusLog(fw.filename)
 
 	if fw.lastUpdate.IsZero() {
 		fw.data = data
@@ -209,15 +217,21 @@ func (fw *FileWatcher) getNewValue() {
 
 }
 
-// ConnectionList returns information about user's connections to the VPN server.
+// ConnectionList returns information about user's connections to the default VPN server.
 func ConnectionList() (list []clEntry, lastUpdate time.Time) {
+	return readStatusLog(TheServer().path(statusLogFile))
+}
 
-	svr := TheServer()
-
-	// Open the status log file.
-	f, err := svr.openFunc(_DefaultStatusLogPath)
+// readStatusLog returns the client list in the status log at path along with
+// the time it's updated.
+func readStatusLog(path string) ([]clEntry, time.Time) {
+	f, err := TheServer().openFunc(path)
 	if err != nil {
-		panic(err)
+		log.Println("Error:", err)
+		return nil, time.Time{}
+	}
+	if c, ok := f.(io.Closer); ok {
+		defer c.Close()
 	}
 
 	cl, _, lU := parseStatusLogWUpdate(f)
@@ -272,7 +286,7 @@ func GetStatisticsByDateRange(startDate, endDate *time.Time, commonNameFilter st
             SUM(bytes_received) as total_bytes_received,
             SUM(bytes_sent) as total_bytes_sent,
             SUM(bytes_received + bytes_sent) as total_bytes,
-			AVG(CAST((strftime('%s', connected_until) - strftime('%s', connected_since)) AS REAL)) 
+			AVG(` + db.secondsBetween("connected_since", "connected_until") + `)
 				as avg_connection_duration_seconds`)
 
 	if startDate != nil && !startDate.IsZero() {
@@ -304,7 +318,7 @@ type StatisticSummary struct {
 	TotalBytesReceived        int64   `json:"total_bytes_received"`
 	TotalBytesSent            int64   `json:"total_bytes_sent"`
 	TotalBytes                int64   `json:"total_bytes"`
-	AvgConnectionDurationSecs float64 `json:"avg_connection_duration_seconds"`
+	AvgConnectionDurationSecs float64 `json:"avg_connection_duration_seconds" gorm:"column:avg_connection_duration_seconds"`
 }
 
 // GetDetailedStatistics возвращает детальные записи с различными фильтрами
@@ -383,9 +397,8 @@ func GetUserStatistics(commonName string, startDate, endDate time.Time) (*UserSt
             SUM(bytes_received) as total_bytes_received,
             SUM(bytes_sent) as total_bytes_sent,
             SUM(bytes_received + bytes_sent) as total_bytes,
-			AVG(CAST((strftime('%s', connected_until) - strftime('%s', connected_since)) AS REAL)) 
-				as avg_connection_duration_seconds,
-			MAX(connected_since) as last_connection
+			AVG(`+db.secondsBetween("connected_since", "connected_until")+`)
+				as avg_connection_duration_seconds
         `).
 		Where("common_name = ? AND connected_since >= ? AND connected_until <= ?",
 			commonName, startDate, endDate).
@@ -395,6 +408,18 @@ func GetUserStatistics(commonName string, startDate, endDate time.Time) (*UserSt
 		return nil, result.Error
 	}
 
+	// MAX(connected_since) comes back as text from sqlite, so the last
+	// connection is read from the row itself.
+	var last dbStatisticModel
+	result = db.Where("common_name = ? AND connected_since >= ? AND connected_until <= ?",
+		commonName, startDate, endDate).
+		Order("connected_since DESC").
+		First(&last)
+	if result.Error != nil && !errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, result.Error
+	}
+	userStats.LastConnection = last.ConnectedSince
+
 	return &userStats, nil
 }
 
@@ -406,6 +431,6 @@ type UserStatistics struct {
 	TotalBytesReceived        int64     `json:"total_bytes_received"`
 	TotalBytesSent            int64     `json:"total_bytes_sent"`
 	TotalBytes                int64     `json:"total_bytes"`
-	AvgConnectionDurationSecs float64   `json:"avg_connection_duration_seconds"`
+	AvgConnectionDurationSecs float64   `json:"avg_connection_duration_seconds" gorm:"column:avg_connection_duration_seconds"`
 	LastConnection            time.Time `json:"last_connection"`
 }
@@ -2,9 +2,10 @@ package ovpm
 
 const ccdFileTemplate = `
 ifconfig-push {{ .IP }} {{ .NetMask }}
+{{ if .IPv6 }}ifconfig-ipv6-push {{ .IPv6 }} {{ .IPv6Server }}{{ end }}
 
 {{if .RedirectGW }}
-push "redirect-gateway def1 bypass-dhcp"
+push "redirect-gateway def1 {{ if .IPv6 }}ipv6 {{ end }}bypass-dhcp"
 {{ end }}
 
 {{range .Servernets}}
@@ -15,7 +16,28 @@ push "route {{index . 0}} {{index . 1}}"
 {{range .Routes}}
 push "route {{index . 0}} {{index . 1}} {{index . 2}}"
 {{ end }}
-`
+
+{{range .Servernets6}}
+push "route-ipv6 {{ . }}"
+{{ end }}
+
+{{range .Routes6}}
+push "route-ipv6 {{index . 0}}{{ if index . 1 }} {{index . 1}}{{ end }}"
+{{ end }}
+
+{{range .IRoutes}}
+iroute {{index . 0}} {{index . 1}}
+{{ end }}
+
+{{range .IRoutes6}}
+iroute-ipv6 {{ . }}
+{{ end }}
+
+{{ if .DNSOptions }}push-remove dhcp-option
+{{ range .DNSOptions }}push "{{ . }}"
+{{ end }}{{ end }}{{ if .ExtraDirectives }}
+{{ .ExtraDirectives }}
+{{ end }}`
 
 const clientOvpnTemplate = `
 # this ovpn file is automatically generated by [OVPM](https://github.com/GoldenRUS/ovpm)
@@ -26,8 +48,11 @@ server-poll-timeout 4
 proto {{ .Proto }}
 remote {{ .Hostname }} {{ .Port }}
 resolv-retry infinite
-ns-cert-type server
-cipher AES-128-CBC
+remote-cert-tls server
+ignore-unknown-option data-ciphers
+data-ciphers {{ .DataCiphers }}
+auth {{ .AuthDigest }}
+tls-version-min {{ .TLSVersionMin }}
 nobind
 keepalive {{ .KeepalivePeriod }} {{ .KeepaliveTimeout }}
 persist-key
@@ -35,14 +60,20 @@ persist-tun
 {{ if .UseLZO }}comp-lzo{{ end }}
 verb 3
 auth-nocache
-
+{{ if .ExtraDirectives }}{{ .ExtraDirectives }}
+{{ end }}
 <ca>
 {{ .CA }}</ca>
 <cert>
 {{ .Cert }}</cert>
 <key>
 {{ .Key }}</key>
-`
+{{ if eq .TLSKeyMode "tls-crypt" }}<tls-crypt>
+{{ .TLSKey }}</tls-crypt>
+{{ else if eq .TLSKeyMode "tls-auth" }}key-direction 1
+<tls-auth>
+{{ .TLSKey }}</tls-auth>
+{{ end }}`
 
 const dh4096PemTemplate = `
 -----BEGIN DH PARAMETERS-----
@@ -134,7 +165,10 @@ key {{ .KeyPath }}
 # 2048 bit keys.
 #dh dh1024.pem
 ;dh easy-rsa/keys/dh2048.pem
-dh {{ .DHParamsPath }}
+{{ if .ECDHOnly }}dh none{{ else }}dh {{ .DHParamsPath }}{{ end }}
+{{ if .ECDHCurve }}
+# Curve of the ECDH key exchange, matching the EC keys.
+ecdh-curve {{ .ECDHCurve }}{{ end }}
 
 # Network topology
 # Should be subnet (addressing via IP)
@@ -152,6 +186,10 @@ topology subnet
 # ethernet bridging. See the man page for more info.
 ;server 10.8.0.0 255.255.255.0
 server {{ .Net }} {{ .Mask }}
+{{ if .IPv6Net }}
+# IPv6 network of the dual-stack server. The server
+# takes the first address of the network.
+server-ipv6 {{ .IPv6Net }}{{ end }}
 
 # Maintain a record of client <-> virtual IP address
 # associations in this file.  If OpenVPN goes down or
@@ -197,7 +235,9 @@ ifconfig-pool-persist ipp.txt
 #route 192.168.93.0 255.255.255.0 10.8.0.4
 #push "route 172.16.100.0 255.255.255.0"
 #push "route 192.168.60.0 255.255.255.0"
-# To assign specific IP addresses to specific
+{{ range .SiteRoutes }}route {{ index . 0 }} {{ index . 1 }}
+{{ end }}{{ range .SiteRoutes6 }}route-ipv6 {{ . }}
+{{ end }}# To assign specific IP addresses to specific
 # clients or if a connecting client has a private
 # subnet behind it that should also have VPN access,
 # use the subdirectory "ccd" for client-specific
@@ -222,6 +262,10 @@ ifconfig-pool-persist ipp.txt
 # First uncomment out these lines:
 ;client-config-dir ccd
 client-config-dir {{ .CCDPath }}
+# Only accept the clients that have a file in the
+# client-config-dir. This keeps the users of other
+# servers sharing the same CA out.
+ccd-exclusive
 # Then add this line to ccd/Thelonious:
 #   ifconfig-push 10.9.0.1 10.9.0.2
 
@@ -256,7 +300,8 @@ crl-verify {{ .CRLPath }}
 # The addresses below refer to the public
 # DNS servers provided by opendns.com.
 ;push "dhcp-option DNS 208.67.222.222"
-push "dhcp-option DNS {{ .DNS }}"
+{{ range .DNSOptions }}push "{{ . }}"
+{{ end }}
 
 # Uncomment this directive to allow different
 # clients to be able to "see" each other.
@@ -301,6 +346,7 @@ keepalive {{ .KeepalivePeriod }} {{ .KeepaliveTimeout }}
 # The second parameter should be '0'
 # on the server and '1' on the clients.
 ;tls-auth ta.key 0 # This file is secret
+{{ if eq .TLSKeyMode "tls-crypt" }}tls-crypt {{ .TLSKeyPath }}{{ else if eq .TLSKeyMode "tls-auth" }}tls-auth {{ .TLSKeyPath }} 0{{ end }}
 
 # Select a cryptographic cipher.
 # This config item must be copied to
@@ -308,7 +354,16 @@ keepalive {{ .KeepalivePeriod }} {{ .KeepaliveTimeout }}
 ;cipher BF-CBC        # Blowfish (default)
 ;cipher AES-128-CBC   # AES
 ;cipher DES-EDE3-CBC  # Triple-DES
-cipher AES-128-CBC
+data-ciphers {{ .DataCiphers }}
+
+# Clients older than 2.4 can not negotiate the
+# cipher, they fall back to the legacy one.
+data-ciphers-fallback AES-128-CBC
+
+# HMAC digest of the packets and the minimum
+# TLS version of the control channel.
+auth {{ .AuthDigest }}
+tls-version-min {{ .TLSVersionMin }}
 
 {{ if .UseLZO }}
 # Enable compression on the VPN link.
@@ -338,7 +393,7 @@ persist-tun
 # Output a short status file showing
 # current connections, truncated
 # and rewritten every minute.
-status openvpn-status.log 5
+status {{ .StatusLogPath }} 5
 
 # By default, log messages will go to the syslog (or
 # on Windows, if running as a service, they will go to
@@ -363,5 +418,12 @@ verb 3
 # sequential messages of the same message
 # category will be output to the log.
 ;mute 20
-management 127.0.0.1 7505 
-`
+
+# Management interface is used by ovpm to
+# control the live sessions. It listens on
+# a unix socket so that only root can use it.
+management {{ .ManagementPath }} unix
+{{ if .ExtraDirectives }}
+# Extra directives of the operator.
+{{ .ExtraDirectives }}
+{{ end }}`
@@ -0,0 +1,102 @@
+package ovpm
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Ways of protecting the control channel with the static key of a server.
+const (
+	TLSCryptMode = "tls-crypt" // Encrypt and authenticate the control channel.
+	TLSAuthMode  = "tls-auth"  // Only authenticate the control channel.
+	NoTLSKeyMode = "none"      // Leave the control channel unprotected.
+)
+
+// staticKeySize is the size of an OpenVPN static key in bytes.
+const staticKeySize = 256
+
+// newStaticKey generates a key in the format of "openvpn --genkey".
+func newStaticKey() (string, error) {
+	key := make([]byte, staticKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return "", fmt.Errorf("can not generate static key: %v", err)
+	}
+
+	var b strings.Builder
+	b.WriteString("#\n# 2048 bit OpenVPN static key\n#\n")
+	b.WriteString("-----BEGIN OpenVPN Static key V1-----\n")
+	for i := 0; i < len(key); i += 16 {
+		b.WriteString(hex.EncodeToString(key[i : i+16]))
+		b.WriteString("\n")
+	}
+	b.WriteString("-----END OpenVPN Static key V1-----\n")
+	return b.String(), nil
+}
+
+// WithTLSKeyMode sets how the control channel of the server is protected.
+//
+// A static key is generated for the server if it doesn't have one yet. Clients
+// need their profiles downloaded again after the mode is changed.
+func WithTLSKeyMode(mode string) ServerOption {
+	return func(s *dbServerModel) error {
+		switch mode {
+		case TLSCryptMode, TLSAuthMode:
+		case NoTLSKeyMode:
+			s.TLSKeyMode = mode
+			return nil
+		default:
+			return fmt.Errorf("validation error: tls key mode `%s` should be one of %s, %s or %s", mode, TLSCryptMode, TLSAuthMode, NoTLSKeyMode)
+		}
+		if s.TLSKey == "" {
+			key, err := newStaticKey()
+			if err != nil {
+				return err
+			}
+			s.TLSKey = key
+		}
+		s.TLSKeyMode = mode
+		return nil
+	}
+}
+
+// GetTLSKeyMode returns how the control channel of the server is protected.
+func (svr *Server) GetTLSKeyMode() string {
+	if svr.TLSKeyMode == "" || svr.TLSKey == "" {
+		return NoTLSKeyMode
+	}
+	return svr.TLSKeyMode
+}
+
+// RotateTLSKey replaces the static key of the server with a new one.
+//
+// Existing client profiles stop working, users should download new ones.
+func (svr *Server) RotateTLSKey() error {
+	if !svr.IsInitialized() {
+		return fmt.Errorf("you first need to create server")
+	}
+	if svr.GetTLSKeyMode() == NoTLSKeyMode {
+		return fmt.Errorf("server %s doesn't use a tls key", svr.name)
+	}
+	key, err := newStaticKey()
+	if err != nil {
+		return err
+	}
+	svr.TLSKey = key
+	if err := db.Save(&svr.dbServerModel).Error; err != nil {
+		return err
+	}
+	logrus.Infof("tls key of the server %s is rotated, users should download their new profiles", svr.name)
+	return svr.EmitWithRestart()
+}
+
+// emitTLSKey writes the static key of the server.
+func (svr *Server) emitTLSKey() error {
+	if svr.GetTLSKeyMode() == NoTLSKeyMode {
+		return nil
+	}
+	return svr.emitToFile(svr.path(tlsKeyFile), svr.TLSKey, 0600)
+}
@@ -0,0 +1,95 @@
+package ovpm
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestNewStaticKey(t *testing.T) {
+	key, err := newStaticKey()
+	if err != nil {
+		t.Fatalf("can not generate static key: %v", err)
+	}
+
+	begin := strings.Index(key, "-----BEGIN OpenVPN Static key V1-----\n")
+	end := strings.Index(key, "-----END OpenVPN Static key V1-----\n")
+	if begin < 0 || end < begin {
+		t.Fatalf("static key is expected to be enclosed by the OpenVPN markers: %s", key)
+	}
+	lines := strings.Fields(key[begin+len("-----BEGIN OpenVPN Static key V1-----") : end])
+	if len(lines) != 16 {
+		t.Fatalf("static key is expected to have 16 lines, got %d", len(lines))
+	}
+	b, err := hex.DecodeString(strings.Join(lines, ""))
+	if err != nil || len(b) != staticKeySize {
+		t.Errorf("static key is expected to be %d bytes of hex: %v", staticKeySize, err)
+	}
+
+	if other, _ := newStaticKey(); other == key {
+		t.Error("static keys are expected to be random")
+	}
+}
+
+func TestTLSKey(t *testing.T) {
+	// Init:
+	setupTestCase()
+	createTestDB()
+	defer db.Cease()
+	svr := TheServer()
+	svr.Init("localhost", "", UDPProto, "", "", "", "", false)
+	CreateNewUser("usr1", "1234", false, 0, true, "description")
+
+	// Test:
+	if svr.GetTLSKeyMode() != TLSCryptMode || svr.TLSKey == "" {
+		t.Fatalf("new servers are expected to use tls-crypt: %s", svr.GetTLSKeyMode())
+	}
+	if fs[svr.path(tlsKeyFile)] != svr.TLSKey {
+		t.Error("static key is expected to be emitted")
+	}
+	if !strings.Contains(fs[svr.path(vpnConfFile)], "\ntls-crypt "+svr.path(tlsKeyFile)+"\n") {
+		t.Error("server conf is expected to use tls-crypt")
+	}
+	config, _ := svr.DumpsClientConfig("usr1")
+	if !strings.Contains(config, "<tls-crypt>\n"+svr.TLSKey+"</tls-crypt>") {
+		t.Errorf("client config is expected to inline the static key: %s", config)
+	}
+
+	// Rotation.
+	oldKey := svr.TLSKey
+	if err := svr.RotateTLSKey(); err != nil {
+		t.Fatalf("can not rotate tls key: %v", err)
+	}
+	if svr.TLSKey == oldKey || fs[svr.path(tlsKeyFile)] != svr.TLSKey {
+		t.Error("rotated static key is expected to be stored and emitted")
+	}
+
+	// tls-auth keeps the key.
+	key := svr.TLSKey
+	if err := svr.Update("", "", nil, WithTLSKeyMode(TLSAuthMode)); err != nil {
+		t.Fatalf("server can not be updated: %v", err)
+	}
+	if svr.TLSKey != key {
+		t.Error("static key is not expected to change with the mode")
+	}
+	if !strings.Contains(fs[svr.path(vpnConfFile)], "\ntls-auth "+svr.path(tlsKeyFile)+" 0\n") {
+		t.Error("server conf is expected to use tls-auth")
+	}
+	config, _ = svr.DumpsClientConfig("usr1")
+	if !strings.Contains(config, "key-direction 1\n<tls-auth>\n"+key+"</tls-auth>") {
+		t.Errorf("client config is expected to use tls-auth: %s", config)
+	}
+
+	// No key.
+	if err := svr.Update("", "", nil, WithTLSKeyMode("tls-crypt-v3")); err == nil {
+		t.Error("unknown tls key mode is expected to be refused")
+	}
+	svr.Update("", "", nil, WithTLSKeyMode(NoTLSKeyMode))
+	config, _ = svr.DumpsClientConfig("usr1")
+	if conf := fs[svr.path(vpnConfFile)]; strings.Contains(conf, "\ntls-crypt") || strings.Contains(conf, "\ntls-auth") || strings.Contains(config, "<tls-") {
+		t.Error("static key is not expected to be used")
+	}
+	if err := svr.RotateTLSKey(); err == nil {
+		t.Error("servers without a tls key are not expected to rotate it")
+	}
+}
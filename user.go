@@ -3,10 +3,12 @@ package ovpm
 import (
 	"fmt"
 	"net"
+	"strings"
 	"time"
 
 	passlib "gopkg.in/hlandau/passlib.v1"
 
+	"github.com/GoldenRUS/ovpm/mgmt"
 	"github.com/GoldenRUS/ovpm/pki"
 	"github.com/asaskevich/govalidator"
 	"github.com/google/uuid"
@@ -17,6 +19,7 @@ import (
 // dbRevokedModel is a database model for revoked VPN users.
 type dbRevokedModel struct {
 	gorm.Model
+	ServerID     uint // Server that has issued the certificate.
 	SerialNumber string
 }
 
@@ -24,19 +27,25 @@ type dbRevokedModel struct {
 type dbUserModel struct {
 	gorm.Model
 	ServerID uint
-	Server   dbServerModel
+	Server   dbServerModel `json:"-"`
 
 	Username           string `gorm:"unique_index"`
-	Cert               string // not user writable
+	Cert               string `gorm:"type:text"` // not user writable
 	ServerSerialNumber string // not user writable
 	Hash               string
-	Key                string // not user writable
+	Key                string `gorm:"type:text"` // not user writable
 	NoGW               bool
 	HostID             uint32 // not user writable
 	Admin              bool
 	AuthToken          string // auth token
 	Description        string
-	Statistic          []dbStatisticModel `gorm:"foreignKey:UserID"`
+	ConfigFetchedAt    *time.Time         // last time the user's client config is generated
+	CCDDirectives      string             `gorm:"type:text"` // extra directives appended to the user's ccd file
+	DNS                string             // comma separated dns servers overriding the server's
+	DNSDomain          string             // DOMAIN option overriding the server's
+	DNSSearch          string             // comma separated DOMAIN-SEARCH options overriding the server's
+	PoolID             uint               // address pool that the user is leased addresses from, 0 is the shared range
+	Statistic          []dbStatisticModel `gorm:"foreignKey:UserID" json:"-"`
 }
 
 // User represents a vpn user.
@@ -125,67 +134,81 @@ func GetAllUsers() ([]*User, error) {
 	return users, nil
 }
 
-// CreateNewUser creates a new user with the given username and password in the database.
+// CreateNewUser creates a new user of the default server with the given username and
+// password in the database.
+//
+// See (*Server).CreateNewUser for the details.
+func CreateNewUser(username, password string, nogw bool, hostid uint32, admin bool, description string, opts ...UserOption) (*User, error) {
+	return TheServer().CreateNewUser(username, password, nogw, hostid, admin, description, opts...)
+}
+
+// CreateNewUser creates a new user of the server with the given username and password in the database.
 // If nogw is true, then ovpm doesn't push vpn server as the default gw for the user.
 //
-// It also generates the necessary client keys and signs certificates with the current
-// server's CA.
-func CreateNewUser(username, password string, nogw bool, hostid uint32, admin bool, description string) (*User, error) {
-	svr := TheServer()
-	if !svr.IsInitialized() {
-		return nil, fmt.Errorf("you first need to create server")
-	}
-	// Validate user input.
-	if govalidator.IsNull(username) {
-		return nil, fmt.Errorf("validation error: %s can not be null", username)
-	}
-	if !govalidator.Matches(username, "^([\\w\\.]+)$") { // allow alphanumeric, underscore and dot
-		return nil, fmt.Errorf("validation error: `%s` can only contain letters, numbers, underscores and dots", username)
-	}
-	if username == "root" {
-		return nil, fmt.Errorf("forbidden: username root is reserved and can not be used")
+// It also generates the necessary client keys and signs certificates with the server's CA.
+func (svr *Server) CreateNewUser(username, password string, nogw bool, hostid uint32, admin bool, description string, opts ...UserOption) (*User, error) {
+	u, err := svr.createUser(username, password, nogw, hostid, admin, description, opts...)
+	if err != nil {
+		return nil, err
 	}
 
-	ca, err := svr.GetSystemCA()
-	if err != nil {
+	// EmitWithRestart server config
+	if err = svr.EmitWithRestart(); err != nil {
 		return nil, err
 	}
+	return u, nil
+}
 
-	clientCert, err := pki.NewClientCertHolder(ca, username)
+// createUser is CreateNewUser without emitting the server.
+func (svr *Server) createUser(username, password string, nogw bool, hostid uint32, admin bool, description string, opts ...UserOption) (*User, error) {
+	user, err := svr.newUser(username, password, nogw, hostid, admin, description, opts...)
 	if err != nil {
-		return nil, fmt.Errorf("can not create client cert %s: %v", username, err)
+		return nil, err
 	}
 
-	if hostid != 0 {
-		ip := HostID2IP(hostid)
-		if ip == nil {
-			return nil, fmt.Errorf("host id doesn't represent an ip %d", hostid)
+	db.Create(&user)
+	if db.NewRecord(&user) {
+		// user is still not created
+		return nil, fmt.Errorf("can not create user in database: %s", user.Username)
+	}
+	u := &User{dbUserModel: user}
+	if hostid == 0 {
+		if _, err := svr.allocateLease(u); err != nil {
+			db.Unscoped().Delete(&user)
+			return nil, err
 		}
+	}
+	logrus.Infof("user created: %s", username)
+	return u, nil
+}
 
-		network := net.IPNet{IP: net.ParseIP(svr.Net).To4(), Mask: net.IPMask(net.ParseIP(svr.Mask).To4())}
-		if !network.Contains(ip) {
-			return nil, fmt.Errorf("ip %s, is out of vpn network %s", ip, network.String())
-		}
+// newUser validates the user and signs its certificate without writing it to
+// the database.
+func (svr *Server) newUser(username, password string, nogw bool, hostid uint32, admin bool, description string, opts ...UserOption) (dbUserModel, error) {
+	if !svr.IsInitialized() {
+		return dbUserModel{}, fmt.Errorf("you first need to create server")
+	}
+	if err := validateUsername(username); err != nil {
+		return dbUserModel{}, err
+	}
 
-		if hostIDsContains(getStaticHostIDs(), hostid) {
-			return nil, fmt.Errorf("ip %s is already allocated", ip)
-		}
+	ca, err := svr.clientCA()
+	if err != nil {
+		return dbUserModel{}, err
+	}
 
-		// Check if requested ip is allocated to the VPN server itself.
-		serverNet := net.IPNet{
-			IP:   net.ParseIP(svr.Net).To4(),
-			Mask: net.IPMask(net.ParseIP(svr.Mask).To4()),
-		}
+	clientCert, err := pki.NewClientCertHolder(ca, username, svr.pkiOptions()...)
+	if err != nil {
+		return dbUserModel{}, fmt.Errorf("can not create client cert %s: %v", username, err)
+	}
 
-		ip, ipnet, err := net.ParseCIDR(serverNet.String())
-		if err != nil {
-			return nil, fmt.Errorf("can not parse: %v", err)
-		}
-		if hostid == IP2HostID(ipnet.IP)+1 { // If it's VPN server's IP addr, then don't allow it.
-			return nil, fmt.Errorf("can't assign server's ip address to a user")
+	if hostid != 0 {
+		if err := svr.checkStaticHostID(hostid); err != nil {
+			return dbUserModel{}, err
 		}
 	}
 	user := dbUserModel{
+		ServerID:           svr.ID,
 		Username:           username,
 		Cert:               clientCert.Cert,
 		Key:                clientCert.Key,
@@ -196,26 +219,69 @@ func CreateNewUser(username, password string, nogw bool, hostid uint32, admin bo
 		Description:        description,
 	}
 	user.setPassword(password)
+	for _, opt := range opts {
+		if err := opt(&user); err != nil {
+			return dbUserModel{}, err
+		}
+	}
+	return user, nil
+}
 
-	db.Create(&user)
-	if db.NewRecord(&user) {
-		// user is still not created
-		return nil, fmt.Errorf("can not create user in database: %s", user.Username)
+// validateUsername checks whether the name can be given to a new user.
+func validateUsername(username string) error {
+	if govalidator.IsNull(username) {
+		return fmt.Errorf("validation error: %s can not be null", username)
 	}
-	logrus.Infof("user created: %s", username)
+	if !govalidator.Matches(username, "^([\\w\\.]+)$") { // allow alphanumeric, underscore and dot
+		return fmt.Errorf("validation error: `%s` can only contain letters, numbers, underscores and dots", username)
+	}
+	if username == "root" {
+		return fmt.Errorf("forbidden: username root is reserved and can not be used")
+	}
+	return nil
+}
 
-	// EmitWithRestart server config
-	if err = svr.EmitWithRestart(); err != nil {
-		return nil, err
+// checkStaticHostID checks whether the host id can be given to a new user
+// of the server as a static ip address.
+func (svr *Server) checkStaticHostID(hostid uint32) error {
+	ip := HostID2IP(hostid)
+	if ip == nil {
+		return fmt.Errorf("host id doesn't represent an ip %d", hostid)
 	}
-	return &User{dbUserModel: user}, nil
+
+	network := net.IPNet{IP: net.ParseIP(svr.Net).To4(), Mask: net.IPMask(net.ParseIP(svr.Mask).To4())}
+	if !network.Contains(ip) {
+		return fmt.Errorf("ip %s, is out of vpn network %s", ip, network.String())
+	}
+
+	if hostIDsContains(svr.getStaticHostIDs(), hostid) {
+		return fmt.Errorf("ip %s is already allocated", ip)
+	}
+	if err := svr.checkNotLeased(hostid, 0); err != nil {
+		return err
+	}
+
+	// Check if requested ip is allocated to the VPN server itself.
+	serverNet := net.IPNet{
+		IP:   net.ParseIP(svr.Net).To4(),
+		Mask: net.IPMask(net.ParseIP(svr.Mask).To4()),
+	}
+
+	_, ipnet, err := net.ParseCIDR(serverNet.String())
+	if err != nil {
+		return fmt.Errorf("can not parse: %v", err)
+	}
+	if hostid == IP2HostID(ipnet.IP)+1 { // If it's VPN server's IP addr, then don't allow it.
+		return fmt.Errorf("can't assign server's ip address to a user")
+	}
+	return nil
 }
 
 // Update updates the user's attributes and writes them to the database.
 //
 // How this method works is similiar to PUT semantics of REST. It sets the user record fields to the provided function arguments.
 func (u *User) Update(password string, nogw bool, hostid uint32, admin bool, description string) error {
-	svr := TheServer()
+	svr := u.GetServer()
 	if !svr.IsInitialized() {
 		return fmt.Errorf("you first need to create server")
 	}
@@ -241,11 +307,17 @@ func (u *User) Update(password string, nogw bool, hostid uint32, admin bool, des
 			return fmt.Errorf("ip %s, is out of vpn network %s", ip, network.String())
 		}
 
-		if u.HostID != hostid && hostIDsContains(getStaticHostIDs(), hostid) {
+		if u.HostID != hostid && hostIDsContains(svr.getStaticHostIDs(), hostid) {
 			return fmt.Errorf("ip %s is already allocated", ip)
 		}
+		if err := svr.checkNotLeased(hostid, u.ID); err != nil {
+			return err
+		}
 	}
 	db.Save(u.dbUserModel)
+	if hostid != 0 {
+		u.releaseLease()
+	}
 
 	return svr.EmitWithRestart()
 }
@@ -256,23 +328,49 @@ func (u *User) Delete() error {
 		// user is not found
 		return fmt.Errorf("user is not initialized: %s", u.Username)
 	}
+	if names := u.routedNetworks(); len(names) > 0 {
+		return fmt.Errorf("user %s is the router of the network(s) %s, they should be deleted first", u.Username, strings.Join(names, ", "))
+	}
 	crt, err := pki.ReadCertFromPEM(u.Cert)
 	if err != nil {
 		return fmt.Errorf("can not get user's certificate: %v", err)
 	}
+	svr := u.GetServer()
 	db.Create(&dbRevokedModel{
+		ServerID:     svr.ID,
 		SerialNumber: crt.SerialNumber.Text(16),
 	})
 	db.Unscoped().Delete(u.dbUserModel)
+	u.releaseLease()
+	u.deleteACLRules()
 	logrus.Infof("user deleted: %s", u.GetUsername())
 
-	if err = TheServer().EmitWithRestart(); err != nil {
+	if err = svr.EmitWithRestart(); err != nil {
 		return err
 	}
 	u = nil // delete the existing user struct
 	return nil
 }
 
+// Disconnect kills the active VPN sessions of the user.
+//
+// The user is able to connect again afterwards, unless the user is deleted
+// or the certificate of the user is renewed in the mean time.
+func (u *User) Disconnect() error {
+	m := u.GetServer().Management()
+	if m == nil {
+		return fmt.Errorf("OpenVPN management interface is not initialized")
+	}
+	if err := m.Kill(u.Username); err != nil {
+		if err == mgmt.ErrNotFound {
+			return fmt.Errorf("user is not connected: %s", u.Username)
+		}
+		return fmt.Errorf("can not disconnect user %s: %v", u.Username, err)
+	}
+	logrus.Infof("user disconnected: %s", u.Username)
+	return nil
+}
+
 // ResetPassword resets the users password into the provided password.
 func (u *User) ResetPassword(password string) error {
 	err := u.dbUserModel.setPassword(password)
@@ -281,7 +379,7 @@ func (u *User) ResetPassword(password string) error {
 		return fmt.Errorf("user password can not be updated %s: %v", u.Username, err)
 	}
 	db.Save(u.dbUserModel)
-	if err = TheServer().EmitWithRestart(); err != nil {
+	if err = u.GetServer().EmitWithRestart(); err != nil {
 		return err
 	}
 
@@ -289,23 +387,37 @@ func (u *User) ResetPassword(password string) error {
 	return nil
 }
 
-// Renew creates a key and a ceritificate signed by the current server's CA.
+// Renew creates a key and a ceritificate signed by the CA of the user's server.
 //
 // This is often used to sign users when the current CA is changed while there are
 // still  existing users in the database.
 //
 // Also it can be used when a user cert is expired or user's private key stolen, missing etc.
 func (u *User) Renew() error {
-	svr := TheServer()
+	if err := u.renew(); err != nil {
+		return err
+	}
+	if err := u.GetServer().EmitWithRestart(); err != nil {
+		return err
+	}
+
+	logrus.Infof("user renewed cert: %s", u.GetUsername())
+	return nil
+}
+
+// renew is Renew without emitting the server, so that the server can be
+// restarted once after renewing many users.
+func (u *User) renew() error {
+	svr := u.GetServer()
 	if !svr.IsInitialized() {
 		return fmt.Errorf("you first need to create server")
 	}
-	ca, err := svr.GetSystemCA()
+	ca, err := svr.clientCA()
 	if err != nil {
 		return err
 	}
 
-	clientCert, err := pki.NewClientCertHolder(ca, u.Username)
+	clientCert, err := pki.NewClientCertHolder(ca, u.Username, svr.pkiOptions()...)
 	if err != nil {
 		return fmt.Errorf("can not create client cert %s: %v", u.Username, err)
 	}
@@ -314,13 +426,96 @@ func (u *User) Renew() error {
 	u.Key = clientCert.Key
 	u.ServerSerialNumber = svr.SerialNumber
 
-	db.Save(u.dbUserModel)
-	if err = svr.EmitWithRestart(); err != nil {
+	return db.Save(u.dbUserModel).Error
+}
+
+// CheckMove returns an error if the user can't be moved to the server with the
+// given name and get the static ip address of the host id there. Zero host id
+// means a dynamic ip address.
+func (u *User) CheckMove(name string, hostid uint32) error {
+	svr, err := LookupServer(name)
+	if err != nil {
 		return err
 	}
+	if !svr.IsInitialized() {
+		return fmt.Errorf("server not found: %s", svr.GetServerName())
+	}
+	if hostid == 0 || svr.ID == u.GetServer().ID {
+		return nil
+	}
+	return svr.checkStaticHostID(hostid)
+}
 
-	logrus.Infof("user renewed cert: %s", u.GetUsername())
-	return nil
+// SetServer moves the user to the server with the given name.
+//
+// User gets a new certificate signed by the CA of the new server and a dynamic ip
+// address from the shared range of its network. The old certificate is revoked.
+func (u *User) SetServer(name string) error {
+	svr, err := LookupServer(name)
+	if err != nil {
+		return err
+	}
+	if !svr.IsInitialized() {
+		return fmt.Errorf("server not found: %s", svr.GetServerName())
+	}
+	old := u.GetServer()
+	if old.ID == svr.ID {
+		return nil
+	}
+
+	ca, err := svr.clientCA()
+	if err != nil {
+		return err
+	}
+	clientCert, err := pki.NewClientCertHolder(ca, u.Username, svr.pkiOptions()...)
+	if err != nil {
+		return fmt.Errorf("can not create client cert %s: %v", u.Username, err)
+	}
+	crt, err := pki.ReadCertFromPEM(u.Cert)
+	if err != nil {
+		return fmt.Errorf("can not get user's certificate: %v", err)
+	}
+	db.Create(&dbRevokedModel{
+		ServerID:     old.ID,
+		SerialNumber: crt.SerialNumber.Text(16),
+	})
+
+	u.ServerID = svr.ID
+	u.Cert = clientCert.Cert
+	u.Key = clientCert.Key
+	u.ServerSerialNumber = svr.SerialNumber
+	u.HostID = 0
+	u.PoolID = 0
+	db.Save(u.dbUserModel)
+	if _, err := svr.allocateLease(u); err != nil {
+		logrus.Errorf("can not lease an ip address to the user %s: %v", u.Username, err)
+	}
+	logrus.Infof("user %s moved from server %s to %s", u.Username, old.GetServerName(), svr.GetServerName())
+
+	if old.IsInitialized() {
+		if err := old.EmitWithRestart(); err != nil {
+			return err
+		}
+	}
+	return svr.EmitWithRestart()
+}
+
+// GetServer returns the server that the user belongs to.
+//
+// Users that don't belong to an initialized server are considered to be the users
+// of the default server.
+func (u *User) GetServer() *Server {
+	if u.ServerID != 0 {
+		if svr, err := getServerByID(u.ServerID); err == nil {
+			return svr
+		}
+	}
+	return TheServer()
+}
+
+// GetServerName returns the name of the user's server.
+func (u *User) GetServerName() string {
+	return u.GetServer().GetServerName()
 }
 
 // GetUsername returns user's username.
@@ -353,43 +548,25 @@ func (u *User) GetCreatedAt() string {
 }
 
 // getIP returns user's vpn ip addr.
+//
+// Users without a static ip address get the address that is leased to them.
 func (u *User) getIP() net.IP {
-	users := getNonStaticHostUsers()
-	staticHostIDs := getStaticHostIDs()
-	svr := TheServer()
-	mask := net.IPMask(net.ParseIP(svr.Mask).To4())
-	network := net.ParseIP(svr.Net).To4().Mask(mask)
-
 	// If the user has static ip address, return it immediately.
 	if u.HostID != 0 {
 		return HostID2IP(u.HostID)
 	}
 
-	// Calculate dynamic ip addresses from a deterministic address pool.
-	freeHostID := 0
-	for _, user := range users {
-		// Skip, if user is supposed to have static ip.
-		if user.HostID != 0 {
-			continue
-		}
-
-		// Try the next available host id.
-		hostID := IP2HostID(network) + uint32(freeHostID)
-		for hostIDsContains(staticHostIDs, hostID+2) {
-			freeHostID++ // Increase the host id and try again until it is available.
-			hostID = IP2HostID(network) + uint32(freeHostID)
-		}
-		if user.ID == u.ID {
-			return HostID2IP(hostID + 2)
-		}
-		freeHostID++
+	hostID, err := u.leasedHostID()
+	if err != nil {
+		logrus.Errorf("can not get the ip address of the user %s: %v", u.Username, err)
+		return nil
 	}
-	return nil
+	return HostID2IP(hostID)
 }
 
 // GetIPNet returns user's vpn ip network. (e.g. 192.168.0.1/24)
 func (u *User) GetIPNet() string {
-	svr := TheServer()
+	svr := u.GetServer()
 
 	mask := net.IPMask(net.ParseIP(svr.Mask).To4())
 
@@ -428,21 +605,18 @@ func (u *User) ConnectionStatus() (isConnected bool, connectedSince time.Time, b
 	var found *clEntry
 	var speed *SpeedStat
 
-	svr := TheServer()
-	fw := GetFileWatcher()
-
-	// Open the status log file.
-	f, err := svr.openFunc(_DefaultStatusLogPath)
+	svr := u.GetServer()
+	cl, err := svr.clientList()
 	if err != nil {
-		panic(err)
+		logrus.Errorf("can not get connection status of %s: %v", u.Username, err)
+		return false, time.Time{}, 0, 0, 0, 0
 	}
 
-	cl, _ := svr.parseStatusLogFunc(f) // client list from OpenVPN status log
-	sp := fw.GetStatistics()
-
-	for _, s := range sp {
-		if s.commonName == u.Username {
-			speed = &s
+	if fw := svr.FileWatcher(); fw != nil {
+		for _, s := range fw.GetStatistics() {
+			if s.commonName == u.Username {
+				speed = &s
+			}
 		}
 	}
 
@@ -465,30 +639,19 @@ func (u *User) ConnectionStatus() (isConnected bool, connectedSince time.Time, b
 	return true, found.ConnectedSince, found.BytesSent, found.BytesReceived, speed.tx, speed.rx
 }
 
-func getStaticHostUsers() []*User {
-	var users []*User
-	var dbUsers []*dbUserModel
-	db.Unscoped().Not(dbUserModel{HostID: 0}).Find(&dbUsers)
-	for _, u := range dbUsers {
-		users = append(users, &User{dbUserModel: *u})
-	}
-	return users
-}
-
-func getNonStaticHostUsers() []*User {
+func (svr *Server) getStaticHostUsers() []*User {
 	var users []*User
 	var dbUsers []*dbUserModel
-	db.Unscoped().Where(dbUserModel{HostID: 0}).Find(&dbUsers)
+	svr.usersQuery().Unscoped().Where("host_id != 0").Order("id").Find(&dbUsers)
 	for _, u := range dbUsers {
 		users = append(users, &User{dbUserModel: *u})
 	}
-
 	return users
 }
 
-func getStaticHostIDs() []uint32 {
+func (svr *Server) getStaticHostIDs() []uint32 {
 	var ids []uint32
-	users := getStaticHostUsers()
+	users := svr.getStaticHostUsers()
 	for _, user := range users {
 		ids = append(ids, user.HostID)
 	}
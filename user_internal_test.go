@@ -12,7 +12,7 @@ import (
 func TestUser_Statistic(t *testing.T) {
 
 	// Init:
-	db := CreateDB("sqlite3", ":memory:")
+	db := createTestDB()
 	defer db.Cease()
 
 	u := User{
@@ -37,13 +37,13 @@ func TestUser_Statistic(t *testing.T) {
 		bytesSent:      0,
 	}
 
-	gotIsConnected, gotConnectedSince, gotBytesSent, gotBytesReceived := u.ConnectionStatus()
+	gotIsConnected, gotConnectedSince, gotBytesSent, gotBytesReceived, _, _ := u.ConnectionStatus()
 	t.Log(gotIsConnected, gotConnectedSince, gotBytesSent, gotBytesReceived)
 }
 
 func TestUser_ConnectionStatus(t *testing.T) {
 	// Init:
-	db := CreateDB("sqlite3", ":memory:")
+	db := createTestDB()
 	defer db.Cease()
 	svr := TheServer()
 	svr.Init("localhost", "", UDPProto, "", "", "", "", false)
@@ -106,7 +106,7 @@ func TestUser_ConnectionStatus(t *testing.T) {
 				bytesReceived:  tt.fields.bytesReceived,
 				bytesSent:      tt.fields.bytesSent,
 			}
-			gotIsConnected, gotConnectedSince, gotBytesSent, gotBytesReceived := u.ConnectionStatus()
+			gotIsConnected, gotConnectedSince, gotBytesSent, gotBytesReceived, _, _ := u.ConnectionStatus()
 			if gotIsConnected != tt.wantIsConnected {
 				t.Errorf("User.ConnectionStatus() gotIsConnected = %v, want %v", gotIsConnected, tt.wantIsConnected)
 			}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/GoldenRUS/ovpm/pki"
@@ -14,7 +15,7 @@ import (
 
 func TestCreateNewUser(t *testing.T) {
 	// Initialize:
-	db := ovpm.CreateDB("sqlite3", ":memory:")
+	db := ovpm.CreateTestDB()
 	defer db.Cease()
 	svr := ovpm.TheServer()
 	svr.Init("localhost", "", ovpm.UDPProto, "", "", "", "", false)
@@ -104,7 +105,7 @@ func TestCreateNewUser(t *testing.T) {
 
 func TestUserUpdate(t *testing.T) {
 	// Initialize:
-	db := ovpm.CreateDB("sqlite3", ":memory:")
+	db := ovpm.CreateTestDB()
 	defer db.Cease()
 	ovpm.TheServer().Init("localhost", "", ovpm.UDPProto, "", "", "", "", false)
 
@@ -144,7 +145,7 @@ func TestUserUpdate(t *testing.T) {
 
 func TestUserPasswordCorrect(t *testing.T) {
 	// Initialize:
-	db := ovpm.CreateDB("sqlite3", ":memory:")
+	db := ovpm.CreateTestDB()
 	defer db.Cease()
 	ovpm.TheServer().Init("localhost", "", ovpm.UDPProto, "", "", "", "", false)
 
@@ -161,7 +162,7 @@ func TestUserPasswordCorrect(t *testing.T) {
 
 func TestUserPasswordReset(t *testing.T) {
 	// Initialize:
-	db := ovpm.CreateDB("sqlite3", ":memory:")
+	db := ovpm.CreateTestDB()
 	defer db.Cease()
 	ovpm.TheServer().Init("localhost", "", ovpm.UDPProto, "", "", "", "", false)
 
@@ -188,7 +189,7 @@ func TestUserPasswordReset(t *testing.T) {
 
 func TestUserDelete(t *testing.T) {
 	// Initialize:
-	db := ovpm.CreateDB("sqlite3", ":memory:")
+	db := ovpm.CreateTestDB()
 	defer db.Cease()
 	ovpm.TheServer().Init("localhost", "", ovpm.UDPProto, "", "", "", "", false)
 
@@ -226,7 +227,7 @@ func TestUserDelete(t *testing.T) {
 
 func TestUserGet(t *testing.T) {
 	// Initialize:
-	db := ovpm.CreateDB("sqlite3", ":memory:")
+	db := ovpm.CreateTestDB()
 	defer db.Cease()
 	ovpm.TheServer().Init("localhost", "", ovpm.UDPProto, "", "", "", "", false)
 
@@ -250,7 +251,7 @@ func TestUserGet(t *testing.T) {
 
 func TestUserGetAll(t *testing.T) {
 	// Initialize:
-	db := ovpm.CreateDB("sqlite3", ":memory:")
+	db := ovpm.CreateTestDB()
 	defer db.Cease()
 	ovpm.TheServer().Init("localhost", "", ovpm.UDPProto, "", "", "", "", false)
 	count := 5
@@ -288,7 +289,7 @@ func TestUserGetAll(t *testing.T) {
 
 func TestUserRenew(t *testing.T) {
 	// Initialize:
-	db := ovpm.CreateDB("sqlite3", ":memory:")
+	db := ovpm.CreateTestDB()
 	defer db.Cease()
 	svr := ovpm.TheServer()
 	svr.Init("localhost", "", ovpm.UDPProto, "", "", "", "", false)
@@ -311,7 +312,7 @@ func TestUserRenew(t *testing.T) {
 
 func TestUserIPAllocator(t *testing.T) {
 	// Initialize:
-	db := ovpm.CreateDB("sqlite3", ":memory:")
+	db := ovpm.CreateTestDB()
 	defer db.Cease()
 	svr := ovpm.TheServer()
 	svr.Init("localhost", "", ovpm.UDPProto, "", "", "", "", false)
@@ -346,9 +347,80 @@ func TestUserIPAllocator(t *testing.T) {
 		}
 	}
 }
+
+func TestUserIPLeases(t *testing.T) {
+	// Initialize:
+	db := ovpm.CreateTestDB()
+	defer db.Cease()
+	svr := ovpm.TheServer()
+	svr.Init("localhost", "", ovpm.UDPProto, "10.9.0.0/29", "", "", "", false)
+
+	// Prepare:
+	for _, username := range []string{"user1", "user2", "user3", "user4"} {
+		ovpm.CreateNewUser(username, "pass", false, 0, true, "description")
+	}
+	ipOf := func(username string) string {
+		user, err := ovpm.GetUser(username)
+		if err != nil {
+			t.Fatalf("can not get user %s: %v", username, err)
+		}
+		return user.GetIPNet()
+	}
+
+	// Test:
+	user2, _ := ovpm.GetUser("user2")
+	user2.Delete()
+	if ipOf("user3") != "10.9.0.4/29" || ipOf("user4") != "10.9.0.5/29" {
+		t.Errorf("addresses of the other users are not expected to change on delete: %s %s", ipOf("user3"), ipOf("user4"))
+	}
+	ovpm.CreateNewUser("user5", "pass", false, 0, true, "description")
+	if ipOf("user5") != "10.9.0.3/29" {
+		t.Errorf("address of the deleted user is expected to be reused, got %s", ipOf("user5"))
+	}
+
+	// Static address that is leased to another user.
+	if _, err := ovpm.CreateNewUser("user6", "pass", false, ovpm.IP2HostID(net.ParseIP("10.9.0.4").To4()), true, "description"); err == nil || !strings.Contains(err.Error(), "user3") {
+		t.Errorf("address leased to user3 is not expected to be given as a static address: %v", err)
+	}
+	if ipOf("user3") != "10.9.0.4/29" {
+		t.Errorf("lease of user3 is not expected to change, got %s", ipOf("user3"))
+	}
+	user3, _ := ovpm.GetUser("user3")
+	if err := user3.Update("", false, ovpm.IP2HostID(net.ParseIP("10.9.0.5").To4()), true, "description"); err == nil {
+		t.Error("address leased to user4 is not expected to be given as a static address")
+	}
+	user6, err := ovpm.CreateNewUser("user6", "pass", false, ovpm.IP2HostID(net.ParseIP("10.9.0.6").To4()), true, "description")
+	if err != nil {
+		t.Fatalf("user with a static address can not be created: %v", err)
+	}
+	if user6.GetIPNet() != "10.9.0.6/29" {
+		t.Errorf("user is expected to have the static address, got %s", user6.GetIPNet())
+	}
+	if _, err := ovpm.CreateNewUser("user7", "pass", false, 0, true, "description"); err == nil {
+		t.Error("user is not expected to be created when the pool is exhausted")
+	}
+	if _, err := ovpm.GetUser("user7"); err == nil {
+		t.Error("user without an address is not expected to be kept")
+	}
+
+	// Static addresses are given back to the pool.
+	user6.Update("", false, 0, true, "description")
+	if ipOf("user6") != "10.9.0.6/29" {
+		t.Errorf("user is expected to be leased the free address, got %s", ipOf("user6"))
+	}
+	// Users can keep their leased addresses as static ones.
+	user1, _ := ovpm.GetUser("user1")
+	if err := user1.Update("", false, ovpm.IP2HostID(net.ParseIP("10.9.0.2").To4()), true, "description"); err != nil {
+		t.Errorf("user is expected to get its own address as a static one: %v", err)
+	}
+	if ipOf("user1") != "10.9.0.2/29" || ipOf("user3") != "10.9.0.4/29" {
+		t.Errorf("addresses are not expected to change: %s %s", ipOf("user1"), ipOf("user3"))
+	}
+}
+
 func TestUser_ExpiresAt(t *testing.T) {
 	// Initialize:
-	db := ovpm.CreateDB("sqlite3", ":memory:")
+	db := ovpm.CreateTestDB()
 	defer db.Cease()
 	svr := ovpm.TheServer()
 	svr.Init("localhost", "", ovpm.UDPProto, "", "", "", "", false)
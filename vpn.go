@@ -18,7 +18,6 @@ import (
 	"github.com/GoldenRUS/ovpm/pki"
 	"github.com/GoldenRUS/ovpm/supervisor"
 	"github.com/asaskevich/govalidator"
-	"github.com/coreos/go-iptables/iptables"
 	"github.com/google/uuid"
 	"github.com/jinzhu/gorm"
 	"github.com/sirupsen/logrus"
@@ -39,54 +38,183 @@ type dbServerModel struct {
 	Hostname         string // Server's ip address or FQDN
 	Port             string // Server's listening port
 	Proto            string // Server's proto udp or tcp
-	Cert             string // Server RSA certificate.
-	Key              string // Server RSA private key.
-	CACert           string // Root CA RSA certificate.
-	CAKey            string // Root CA RSA key.
+	Cert             string `gorm:"type:text"` // Server RSA certificate.
+	Key              string `gorm:"type:text"` // Server RSA private key.
+	CACert           string `gorm:"type:text"` // Root CA RSA certificate.
+	CAKey            string `gorm:"type:text"` // Root CA RSA key.
 	Net              string // VPN network.
 	Mask             string // VPN network mask.
-	CRL              string // Certificate Revocation List
-	DNS              string // DNS servers to push to the clients.
+	CRL              string `gorm:"type:text"` // Certificate Revocation List
+	DNS              string // Comma separated DNS servers to push to the clients.
+	DNSDomain        string // DOMAIN option pushed to the clients.
+	DNSSearch        string // Comma separated DOMAIN-SEARCH options pushed to the clients.
 	KeepalivePeriod  string // Keepalive ping period
 	KeepaliveTimeout string // Keepalive timeout
 	UseLZO           bool   // Use LZO compression
+
+	KeyAlgorithm     string // Algorithm of the keys, see pki.KeyAlgorithm. Empty means RSA.
+	RSAKeySize       int    // Size of the RSA keys in bits. Zero means the pki default.
+	CertValidityDays int    // Validity period of the certificates. Zero means the pki default.
+
+	DHParams string `gorm:"type:text"` // DH parameters generated for the server.
+	ECDHOnly bool   // Run with "dh none", DH parameters are not used.
+
+	TLSKey     string `gorm:"type:text"` // OpenVPN static key of the control channel.
+	TLSKeyMode string // TLSCryptMode, TLSAuthMode or NoTLSKeyMode. Empty means NoTLSKeyMode.
+
+	DataCiphers   string // Colon separated data channel ciphers. Empty means DefaultDataCiphers.
+	AuthDigest    string // HMAC digest. Empty means DefaultAuthDigest.
+	TLSVersionMin string // Minimum TLS version. Empty means DefaultTLSVersionMin.
+
+	ServerDirectives string `gorm:"type:text"` // Extra directives appended to server.conf.
+	ClientDirectives string `gorm:"type:text"` // Extra directives appended to the client profiles.
+
+	IPv6Net string // IPv6 VPN network in the CIDR form. Empty means IPv4 only.
+
+	Resolver bool // Run the built-in resolver on the VPN gateway and push it to the clients as their DNS.
+
+	NextCACert          string     `gorm:"type:text"` // CA that replaces the current one at the end of the CA rotation.
+	NextCAKey           string     `gorm:"type:text"` // Key of the next CA.
+	CARotationStartedAt *time.Time // Start of the ongoing CA rotation.
 }
 
-var serverInstance *Server
-var once sync.Once
+var (
+	servers   = make(map[string]*Server)
+	serversMu sync.Mutex
+)
 
 // Server represents VPN server.
 type Server struct {
 	dbServerModel
 
+	name    string
 	webPort string
 
+	// proc is the OpenVPN process of the server that is managed by the ovpm supervisor.
+	proc supervisor.Supervisable
+
+	// State of the DH parameter generation, see DHParamsStatus.
+	dhMu        sync.Mutex
+	dhSerial    string // Serial number of the server that the parameters are being generated for.
+	dhStartedAt time.Time
+	dhErr       error
+
+	monitorMu   sync.Mutex
+	management  *Management
+	fileWatcher *FileWatcher
+	resolver    *resolverRunner
+
 	emitToFileFunc     func(path, content string, mode uint) error
 	openFunc           func(path string) (io.Reader, error)
 	parseStatusLogFunc func(f io.Reader) ([]clEntry, []rtEntry)
 }
 
-// TheServer returns a pointer to the server instance.
-//
-// Server instance is a singleton instance that is initialized
-// on the first call made to the TheServer().
+// newVPNProcFunc creates the OpenVPN process of the given server.
+var newVPNProcFunc = newVPNProc
+
+// TheServer returns a pointer to the default server instance.
 func TheServer() *Server {
-	once.Do(func() {
+	return GetServer(DefaultServerName)
+}
+
+// GetServer returns a pointer to the server instance with the given name.
+//
+// Server instances are singletons that are initialized on the first call made
+// to the GetServer(). The returned server is not necessarily initialized,
+// which can be checked with IsInitialized().
+//
+// If name is empty, the default server is returned.
+func GetServer(name string) *Server {
+	if name == "" {
+		name = DefaultServerName
+	}
+
+	serversMu.Lock()
+	svr, ok := servers[name]
+	if !ok {
 		// Initialize the server instance by setting default mockable funcs & attributes.
-		serverInstance = &Server{
+		svr = &Server{
+			name:           name,
 			emitToFileFunc: emitToFile,
 			openFunc: func(path string) (io.Reader, error) {
 				return os.Open(path)
 			},
 			parseStatusLogFunc: parseStatusLog,
 		}
-	})
+		svr.dbServerModel.Name = name
+		svr.proc = newVPNProcFunc(svr)
+		servers[name] = svr
+	}
+	serversMu.Unlock()
+
 	if db != nil {
-		serverInstance.Refresh()
+		svr.Refresh()
 	} else {
 		logrus.Warn("database is not connected yet. skipping server instance refresh")
 	}
-	return serverInstance
+	return svr
+}
+
+// LookupServer returns the server with the given name, if it's in the db.
+//
+// Unlike GetServer, it doesn't create an instance for a name that it doesn't
+// know, so it should be used for the names that come from the users. The
+// default server is returned even if it's not initialized yet. If name is
+// empty, the default server is returned.
+func LookupServer(name string) (*Server, error) {
+	if name == "" || name == DefaultServerName {
+		return TheServer(), nil
+	}
+	var server dbServerModel
+	q := db.Where(&dbServerModel{Name: name}).First(&server)
+	if q.RecordNotFound() {
+		return nil, fmt.Errorf("server not found: %s", name)
+	}
+	if err := q.Error; err != nil {
+		return nil, fmt.Errorf("can't get server from db: %v", err)
+	}
+	return GetServer(server.Name), nil
+}
+
+// GetAllServers returns all of the initialized servers.
+func GetAllServers() []*Server {
+	var dbServers []*dbServerModel
+	db.Order("id").Find(&dbServers)
+
+	var svrs []*Server
+	for _, s := range dbServers {
+		svrs = append(svrs, GetServer(s.Name))
+	}
+	return svrs
+}
+
+// getServerByID returns the initialized server with the given id.
+func getServerByID(id uint) (*Server, error) {
+	var server dbServerModel
+	q := db.First(&server, id)
+	if q.RecordNotFound() {
+		return nil, fmt.Errorf("server not found: %d", id)
+	}
+	if err := q.Error; err != nil {
+		return nil, fmt.Errorf("can't get server from db: %v", err)
+	}
+	return GetServer(server.Name), nil
+}
+
+// isAnyServerInitialized checks if there is at least one initialized server.
+func isAnyServerInitialized() bool {
+	var count int
+	db.Model(&dbServerModel{}).Count(&count)
+	return count > 0
+}
+
+// restartAllServers emits the configs of all initialized servers and restarts them.
+func restartAllServers() {
+	for _, svr := range GetAllServers() {
+		if err := svr.EmitWithRestart(); err != nil {
+			logrus.Errorf("can not restart server %s: %v", svr.GetServerName(), err)
+		}
+	}
 }
 
 // CheckSerial takes a serial number and checks it against the current server's serial number.
@@ -101,10 +229,12 @@ func (svr *Server) GetSerialNumber() string {
 
 // GetServerName returns server's name.
 func (svr *Server) GetServerName() string {
-	if svr.Name != "" {
-		return svr.Name
-	}
-	return "default"
+	return svr.name
+}
+
+// IsDefault tells whether this is the default server.
+func (svr *Server) IsDefault() bool {
+	return svr.name == DefaultServerName
 }
 
 // GetHostname returns vpn server's hostname.
@@ -183,7 +313,7 @@ func (svr *Server) GetCRL() string {
 	return svr.CRL
 }
 
-// GetDNS returns vpn server's dns.
+// GetDNS returns vpn server's dns servers separated by commas.
 func (svr *Server) GetDNS() string {
 	if svr.DNS != "" {
 		return svr.DNS
@@ -191,6 +321,30 @@ func (svr *Server) GetDNS() string {
 	return DefaultVPNDNS
 }
 
+// GetDNSDomain returns the DOMAIN option pushed to the clients.
+func (svr *Server) GetDNSDomain() string {
+	return svr.DNSDomain
+}
+
+// GetDNSSearch returns the DOMAIN-SEARCH options pushed to the clients separated by commas.
+func (svr *Server) GetDNSSearch() string {
+	return svr.DNSSearch
+}
+
+// GetKeyAlgorithm returns the algorithm of the keys of the server.
+func (svr *Server) GetKeyAlgorithm() string {
+	if svr.KeyAlgorithm == "" {
+		return string(pki.RSAKey)
+	}
+	return svr.KeyAlgorithm
+}
+
+// GetCertValidityDays returns the number of days the certificates issued for the server
+// are valid, or 0 if they are valid for the default period.
+func (svr *Server) GetCertValidityDays() int {
+	return svr.CertValidityDays
+}
+
 // GetCreatedAt returns server's created at.
 func (svr *Server) GetCreatedAt() string {
 	return svr.CreatedAt.Format(time.UnixDate)
@@ -214,6 +368,73 @@ func (svr *Server) IsUseLZO() bool {
 	return svr.UseLZO
 }
 
+// ServerOption sets an optional attribute of a VPN server.
+//
+// Options are accepted by both Init and Update.
+type ServerOption func(s *dbServerModel) error
+
+// WithSharedCA makes the server use the CA of the server with the given name
+// instead of generating a new one.
+//
+// Users of a server can't connect to the other servers sharing the same CA,
+// because OpenVPN only accepts the clients that it has a ccd file for.
+//
+// Passing the name of the server itself on Init keeps its current CA, so that the
+// existing client certificates stay valid.
+func WithSharedCA(name string) ServerOption {
+	return func(s *dbServerModel) error {
+		src, err := LookupServer(name)
+		if err != nil || !src.IsInitialized() {
+			return fmt.Errorf("validation error: server to share the CA with is not found: %s", name)
+		}
+		s.CACert = src.CACert
+		s.CAKey = src.CAKey
+		return nil
+	}
+}
+
+// WithKeyAlgorithm sets the algorithm of the keys that are generated for the server.
+//
+// rsaKeySize is only used with pki.RSAKey and zero means the default size.
+// Keys of an initialized server can't be changed by Update, since the CA keeps its key.
+func WithKeyAlgorithm(alg string, rsaKeySize int) ServerOption {
+	return func(s *dbServerModel) error {
+		keyAlg, err := pki.ParseKeyAlgorithm(alg)
+		if err != nil {
+			return fmt.Errorf("validation error: %v", err)
+		}
+		if keyAlg != pki.RSAKey {
+			rsaKeySize = 0
+		}
+		if rsaKeySize != 0 && (rsaKeySize < pki.MinRSAKeySize || rsaKeySize%8 != 0) {
+			return fmt.Errorf("validation error: rsa key size `%d` should be a multiple of 8 and at least %d", rsaKeySize, pki.MinRSAKeySize)
+		}
+		s.KeyAlgorithm = string(keyAlg)
+		s.RSAKeySize = rsaKeySize
+		return nil
+	}
+}
+
+// WithCertValidity sets the number of days the certificates issued for the server
+// and its users are valid. Zero means the default validity. The CA keeps the default.
+//
+// Validities that aren't longer than the renew threshold are refused, as the
+// certificates would be renewed on every check.
+//
+// It only affects the certificates that are issued afterwards.
+func WithCertValidity(days int) ServerOption {
+	return func(s *dbServerModel) error {
+		if days < 0 {
+			return fmt.Errorf("validation error: cert validity `%d` can not be negative", days)
+		}
+		if threshold := GetConfig().CertRenewThreshold; days != 0 && time.Duration(days)*24*time.Hour <= threshold {
+			return fmt.Errorf("validation error: cert validity `%d` days should be longer than the renew threshold %s", days, threshold)
+		}
+		s.CertValidityDays = days
+		return nil
+	}
+}
+
 // Init regenerates keys and certs for a Root CA, gets initial settings for the VPN server
 // and saves them in the database.
 //
@@ -231,9 +452,12 @@ func (svr *Server) IsUseLZO() bool {
 // 'useLZO' is used to determine whether to use the lzo compression algorithm to support older clients.
 // It defaults to false due to security issues and deprecation
 //
+// Port and protocol pair as well as the VPN network of the server can't be shared
+// with the other servers.
+//
 // Please note that, Init is potentially destructive procedure, it will cause invalidation of
 // existing .ovpn profiles of the current users. So it should be used carefully.
-func (svr *Server) Init(hostname string, port string, proto string, ipblock string, dns string, keepalivePeriod string, keepaliveTimeout string, useLZO bool) error {
+func (svr *Server) Init(hostname string, port string, proto string, ipblock string, dns string, keepalivePeriod string, keepaliveTimeout string, useLZO bool, opts ...ServerOption) error {
 	if port == "" {
 		port = DefaultVPNPort
 	}
@@ -261,6 +485,10 @@ func (svr *Server) Init(hostname string, port string, proto string, ipblock stri
 		return fmt.Errorf("validation error: proto:`%s` should be either 'tcp' or 'udp'", proto)
 	}
 
+	if !govalidator.Matches(svr.name, "^([\\w\\.]+)$") { // allow alphanumeric, underscore and dot
+		return fmt.Errorf("validation error: server name `%s` can only contain letters, numbers, underscores and dots", svr.name)
+	}
+
 	// vpn network to use.
 	var ipnet *net.IPNet
 
@@ -300,59 +528,90 @@ func (svr *Server) Init(hostname string, port string, proto string, ipblock stri
 		return fmt.Errorf("validation error: keepalivePeriod:`%s` should be numeric", keepalivePeriod)
 	}
 
-	serverName := "default"
-	if svr := TheServer(); svr.IsInitialized() {
-		if err := svr.Deinit(); err != nil {
-			logrus.Errorf("server can not be deleted: %v", err)
-			return err
-		}
-	}
-
 	if !govalidator.IsHost(hostname) {
 		return fmt.Errorf("validation error: hostname:`%s` should be either an ip address or a FQDN", hostname)
 	}
 
-	if !govalidator.IsIPv4(dns) {
-		return fmt.Errorf("validation error: dns:`%s` should be an ip address", dns)
-	}
-
-	ca, err := pki.NewCA()
+	dnsOpts, err := DNSOptions{}.With(dns, "", "")
 	if err != nil {
-		return fmt.Errorf("can not create ca creds: %s", err)
+		return err
 	}
 
-	srv, err := pki.NewServerCertHolder(ca)
-	if err != nil {
-		return fmt.Errorf("can not create server cert creds: %s", err)
+	// Check if the other servers are conflicting with this one.
+	for _, other := range GetAllServers() {
+		if other.name == svr.name {
+			continue
+		}
+		if other.GetPort() == port && other.GetProto() == proto {
+			return fmt.Errorf("validation error: %s/%s is already used by the server %s", port, proto, other.name)
+		}
+		if otherNet := other.ipNet(); otherNet.Contains(ipnet.IP) || ipnet.Contains(otherNet.IP) {
+			return fmt.Errorf("validation error: ipblock:`%s` overlaps with the network of the server %s", ipnet, other.name)
+		}
 	}
 
 	serialNumber := uuid.New().String()
 	serverInstance := dbServerModel{
-		Name: serverName,
+		Name: svr.name,
 
 		SerialNumber:     serialNumber,
 		Hostname:         hostname,
 		Proto:            proto,
 		Port:             port,
-		Cert:             srv.Cert,
-		Key:              srv.Key,
-		CACert:           ca.Cert,
-		CAKey:            ca.Key,
 		Net:              ipnet.IP.To4().String(),
 		Mask:             net.IP(ipnet.Mask).To4().String(),
-		DNS:              dns,
+		DNS:              strings.Join(dnsOpts.Servers, ","),
 		KeepalivePeriod:  keepalivePeriod,
 		KeepaliveTimeout: keepaliveTimeout,
 		UseLZO:           useLZO,
 	}
+	// New servers protect their control channels unless told otherwise.
+	opts = append([]ServerOption{WithTLSKeyMode(TLSCryptMode)}, opts...)
+	for _, opt := range opts {
+		if err := opt(&serverInstance); err != nil {
+			return err
+		}
+	}
+	if err := svr.checkIPv6Net(&serverInstance); err != nil {
+		return err
+	}
 
-	db.Create(&serverInstance)
+	ca := &pki.CA{CertHolder: pki.CertHolder{Cert: serverInstance.CACert, Key: serverInstance.CAKey}}
+	if serverInstance.CACert == "" {
+		var err error
+		ca, err = pki.NewCA(serverInstance.caOptions()...)
+		if err != nil {
+			return fmt.Errorf("can not create ca creds: %s", err)
+		}
+	}
+
+	srv, err := pki.NewServerCertHolder(ca, serverInstance.pkiOptions()...)
+	if err != nil {
+		return fmt.Errorf("can not create server cert creds: %s", err)
+	}
+	serverInstance.Cert = srv.Cert
+	serverInstance.Key = srv.Key
+	serverInstance.CACert = ca.Cert
+	serverInstance.CAKey = ca.Key
+
+	// Re-initialization keeps the database record of the server,
+	// so the users stay assigned to it.
+	if svr.IsInitialized() {
+		serverInstance.Model = svr.dbServerModel.Model
+		if svr.CACert != serverInstance.CACert {
+			// Revoked certificates belong to the old CA.
+			svr.revokedQuery().Unscoped().Delete(&dbRevokedModel{})
+		}
+	}
+
+	db.Save(&serverInstance)
 
 	if db.NewRecord(&serverInstance) {
 		return fmt.Errorf("can not create server instance on database")
 	}
+	svr.dbServerModel = serverInstance
 
-	users, err := GetAllUsers()
+	users, err := svr.GetUsers()
 	if err != nil {
 		return err
 	}
@@ -366,71 +625,186 @@ func (svr *Server) Init(hostname string, port string, proto string, ipblock stri
 		}
 		// Set dynamic ip to user.
 		user.HostID = 0
+		user.ServerID = serverInstance.ID
 		db.Save(&user.dbUserModel)
+		user.releaseLease()
 	}
-	TheServer().EmitWithRestart()
-	logrus.Infof("server initialized")
+	svr.EmitWithRestart()
+	logrus.Infof("server initialized: %s", svr.name)
 	return nil
 }
 
 // Update updates VPN server attributes.
-func (svr *Server) Update(ipblock string, dns string, useLzo *bool) error {
+func (svr *Server) Update(ipblock string, dns string, useLzo *bool, opts ...ServerOption) error {
 	if !svr.IsInitialized() {
 		return fmt.Errorf("server is not initialized")
 	}
 
-	var changed bool
+	var changed, netChanged bool
+	var oldNet *net.IPNet
 	if ipblock != "" && govalidator.IsCIDR(ipblock) {
 		var ipnet *net.IPNet
 		_, ipnet, err := net.ParseCIDR(ipblock)
 		if err != nil {
 			return fmt.Errorf("can not parse CIDR %s: %v", ipblock, err)
 		}
+		// Not using GetAllServers, it would refresh the server instances.
+		var others []dbServerModel
+		if err := db.Where("name <> ?", svr.name).Find(&others).Error; err != nil {
+			return err
+		}
+		for _, other := range others {
+			if otherNet := other.ipNet(); otherNet.Contains(ipnet.IP) || ipnet.Contains(otherNet.IP) {
+				return fmt.Errorf("validation error: ipblock:`%s` overlaps with the network of the server %s", ipnet, other.Name)
+			}
+		}
+		netChanged = svr.ipNet().String() != ipnet.String()
+		oldNet = svr.ipNet()
 		svr.dbServerModel.Net = ipnet.IP.To4().String()
 		svr.dbServerModel.Mask = net.IP(ipnet.Mask).To4().String()
 		changed = true
 	}
 
-	if dns != "" && govalidator.IsIPv4(dns) {
-		svr.dbServerModel.DNS = dns
+	if dns != "" {
+		if err := WithDNSOptions(dns, "", "")(&svr.dbServerModel); err != nil {
+			svr.Refresh()
+			return err
+		}
 		changed = true
 	}
 	if useLzo != nil {
 		svr.dbServerModel.UseLZO = *useLzo
 		changed = true
 	}
-	if changed {
-		db.Save(svr.dbServerModel)
-		users, err := GetAllUsers()
-		if err != nil {
+	for _, opt := range opts {
+		caCert, keyAlg, rsaKeySize := svr.CACert, svr.KeyAlgorithm, svr.RSAKeySize
+		if err := opt(&svr.dbServerModel); err != nil {
+			svr.Refresh()
 			return err
 		}
-
-		// Set all users to dynamic ip address.
-		// This way we prevent any ip range mismatch.
-		for _, user := range users {
-			user.HostID = 0
-			db.Save(user.dbUserModel)
+		if svr.CACert != caCert {
+			svr.Refresh()
+			return fmt.Errorf("CA of an initialized server can not be changed, the server should be initialized again")
+		}
+		if svr.KeyAlgorithm != keyAlg || svr.RSAKeySize != rsaKeySize {
+			svr.Refresh()
+			return fmt.Errorf("key algorithm of an initialized server can not be changed, the server should be initialized again")
+		}
+		changed = true
+	}
+	if err := svr.checkIPv6Net(&svr.dbServerModel); err != nil {
+		svr.Refresh()
+		return err
+	}
+	if netChanged {
+		if err := svr.checkPoolsFit(oldNet, svr.ipNet()); err != nil {
+			svr.Refresh()
+			return err
+		}
+	}
+	if changed {
+		db.Save(&svr.dbServerModel)
+		if netChanged {
+			if err := svr.moveAddresses(oldNet); err != nil {
+				return fmt.Errorf("can not move the addresses of the users to the new network: %v", err)
+			}
 		}
 
 		svr.EmitWithRestart()
-		logrus.Infof("server updated")
+		logrus.Infof("server updated: %s", svr.name)
 	}
 	return nil
 }
 
 // Deinit deletes the VPN server from the database and frees the allocated resources.
+//
+// Users of the default server are kept, so that they are signed again when it's
+// initialized again. Other servers can't be deleted before their users are moved
+// to another server or deleted.
 func (svr *Server) Deinit() error {
 	if !svr.IsInitialized() {
 		return fmt.Errorf("server not found")
 	}
 
-	db.Unscoped().Delete(&dbServerModel{})
-	db.Unscoped().Delete(&dbRevokedModel{})
-	svr.EmitWithRestart()
+	users, err := svr.GetUsers()
+	if err != nil {
+		return err
+	}
+	if !svr.IsDefault() && len(users) > 0 {
+		return fmt.Errorf("server %s still has %d user(s), they should be moved to another server or deleted first", svr.name, len(users))
+	}
+	db.Model(&dbUserModel{}).Where("server_id = ?", svr.ID).Update("server_id", 0)
+
+	svr.revokedQuery().Unscoped().Delete(&dbRevokedModel{})
+	svr.releaseLeases()
+	svr.deletePools()
+	db.Unscoped().Delete(&svr.dbServerModel)
+	svr.CloseMonitors()
+	if svr.proc != nil && svr.proc.Status() == supervisor.RUNNING {
+		svr.proc.Stop()
+	}
+	if err := reconcileFirewall(); err != nil {
+		logrus.Errorf("can not remove the firewall rules of the server %s: %v", svr.name, err)
+	}
+	svr.Refresh()
+	logrus.Infof("server deinitialized: %s", svr.name)
 	return nil
 }
 
+// GetUsers returns the users of the server.
+func (svr *Server) GetUsers() ([]*User, error) {
+	var users []*User
+	var dbUsers []*dbUserModel
+	if err := svr.usersQuery().Order("id").Find(&dbUsers).Error; err != nil {
+		return nil, err
+	}
+	for _, u := range dbUsers {
+		users = append(users, &User{dbUserModel: *u})
+	}
+	return users, nil
+}
+
+// usersQuery returns a query that selects the users of the server.
+func (svr *Server) usersQuery() *gorm.DB {
+	if svr.IsDefault() {
+		// Users of the default server might not have a server id. e.g. the ones
+		// that are created before the introduction of multiple servers.
+		return db.Where("server_id = ? OR server_id = 0", svr.ID)
+	}
+	if svr.ID == 0 {
+		// Server is not initialized, so it can't have users.
+		return db.Where("1 = 0")
+	}
+	return db.Where("server_id = ?", svr.ID)
+}
+
+// revokedQuery returns a query that selects the revoked certificates of the server.
+func (svr *Server) revokedQuery() *gorm.DB {
+	if svr.IsDefault() {
+		return db.Where("server_id = ? OR server_id = 0", svr.ID)
+	}
+	return db.Where("server_id = ?", svr.ID)
+}
+
+// ipNet returns the VPN network of the server.
+func (s *dbServerModel) ipNet() *net.IPNet {
+	return &net.IPNet{IP: net.ParseIP(s.Net).To4(), Mask: net.IPMask(net.ParseIP(s.Mask).To4())}
+}
+
+// dir returns the directory that the OpenVPN files of the server are emitted to.
+func (svr *Server) dir() string {
+	varDir := GetConfig().VarDir
+	if svr.IsDefault() {
+		return varDir
+	}
+	return filepath.Join(varDir, "servers", svr.name) + "/"
+}
+
+// path returns the path of the named OpenVPN file of the server.
+func (svr *Server) path(name string) string {
+	return filepath.Join(svr.dir(), name)
+}
+
 // DumpsClientConfig generates .ovpn file for the given vpn user and returns it as a string.
 func (svr *Server) DumpsClientConfig(username string) (string, error) {
 	var result bytes.Buffer
@@ -438,6 +812,9 @@ func (svr *Server) DumpsClientConfig(username string) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	if user.GetServer() != svr {
+		return "", fmt.Errorf("user %s doesn't belong to the server %s", username, svr.name)
+	}
 
 	params := struct {
 		Hostname         string
@@ -450,10 +827,16 @@ func (svr *Server) DumpsClientConfig(username string) (string, error) {
 		KeepalivePeriod  string
 		KeepaliveTimeout string
 		UseLZO           bool
+		TLSKeyMode       string
+		TLSKey           string
+		DataCiphers      string
+		AuthDigest       string
+		TLSVersionMin    string
+		ExtraDirectives  string
 	}{
 		Hostname:         svr.GetHostname(),
 		Port:             svr.GetPort(),
-		CA:               svr.GetCACert(),
+		CA:               svr.caBundle(),
 		Key:              user.getKey(),
 		Cert:             user.GetCert(),
 		NoGW:             user.IsNoGW(),
@@ -461,6 +844,12 @@ func (svr *Server) DumpsClientConfig(username string) (string, error) {
 		KeepalivePeriod:  svr.GetKeepalivePeriod(),
 		KeepaliveTimeout: svr.GetKeepaliveTimeout(),
 		UseLZO:           svr.IsUseLZO(),
+		TLSKeyMode:       svr.GetTLSKeyMode(),
+		TLSKey:           svr.TLSKey,
+		DataCiphers:      svr.GetDataCiphers(),
+		AuthDigest:       svr.GetAuthDigest(),
+		TLSVersionMin:    svr.GetTLSVersionMin(),
+		ExtraDirectives:  svr.ClientDirectives,
 	}
 
 	t, err := template.New("client.ovpn").Parse(clientOvpnTemplate)
@@ -473,6 +862,11 @@ func (svr *Server) DumpsClientConfig(username string) (string, error) {
 		return "", fmt.Errorf("can not render client.ovpn: %s", err)
 	}
 
+	// Keep track of the users who have their new profiles during a CA rotation.
+	now := time.Now()
+	user.ConfigFetchedAt = &now
+	db.Model(&user.dbUserModel).UpdateColumn("config_fetched_at", now)
+
 	return result.String(), nil
 }
 
@@ -487,10 +881,26 @@ func (svr *Server) DumpClientConfig(username, path string) error {
 
 }
 
+// pkiOptions returns the options that the keys and certificates of the server are generated with.
+func (s *dbServerModel) pkiOptions() []pki.Option {
+	return append(s.caOptions(), pki.WithValidity(time.Duration(s.CertValidityDays)*24*time.Hour))
+}
+
+// caOptions returns the options that the CA of the server is generated with.
+//
+// CAs are valid for the default validity of pki, since the certificates they
+// sign can't outlive them.
+func (s *dbServerModel) caOptions() []pki.Option {
+	return []pki.Option{
+		pki.WithKeyAlgorithm(pki.KeyAlgorithm(s.KeyAlgorithm)),
+		pki.WithRSAKeySize(s.RSAKeySize),
+	}
+}
+
 // GetSystemCA returns the system CA from the database if available.
 func (svr *Server) GetSystemCA() (*pki.CA, error) {
 	server := dbServerModel{}
-	db.First(&server)
+	db.Where(&dbServerModel{Name: svr.name}).First(&server)
 	if db.NewRecord(&server) {
 		return nil, fmt.Errorf("server record does not exists in db")
 	}
@@ -503,25 +913,25 @@ func (svr *Server) GetSystemCA() (*pki.CA, error) {
 
 }
 
-// vpnProc represents the OpenVPN process that is managed by the ovpm supervisor globally OpenVPN.
-var vpnProc supervisor.Supervisable
-
 // StartVPNProc starts the OpenVPN process.
 func (svr *Server) StartVPNProc() {
 	if !svr.IsInitialized() {
 		logrus.Error("can not launch OpenVPN because system is not initialized")
 		return
 	}
-	if vpnProc == nil {
-		panic(fmt.Sprintf("vpnProc is not initialized!"))
+	if svr.proc == nil {
+		panic(fmt.Sprintf("OpenVPN process of the server %s is not initialized!", svr.name))
 	}
-	if vpnProc.Status() == supervisor.RUNNING {
+	if svr.proc.Status() == supervisor.RUNNING {
 		logrus.Error("OpenVPN is already started")
 		return
 	}
 	svr.Emit()
-	vpnProc.Start()
-	ensureNatEnabled()
+	svr.proc.Start()
+	svr.ensureNatEnabled()
+	if err := svr.startMonitors(); err != nil {
+		logrus.Error(err)
+	}
 }
 
 // RestartVPNProc restarts the OpenVPN process.
@@ -530,24 +940,96 @@ func (svr *Server) RestartVPNProc() {
 		logrus.Error("can not launch OpenVPN because system is not initialized")
 		return
 	}
-	if vpnProc == nil {
-		panic(fmt.Sprintf("vpnProc is not initialized!"))
+	if svr.proc == nil {
+		panic(fmt.Sprintf("OpenVPN process of the server %s is not initialized!", svr.name))
 	}
 	svr.Emit()
-	vpnProc.Restart()
-	ensureNatEnabled()
+	svr.proc.Restart()
+	svr.ensureNatEnabled()
+	if err := svr.startMonitors(); err != nil {
+		logrus.Error(err)
+	}
 }
 
 // StopVPNProc stops the OpenVPN process.
 func (svr *Server) StopVPNProc() {
-	if vpnProc == nil {
-		panic(fmt.Sprintf("vpnProc is not initialized!"))
+	if svr.proc == nil {
+		panic(fmt.Sprintf("OpenVPN process of the server %s is not initialized!", svr.name))
 	}
-	if vpnProc.Status() != supervisor.RUNNING {
+	if svr.proc.Status() != supervisor.RUNNING {
 		logrus.Error("OpenVPN is already not running")
 		return
 	}
-	vpnProc.Stop()
+	svr.proc.Stop()
+}
+
+// startMonitors starts watching the status log and connects to the management
+// interface of the server if they are enabled and not started yet.
+func (svr *Server) startMonitors() error {
+	serversMu.Lock()
+	watch, manage, resolve := fileWatcherEnabled, managementEnabled, resolverEnabled
+	serversMu.Unlock()
+
+	svr.monitorMu.Lock()
+	defer svr.monitorMu.Unlock()
+
+	if manage && svr.management == nil {
+		m := NewManagement("unix", svr.path(managementFile))
+		m.OnClientEvent(logClientEvent)
+		svr.management = m
+		go m.Run()
+	}
+
+	if watch && svr.fileWatcher == nil && !Testing {
+		// OpenVPN might not have written the status log yet.
+		statusLogPath := svr.path(statusLogFile)
+		if _, err := os.Stat(statusLogPath); os.IsNotExist(err) {
+			os.MkdirAll(svr.dir(), 0755)
+			if f, err := os.Create(statusLogPath); err == nil {
+				f.Close()
+			}
+		}
+		fw, err := svr.newStatisticFileWatcher()
+		if err != nil {
+			return fmt.Errorf("can not watch the status log of the server %s: %v", svr.name, err)
+		}
+		svr.fileWatcher = fw
+		go fw.Watch()
+	}
+
+	if resolve && !Testing {
+		// Move the resolver along with the gateway if the network is changed.
+		addr := svr.resolverAddr()
+		if svr.resolver != nil && svr.resolver.addr != addr {
+			svr.resolver.Close()
+			svr.resolver = nil
+		}
+		if svr.resolver == nil && addr != "" {
+			svr.resolver = svr.newResolverRunner(addr)
+			go svr.resolver.Run()
+		}
+	}
+	return nil
+}
+
+// CloseMonitors stops watching the status log and disconnects from the management
+// interface of the server.
+func (svr *Server) CloseMonitors() {
+	svr.monitorMu.Lock()
+	defer svr.monitorMu.Unlock()
+
+	if svr.management != nil {
+		svr.management.Close()
+		svr.management = nil
+	}
+	if svr.fileWatcher != nil {
+		svr.fileWatcher.Close()
+		svr.fileWatcher = nil
+	}
+	if svr.resolver != nil {
+		svr.resolver.Close()
+		svr.resolver = nil
+	}
 }
 
 // Emit generates all needed files for the OpenVPN server and dumps them to their corresponding paths defined in the config.
@@ -562,14 +1044,20 @@ func (svr *Server) Emit() error {
 
 	}
 
-	if !checkIptablesExecutable() {
-		return fmt.Errorf("iptables executable can not be found")
+	if _, err := getFirewall(); err != nil {
+		return fmt.Errorf("firewall backend is not available: %v", err)
 	}
 
 	if !svr.IsInitialized() {
 		return fmt.Errorf("you should create a server first. e.g. $ ovpm vpn create-server")
 	}
 
+	if !Testing {
+		if err := os.MkdirAll(svr.dir(), 0755); err != nil {
+			return fmt.Errorf("can not create server directory: %s", err)
+		}
+	}
+
 	if err := svr.emitServerConf(); err != nil {
 		return fmt.Errorf("can not emit server conf: %s", err)
 	}
@@ -594,30 +1082,34 @@ func (svr *Server) Emit() error {
 		return fmt.Errorf("can not emit dhparams: %s", err)
 	}
 
+	if err := svr.emitTLSKey(); err != nil {
+		return fmt.Errorf("can not emit tls key: %s", err)
+	}
+
 	if err := svr.emitCCD(); err != nil {
 		return fmt.Errorf("can not emit ccd: %s", err)
 	}
 
-	if err := svr.emitIptables(); err != nil {
-		return fmt.Errorf("can not emit iptables: %s", err)
+	if err := reconcileFirewall(); err != nil {
+		return fmt.Errorf("can not emit firewall rules: %s", err)
 	}
 
 	if err := svr.emitCRL(); err != nil {
 		return fmt.Errorf("can not emit crl: %s", err)
 	}
 
-	logrus.Info("configurations emitted to the filesystem")
+	logrus.Infof("configurations of the server %s emitted to the filesystem", svr.name)
 	return nil
 }
 
-// EmitWithRestart restarts vpnProc after calling EmitWithRestart().
+// EmitWithRestart restarts the OpenVPN process after calling Emit().
 func (svr *Server) EmitWithRestart() error {
 	if err := svr.Emit(); err != nil {
 		return err
 	}
 	if svr.IsInitialized() {
 		for {
-			if vpnProc.Status() == supervisor.RUNNING || vpnProc.Status() == supervisor.STOPPED {
+			if svr.proc.Status() == supervisor.RUNNING || svr.proc.Status() == supervisor.STOPPED {
 				logrus.Info("OpenVPN process is restarting")
 				svr.RestartVPNProc()
 				break
@@ -650,26 +1142,13 @@ func emitToFile(path, content string, mode uint) error {
 		file.Chmod(os.FileMode(mode))
 	}
 	defer file.Close()
-	fmt.Fprintf(file, content)
+	if _, err := io.WriteString(file, content); err != nil {
+		return fmt.Errorf("Cannot write file %s: %v", path, err)
+	}
 	return nil
 }
 
 func (svr *Server) emitServerConf() error {
-	port := DefaultVPNPort
-	if serverInstance.Port != "" {
-		port = serverInstance.Port
-	}
-
-	proto := DefaultVPNProto
-	if serverInstance.Proto != "" {
-		proto = serverInstance.Proto
-	}
-
-	dns := DefaultVPNDNS
-	if serverInstance.DNS != "" {
-		dns = serverInstance.DNS
-	}
-
 	var result bytes.Buffer
 
 	server := struct {
@@ -680,30 +1159,66 @@ func (svr *Server) emitServerConf() error {
 		CCDPath          string
 		CRLPath          string
 		DHParamsPath     string
+		ECDHOnly         bool
+		TLSKeyMode       string
+		TLSKeyPath       string
+		ECDHCurve        string
+		DataCiphers      string
+		AuthDigest       string
+		TLSVersionMin    string
+		StatusLogPath    string
+		ManagementPath   string
 		Net              string
 		Mask             string
 		Port             string
 		Proto            string
-		DNS              string
+		DNSOptions       []string
+		IPv6Net          string
 		KeepalivePeriod  string
 		KeepaliveTimeout string
 		UseLZO           bool
+		SiteRoutes       [][2]string // [0] is IP, [1] is Netmask
+		SiteRoutes6      []string    // CIDRs
+		ExtraDirectives  string
 	}{
-		CertPath:         _DefaultCertPath,
-		KeyPath:          _DefaultKeyPath,
-		CACertPath:       _DefaultCACertPath,
-		CAKeyPath:        _DefaultCAKeyPath,
-		CCDPath:          _DefaultVPNCCDPath,
-		CRLPath:          _DefaultCRLPath,
-		DHParamsPath:     _DefaultDHParamsPath,
+		CertPath:         svr.path(certFile),
+		KeyPath:          svr.path(keyFile),
+		CACertPath:       svr.path(caCertFile),
+		CAKeyPath:        svr.path(caKeyFile),
+		CCDPath:          svr.path(vpnCCDDir),
+		CRLPath:          svr.path(crlFile),
+		DHParamsPath:     svr.path(dhParamsFile),
+		ECDHOnly:         svr.IsECDHOnly(),
+		TLSKeyMode:       svr.GetTLSKeyMode(),
+		TLSKeyPath:       svr.path(tlsKeyFile),
+		ECDHCurve:        pki.KeyAlgorithm(svr.GetKeyAlgorithm()).ECDHCurve(),
+		DataCiphers:      svr.GetDataCiphers(),
+		AuthDigest:       svr.GetAuthDigest(),
+		TLSVersionMin:    svr.GetTLSVersionMin(),
+		StatusLogPath:    svr.path(statusLogFile),
+		ManagementPath:   svr.path(managementFile),
 		Net:              svr.Net,
 		Mask:             svr.Mask,
-		Port:             port,
-		Proto:            proto,
-		DNS:              dns,
+		Port:             svr.GetPort(),
+		Proto:            svr.GetProto(),
+		DNSOptions:       svr.clientDNSOptions().pushOptions(),
+		IPv6Net:          svr.GetIPv6Net(),
 		KeepalivePeriod:  svr.GetKeepalivePeriod(),
 		KeepaliveTimeout: svr.GetKeepaliveTimeout(),
 		UseLZO:           svr.IsUseLZO(),
+		ExtraDirectives:  svr.ServerDirectives,
+	}
+	// Route the SITE networks to the vpn, the routers take them from there.
+	for _, network := range svr.siteNetworks() {
+		ip, mask, err := net.ParseCIDR(network.CIDR)
+		if err != nil {
+			return err
+		}
+		if ip.To4() != nil {
+			server.SiteRoutes = append(server.SiteRoutes, [2]string{ip.To4().String(), net.IP(mask.Mask).To4().String()})
+		} else if svr.ipv6Net() != nil {
+			server.SiteRoutes6 = append(server.SiteRoutes6, mask.String())
+		}
 	}
 
 	t, err := template.New("server.conf").Parse(serverConfTemplate)
@@ -717,21 +1232,21 @@ func (svr *Server) emitServerConf() error {
 	}
 
 	// Wite rendered content into openvpn server conf.
-	return svr.emitToFile(_DefaultVPNConfPath, result.String(), 0)
+	return svr.emitToFile(svr.path(vpnConfFile), result.String(), 0)
 }
 
 // Refresh synchronizes the server instance from db.
 func (svr *Server) Refresh() error {
-	//db = CreateDB("sqlite3", "")
 	var dbServer dbServerModel
 
-	q := db.First(&dbServer)
-	if err := q.Error; err != nil {
-		return fmt.Errorf("can't get server from db: %v", err)
-	}
+	q := db.Where(&dbServerModel{Name: svr.name}).First(&dbServer)
 	if q.RecordNotFound() {
+		svr.dbServerModel = dbServerModel{Name: svr.name}
 		return fmt.Errorf("server is not initialized")
 	}
+	if err := q.Error; err != nil {
+		return fmt.Errorf("can't get server from db: %v", err)
+	}
 	svr.dbServerModel = dbServer
 	return nil
 }
@@ -741,16 +1256,13 @@ func (svr *Server) Refresh() error {
 func (svr *Server) GetConnectedUsers() ([]User, error) {
 	var users []User
 
-	// Open the status log file.
-	f, err := svr.openFunc(_DefaultStatusLogPath)
+	cl, err := svr.clientList()
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
-
-	cl, _ := svr.parseStatusLogFunc(f) // client list from OpenVPN status log
 	for _, c := range cl {
 		var u dbUserModel
-		q := db.Where(dbUserModel{Username: c.CommonName}).First(&u)
+		q := svr.usersQuery().Where(&dbUserModel{Username: c.CommonName}).First(&u)
 		if q.RecordNotFound() {
 			logrus.WithFields(
 				logrus.Fields{"CommonName": c.CommonName},
@@ -772,14 +1284,41 @@ func (svr *Server) GetConnectedUsers() ([]User, error) {
 	return users, nil
 }
 
-// IsInitialized checks if there is a default VPN server configured in the database or not.
+// clientList returns the clients that are connected to the VPN server.
+//
+// Live data from the management interface is preferred. The status log is
+// used when the management interface is not reachable.
+func (svr *Server) clientList() ([]clEntry, error) {
+	if m := svr.Management(); m != nil && m.IsConnected() {
+		s, err := m.Status()
+		if err == nil {
+			return clEntriesFromStatus(s), nil
+		}
+		logrus.Debugf("can not get status from the management interface: %v", err)
+	}
+
+	// Open the status log file.
+	f, err := svr.openFunc(svr.path(statusLogFile))
+	if err != nil {
+		return nil, fmt.Errorf("can not open status log: %v", err)
+	}
+	if c, ok := f.(io.Closer); ok {
+		defer c.Close()
+	}
+
+	cl, _ := svr.parseStatusLogFunc(f) // client list from OpenVPN status log
+	return cl, nil
+}
+
+// IsInitialized checks if the VPN server is configured in the database or not.
 func (svr *Server) IsInitialized() bool {
 	var serverModel dbServerModel
-	q := db.First(&serverModel)
+	q := db.Where(&dbServerModel{Name: svr.name}).First(&serverModel)
+	if q.RecordNotFound() {
+		return false
+	}
 	if err := q.Error; err != nil {
 		logrus.Errorf("can't retrieve server from db: %v", err)
-	}
-	if q.RecordNotFound() {
 		return false
 	}
 	return true
@@ -787,12 +1326,12 @@ func (svr *Server) IsInitialized() bool {
 
 func (svr *Server) emitServerKey() error {
 	// Write rendered content into key file.
-	return svr.emitToFile(_DefaultKeyPath, svr.Key, 0600)
+	return svr.emitToFile(svr.path(keyFile), svr.Key, 0600)
 }
 
 func (svr *Server) emitServerCert() error {
 	// Write rendered content into the cert file.
-	return svr.emitToFile(_DefaultCertPath, svr.Cert, 0)
+	return svr.emitToFile(svr.path(certFile), svr.Cert, 0)
 }
 
 func (svr *Server) emitCRL() error {
@@ -813,29 +1352,40 @@ func (svr *Server) emitCRL() error {
 		return fmt.Errorf("can not emit crl: %v", err)
 	}
 
-	return svr.emitToFile(_DefaultCRLPath, crl, 0)
+	// OpenVPN rejects the clients of a CA that it has no CRL for.
+	if svr.IsRotatingCA() {
+		nextCA := &pki.CA{CertHolder: pki.CertHolder{Cert: svr.NextCACert, Key: svr.NextCAKey}}
+		nextCRL, err := pki.NewCRL(nextCA, revokedCertSerials...)
+		if err != nil {
+			return fmt.Errorf("can not emit crl: %v", err)
+		}
+		crl += nextCRL
+	}
+
+	return svr.emitToFile(svr.path(crlFile), crl, 0)
 }
 
 func (svr *Server) emitCACert() error {
 	// Write rendered content into the ca cert file.
-	return svr.emitToFile(_DefaultCACertPath, svr.CACert, 0)
+	return svr.emitToFile(svr.path(caCertFile), svr.caBundle(), 0)
 }
 
 func (svr *Server) emitCAKey() error {
 	// Write rendered content into the ca key file.
-	return svr.emitToFile(_DefaultCAKeyPath, svr.CAKey, 0600)
+	return svr.emitToFile(svr.path(caKeyFile), svr.CAKey, 0600)
 }
 
 func (svr *Server) emitCCD() error {
-	users, err := GetAllUsers()
+	users, err := svr.GetUsers()
 	if err != nil {
 		return err
 	}
+	ccdPath := svr.path(vpnCCDDir)
 
 	// Filesystem related stuff. Skipping when testing.
 	if !Testing {
 		// Clean and then create and write rendered ccd data.
-		err = os.RemoveAll(_DefaultVPNCCDPath)
+		err = os.RemoveAll(ccdPath)
 		if err != nil {
 			if os.IsNotExist(err) {
 			} else {
@@ -843,10 +1393,10 @@ func (svr *Server) emitCCD() error {
 			}
 		}
 
-		if _, err := os.Stat(_DefaultVPNCCDPath); err != nil {
+		if _, err := os.Stat(ccdPath); err != nil {
 		}
 
-		err = os.Mkdir(_DefaultVPNCCDPath, 0755)
+		err = os.Mkdir(ccdPath, 0755)
 		if err != nil {
 			if !os.IsExist(err) {
 				return err
@@ -854,45 +1404,104 @@ func (svr *Server) emitCCD() error {
 		}
 	}
 	// Render ccd templates for the users.
+	ipv6Net := svr.ipv6Net()
 	for _, user := range users {
 		var associatedRoutes [][3]string
 		var serverNets [][2]string
+		var associatedRoutes6 [][2]string
+		var serverNets6 []string
+		var iroutes [][2]string
+		var iroutes6 []string
+		var associatedNets []*Network
 		for _, network := range GetAllNetworks() {
+			associated := network.includes(user)
+			if associated {
+				associatedNets = append(associatedNets, network)
+			}
+			ip, mask, err := net.ParseCIDR(network.CIDR)
+			if err != nil {
+				return err
+			}
+			isIPv6 := ip.To4() == nil
+			if isIPv6 && ipv6Net == nil {
+				// IPv6 routes can't be pushed through an IPv4 only tunnel.
+				continue
+			}
 			switch network.Type {
 			case ROUTE:
-				for _, assocUsername := range network.GetAssociatedUsernames() {
-					if assocUsername == user.Username {
-						via := network.Via
-						ip, mask, err := net.ParseCIDR(network.CIDR)
-						if err != nil {
-							return err
-						}
+				if associated {
+					via := network.Via
+					if isIPv6 {
+						associatedRoutes6 = append(associatedRoutes6, [2]string{mask.String(), via})
+					} else {
 						associatedRoutes = append(associatedRoutes, [3]string{ip.To4().String(), net.IP(mask.Mask).To4().String(), via})
 					}
 				}
 			case SERVERNET:
 				// Push associated servernets to client when client is not getting vpn server as default gw.
-				if user.IsNoGW() {
-					for _, assocUsername := range network.GetAssociatedUsernames() {
-						if assocUsername == user.Username {
-							ip, mask, err := net.ParseCIDR(network.CIDR)
-							if err != nil {
-								return err
-							}
-							serverNets = append(serverNets, [2]string{ip.To4().String(), net.IP(mask.Mask).To4().String()})
-						}
+				if user.IsNoGW() && associated {
+					if isIPv6 {
+						serverNets6 = append(serverNets6, mask.String())
+					} else {
+						serverNets = append(serverNets, [2]string{ip.To4().String(), net.IP(mask.Mask).To4().String()})
+					}
+				}
+			case SITE:
+				router := network.getRouter()
+				if router == nil || router.GetServer().name != svr.name {
+					// Only the users of the router's server can reach the network.
+					continue
+				}
+				if router.ID == user.ID {
+					// The network is behind this user.
+					if isIPv6 {
+						iroutes6 = append(iroutes6, mask.String())
+					} else {
+						iroutes = append(iroutes, [2]string{ip.To4().String(), net.IP(mask.Mask).To4().String()})
+					}
+				} else if associated && network.PushRoute {
+					if isIPv6 {
+						serverNets6 = append(serverNets6, mask.String())
+					} else {
+						serverNets = append(serverNets, [2]string{ip.To4().String(), net.IP(mask.Mask).To4().String()})
 					}
 				}
 			}
 		}
+		var ipv6, ipv6Server string
+		if ipv6Net != nil {
+			ipv6, ipv6Server = user.GetIPv6Net(), svr.ipv6ServerIP().String()
+		}
 		var result bytes.Buffer
 		params := struct {
-			IP         string
-			NetMask    string
-			Routes     [][3]string // [0] is IP, [1] is Netmask, [2] is Via
-			Servernets [][2]string // [0] is IP, [1] is Netmask
-			RedirectGW bool
-		}{IP: user.getIP().String(), NetMask: svr.Mask, Routes: associatedRoutes, Servernets: serverNets, RedirectGW: !user.NoGW}
+			IP              string
+			NetMask         string
+			IPv6            string      // User's IPv6 address with the prefix length, empty if IPv4 only.
+			IPv6Server      string      // Server's IPv6 address.
+			Routes          [][3]string // [0] is IP, [1] is Netmask, [2] is Via
+			Servernets      [][2]string // [0] is IP, [1] is Netmask
+			Routes6         [][2]string // [0] is CIDR, [1] is Via
+			Servernets6     []string    // CIDRs
+			IRoutes         [][2]string // [0] is IP, [1] is Netmask
+			IRoutes6        []string    // CIDRs
+			RedirectGW      bool
+			DNSOptions      []string // dhcp-options replacing the ones of the server, if any.
+			ExtraDirectives string
+		}{
+			IP:              user.getIP().String(),
+			NetMask:         svr.Mask,
+			IPv6:            ipv6,
+			IPv6Server:      ipv6Server,
+			Routes:          associatedRoutes,
+			Servernets:      serverNets,
+			Routes6:         associatedRoutes6,
+			Servernets6:     serverNets6,
+			IRoutes:         iroutes,
+			IRoutes6:        iroutes6,
+			RedirectGW:      !user.NoGW,
+			DNSOptions:      user.dnsOverride(associatedNets).pushOptions(),
+			ExtraDirectives: user.CCDDirectives,
+		}
 
 		t, err := template.New("ccd.file.tmpl").Parse(ccdFileTemplate)
 		if err != nil {
@@ -903,87 +1512,83 @@ func (svr *Server) emitCCD() error {
 		if err != nil {
 			return fmt.Errorf("can not render ccd file %s: %s", user.Username, err)
 		}
-		if err = svr.emitToFile(filepath.Join(_DefaultVPNCCDPath, user.Username), result.String(), 0); err != nil {
+		if err = svr.emitToFile(filepath.Join(ccdPath, user.Username), result.String(), 0); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func (svr *Server) emitDHParams() error {
-	var result bytes.Buffer
-
-	t, err := template.New("dh4096.pem.tmpl").Parse(dh4096PemTemplate)
-	if err != nil {
-		return fmt.Errorf("can not parse dh4096.pem template: %s", err)
+// planFirewall adds the NAT and the forwarding rules of the server to the
+// plans: the vpn network is masqueraded behind the outbound interface once
+// the vpn interface is up, and the users and the pools that are associated
+// with the SERVERNET networks are masqueraded towards them.
+func (svr *Server) planFirewall(plans map[bool]*firewallPlan) error {
+	ipv6Net := svr.ipv6Net()
+	isDualStack := ipv6Net != nil
+
+	if rif, vpnIfc := natInterfacesFunc(svr); rif != "" && vpnIfc != "" {
+		mask := net.IPMask(net.ParseIP(svr.Mask))
+		netw := net.ParseIP(svr.Net).Mask(mask).To4()
+		netw[3] = byte(1) // Server is always gets xxx.xxx.xxx.1
+		planNat(plans[false], (&net.IPNet{IP: netw, Mask: mask}).String(), rif, vpnIfc)
+		if isDualStack {
+			planNat(plans[true], ipv6Net.String(), rif, vpnIfc)
+		}
 	}
 
-	err = t.Execute(&result, nil)
+	users, err := svr.GetUsers()
 	if err != nil {
-		return fmt.Errorf("can not render dh4096.pem file: %s", err)
-	}
-
-	return svr.emitToFile(_DefaultDHParamsPath, result.String(), 0)
-}
-
-func (svr *Server) emitIptables() error {
-	if Testing {
-		return nil
+		return err
 	}
-	ipt, err := iptables.NewWithProtocol(iptables.ProtocolIPv4)
+	pools, err := svr.GetPools()
 	if err != nil {
-		return fmt.Errorf("can not create new iptables object: %v", err)
+		return err
 	}
-
 	for _, network := range GetAllNetworks() {
+		if network.Type != SERVERNET {
+			continue
+		}
+		_, networkIPNet, err := net.ParseCIDR(network.CIDR)
+		if err != nil {
+			return err
+		}
+		isIPv6 := networkIPNet.IP.To4() == nil
+		if isIPv6 && !isDualStack {
+			continue
+		}
+		// get destination network's iface
+		iface := interfaceOfIP(networkIPNet)
+		if iface == nil {
+			logrus.Warnf("network doesn't exist on server %s[SERVERNET]: cant find interface for %s", network.Name, networkIPNet.String())
+			continue
+		}
+		plan := plans[isIPv6]
+
+		// IPv4 addresses of the pool users are covered by the rules of
+		// their pools.
 		associatedUsernames := network.GetAssociatedUsernames()
-		switch network.Type {
-		case SERVERNET:
-			users, err := GetAllUsers()
-			if err != nil {
-				return err
+		for _, user := range users {
+			if !isOneOf(user.Username, associatedUsernames) && !(isIPv6 && network.includesPool(user.PoolID)) {
+				continue
 			}
+			userIP := user.getIP()
+			if isIPv6 {
+				userIP = user.getIPv6()
+			}
+			if userIP == nil {
+				continue
+			}
+			plan.addNAT(natRule{Source: userIP.String(), OutIface: iface.Name})
+		}
 
-			// Find associated users and emit iptables configs for the users
-			// regarding the network's type and attributes.
-			for _, user := range users {
-				// Find out if the user is associated or not.
-				var found bool
-				for _, auser := range associatedUsernames {
-					if user.Username == auser {
-						found = true
-						break
-					}
-				}
-
-				userIP, _, err := net.ParseCIDR(user.GetIPNet())
-				if err != nil {
-					return err
-				}
-				_, networkIPNet, err := net.ParseCIDR(network.CIDR)
-				if err != nil {
-					return err
-				}
-
-				// get destination network's iface
-				iface := interfaceOfIP(networkIPNet)
-				if iface == nil {
-					logrus.Warnf("network doesn't exist on server %s[SERVERNET]: cant find interface for %s", network.Name, networkIPNet.String())
-					return nil
-				}
-				// enable nat for the user to the destination network n
-				if found {
-					err = ipt.AppendUnique("nat", "POSTROUTING", "-s", userIP.String(), "-o", iface.Name, "-j", "MASQUERADE")
-					if err != nil {
-						logrus.Error(err)
-						return err
-					}
-				} else {
-					err = ipt.Delete("nat", "POSTROUTING", "-s", userIP.String(), "-o", iface.Name, "-j", "MASQUERADE")
-					if err != nil {
-						logrus.Debug(err)
-					}
-				}
+		// Pools are IPv4 ranges.
+		if isIPv6 {
+			continue
+		}
+		for _, pool := range pools {
+			if network.includesPool(pool.ID) {
+				plan.addNAT(natRule{Source: pool.CIDR, OutIface: iface.Name})
 			}
 		}
 	}
@@ -1001,6 +1606,13 @@ func checkOpenVPNExecutable() bool {
 }
 
 func getOpenVPNExecutable() string {
+	if executable := GetConfig().OpenVPNPath; executable != "" {
+		if _, err := os.Stat(executable); err != nil {
+			logrus.Errorf("openvpn executable can not be found: %s  ✘", err)
+			return ""
+		}
+		return executable
+	}
 	cmd := exec.Command("which", "openvpn")
 	output, err := cmd.Output()
 	if err != nil {
@@ -1022,29 +1634,11 @@ func checkOpenSSLExecutable() bool {
 	return true
 }
 
-func checkIptablesExecutable() bool {
-	cmd := exec.Command("which", "iptables")
-	output, err := cmd.Output()
-	if err != nil {
-		logrus.Errorf("iptables is not installed: %s  ✘", err)
-		return false
-	}
-	logrus.Debugf("iptables executable detected: %s  ✔", strings.TrimSpace(string(output[:])))
-	return true
-}
-
-func ensureBaseDir() {
-	if Testing {
-		return
-	}
-	os.Mkdir(varBasePath, 0755)
-}
-
-func init() {
-	ensureBaseDir()
-	var err error
-	vpnProc, err = supervisor.NewProcess(getOpenVPNExecutable(), varBasePath, []string{"--config", _DefaultVPNConfPath})
+// newVPNProc is the implementation of newVPNProcFunc.
+func newVPNProc(svr *Server) supervisor.Supervisable {
+	proc, err := supervisor.NewProcess(getOpenVPNExecutable(), svr.dir(), []string{"--config", svr.path(vpnConfFile)})
 	if err != nil {
 		logrus.Errorf("can not create process: %v", err)
 	}
+	return proc
 }
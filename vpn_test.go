@@ -1,8 +1,10 @@
 package ovpm
 
 import (
+	"crypto/x509"
 	"fmt"
 	"io"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
@@ -18,13 +20,16 @@ var fs map[string]string
 func setupTestCase() {
 	// Initialize.
 	fs = make(map[string]string)
-	vpnProc.Stop()
+	testFirewall = newRecordingFirewall()
+	fw = testFirewall
+	fwInstalled = make(map[bool]firewallPlan)
+	TheServer().proc.Stop()
 }
 
 func TestVPNInit(t *testing.T) {
 	// Init:
 	setupTestCase()
-	CreateDB("sqlite3", ":memory:")
+	createTestDB()
 	defer db.Cease()
 	// Prepare:
 	// Test:
@@ -57,10 +62,69 @@ func TestVPNInit(t *testing.T) {
 	}
 }
 
+func TestVPNInitKeyAlgorithm(t *testing.T) {
+	// Init:
+	setupTestCase()
+	createTestDB()
+	defer db.Cease()
+	svr := TheServer()
+
+	// Prepare:
+	if err := svr.Init("localhost", "", UDPProto, "", "", "", "", false, WithKeyAlgorithm("dsa", 0)); err == nil {
+		t.Error("unknown key algorithm is expected to be refused")
+	}
+	if err := svr.Init("localhost", "", UDPProto, "", "", "", "", false, WithKeyAlgorithm("rsa", 1024)); err == nil {
+		t.Error("small rsa key size is expected to be refused")
+	}
+	if err := svr.Init("localhost", "", UDPProto, "", "", "", "", false, WithCertValidity(30)); err == nil {
+		t.Error("cert validity within the renew threshold is expected to be refused")
+	}
+	if err := svr.Init("localhost", "", UDPProto, "", "", "", "", false, WithKeyAlgorithm("ecdsa-p384", 0), WithCertValidity(45)); err != nil {
+		t.Fatalf("server can not be initialized: %v", err)
+	}
+	usr, err := CreateNewUser("usr1", "1234", false, 0, true, "description")
+	if err != nil {
+		t.Fatalf("user can not be created: %v", err)
+	}
+
+	// Test:
+	if svr.GetKeyAlgorithm() != "ecdsa-p384" || svr.GetCertValidityDays() != 45 {
+		t.Errorf("key options are expected to be stored: %s %d", svr.GetKeyAlgorithm(), svr.GetCertValidityDays())
+	}
+	for _, cert := range []string{svr.CACert, svr.Cert, usr.Cert} {
+		crt, _ := pki.ReadCertFromPEM(cert)
+		if crt.PublicKeyAlgorithm != x509.ECDSA {
+			t.Errorf("certificates are expected to have ECDSA keys, got %s", crt.PublicKeyAlgorithm)
+		}
+		if cert == svr.CACert {
+			if crt.NotAfter.Before(time.Now().Add(365 * 24 * time.Hour)) {
+				t.Errorf("CA is expected to keep the default validity, got %s", crt.NotAfter)
+			}
+		} else if crt.NotAfter.After(time.Now().Add(46 * 24 * time.Hour)) {
+			t.Errorf("certificates are expected to be valid for 45 days, got %s", crt.NotAfter)
+		}
+	}
+	if !strings.Contains(fs[svr.path(vpnConfFile)], "ecdh-curve secp384r1") {
+		t.Error("server conf is expected to have the matching ecdh-curve")
+	}
+	if err := svr.Update("", "", nil, WithKeyAlgorithm("rsa", 0)); err == nil {
+		t.Error("key algorithm of an initialized server is not expected to be changed")
+	}
+	if err := svr.Update("", "", nil, WithCertValidity(60)); err != nil || svr.GetCertValidityDays() != 60 {
+		t.Errorf("cert validity is expected to be updated: %v", err)
+	}
+
+	// RSA servers don't get an ecdh-curve.
+	svr.Init("localhost", "", UDPProto, "", "", "", "", false)
+	if strings.Contains(fs[svr.path(vpnConfFile)], "ecdh-curve") {
+		t.Error("server conf of an RSA server is not expected to have an ecdh-curve")
+	}
+}
+
 func TestVPNDeinit(t *testing.T) {
 	// Init:
 	setupTestCase()
-	CreateDB("sqlite3", ":memory:")
+	createTestDB()
 	defer db.Cease()
 
 	// Prepare:
@@ -113,7 +177,7 @@ func TestVPNDeinit(t *testing.T) {
 func TestVPNUpdate(t *testing.T) {
 	// Init:
 	setupTestCase()
-	CreateDB("sqlite3", ":memory:")
+	createTestDB()
 	defer db.Cease()
 	// Prepare:
 	TheServer().Init("localhost", "", UDPProto, "", "", "", "", false)
@@ -155,7 +219,7 @@ func TestVPNUpdate(t *testing.T) {
 func TestVPNIsInitialized(t *testing.T) {
 	// Init:
 	setupTestCase()
-	CreateDB("sqlite3", ":memory:")
+	createTestDB()
 	defer db.Cease()
 
 	// Prepare:
@@ -178,7 +242,7 @@ func TestVPNIsInitialized(t *testing.T) {
 func TestVPNTheServer(t *testing.T) {
 	// Init:
 	setupTestCase()
-	CreateDB("sqlite3", ":memory:")
+	createTestDB()
 	defer db.Cease()
 
 	// Prepare:
@@ -205,7 +269,7 @@ func TestVPNTheServer(t *testing.T) {
 func TestVPNDumpsClientConfig(t *testing.T) {
 	// Init:
 	setupTestCase()
-	CreateDB("sqlite3", ":memory:")
+	createTestDB()
 	defer db.Cease()
 	svr := TheServer()
 	svr.Init("localhost", "", UDPProto, "", "", "", "", false)
@@ -231,7 +295,7 @@ func TestVPNDumpsClientConfig(t *testing.T) {
 func TestVPNDumpClientConfig(t *testing.T) {
 	// Init:
 	setupTestCase()
-	CreateDB("sqlite3", ":memory:")
+	createTestDB()
 	defer db.Cease()
 	svr := TheServer()
 	svr.Init("localhost", "", UDPProto, "", "", "", "", false)
@@ -282,7 +346,7 @@ func TestVPNDumpClientConfig(t *testing.T) {
 func TestVPNGetSystemCA(t *testing.T) {
 	// Init:
 	setupTestCase()
-	CreateDB("sqlite3", ":memory:")
+	createTestDB()
 	defer db.Cease()
 
 	// Prepare:
@@ -315,7 +379,7 @@ func TestVPNGetSystemCA(t *testing.T) {
 func TestVPNStartVPNProc(t *testing.T) {
 	// Init:
 	setupTestCase()
-	CreateDB("sqlite3", ":memory:")
+	createTestDB()
 	defer db.Cease()
 	svr := TheServer()
 
@@ -323,16 +387,16 @@ func TestVPNStartVPNProc(t *testing.T) {
 
 	// Test:
 	// Isn't it stopped?
-	if vpnProc.Status() != supervisor.STOPPED {
-		t.Fatalf("expected state is STOPPED, got %s instead", vpnProc.Status())
+	if TheServer().proc.Status() != supervisor.STOPPED {
+		t.Fatalf("expected state is STOPPED, got %s instead", TheServer().proc.Status())
 	}
 
 	// Call start without server initialization.
 	svr.StartVPNProc()
 
 	// Isn't it still stopped?
-	if vpnProc.Status() != supervisor.STOPPED {
-		t.Fatalf("expected state is STOPPED, got %s instead", vpnProc.Status())
+	if TheServer().proc.Status() != supervisor.STOPPED {
+		t.Fatalf("expected state is STOPPED, got %s instead", TheServer().proc.Status())
 	}
 
 	// Initialize OVPM server.
@@ -342,40 +406,40 @@ func TestVPNStartVPNProc(t *testing.T) {
 	svr.StartVPNProc()
 
 	// Isn't it RUNNING?
-	if vpnProc.Status() != supervisor.RUNNING {
-		t.Fatalf("expected state is RUNNING, got %s instead", vpnProc.Status())
+	if TheServer().proc.Status() != supervisor.RUNNING {
+		t.Fatalf("expected state is RUNNING, got %s instead", TheServer().proc.Status())
 	}
 }
 
 func TestVPNStopVPNProc(t *testing.T) {
 	// Init:
 	setupTestCase()
-	CreateDB("sqlite3", ":memory:")
+	createTestDB()
 	defer db.Cease()
 	svr := TheServer()
 	svr.Init("localhost", "", UDPProto, "", "", "", "", false)
 
 	// Prepare:
-	vpnProc.Start()
+	TheServer().proc.Start()
 
 	// Test:
 	// Isn't it running?
-	if vpnProc.Status() != supervisor.RUNNING {
-		t.Fatalf("expected state is RUNNING, got %s instead", vpnProc.Status())
+	if TheServer().proc.Status() != supervisor.RUNNING {
+		t.Fatalf("expected state is RUNNING, got %s instead", TheServer().proc.Status())
 	}
 
 	// Call stop.
 	svr.StopVPNProc()
 
 	// Isn't it stopped?
-	if vpnProc.Status() != supervisor.STOPPED {
-		t.Fatalf("expected state is STOPPED, got %s instead", vpnProc.Status())
+	if TheServer().proc.Status() != supervisor.STOPPED {
+		t.Fatalf("expected state is STOPPED, got %s instead", TheServer().proc.Status())
 	}
 }
 
 func TestVPNRestartVPNProc(t *testing.T) {
 	// Init:
-	CreateDB("sqlite3", ":memory:")
+	createTestDB()
 	defer db.Cease()
 	svr := TheServer()
 	svr.Init("localhost", "", UDPProto, "", "", "", "", false)
@@ -388,23 +452,23 @@ func TestVPNRestartVPNProc(t *testing.T) {
 	svr.RestartVPNProc()
 
 	// Isn't it running?
-	if vpnProc.Status() != supervisor.RUNNING {
-		t.Fatalf("expected state is RUNNING, got %s instead", vpnProc.Status())
+	if TheServer().proc.Status() != supervisor.RUNNING {
+		t.Fatalf("expected state is RUNNING, got %s instead", TheServer().proc.Status())
 	}
 
 	// Call restart again.
 	svr.RestartVPNProc()
 
 	// Isn't it running?
-	if vpnProc.Status() != supervisor.RUNNING {
-		t.Fatalf("expected state is RUNNING, got %s instead", vpnProc.Status())
+	if TheServer().proc.Status() != supervisor.RUNNING {
+		t.Fatalf("expected state is RUNNING, got %s instead", TheServer().proc.Status())
 	}
 }
 
 func TestVPNEmit(t *testing.T) {
 	// Init:
 	setupTestCase()
-	CreateDB("sqlite3", ":memory:")
+	createTestDB()
 	defer db.Cease()
 	svr := TheServer()
 	svr.Init("localhost", "", UDPProto, "", "", "", "", false)
@@ -433,6 +497,145 @@ func TestVPNEmit(t *testing.T) {
 	// TODO(cad): Write test cases for ccd/ files as well.
 }
 
+func TestVPNMultipleServers(t *testing.T) {
+	// Init:
+	setupTestCase()
+	createTestDB()
+	defer db.Cease()
+	svr := TheServer()
+	svr.Init("localhost", "", UDPProto, "", "", "", "", false)
+
+	// Prepare:
+	svr2 := GetServer("second")
+	svr2.emitToFileFunc = TheServer().emitToFileFunc
+	if err := svr2.Init("localhost", "1195", UDPProto, "10.10.0.0/24", "", "", "", false); err != nil {
+		t.Fatalf("second server can not be initialized: %v", err)
+	}
+
+	// Test:
+	if svrs := GetAllServers(); len(svrs) != 2 || svrs[0] != svr || svrs[1] != svr2 {
+		t.Fatalf("expected servers are [default second], got %v", svrs)
+	}
+	if svr.GetCACert() == svr2.GetCACert() {
+		t.Error("servers are expected to have their own CAs")
+	}
+
+	var conflicttests = []struct {
+		name    string
+		port    string
+		ipblock string
+	}{
+		{"third", DefaultVPNPort, "10.11.0.0/24"}, // port conflict with the default server
+		{"third", "1196", "10.10.0.128/25"},       // network overlap with the second server
+		{"third", "1196", "10.0.0.0/8"},           // network overlap with both of the servers
+		{"thi rd", "1196", "10.11.0.0/24"},        // invalid name
+	}
+	for _, tt := range conflicttests {
+		if err := GetServer(tt.name).Init("localhost", tt.port, UDPProto, tt.ipblock, "", "", "", false); err == nil {
+			t.Errorf("Init(%s, %s, %s) is expected to fail", tt.name, tt.port, tt.ipblock)
+		}
+	}
+	if len(GetAllServers()) != 2 {
+		t.Fatal("failed inits are not expected to create servers")
+	}
+
+	// Looking up a name doesn't create a server instance.
+	if s, err := LookupServer("second"); err != nil || s != svr2 {
+		t.Errorf("second server is expected to be looked up, got %v %v", s, err)
+	}
+	if s, err := LookupServer(""); err != nil || s != svr {
+		t.Errorf("default server is expected to be looked up by an empty name, got %v %v", s, err)
+	}
+	if _, err := LookupServer("unknown"); err == nil {
+		t.Error("unknown server is not expected to be looked up")
+	}
+	serversMu.Lock()
+	_, ok := servers["unknown"]
+	serversMu.Unlock()
+	if ok {
+		t.Error("looking up an unknown server is not expected to create an instance")
+	}
+
+	// Users are created on their own servers.
+	usr1, err := CreateNewUser("usr1", "1234", false, 0, false, "description")
+	if err != nil {
+		t.Fatalf("user can not be created: %v", err)
+	}
+	usr2, err := svr2.CreateNewUser("usr2", "1234", false, 0, false, "description")
+	if err != nil {
+		t.Fatalf("user can not be created: %v", err)
+	}
+	if usr1.GetServer() != svr || usr2.GetServer() != svr2 {
+		t.Fatalf("users are expected to belong to their servers: %s %s", usr1.GetServerName(), usr2.GetServerName())
+	}
+	if ip := usr2.getIP().String(); ip != "10.10.0.2" {
+		t.Errorf("usr2 is expected to get an ip from the network of the second server, got %s", ip)
+	}
+	if _, err := svr.DumpsClientConfig("usr2"); err == nil {
+		t.Error("client config of usr2 is not expected to be generated by the default server")
+	}
+
+	// Servers emit their files into their own directories.
+	fs = make(map[string]string)
+	svr2.Emit()
+	if len(fs[svr2.path(vpnConfFile)]) == 0 || svr2.path(vpnConfFile) == _DefaultVPNConfPath {
+		t.Errorf("second server's conf is expected to be emitted to its own directory")
+	}
+	if !strings.Contains(fs[svr2.path(vpnConfFile)], "port 1195") {
+		t.Errorf("second server's conf is expected to use its own port")
+	}
+	if _, ok := fs[filepath.Join(svr2.path(vpnCCDDir), "usr2")]; !ok {
+		t.Error("ccd file of usr2 is expected to be emitted for the second server")
+	}
+	if _, ok := fs[filepath.Join(svr2.path(vpnCCDDir), "usr1")]; ok {
+		t.Error("ccd file of usr1 is not expected to be emitted for the second server")
+	}
+
+	// Shared CA.
+	svr3 := GetServer("third")
+	if err := svr3.Init("localhost", "1196", UDPProto, "10.11.0.0/24", "", "", "", false, WithSharedCA("second")); err != nil {
+		t.Fatalf("third server can not be initialized: %v", err)
+	}
+	if svr3.GetCACert() != svr2.GetCACert() {
+		t.Error("third server is expected to share the CA of the second server")
+	}
+	if err := svr3.Update("", "", nil, WithSharedCA(DefaultServerName)); err == nil {
+		t.Error("CA of an initialized server is not expected to be changed by Update")
+	}
+	if svr3.GetCACert() != svr2.GetCACert() {
+		t.Error("failed update is not expected to change the CA")
+	}
+	// Validating an update doesn't refresh the other server instances.
+	svr2.dbServerModel.Hostname = "pending.example.com"
+	if err := svr3.Update("10.10.0.0/25", "", nil); err == nil {
+		t.Error("network of the third server is not expected to overlap with the second server")
+	}
+	if svr2.GetHostname() != "pending.example.com" {
+		t.Error("other server instances are not expected to be refreshed by an update")
+	}
+	svr2.Refresh()
+	if err := svr3.Update("10.12.0.0/24", "", nil); err != nil {
+		t.Errorf("network of the third server is expected to be updated: %v", err)
+	}
+
+	// Servers that still have users can not be deleted.
+	if err := svr2.Deinit(); err == nil {
+		t.Error("second server is not expected to be deleted while it has users")
+	}
+	if err := usr2.SetServer(DefaultServerName); err != nil {
+		t.Fatalf("usr2 can not be moved to the default server: %v", err)
+	}
+	if usr2.GetServer() != svr || !svr.ipNet().Contains(usr2.getIP()) {
+		t.Errorf("usr2 is expected to be moved to the default server: %s %s", usr2.GetServerName(), usr2.getIP())
+	}
+	if err := svr2.Deinit(); err != nil {
+		t.Errorf("second server is expected to be deleted: %v", err)
+	}
+	if svr2.IsInitialized() || !svr3.IsInitialized() || !svr.IsInitialized() {
+		t.Error("only the second server is expected to be deleted")
+	}
+}
+
 func TestVPNemitToFile(t *testing.T) {
 	// Initialize:
 
@@ -481,7 +684,7 @@ func (f *fakeProcess) Status() supervisor.State {
 func TestGetConnectedUsers(t *testing.T) {
 	// Init:
 	setupTestCase()
-	CreateDB("sqlite3", ":memory:")
+	createTestDB()
 	defer db.Cease()
 	svr := TheServer()
 	svr.Init("localhost", "", UDPProto, "", "", "", "", false)
@@ -566,7 +769,7 @@ func TestGetConnectedUsers(t *testing.T) {
 
 func TestVPN_ExpiresAt(t *testing.T) {
 	// Initialize:
-	db := CreateDB("sqlite3", ":memory:")
+	db := createTestDB()
 	defer db.Cease()
 	svr := TheServer()
 	svr.Init("localhost", "", UDPProto, "", "", "", "", false)
@@ -584,7 +787,7 @@ func TestVPN_ExpiresAt(t *testing.T) {
 
 func TestVPN_CAExpiresAt(t *testing.T) {
 	// Initialize:
-	db := CreateDB("sqlite3", ":memory:")
+	db := createTestDB()
 	defer db.Cease()
 	svr := TheServer()
 	svr.Init("localhost", "", UDPProto, "", "", "", "", false)
@@ -604,13 +807,29 @@ func init() {
 	Testing = true
 	fs = make(map[string]string)
 
-	CreateDB("sqlite3", ":memory:")
+	createTestDB()
 	defer db.Cease()
 
+	// Monkeypatch the OpenVPN processes of the servers.
+	newVPNProcFunc = func(*Server) supervisor.Supervisable {
+		return &fakeProcess{state: supervisor.STOPPED}
+	}
+
+	// Generate the DH parameters right away, without openssl.
+	generateDHParamsFunc = func(bits int) (string, error) {
+		return fakeDHParams, nil
+	}
+	runDHParamsGeneration = func(f func()) { f() }
+
+	// Record the firewall rules instead of installing them. Vpn interfaces
+	// are never up.
+	testFirewall = newRecordingFirewall()
+	fw = testFirewall
+	natInterfacesFunc = func(*Server) (string, string) { return "", "" }
+
 	// Monkeypatch emitToFile()
 	TheServer().emitToFileFunc = func(path, content string, mode uint) error {
 		fs[path] = content
 		return nil
 	}
-	vpnProc = &fakeProcess{state: supervisor.STOPPED}
 }